@@ -2088,27 +2088,602 @@ func (x *AnalyzeDbFileResponse) GetHotFunctions() []*HotFunction {
 
 // 插桩请求
 type InstrumentProjectReq struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Path           string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                            // 项目路径
+	Undo           bool                   `protobuf:"varint,2,opt,name=undo,proto3" json:"undo,omitempty"`                                           // 是否撤销插桩
+	OverlayDir     string                 `protobuf:"bytes,3,opt,name=overlayDir,proto3" json:"overlayDir,omitempty"`                                // 非空时以overlay模式插桩, 插桩副本写入该目录, 不修改源文件
+	Filter         *InstrumentFilter      `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`                                        // 插桩过滤规则
+	DryRun         bool                   `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                         // 预览模式, 只返回diff和插桩统计, 不修改文件
+	Async          bool                   `protobuf:"varint,6,opt,name=async,proto3" json:"async,omitempty"`                                         // 异步执行, 立即返回taskId, 结果通过GetInstrumentTask获取
+	LineDirectives bool                   `protobuf:"varint,7,opt,name=line_directives,json=lineDirectives,proto3" json:"line_directives,omitempty"` // 在插入的代码之后写入 //line 指令, 保持panic堆栈和日志中的原始行号
+	Tests          bool                   `protobuf:"varint,8,opt,name=tests,proto3" json:"tests,omitempty"`                                         // 同时对测试文件插桩, 并为测试包生成或修改TestMain以落盘追踪数据
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *InstrumentProjectReq) Reset() {
+	*x = InstrumentProjectReq{}
+	mi := &file_analysis_v1_analysis_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstrumentProjectReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstrumentProjectReq) ProtoMessage() {}
+
+func (x *InstrumentProjectReq) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_v1_analysis_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstrumentProjectReq.ProtoReflect.Descriptor instead.
+func (*InstrumentProjectReq) Descriptor() ([]byte, []int) {
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *InstrumentProjectReq) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *InstrumentProjectReq) GetUndo() bool {
+	if x != nil {
+		return x.Undo
+	}
+	return false
+}
+
+func (x *InstrumentProjectReq) GetOverlayDir() string {
+	if x != nil {
+		return x.OverlayDir
+	}
+	return ""
+}
+
+func (x *InstrumentProjectReq) GetFilter() *InstrumentFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *InstrumentProjectReq) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *InstrumentProjectReq) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *InstrumentProjectReq) GetLineDirectives() bool {
+	if x != nil {
+		return x.LineDirectives
+	}
+	return false
+}
+
+func (x *InstrumentProjectReq) GetTests() bool {
+	if x != nil {
+		return x.Tests
+	}
+	return false
+}
+
+// 插桩数量统计
+type InstrumentStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Funcs         int32                  `protobuf:"varint,1,opt,name=funcs,proto3" json:"funcs,omitempty"`           // 函数和方法声明
+	Closures      int32                  `protobuf:"varint,2,opt,name=closures,proto3" json:"closures,omitempty"`     // go语句之外的函数字面量
+	Goroutines    int32                  `protobuf:"varint,3,opt,name=goroutines,proto3" json:"goroutines,omitempty"` // go语句直接启动的函数字面量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstrumentStats) Reset() {
+	*x = InstrumentStats{}
+	mi := &file_analysis_v1_analysis_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstrumentStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstrumentStats) ProtoMessage() {}
+
+func (x *InstrumentStats) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_v1_analysis_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstrumentStats.ProtoReflect.Descriptor instead.
+func (*InstrumentStats) Descriptor() ([]byte, []int) {
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *InstrumentStats) GetFuncs() int32 {
+	if x != nil {
+		return x.Funcs
+	}
+	return 0
+}
+
+func (x *InstrumentStats) GetClosures() int32 {
+	if x != nil {
+		return x.Closures
+	}
+	return 0
+}
+
+func (x *InstrumentStats) GetGoroutines() int32 {
+	if x != nil {
+		return x.Goroutines
+	}
+	return 0
+}
+
+// 单个文件的插桩预览
+type InstrumentFileDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`   // 文件路径
+	Diff          string                 `protobuf:"bytes,2,opt,name=diff,proto3" json:"diff,omitempty"`   // unified diff
+	Stats         *InstrumentStats       `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"` // 该文件的插桩统计
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstrumentFileDiff) Reset() {
+	*x = InstrumentFileDiff{}
+	mi := &file_analysis_v1_analysis_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstrumentFileDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstrumentFileDiff) ProtoMessage() {}
+
+func (x *InstrumentFileDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_v1_analysis_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstrumentFileDiff.ProtoReflect.Descriptor instead.
+func (*InstrumentFileDiff) Descriptor() ([]byte, []int) {
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *InstrumentFileDiff) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *InstrumentFileDiff) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+func (x *InstrumentFileDiff) GetStats() *InstrumentStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// 插桩过滤规则, 同一维度中exclude优先于include, include为空表示不限制
+type InstrumentFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IncludePkgs   []string               `protobuf:"bytes,1,rep,name=includePkgs,proto3" json:"includePkgs,omitempty"` // 包路径glob, 支持 * 和 ...
+	ExcludePkgs   []string               `protobuf:"bytes,2,rep,name=excludePkgs,proto3" json:"excludePkgs,omitempty"`
+	IncludeFiles  []string               `protobuf:"bytes,3,rep,name=includeFiles,proto3" json:"includeFiles,omitempty"` // 文件glob, 相对于模块根目录, 支持 **
+	ExcludeFiles  []string               `protobuf:"bytes,4,rep,name=excludeFiles,proto3" json:"excludeFiles,omitempty"`
+	IncludeFuncs  []string               `protobuf:"bytes,5,rep,name=includeFuncs,proto3" json:"includeFuncs,omitempty"` // 函数名正则
+	ExcludeFuncs  []string               `protobuf:"bytes,6,rep,name=excludeFuncs,proto3" json:"excludeFuncs,omitempty"`
+	IncludeRecvs  []string               `protobuf:"bytes,7,rep,name=includeRecvs,proto3" json:"includeRecvs,omitempty"` // 接收器类型名
+	ExcludeRecvs  []string               `protobuf:"bytes,8,rep,name=excludeRecvs,proto3" json:"excludeRecvs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstrumentFilter) Reset() {
+	*x = InstrumentFilter{}
+	mi := &file_analysis_v1_analysis_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstrumentFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstrumentFilter) ProtoMessage() {}
+
+func (x *InstrumentFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_v1_analysis_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstrumentFilter.ProtoReflect.Descriptor instead.
+func (*InstrumentFilter) Descriptor() ([]byte, []int) {
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *InstrumentFilter) GetIncludePkgs() []string {
+	if x != nil {
+		return x.IncludePkgs
+	}
+	return nil
+}
+
+func (x *InstrumentFilter) GetExcludePkgs() []string {
+	if x != nil {
+		return x.ExcludePkgs
+	}
+	return nil
+}
+
+func (x *InstrumentFilter) GetIncludeFiles() []string {
+	if x != nil {
+		return x.IncludeFiles
+	}
+	return nil
+}
+
+func (x *InstrumentFilter) GetExcludeFiles() []string {
+	if x != nil {
+		return x.ExcludeFiles
+	}
+	return nil
+}
+
+func (x *InstrumentFilter) GetIncludeFuncs() []string {
+	if x != nil {
+		return x.IncludeFuncs
+	}
+	return nil
+}
+
+func (x *InstrumentFilter) GetExcludeFuncs() []string {
+	if x != nil {
+		return x.ExcludeFuncs
+	}
+	return nil
+}
+
+func (x *InstrumentFilter) GetIncludeRecvs() []string {
+	if x != nil {
+		return x.IncludeRecvs
+	}
+	return nil
+}
+
+func (x *InstrumentFilter) GetExcludeRecvs() []string {
+	if x != nil {
+		return x.ExcludeRecvs
+	}
+	return nil
+}
+
+// 被跳过插桩的文件或函数
+type InstrumentSkipped struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          string                 `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`     // 文件路径
+	Func          string                 `protobuf:"bytes,2,opt,name=func,proto3" json:"func,omitempty"`     // 函数名, 整个文件被跳过时为空
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // 跳过原因: package, file, func, receiver, directive
+	Detail        string                 `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"` // 详细说明
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstrumentSkipped) Reset() {
+	*x = InstrumentSkipped{}
+	mi := &file_analysis_v1_analysis_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstrumentSkipped) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstrumentSkipped) ProtoMessage() {}
+
+func (x *InstrumentSkipped) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_v1_analysis_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstrumentSkipped.ProtoReflect.Descriptor instead.
+func (*InstrumentSkipped) Descriptor() ([]byte, []int) {
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *InstrumentSkipped) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *InstrumentSkipped) GetFunc() string {
+	if x != nil {
+		return x.Func
+	}
+	return ""
+}
+
+func (x *InstrumentSkipped) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *InstrumentSkipped) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// 插桩响应
+type InstrumentProjectReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`        // 是否成功
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`         // 消息
+	Files         []string               `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`             // 撤销插桩时被修改的文件清单
+	OverlayPath   string                 `protobuf:"bytes,4,opt,name=overlayPath,proto3" json:"overlayPath,omitempty"` // overlay模式下生成的overlay.json路径, 用于go build -overlay
+	Skipped       []*InstrumentSkipped   `protobuf:"bytes,5,rep,name=skipped,proto3" json:"skipped,omitempty"`         // 被过滤规则跳过的文件和函数
+	Diffs         []*InstrumentFileDiff  `protobuf:"bytes,6,rep,name=diffs,proto3" json:"diffs,omitempty"`             // 预览模式下每个文件的diff
+	Stats         *InstrumentStats       `protobuf:"bytes,7,opt,name=stats,proto3" json:"stats,omitempty"`             // 插桩统计
+	TaskId        string                 `protobuf:"bytes,8,opt,name=taskId,proto3" json:"taskId,omitempty"`           // 异步执行时的任务ID
+	Report        *InstrumentReport      `protobuf:"bytes,9,opt,name=report,proto3" json:"report,omitempty"`           // 插桩报告, 撤销插桩和异步启动时为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstrumentProjectReply) Reset() {
+	*x = InstrumentProjectReply{}
+	mi := &file_analysis_v1_analysis_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstrumentProjectReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstrumentProjectReply) ProtoMessage() {}
+
+func (x *InstrumentProjectReply) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_v1_analysis_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstrumentProjectReply.ProtoReflect.Descriptor instead.
+func (*InstrumentProjectReply) Descriptor() ([]byte, []int) {
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *InstrumentProjectReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *InstrumentProjectReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *InstrumentProjectReply) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *InstrumentProjectReply) GetOverlayPath() string {
+	if x != nil {
+		return x.OverlayPath
+	}
+	return ""
+}
+
+func (x *InstrumentProjectReply) GetSkipped() []*InstrumentSkipped {
+	if x != nil {
+		return x.Skipped
+	}
+	return nil
+}
+
+func (x *InstrumentProjectReply) GetDiffs() []*InstrumentFileDiff {
+	if x != nil {
+		return x.Diffs
+	}
+	return nil
+}
+
+func (x *InstrumentProjectReply) GetStats() *InstrumentStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *InstrumentProjectReply) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *InstrumentProjectReply) GetReport() *InstrumentReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+// 插桩时单个文件的错误
+type InstrumentError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          string                 `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`       // 文件路径
+	Line          int32                  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`      // 行号, 非语法错误时为0
+	Column        int32                  `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"`  // 列号
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"` // 错误信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstrumentError) Reset() {
+	*x = InstrumentError{}
+	mi := &file_analysis_v1_analysis_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstrumentError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstrumentError) ProtoMessage() {}
+
+func (x *InstrumentError) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_v1_analysis_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstrumentError.ProtoReflect.Descriptor instead.
+func (*InstrumentError) Descriptor() ([]byte, []int) {
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *InstrumentError) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *InstrumentError) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *InstrumentError) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *InstrumentError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// 插桩报告
+type InstrumentReport struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // 项目路径
+	Rewritten     []string               `protobuf:"bytes,1,rep,name=rewritten,proto3" json:"rewritten,omitempty"` // 有插桩改动的文件
+	Skipped       []string               `protobuf:"bytes,2,rep,name=skipped,proto3" json:"skipped,omitempty"`     // 没有改动的文件, 包括被过滤规则排除的文件
+	Errors        []*InstrumentError     `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`       // 解析、格式化或写入失败的文件
+	Stats         *InstrumentStats       `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`         // 插桩统计
+	Generated     []string               `protobuf:"bytes,5,rep,name=generated,proto3" json:"generated,omitempty"` // 新生成的文件, 如测试包的TestMain
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InstrumentProjectReq) Reset() {
-	*x = InstrumentProjectReq{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[38]
+func (x *InstrumentReport) Reset() {
+	*x = InstrumentReport{}
+	mi := &file_analysis_v1_analysis_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InstrumentProjectReq) String() string {
+func (x *InstrumentReport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InstrumentProjectReq) ProtoMessage() {}
+func (*InstrumentReport) ProtoMessage() {}
 
-func (x *InstrumentProjectReq) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[38]
+func (x *InstrumentReport) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_v1_analysis_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2119,42 +2694,69 @@ func (x *InstrumentProjectReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InstrumentProjectReq.ProtoReflect.Descriptor instead.
-func (*InstrumentProjectReq) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use InstrumentReport.ProtoReflect.Descriptor instead.
+func (*InstrumentReport) Descriptor() ([]byte, []int) {
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *InstrumentProjectReq) GetPath() string {
+func (x *InstrumentReport) GetRewritten() []string {
 	if x != nil {
-		return x.Path
+		return x.Rewritten
 	}
-	return ""
+	return nil
 }
 
-// 插桩响应
-type InstrumentProjectReply struct {
+func (x *InstrumentReport) GetSkipped() []string {
+	if x != nil {
+		return x.Skipped
+	}
+	return nil
+}
+
+func (x *InstrumentReport) GetErrors() []*InstrumentError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *InstrumentReport) GetStats() *InstrumentStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *InstrumentReport) GetGenerated() []string {
+	if x != nil {
+		return x.Generated
+	}
+	return nil
+}
+
+// 获取插桩任务请求
+type GetInstrumentTaskReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // 是否成功
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`  // 消息
+	TaskId        string                 `protobuf:"bytes,1,opt,name=taskId,proto3" json:"taskId,omitempty"` // 任务ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InstrumentProjectReply) Reset() {
-	*x = InstrumentProjectReply{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[39]
+func (x *GetInstrumentTaskReq) Reset() {
+	*x = GetInstrumentTaskReq{}
+	mi := &file_analysis_v1_analysis_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InstrumentProjectReply) String() string {
+func (x *GetInstrumentTaskReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InstrumentProjectReply) ProtoMessage() {}
+func (*GetInstrumentTaskReq) ProtoMessage() {}
 
-func (x *InstrumentProjectReply) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[39]
+func (x *GetInstrumentTaskReq) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_v1_analysis_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2165,25 +2767,79 @@ func (x *InstrumentProjectReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InstrumentProjectReply.ProtoReflect.Descriptor instead.
-func (*InstrumentProjectReply) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use GetInstrumentTaskReq.ProtoReflect.Descriptor instead.
+func (*GetInstrumentTaskReq) Descriptor() ([]byte, []int) {
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *InstrumentProjectReply) GetSuccess() bool {
+func (x *GetInstrumentTaskReq) GetTaskId() string {
 	if x != nil {
-		return x.Success
+		return x.TaskId
 	}
-	return false
+	return ""
 }
 
-func (x *InstrumentProjectReply) GetMessage() string {
+// 获取插桩任务响应
+type GetInstrumentTaskReply struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	TaskId        string                  `protobuf:"bytes,1,opt,name=taskId,proto3" json:"taskId,omitempty"`  // 任务ID
+	Status        int32                   `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"` // 任务状态: 0 启动中, 1 处理中, 2 已完成, -1 失败, -2 未找到(包括结束超过1小时或超出最近100个的已结束任务)
+	Result        *InstrumentProjectReply `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`  // 任务完成后的插桩结果
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInstrumentTaskReply) Reset() {
+	*x = GetInstrumentTaskReply{}
+	mi := &file_analysis_v1_analysis_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInstrumentTaskReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstrumentTaskReply) ProtoMessage() {}
+
+func (x *GetInstrumentTaskReply) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_v1_analysis_proto_msgTypes[47]
 	if x != nil {
-		return x.Message
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstrumentTaskReply.ProtoReflect.Descriptor instead.
+func (*GetInstrumentTaskReply) Descriptor() ([]byte, []int) {
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetInstrumentTaskReply) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
 	}
 	return ""
 }
 
+func (x *GetInstrumentTaskReply) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *GetInstrumentTaskReply) GetResult() *InstrumentProjectReply {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
 // 获取树状图请求
 type GetTreeGraphReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2197,7 +2853,7 @@ type GetTreeGraphReq struct {
 
 func (x *GetTreeGraphReq) Reset() {
 	*x = GetTreeGraphReq{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[40]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2209,7 +2865,7 @@ func (x *GetTreeGraphReq) String() string {
 func (*GetTreeGraphReq) ProtoMessage() {}
 
 func (x *GetTreeGraphReq) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[40]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2222,7 +2878,7 @@ func (x *GetTreeGraphReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTreeGraphReq.ProtoReflect.Descriptor instead.
 func (*GetTreeGraphReq) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{40}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *GetTreeGraphReq) GetDbPath() string {
@@ -2266,7 +2922,7 @@ type TreeNode struct {
 
 func (x *TreeNode) Reset() {
 	*x = TreeNode{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[41]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2278,7 +2934,7 @@ func (x *TreeNode) String() string {
 func (*TreeNode) ProtoMessage() {}
 
 func (x *TreeNode) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[41]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2291,7 +2947,7 @@ func (x *TreeNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TreeNode.ProtoReflect.Descriptor instead.
 func (*TreeNode) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{41}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *TreeNode) GetName() string {
@@ -2332,7 +2988,7 @@ type GetTreeGraphReply struct {
 
 func (x *GetTreeGraphReply) Reset() {
 	*x = GetTreeGraphReply{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[42]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2344,7 +3000,7 @@ func (x *GetTreeGraphReply) String() string {
 func (*GetTreeGraphReply) ProtoMessage() {}
 
 func (x *GetTreeGraphReply) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[42]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2357,7 +3013,7 @@ func (x *GetTreeGraphReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTreeGraphReply.ProtoReflect.Descriptor instead.
 func (*GetTreeGraphReply) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{42}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *GetTreeGraphReply) GetTrees() []*TreeNode {
@@ -2378,7 +3034,7 @@ type GetTreeGraphByGIDReq struct {
 
 func (x *GetTreeGraphByGIDReq) Reset() {
 	*x = GetTreeGraphByGIDReq{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[43]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2390,7 +3046,7 @@ func (x *GetTreeGraphByGIDReq) String() string {
 func (*GetTreeGraphByGIDReq) ProtoMessage() {}
 
 func (x *GetTreeGraphByGIDReq) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[43]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2403,7 +3059,7 @@ func (x *GetTreeGraphByGIDReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTreeGraphByGIDReq.ProtoReflect.Descriptor instead.
 func (*GetTreeGraphByGIDReq) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{43}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *GetTreeGraphByGIDReq) GetDbPath() string {
@@ -2430,7 +3086,7 @@ type GetTreeGraphByGIDReply struct {
 
 func (x *GetTreeGraphByGIDReply) Reset() {
 	*x = GetTreeGraphByGIDReply{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[44]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2442,7 +3098,7 @@ func (x *GetTreeGraphByGIDReply) String() string {
 func (*GetTreeGraphByGIDReply) ProtoMessage() {}
 
 func (x *GetTreeGraphByGIDReply) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[44]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2455,7 +3111,7 @@ func (x *GetTreeGraphByGIDReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTreeGraphByGIDReply.ProtoReflect.Descriptor instead.
 func (*GetTreeGraphByGIDReply) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{44}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *GetTreeGraphByGIDReply) GetTrees() []*TreeNode {
@@ -2476,7 +3132,7 @@ type GetFunctionCallStatsReq struct {
 
 func (x *GetFunctionCallStatsReq) Reset() {
 	*x = GetFunctionCallStatsReq{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[45]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2488,7 +3144,7 @@ func (x *GetFunctionCallStatsReq) String() string {
 func (*GetFunctionCallStatsReq) ProtoMessage() {}
 
 func (x *GetFunctionCallStatsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[45]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2501,7 +3157,7 @@ func (x *GetFunctionCallStatsReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFunctionCallStatsReq.ProtoReflect.Descriptor instead.
 func (*GetFunctionCallStatsReq) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{45}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *GetFunctionCallStatsReq) GetDbPath() string {
@@ -2536,7 +3192,7 @@ type FunctionCallStats struct {
 
 func (x *FunctionCallStats) Reset() {
 	*x = FunctionCallStats{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[46]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2548,7 +3204,7 @@ func (x *FunctionCallStats) String() string {
 func (*FunctionCallStats) ProtoMessage() {}
 
 func (x *FunctionCallStats) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[46]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2561,7 +3217,7 @@ func (x *FunctionCallStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FunctionCallStats.ProtoReflect.Descriptor instead.
 func (*FunctionCallStats) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{46}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *FunctionCallStats) GetName() string {
@@ -2637,7 +3293,7 @@ type GetFunctionCallStatsReply struct {
 
 func (x *GetFunctionCallStatsReply) Reset() {
 	*x = GetFunctionCallStatsReply{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[47]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2649,7 +3305,7 @@ func (x *GetFunctionCallStatsReply) String() string {
 func (*GetFunctionCallStatsReply) ProtoMessage() {}
 
 func (x *GetFunctionCallStatsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[47]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2662,7 +3318,7 @@ func (x *GetFunctionCallStatsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFunctionCallStatsReply.ProtoReflect.Descriptor instead.
 func (*GetFunctionCallStatsReply) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{47}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *GetFunctionCallStatsReply) GetStats() []*FunctionCallStats {
@@ -2684,7 +3340,7 @@ type GetPerformanceAnomaliesReq struct {
 
 func (x *GetPerformanceAnomaliesReq) Reset() {
 	*x = GetPerformanceAnomaliesReq{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[48]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2696,7 +3352,7 @@ func (x *GetPerformanceAnomaliesReq) String() string {
 func (*GetPerformanceAnomaliesReq) ProtoMessage() {}
 
 func (x *GetPerformanceAnomaliesReq) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[48]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2709,7 +3365,7 @@ func (x *GetPerformanceAnomaliesReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPerformanceAnomaliesReq.ProtoReflect.Descriptor instead.
 func (*GetPerformanceAnomaliesReq) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{48}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *GetPerformanceAnomaliesReq) GetDbPath() string {
@@ -2748,7 +3404,7 @@ type PerformanceAnomaly struct {
 
 func (x *PerformanceAnomaly) Reset() {
 	*x = PerformanceAnomaly{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[49]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2760,7 +3416,7 @@ func (x *PerformanceAnomaly) String() string {
 func (*PerformanceAnomaly) ProtoMessage() {}
 
 func (x *PerformanceAnomaly) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[49]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2773,7 +3429,7 @@ func (x *PerformanceAnomaly) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PerformanceAnomaly.ProtoReflect.Descriptor instead.
 func (*PerformanceAnomaly) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{49}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *PerformanceAnomaly) GetName() string {
@@ -2828,7 +3484,7 @@ type GetPerformanceAnomaliesReply struct {
 
 func (x *GetPerformanceAnomaliesReply) Reset() {
 	*x = GetPerformanceAnomaliesReply{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[50]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2840,7 +3496,7 @@ func (x *GetPerformanceAnomaliesReply) String() string {
 func (*GetPerformanceAnomaliesReply) ProtoMessage() {}
 
 func (x *GetPerformanceAnomaliesReply) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[50]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2853,7 +3509,7 @@ func (x *GetPerformanceAnomaliesReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPerformanceAnomaliesReply.ProtoReflect.Descriptor instead.
 func (*GetPerformanceAnomaliesReply) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{50}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *GetPerformanceAnomaliesReply) GetAnomalies() []*PerformanceAnomaly {
@@ -2874,7 +3530,7 @@ type GetHotFunctionsReq struct {
 
 func (x *GetHotFunctionsReq) Reset() {
 	*x = GetHotFunctionsReq{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[51]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2886,7 +3542,7 @@ func (x *GetHotFunctionsReq) String() string {
 func (*GetHotFunctionsReq) ProtoMessage() {}
 
 func (x *GetHotFunctionsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[51]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2899,7 +3555,7 @@ func (x *GetHotFunctionsReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetHotFunctionsReq.ProtoReflect.Descriptor instead.
 func (*GetHotFunctionsReq) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{51}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *GetHotFunctionsReq) GetSortBy() string {
@@ -2926,7 +3582,7 @@ type GetHotFunctionsReply struct {
 
 func (x *GetHotFunctionsReply) Reset() {
 	*x = GetHotFunctionsReply{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[52]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2938,7 +3594,7 @@ func (x *GetHotFunctionsReply) String() string {
 func (*GetHotFunctionsReply) ProtoMessage() {}
 
 func (x *GetHotFunctionsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[52]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2951,7 +3607,7 @@ func (x *GetHotFunctionsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetHotFunctionsReply.ProtoReflect.Descriptor instead.
 func (*GetHotFunctionsReply) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{52}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *GetHotFunctionsReply) GetFunctions() []*GetHotFunctionsReply_HotFunction {
@@ -2973,7 +3629,7 @@ type SearchFunctionsReq struct {
 
 func (x *SearchFunctionsReq) Reset() {
 	*x = SearchFunctionsReq{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[53]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2985,7 +3641,7 @@ func (x *SearchFunctionsReq) String() string {
 func (*SearchFunctionsReq) ProtoMessage() {}
 
 func (x *SearchFunctionsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[53]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2998,7 +3654,7 @@ func (x *SearchFunctionsReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchFunctionsReq.ProtoReflect.Descriptor instead.
 func (*SearchFunctionsReq) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{53}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *SearchFunctionsReq) GetDbpath() string {
@@ -3033,7 +3689,7 @@ type SearchFunctionsReply struct {
 
 func (x *SearchFunctionsReply) Reset() {
 	*x = SearchFunctionsReply{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[54]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3045,7 +3701,7 @@ func (x *SearchFunctionsReply) String() string {
 func (*SearchFunctionsReply) ProtoMessage() {}
 
 func (x *SearchFunctionsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[54]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3058,7 +3714,7 @@ func (x *SearchFunctionsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchFunctionsReply.ProtoReflect.Descriptor instead.
 func (*SearchFunctionsReply) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{54}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{62}
 }
 
 func (x *SearchFunctionsReply) GetFunctions() []*SearchFunctionsReply_FunctionInfo {
@@ -3088,7 +3744,7 @@ type GetFunctionInfoInGoroutineReq struct {
 
 func (x *GetFunctionInfoInGoroutineReq) Reset() {
 	*x = GetFunctionInfoInGoroutineReq{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[55]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3100,7 +3756,7 @@ func (x *GetFunctionInfoInGoroutineReq) String() string {
 func (*GetFunctionInfoInGoroutineReq) ProtoMessage() {}
 
 func (x *GetFunctionInfoInGoroutineReq) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[55]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3113,7 +3769,7 @@ func (x *GetFunctionInfoInGoroutineReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFunctionInfoInGoroutineReq.ProtoReflect.Descriptor instead.
 func (*GetFunctionInfoInGoroutineReq) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{55}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{63}
 }
 
 func (x *GetFunctionInfoInGoroutineReq) GetDbpath() string {
@@ -3156,7 +3812,7 @@ type ParentInfo struct {
 
 func (x *ParentInfo) Reset() {
 	*x = ParentInfo{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[56]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3168,7 +3824,7 @@ func (x *ParentInfo) String() string {
 func (*ParentInfo) ProtoMessage() {}
 
 func (x *ParentInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[56]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3181,7 +3837,7 @@ func (x *ParentInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ParentInfo.ProtoReflect.Descriptor instead.
 func (*ParentInfo) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{56}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{64}
 }
 
 func (x *ParentInfo) GetParentId() int64 {
@@ -3215,7 +3871,7 @@ type GetFunctionInfoInGoroutineReply struct {
 
 func (x *GetFunctionInfoInGoroutineReply) Reset() {
 	*x = GetFunctionInfoInGoroutineReply{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[57]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3227,7 +3883,7 @@ func (x *GetFunctionInfoInGoroutineReply) String() string {
 func (*GetFunctionInfoInGoroutineReply) ProtoMessage() {}
 
 func (x *GetFunctionInfoInGoroutineReply) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[57]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3240,7 +3896,7 @@ func (x *GetFunctionInfoInGoroutineReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFunctionInfoInGoroutineReply.ProtoReflect.Descriptor instead.
 func (*GetFunctionInfoInGoroutineReply) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{57}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{65}
 }
 
 func (x *GetFunctionInfoInGoroutineReply) GetFunctionInfo() *GetFunctionInfoInGoroutineReply_FunctionInfo {
@@ -3261,7 +3917,7 @@ type GetModuleNamesReq struct {
 
 func (x *GetModuleNamesReq) Reset() {
 	*x = GetModuleNamesReq{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[58]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3273,7 +3929,7 @@ func (x *GetModuleNamesReq) String() string {
 func (*GetModuleNamesReq) ProtoMessage() {}
 
 func (x *GetModuleNamesReq) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[58]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3286,7 +3942,7 @@ func (x *GetModuleNamesReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetModuleNamesReq.ProtoReflect.Descriptor instead.
 func (*GetModuleNamesReq) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{58}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{66}
 }
 
 func (x *GetModuleNamesReq) GetDbpath() string {
@@ -3313,7 +3969,7 @@ type GetModuleNamesReply struct {
 
 func (x *GetModuleNamesReply) Reset() {
 	*x = GetModuleNamesReply{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[59]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3325,7 +3981,7 @@ func (x *GetModuleNamesReply) String() string {
 func (*GetModuleNamesReply) ProtoMessage() {}
 
 func (x *GetModuleNamesReply) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[59]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3338,7 +3994,7 @@ func (x *GetModuleNamesReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetModuleNamesReply.ProtoReflect.Descriptor instead.
 func (*GetModuleNamesReply) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{59}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{67}
 }
 
 func (x *GetModuleNamesReply) GetModuleNames() []string {
@@ -3362,7 +4018,7 @@ type GetGidsByFunctionNameReply_Body struct {
 
 func (x *GetGidsByFunctionNameReply_Body) Reset() {
 	*x = GetGidsByFunctionNameReply_Body{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[60]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3374,7 +4030,7 @@ func (x *GetGidsByFunctionNameReply_Body) String() string {
 func (*GetGidsByFunctionNameReply_Body) ProtoMessage() {}
 
 func (x *GetGidsByFunctionNameReply_Body) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[60]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3443,13 +4099,15 @@ type AnalysisByGIDReply_TraceData struct {
 	TimeCost      string                 `protobuf:"bytes,7,opt,name=timeCost,proto3" json:"timeCost,omitempty"`
 	ParentId      int64                  `protobuf:"varint,8,opt,name=parentId,proto3" json:"parentId,omitempty"` // 父函数ID
 	Seq           string                 `protobuf:"bytes,9,opt,name=seq,proto3" json:"seq,omitempty"`            // 序列号
+	Error         string                 `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`       // 返回的错误信息, 插桩时开启返回值捕获才会记录
+	Panic         string                 `protobuf:"bytes,11,opt,name=panic,proto3" json:"panic,omitempty"`       // recover到的panic信息
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AnalysisByGIDReply_TraceData) Reset() {
 	*x = AnalysisByGIDReply_TraceData{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[61]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3461,7 +4119,7 @@ func (x *AnalysisByGIDReply_TraceData) String() string {
 func (*AnalysisByGIDReply_TraceData) ProtoMessage() {}
 
 func (x *AnalysisByGIDReply_TraceData) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[61]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3540,6 +4198,20 @@ func (x *AnalysisByGIDReply_TraceData) GetSeq() string {
 	return ""
 }
 
+func (x *AnalysisByGIDReply_TraceData) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AnalysisByGIDReply_TraceData) GetPanic() string {
+	if x != nil {
+		return x.Panic
+	}
+	return ""
+}
+
 type GetAllGIDsReply_Body struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Gid           uint64                 `protobuf:"varint,1,opt,name=gid,proto3" json:"gid,omitempty"`
@@ -3553,7 +4225,7 @@ type GetAllGIDsReply_Body struct {
 
 func (x *GetAllGIDsReply_Body) Reset() {
 	*x = GetAllGIDsReply_Body{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[62]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3565,7 +4237,7 @@ func (x *GetAllGIDsReply_Body) String() string {
 func (*GetAllGIDsReply_Body) ProtoMessage() {}
 
 func (x *GetAllGIDsReply_Body) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[62]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3627,13 +4299,15 @@ type GetTracesByParentFuncReply_TraceData struct {
 	TimeCost      string                 `protobuf:"bytes,7,opt,name=timeCost,proto3" json:"timeCost,omitempty"`
 	ParentId      int64                  `protobuf:"varint,8,opt,name=parentId,proto3" json:"parentId,omitempty"` // 父函数ID
 	Seq           string                 `protobuf:"bytes,9,opt,name=seq,proto3" json:"seq,omitempty"`            // 序列号
+	Error         string                 `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`       // 返回的错误信息
+	Panic         string                 `protobuf:"bytes,11,opt,name=panic,proto3" json:"panic,omitempty"`       // recover到的panic信息
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetTracesByParentFuncReply_TraceData) Reset() {
 	*x = GetTracesByParentFuncReply_TraceData{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[63]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3645,7 +4319,7 @@ func (x *GetTracesByParentFuncReply_TraceData) String() string {
 func (*GetTracesByParentFuncReply_TraceData) ProtoMessage() {}
 
 func (x *GetTracesByParentFuncReply_TraceData) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[63]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3724,6 +4398,20 @@ func (x *GetTracesByParentFuncReply_TraceData) GetSeq() string {
 	return ""
 }
 
+func (x *GetTracesByParentFuncReply_TraceData) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetTracesByParentFuncReply_TraceData) GetPanic() string {
+	if x != nil {
+		return x.Panic
+	}
+	return ""
+}
+
 type GetFunctionAnalysisReply_FunctionNode struct {
 	state         protoimpl.MessageState                   `protogen:"open.v1"`
 	Id            string                                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                // 节点ID
@@ -3738,7 +4426,7 @@ type GetFunctionAnalysisReply_FunctionNode struct {
 
 func (x *GetFunctionAnalysisReply_FunctionNode) Reset() {
 	*x = GetFunctionAnalysisReply_FunctionNode{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[64]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3750,7 +4438,7 @@ func (x *GetFunctionAnalysisReply_FunctionNode) String() string {
 func (*GetFunctionAnalysisReply_FunctionNode) ProtoMessage() {}
 
 func (x *GetFunctionAnalysisReply_FunctionNode) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[64]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3821,7 +4509,7 @@ type GetHotFunctionsReply_HotFunction struct {
 
 func (x *GetHotFunctionsReply_HotFunction) Reset() {
 	*x = GetHotFunctionsReply_HotFunction{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[66]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3833,7 +4521,7 @@ func (x *GetHotFunctionsReply_HotFunction) String() string {
 func (*GetHotFunctionsReply_HotFunction) ProtoMessage() {}
 
 func (x *GetHotFunctionsReply_HotFunction) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[66]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3846,7 +4534,7 @@ func (x *GetHotFunctionsReply_HotFunction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetHotFunctionsReply_HotFunction.ProtoReflect.Descriptor instead.
 func (*GetHotFunctionsReply_HotFunction) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{52, 0}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{60, 0}
 }
 
 func (x *GetHotFunctionsReply_HotFunction) GetName() string {
@@ -3894,7 +4582,7 @@ type SearchFunctionsReply_FunctionInfo struct {
 
 func (x *SearchFunctionsReply_FunctionInfo) Reset() {
 	*x = SearchFunctionsReply_FunctionInfo{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[67]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3906,7 +4594,7 @@ func (x *SearchFunctionsReply_FunctionInfo) String() string {
 func (*SearchFunctionsReply_FunctionInfo) ProtoMessage() {}
 
 func (x *SearchFunctionsReply_FunctionInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[67]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3919,7 +4607,7 @@ func (x *SearchFunctionsReply_FunctionInfo) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use SearchFunctionsReply_FunctionInfo.ProtoReflect.Descriptor instead.
 func (*SearchFunctionsReply_FunctionInfo) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{54, 0}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{62, 0}
 }
 
 func (x *SearchFunctionsReply_FunctionInfo) GetName() string {
@@ -3948,7 +4636,7 @@ type GetFunctionInfoInGoroutineReply_FunctionInfo struct {
 
 func (x *GetFunctionInfoInGoroutineReply_FunctionInfo) Reset() {
 	*x = GetFunctionInfoInGoroutineReply_FunctionInfo{}
-	mi := &file_analysis_v1_analysis_proto_msgTypes[68]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3960,7 +4648,7 @@ func (x *GetFunctionInfoInGoroutineReply_FunctionInfo) String() string {
 func (*GetFunctionInfoInGoroutineReply_FunctionInfo) ProtoMessage() {}
 
 func (x *GetFunctionInfoInGoroutineReply_FunctionInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_v1_analysis_proto_msgTypes[68]
+	mi := &file_analysis_v1_analysis_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3973,7 +4661,7 @@ func (x *GetFunctionInfoInGoroutineReply_FunctionInfo) ProtoReflect() protorefle
 
 // Deprecated: Use GetFunctionInfoInGoroutineReply_FunctionInfo.ProtoReflect.Descriptor instead.
 func (*GetFunctionInfoInGoroutineReply_FunctionInfo) Descriptor() ([]byte, []int) {
-	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{57, 0}
+	return file_analysis_v1_analysis_proto_rawDescGZIP(), []int{65, 0}
 }
 
 func (x *GetFunctionInfoInGoroutineReply_FunctionInfo) GetId() int64 {
@@ -4054,9 +4742,9 @@ const file_analysis_v1_analysis_proto_rawDesc = "" +
 	"createTime\"5\n" +
 	"\vTraceParams\x12\x10\n" +
 	"\x03pos\x18\x01 \x01(\x05R\x03pos\x12\x14\n" +
-	"\x05param\x18\x02 \x01(\tR\x05param\"\xd5\x02\n" +
+	"\x05param\x18\x02 \x01(\tR\x05param\"\x81\x03\n" +
 	"\x12AnalysisByGIDReply\x12G\n" +
-	"\ttraceData\x18\x01 \x03(\v2).analysis.v1.AnalysisByGIDReply.TraceDataR\ttraceData\x1a\xf5\x01\n" +
+	"\ttraceData\x18\x01 \x03(\v2).analysis.v1.AnalysisByGIDReply.TraceDataR\ttraceData\x1a\xa1\x02\n" +
 	"\tTraceData\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
@@ -4068,7 +4756,10 @@ const file_analysis_v1_analysis_proto_rawDesc = "" +
 	"paramCount\x12\x1a\n" +
 	"\btimeCost\x18\a \x01(\tR\btimeCost\x12\x1a\n" +
 	"\bparentId\x18\b \x01(\x03R\bparentId\x12\x10\n" +
-	"\x03seq\x18\t \x01(\tR\x03seq\"y\n" +
+	"\x03seq\x18\t \x01(\tR\x03seq\x12\x14\n" +
+	"\x05error\x18\n" +
+	" \x01(\tR\x05error\x12\x14\n" +
+	"\x05panic\x18\v \x01(\tR\x05panic\"y\n" +
 	"\rGetAllGIDsReq\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12&\n" +
@@ -4109,9 +4800,9 @@ const file_analysis_v1_analysis_proto_rawDesc = "" +
 	"\x05edges\x18\x02 \x03(\v2\x16.analysis.v1.GraphEdgeR\x05edges\"N\n" +
 	"\x18GetTracesByParentFuncReq\x12\x1a\n" +
 	"\bparentId\x18\x01 \x01(\x03R\bparentId\x12\x16\n" +
-	"\x06dbpath\x18\x02 \x01(\tR\x06dbpath\"\xe5\x02\n" +
+	"\x06dbpath\x18\x02 \x01(\tR\x06dbpath\"\x91\x03\n" +
 	"\x1aGetTracesByParentFuncReply\x12O\n" +
-	"\ttraceData\x18\x01 \x03(\v21.analysis.v1.GetTracesByParentFuncReply.TraceDataR\ttraceData\x1a\xf5\x01\n" +
+	"\ttraceData\x18\x01 \x03(\v21.analysis.v1.GetTracesByParentFuncReply.TraceDataR\ttraceData\x1a\xa1\x02\n" +
 	"\tTraceData\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
@@ -4123,7 +4814,10 @@ const file_analysis_v1_analysis_proto_rawDesc = "" +
 	"paramCount\x12\x1a\n" +
 	"\btimeCost\x18\a \x01(\tR\btimeCost\x12\x1a\n" +
 	"\bparentId\x18\b \x01(\x03R\bparentId\x12\x10\n" +
-	"\x03seq\x18\t \x01(\tR\x03seq\"S\n" +
+	"\x03seq\x18\t \x01(\tR\x03seq\x12\x14\n" +
+	"\x05error\x18\n" +
+	" \x01(\tR\x05error\x12\x14\n" +
+	"\x05panic\x18\v \x01(\tR\x05panic\"S\n" +
 	"\x15GetParentFunctionsReq\x12\x16\n" +
 	"\x06dbpath\x18\x01 \x01(\tR\x06dbpath\x12\"\n" +
 	"\ffunctionName\x18\x02 \x01(\tR\ffunctionName\"\xe8\x01\n" +
@@ -4188,12 +4882,69 @@ const file_analysis_v1_analysis_proto_rawDesc = "" +
 	"totalCalls\x12$\n" +
 	"\rtotalPackages\x18\x03 \x01(\x05R\rtotalPackages\x12P\n" +
 	"\x13packageDependencies\x18\x04 \x03(\v2\x1e.analysis.v1.PackageDependencyR\x13packageDependencies\x12<\n" +
-	"\fhotFunctions\x18\x05 \x03(\v2\x18.analysis.v1.HotFunctionR\fhotFunctions\"*\n" +
+	"\fhotFunctions\x18\x05 \x03(\v2\x18.analysis.v1.HotFunctionR\fhotFunctions\"\x83\x02\n" +
 	"\x14InstrumentProjectReq\x12\x12\n" +
-	"\x04path\x18\x01 \x01(\tR\x04path\"L\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04undo\x18\x02 \x01(\bR\x04undo\x12\x1e\n" +
+	"\n" +
+	"overlayDir\x18\x03 \x01(\tR\n" +
+	"overlayDir\x125\n" +
+	"\x06filter\x18\x04 \x01(\v2\x1d.analysis.v1.InstrumentFilterR\x06filter\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\x12\x14\n" +
+	"\x05async\x18\x06 \x01(\bR\x05async\x12'\n" +
+	"\x0fline_directives\x18\a \x01(\bR\x0elineDirectives\x12\x14\n" +
+	"\x05tests\x18\b \x01(\bR\x05tests\"c\n" +
+	"\x0fInstrumentStats\x12\x14\n" +
+	"\x05funcs\x18\x01 \x01(\x05R\x05funcs\x12\x1a\n" +
+	"\bclosures\x18\x02 \x01(\x05R\bclosures\x12\x1e\n" +
+	"\n" +
+	"goroutines\x18\x03 \x01(\x05R\n" +
+	"goroutines\"p\n" +
+	"\x12InstrumentFileDiff\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04diff\x18\x02 \x01(\tR\x04diff\x122\n" +
+	"\x05stats\x18\x03 \x01(\v2\x1c.analysis.v1.InstrumentStatsR\x05stats\"\xae\x02\n" +
+	"\x10InstrumentFilter\x12 \n" +
+	"\vincludePkgs\x18\x01 \x03(\tR\vincludePkgs\x12 \n" +
+	"\vexcludePkgs\x18\x02 \x03(\tR\vexcludePkgs\x12\"\n" +
+	"\fincludeFiles\x18\x03 \x03(\tR\fincludeFiles\x12\"\n" +
+	"\fexcludeFiles\x18\x04 \x03(\tR\fexcludeFiles\x12\"\n" +
+	"\fincludeFuncs\x18\x05 \x03(\tR\fincludeFuncs\x12\"\n" +
+	"\fexcludeFuncs\x18\x06 \x03(\tR\fexcludeFuncs\x12\"\n" +
+	"\fincludeRecvs\x18\a \x03(\tR\fincludeRecvs\x12\"\n" +
+	"\fexcludeRecvs\x18\b \x03(\tR\fexcludeRecvs\"k\n" +
+	"\x11InstrumentSkipped\x12\x12\n" +
+	"\x04file\x18\x01 \x01(\tR\x04file\x12\x12\n" +
+	"\x04func\x18\x02 \x01(\tR\x04func\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06detail\x18\x04 \x01(\tR\x06detail\"\xf8\x02\n" +
 	"\x16InstrumentProjectReply\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x81\x01\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05files\x18\x03 \x03(\tR\x05files\x12 \n" +
+	"\voverlayPath\x18\x04 \x01(\tR\voverlayPath\x128\n" +
+	"\askipped\x18\x05 \x03(\v2\x1e.analysis.v1.InstrumentSkippedR\askipped\x125\n" +
+	"\x05diffs\x18\x06 \x03(\v2\x1f.analysis.v1.InstrumentFileDiffR\x05diffs\x122\n" +
+	"\x05stats\x18\a \x01(\v2\x1c.analysis.v1.InstrumentStatsR\x05stats\x12\x16\n" +
+	"\x06taskId\x18\b \x01(\tR\x06taskId\x125\n" +
+	"\x06report\x18\t \x01(\v2\x1d.analysis.v1.InstrumentReportR\x06report\"k\n" +
+	"\x0fInstrumentError\x12\x12\n" +
+	"\x04file\x18\x01 \x01(\tR\x04file\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x05R\x04line\x12\x16\n" +
+	"\x06column\x18\x03 \x01(\x05R\x06column\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\xd2\x01\n" +
+	"\x10InstrumentReport\x12\x1c\n" +
+	"\trewritten\x18\x01 \x03(\tR\trewritten\x12\x18\n" +
+	"\askipped\x18\x02 \x03(\tR\askipped\x124\n" +
+	"\x06errors\x18\x03 \x03(\v2\x1c.analysis.v1.InstrumentErrorR\x06errors\x122\n" +
+	"\x05stats\x18\x04 \x01(\v2\x1c.analysis.v1.InstrumentStatsR\x05stats\x12\x1c\n" +
+	"\tgenerated\x18\x05 \x03(\tR\tgenerated\".\n" +
+	"\x14GetInstrumentTaskReq\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\tR\x06taskId\"\x85\x01\n" +
+	"\x16GetInstrumentTaskReply\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\tR\x06taskId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12;\n" +
+	"\x06result\x18\x03 \x01(\v2#.analysis.v1.InstrumentProjectReplyR\x06result\"\x81\x01\n" +
 	"\x0fGetTreeGraphReq\x12\x16\n" +
 	"\x06dbPath\x18\x01 \x01(\tR\x06dbPath\x12\"\n" +
 	"\ffunctionName\x18\x02 \x01(\tR\ffunctionName\x12\x1c\n" +
@@ -4292,10 +5043,11 @@ const file_analysis_v1_analysis_proto_rawDesc = "" +
 	"maxSamples\x18\x02 \x01(\x05R\n" +
 	"maxSamples\"7\n" +
 	"\x13GetModuleNamesReply\x12 \n" +
-	"\vmoduleNames\x18\x01 \x03(\tR\vmoduleNames2\xbb\x10\n" +
+	"\vmoduleNames\x18\x01 \x03(\tR\vmoduleNames2\xc3\x11\n" +
 	"\bAnalysis\x12d\n" +
 	"\vGetAnalysis\x12\x1c.analysis.v1.AnalysisRequest\x1a\x1a.analysis.v1.AnalysisReply\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/analysis/{name}\x12\x7f\n" +
-	"\x11InstrumentProject\x12!.analysis.v1.InstrumentProjectReq\x1a#.analysis.v1.InstrumentProjectReply\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/runtime/instrument\x12|\n" +
+	"\x11InstrumentProject\x12!.analysis.v1.InstrumentProjectReq\x1a#.analysis.v1.InstrumentProjectReply\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/runtime/instrument\x12\x85\x01\n" +
+	"\x11GetInstrumentTask\x12!.analysis.v1.GetInstrumentTaskReq\x1a#.analysis.v1.GetInstrumentTaskReply\"(\x82\xd3\xe4\x93\x02\"\x12 /api/runtime/instrument/{taskId}\x12|\n" +
 	"\x10GetAnalysisByGID\x12!.analysis.v1.AnalysisByGIDRequest\x1a\x1f.analysis.v1.AnalysisByGIDReply\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/runtime/traces/{gid}\x12d\n" +
 	"\n" +
 	"GetAllGIDs\x12\x1a.analysis.v1.GetAllGIDsReq\x1a\x1c.analysis.v1.GetAllGIDsReply\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/api/runtime/gids\x12t\n" +
@@ -4324,7 +5076,7 @@ func file_analysis_v1_analysis_proto_rawDescGZIP() []byte {
 	return file_analysis_v1_analysis_proto_rawDescData
 }
 
-var file_analysis_v1_analysis_proto_msgTypes = make([]protoimpl.MessageInfo, 69)
+var file_analysis_v1_analysis_proto_msgTypes = make([]protoimpl.MessageInfo, 77)
 var file_analysis_v1_analysis_proto_goTypes = []any{
 	(*VerifyProjectPathReq)(nil),                  // 0: analysis.v1.VerifyProjectPathReq
 	(*VerifyProjectPathReply)(nil),                // 1: analysis.v1.VerifyProjectPathReply
@@ -4365,100 +5117,119 @@ var file_analysis_v1_analysis_proto_goTypes = []any{
 	(*HotFunction)(nil),                           // 36: analysis.v1.HotFunction
 	(*AnalyzeDbFileResponse)(nil),                 // 37: analysis.v1.AnalyzeDbFileResponse
 	(*InstrumentProjectReq)(nil),                  // 38: analysis.v1.InstrumentProjectReq
-	(*InstrumentProjectReply)(nil),                // 39: analysis.v1.InstrumentProjectReply
-	(*GetTreeGraphReq)(nil),                       // 40: analysis.v1.GetTreeGraphReq
-	(*TreeNode)(nil),                              // 41: analysis.v1.TreeNode
-	(*GetTreeGraphReply)(nil),                     // 42: analysis.v1.GetTreeGraphReply
-	(*GetTreeGraphByGIDReq)(nil),                  // 43: analysis.v1.GetTreeGraphByGIDReq
-	(*GetTreeGraphByGIDReply)(nil),                // 44: analysis.v1.GetTreeGraphByGIDReply
-	(*GetFunctionCallStatsReq)(nil),               // 45: analysis.v1.GetFunctionCallStatsReq
-	(*FunctionCallStats)(nil),                     // 46: analysis.v1.FunctionCallStats
-	(*GetFunctionCallStatsReply)(nil),             // 47: analysis.v1.GetFunctionCallStatsReply
-	(*GetPerformanceAnomaliesReq)(nil),            // 48: analysis.v1.GetPerformanceAnomaliesReq
-	(*PerformanceAnomaly)(nil),                    // 49: analysis.v1.PerformanceAnomaly
-	(*GetPerformanceAnomaliesReply)(nil),          // 50: analysis.v1.GetPerformanceAnomaliesReply
-	(*GetHotFunctionsReq)(nil),                    // 51: analysis.v1.GetHotFunctionsReq
-	(*GetHotFunctionsReply)(nil),                  // 52: analysis.v1.GetHotFunctionsReply
-	(*SearchFunctionsReq)(nil),                    // 53: analysis.v1.SearchFunctionsReq
-	(*SearchFunctionsReply)(nil),                  // 54: analysis.v1.SearchFunctionsReply
-	(*GetFunctionInfoInGoroutineReq)(nil),         // 55: analysis.v1.GetFunctionInfoInGoroutineReq
-	(*ParentInfo)(nil),                            // 56: analysis.v1.ParentInfo
-	(*GetFunctionInfoInGoroutineReply)(nil),       // 57: analysis.v1.GetFunctionInfoInGoroutineReply
-	(*GetModuleNamesReq)(nil),                     // 58: analysis.v1.GetModuleNamesReq
-	(*GetModuleNamesReply)(nil),                   // 59: analysis.v1.GetModuleNamesReply
-	(*GetGidsByFunctionNameReply_Body)(nil),       // 60: analysis.v1.GetGidsByFunctionNameReply.Body
-	(*AnalysisByGIDReply_TraceData)(nil),          // 61: analysis.v1.AnalysisByGIDReply.TraceData
-	(*GetAllGIDsReply_Body)(nil),                  // 62: analysis.v1.GetAllGIDsReply.Body
-	(*GetTracesByParentFuncReply_TraceData)(nil),  // 63: analysis.v1.GetTracesByParentFuncReply.TraceData
-	(*GetFunctionAnalysisReply_FunctionNode)(nil), // 64: analysis.v1.GetFunctionAnalysisReply.FunctionNode
-	nil,                                      // 65: analysis.v1.PerformanceAnomaly.DetailsEntry
-	(*GetHotFunctionsReply_HotFunction)(nil), // 66: analysis.v1.GetHotFunctionsReply.HotFunction
-	(*SearchFunctionsReply_FunctionInfo)(nil),            // 67: analysis.v1.SearchFunctionsReply.FunctionInfo
-	(*GetFunctionInfoInGoroutineReply_FunctionInfo)(nil), // 68: analysis.v1.GetFunctionInfoInGoroutineReply.FunctionInfo
+	(*InstrumentStats)(nil),                       // 39: analysis.v1.InstrumentStats
+	(*InstrumentFileDiff)(nil),                    // 40: analysis.v1.InstrumentFileDiff
+	(*InstrumentFilter)(nil),                      // 41: analysis.v1.InstrumentFilter
+	(*InstrumentSkipped)(nil),                     // 42: analysis.v1.InstrumentSkipped
+	(*InstrumentProjectReply)(nil),                // 43: analysis.v1.InstrumentProjectReply
+	(*InstrumentError)(nil),                       // 44: analysis.v1.InstrumentError
+	(*InstrumentReport)(nil),                      // 45: analysis.v1.InstrumentReport
+	(*GetInstrumentTaskReq)(nil),                  // 46: analysis.v1.GetInstrumentTaskReq
+	(*GetInstrumentTaskReply)(nil),                // 47: analysis.v1.GetInstrumentTaskReply
+	(*GetTreeGraphReq)(nil),                       // 48: analysis.v1.GetTreeGraphReq
+	(*TreeNode)(nil),                              // 49: analysis.v1.TreeNode
+	(*GetTreeGraphReply)(nil),                     // 50: analysis.v1.GetTreeGraphReply
+	(*GetTreeGraphByGIDReq)(nil),                  // 51: analysis.v1.GetTreeGraphByGIDReq
+	(*GetTreeGraphByGIDReply)(nil),                // 52: analysis.v1.GetTreeGraphByGIDReply
+	(*GetFunctionCallStatsReq)(nil),               // 53: analysis.v1.GetFunctionCallStatsReq
+	(*FunctionCallStats)(nil),                     // 54: analysis.v1.FunctionCallStats
+	(*GetFunctionCallStatsReply)(nil),             // 55: analysis.v1.GetFunctionCallStatsReply
+	(*GetPerformanceAnomaliesReq)(nil),            // 56: analysis.v1.GetPerformanceAnomaliesReq
+	(*PerformanceAnomaly)(nil),                    // 57: analysis.v1.PerformanceAnomaly
+	(*GetPerformanceAnomaliesReply)(nil),          // 58: analysis.v1.GetPerformanceAnomaliesReply
+	(*GetHotFunctionsReq)(nil),                    // 59: analysis.v1.GetHotFunctionsReq
+	(*GetHotFunctionsReply)(nil),                  // 60: analysis.v1.GetHotFunctionsReply
+	(*SearchFunctionsReq)(nil),                    // 61: analysis.v1.SearchFunctionsReq
+	(*SearchFunctionsReply)(nil),                  // 62: analysis.v1.SearchFunctionsReply
+	(*GetFunctionInfoInGoroutineReq)(nil),         // 63: analysis.v1.GetFunctionInfoInGoroutineReq
+	(*ParentInfo)(nil),                            // 64: analysis.v1.ParentInfo
+	(*GetFunctionInfoInGoroutineReply)(nil),       // 65: analysis.v1.GetFunctionInfoInGoroutineReply
+	(*GetModuleNamesReq)(nil),                     // 66: analysis.v1.GetModuleNamesReq
+	(*GetModuleNamesReply)(nil),                   // 67: analysis.v1.GetModuleNamesReply
+	(*GetGidsByFunctionNameReply_Body)(nil),       // 68: analysis.v1.GetGidsByFunctionNameReply.Body
+	(*AnalysisByGIDReply_TraceData)(nil),          // 69: analysis.v1.AnalysisByGIDReply.TraceData
+	(*GetAllGIDsReply_Body)(nil),                  // 70: analysis.v1.GetAllGIDsReply.Body
+	(*GetTracesByParentFuncReply_TraceData)(nil),  // 71: analysis.v1.GetTracesByParentFuncReply.TraceData
+	(*GetFunctionAnalysisReply_FunctionNode)(nil), // 72: analysis.v1.GetFunctionAnalysisReply.FunctionNode
+	nil,                                      // 73: analysis.v1.PerformanceAnomaly.DetailsEntry
+	(*GetHotFunctionsReply_HotFunction)(nil), // 74: analysis.v1.GetHotFunctionsReply.HotFunction
+	(*SearchFunctionsReply_FunctionInfo)(nil),            // 75: analysis.v1.SearchFunctionsReply.FunctionInfo
+	(*GetFunctionInfoInGoroutineReply_FunctionInfo)(nil), // 76: analysis.v1.GetFunctionInfoInGoroutineReply.FunctionInfo
 }
 var file_analysis_v1_analysis_proto_depIdxs = []int32{
-	60, // 0: analysis.v1.GetGidsByFunctionNameReply.body:type_name -> analysis.v1.GetGidsByFunctionNameReply.Body
-	61, // 1: analysis.v1.AnalysisByGIDReply.traceData:type_name -> analysis.v1.AnalysisByGIDReply.TraceData
-	62, // 2: analysis.v1.GetAllGIDsReply.body:type_name -> analysis.v1.GetAllGIDsReply.Body
+	68, // 0: analysis.v1.GetGidsByFunctionNameReply.body:type_name -> analysis.v1.GetGidsByFunctionNameReply.Body
+	69, // 1: analysis.v1.AnalysisByGIDReply.traceData:type_name -> analysis.v1.AnalysisByGIDReply.TraceData
+	70, // 2: analysis.v1.GetAllGIDsReply.body:type_name -> analysis.v1.GetAllGIDsReply.Body
 	11, // 3: analysis.v1.GetParamsByIDReply.params:type_name -> analysis.v1.TraceParams
 	17, // 4: analysis.v1.GetTraceGraphReply.nodes:type_name -> analysis.v1.GraphNode
 	18, // 5: analysis.v1.GetTraceGraphReply.edges:type_name -> analysis.v1.GraphEdge
-	63, // 6: analysis.v1.GetTracesByParentFuncReply.traceData:type_name -> analysis.v1.GetTracesByParentFuncReply.TraceData
+	71, // 6: analysis.v1.GetTracesByParentFuncReply.traceData:type_name -> analysis.v1.GetTracesByParentFuncReply.TraceData
 	24, // 7: analysis.v1.GetParentFunctionsReply.functions:type_name -> analysis.v1.FunctionNode
 	24, // 8: analysis.v1.GetChildFunctionsReply.functions:type_name -> analysis.v1.FunctionNode
-	64, // 9: analysis.v1.GetFunctionAnalysisReply.callData:type_name -> analysis.v1.GetFunctionAnalysisReply.FunctionNode
+	72, // 9: analysis.v1.GetFunctionAnalysisReply.callData:type_name -> analysis.v1.GetFunctionAnalysisReply.FunctionNode
 	35, // 10: analysis.v1.AnalyzeDbFileResponse.packageDependencies:type_name -> analysis.v1.PackageDependency
 	36, // 11: analysis.v1.AnalyzeDbFileResponse.hotFunctions:type_name -> analysis.v1.HotFunction
-	41, // 12: analysis.v1.TreeNode.children:type_name -> analysis.v1.TreeNode
-	41, // 13: analysis.v1.GetTreeGraphReply.trees:type_name -> analysis.v1.TreeNode
-	41, // 14: analysis.v1.GetTreeGraphByGIDReply.trees:type_name -> analysis.v1.TreeNode
-	46, // 15: analysis.v1.GetFunctionCallStatsReply.stats:type_name -> analysis.v1.FunctionCallStats
-	65, // 16: analysis.v1.PerformanceAnomaly.details:type_name -> analysis.v1.PerformanceAnomaly.DetailsEntry
-	49, // 17: analysis.v1.GetPerformanceAnomaliesReply.anomalies:type_name -> analysis.v1.PerformanceAnomaly
-	66, // 18: analysis.v1.GetHotFunctionsReply.functions:type_name -> analysis.v1.GetHotFunctionsReply.HotFunction
-	67, // 19: analysis.v1.SearchFunctionsReply.functions:type_name -> analysis.v1.SearchFunctionsReply.FunctionInfo
-	68, // 20: analysis.v1.GetFunctionInfoInGoroutineReply.functionInfo:type_name -> analysis.v1.GetFunctionInfoInGoroutineReply.FunctionInfo
-	11, // 21: analysis.v1.AnalysisByGIDReply.TraceData.params:type_name -> analysis.v1.TraceParams
-	11, // 22: analysis.v1.GetTracesByParentFuncReply.TraceData.params:type_name -> analysis.v1.TraceParams
-	64, // 23: analysis.v1.GetFunctionAnalysisReply.FunctionNode.children:type_name -> analysis.v1.GetFunctionAnalysisReply.FunctionNode
-	56, // 24: analysis.v1.GetFunctionInfoInGoroutineReply.FunctionInfo.parentIds:type_name -> analysis.v1.ParentInfo
-	8,  // 25: analysis.v1.Analysis.GetAnalysis:input_type -> analysis.v1.AnalysisRequest
-	38, // 26: analysis.v1.Analysis.InstrumentProject:input_type -> analysis.v1.InstrumentProjectReq
-	10, // 27: analysis.v1.Analysis.GetAnalysisByGID:input_type -> analysis.v1.AnalysisByGIDRequest
-	13, // 28: analysis.v1.Analysis.GetAllGIDs:input_type -> analysis.v1.GetAllGIDsReq
-	15, // 29: analysis.v1.Analysis.GetParamsByID:input_type -> analysis.v1.GetParamsByIDReq
-	2,  // 30: analysis.v1.Analysis.GetGidsByFunctionName:input_type -> analysis.v1.GetGidsByFunctionNameReq
-	0,  // 31: analysis.v1.Analysis.VerifyProjectPath:input_type -> analysis.v1.VerifyProjectPathReq
-	21, // 32: analysis.v1.Analysis.GetTracesByParentFunc:input_type -> analysis.v1.GetTracesByParentFuncReq
-	23, // 33: analysis.v1.Analysis.GetParentFunctions:input_type -> analysis.v1.GetParentFunctionsReq
-	26, // 34: analysis.v1.Analysis.GetChildFunctions:input_type -> analysis.v1.GetChildFunctionsReq
-	51, // 35: analysis.v1.Analysis.GetHotFunctions:input_type -> analysis.v1.GetHotFunctionsReq
-	28, // 36: analysis.v1.Analysis.GetGoroutineStats:input_type -> analysis.v1.GetGoroutineStatsReq
-	45, // 37: analysis.v1.Analysis.GetFunctionCallStats:input_type -> analysis.v1.GetFunctionCallStatsReq
-	53, // 38: analysis.v1.Analysis.SearchFunctions:input_type -> analysis.v1.SearchFunctionsReq
-	55, // 39: analysis.v1.Analysis.GetFunctionInfoInGoroutine:input_type -> analysis.v1.GetFunctionInfoInGoroutineReq
-	58, // 40: analysis.v1.Analysis.GetModuleNames:input_type -> analysis.v1.GetModuleNamesReq
-	9,  // 41: analysis.v1.Analysis.GetAnalysis:output_type -> analysis.v1.AnalysisReply
-	39, // 42: analysis.v1.Analysis.InstrumentProject:output_type -> analysis.v1.InstrumentProjectReply
-	12, // 43: analysis.v1.Analysis.GetAnalysisByGID:output_type -> analysis.v1.AnalysisByGIDReply
-	14, // 44: analysis.v1.Analysis.GetAllGIDs:output_type -> analysis.v1.GetAllGIDsReply
-	16, // 45: analysis.v1.Analysis.GetParamsByID:output_type -> analysis.v1.GetParamsByIDReply
-	3,  // 46: analysis.v1.Analysis.GetGidsByFunctionName:output_type -> analysis.v1.GetGidsByFunctionNameReply
-	1,  // 47: analysis.v1.Analysis.VerifyProjectPath:output_type -> analysis.v1.VerifyProjectPathReply
-	22, // 48: analysis.v1.Analysis.GetTracesByParentFunc:output_type -> analysis.v1.GetTracesByParentFuncReply
-	25, // 49: analysis.v1.Analysis.GetParentFunctions:output_type -> analysis.v1.GetParentFunctionsReply
-	27, // 50: analysis.v1.Analysis.GetChildFunctions:output_type -> analysis.v1.GetChildFunctionsReply
-	52, // 51: analysis.v1.Analysis.GetHotFunctions:output_type -> analysis.v1.GetHotFunctionsReply
-	29, // 52: analysis.v1.Analysis.GetGoroutineStats:output_type -> analysis.v1.GetGoroutineStatsReply
-	47, // 53: analysis.v1.Analysis.GetFunctionCallStats:output_type -> analysis.v1.GetFunctionCallStatsReply
-	54, // 54: analysis.v1.Analysis.SearchFunctions:output_type -> analysis.v1.SearchFunctionsReply
-	57, // 55: analysis.v1.Analysis.GetFunctionInfoInGoroutine:output_type -> analysis.v1.GetFunctionInfoInGoroutineReply
-	59, // 56: analysis.v1.Analysis.GetModuleNames:output_type -> analysis.v1.GetModuleNamesReply
-	41, // [41:57] is the sub-list for method output_type
-	25, // [25:41] is the sub-list for method input_type
-	25, // [25:25] is the sub-list for extension type_name
-	25, // [25:25] is the sub-list for extension extendee
-	0,  // [0:25] is the sub-list for field type_name
+	41, // 12: analysis.v1.InstrumentProjectReq.filter:type_name -> analysis.v1.InstrumentFilter
+	39, // 13: analysis.v1.InstrumentFileDiff.stats:type_name -> analysis.v1.InstrumentStats
+	42, // 14: analysis.v1.InstrumentProjectReply.skipped:type_name -> analysis.v1.InstrumentSkipped
+	40, // 15: analysis.v1.InstrumentProjectReply.diffs:type_name -> analysis.v1.InstrumentFileDiff
+	39, // 16: analysis.v1.InstrumentProjectReply.stats:type_name -> analysis.v1.InstrumentStats
+	45, // 17: analysis.v1.InstrumentProjectReply.report:type_name -> analysis.v1.InstrumentReport
+	44, // 18: analysis.v1.InstrumentReport.errors:type_name -> analysis.v1.InstrumentError
+	39, // 19: analysis.v1.InstrumentReport.stats:type_name -> analysis.v1.InstrumentStats
+	43, // 20: analysis.v1.GetInstrumentTaskReply.result:type_name -> analysis.v1.InstrumentProjectReply
+	49, // 21: analysis.v1.TreeNode.children:type_name -> analysis.v1.TreeNode
+	49, // 22: analysis.v1.GetTreeGraphReply.trees:type_name -> analysis.v1.TreeNode
+	49, // 23: analysis.v1.GetTreeGraphByGIDReply.trees:type_name -> analysis.v1.TreeNode
+	54, // 24: analysis.v1.GetFunctionCallStatsReply.stats:type_name -> analysis.v1.FunctionCallStats
+	73, // 25: analysis.v1.PerformanceAnomaly.details:type_name -> analysis.v1.PerformanceAnomaly.DetailsEntry
+	57, // 26: analysis.v1.GetPerformanceAnomaliesReply.anomalies:type_name -> analysis.v1.PerformanceAnomaly
+	74, // 27: analysis.v1.GetHotFunctionsReply.functions:type_name -> analysis.v1.GetHotFunctionsReply.HotFunction
+	75, // 28: analysis.v1.SearchFunctionsReply.functions:type_name -> analysis.v1.SearchFunctionsReply.FunctionInfo
+	76, // 29: analysis.v1.GetFunctionInfoInGoroutineReply.functionInfo:type_name -> analysis.v1.GetFunctionInfoInGoroutineReply.FunctionInfo
+	11, // 30: analysis.v1.AnalysisByGIDReply.TraceData.params:type_name -> analysis.v1.TraceParams
+	11, // 31: analysis.v1.GetTracesByParentFuncReply.TraceData.params:type_name -> analysis.v1.TraceParams
+	72, // 32: analysis.v1.GetFunctionAnalysisReply.FunctionNode.children:type_name -> analysis.v1.GetFunctionAnalysisReply.FunctionNode
+	64, // 33: analysis.v1.GetFunctionInfoInGoroutineReply.FunctionInfo.parentIds:type_name -> analysis.v1.ParentInfo
+	8,  // 34: analysis.v1.Analysis.GetAnalysis:input_type -> analysis.v1.AnalysisRequest
+	38, // 35: analysis.v1.Analysis.InstrumentProject:input_type -> analysis.v1.InstrumentProjectReq
+	46, // 36: analysis.v1.Analysis.GetInstrumentTask:input_type -> analysis.v1.GetInstrumentTaskReq
+	10, // 37: analysis.v1.Analysis.GetAnalysisByGID:input_type -> analysis.v1.AnalysisByGIDRequest
+	13, // 38: analysis.v1.Analysis.GetAllGIDs:input_type -> analysis.v1.GetAllGIDsReq
+	15, // 39: analysis.v1.Analysis.GetParamsByID:input_type -> analysis.v1.GetParamsByIDReq
+	2,  // 40: analysis.v1.Analysis.GetGidsByFunctionName:input_type -> analysis.v1.GetGidsByFunctionNameReq
+	0,  // 41: analysis.v1.Analysis.VerifyProjectPath:input_type -> analysis.v1.VerifyProjectPathReq
+	21, // 42: analysis.v1.Analysis.GetTracesByParentFunc:input_type -> analysis.v1.GetTracesByParentFuncReq
+	23, // 43: analysis.v1.Analysis.GetParentFunctions:input_type -> analysis.v1.GetParentFunctionsReq
+	26, // 44: analysis.v1.Analysis.GetChildFunctions:input_type -> analysis.v1.GetChildFunctionsReq
+	59, // 45: analysis.v1.Analysis.GetHotFunctions:input_type -> analysis.v1.GetHotFunctionsReq
+	28, // 46: analysis.v1.Analysis.GetGoroutineStats:input_type -> analysis.v1.GetGoroutineStatsReq
+	53, // 47: analysis.v1.Analysis.GetFunctionCallStats:input_type -> analysis.v1.GetFunctionCallStatsReq
+	61, // 48: analysis.v1.Analysis.SearchFunctions:input_type -> analysis.v1.SearchFunctionsReq
+	63, // 49: analysis.v1.Analysis.GetFunctionInfoInGoroutine:input_type -> analysis.v1.GetFunctionInfoInGoroutineReq
+	66, // 50: analysis.v1.Analysis.GetModuleNames:input_type -> analysis.v1.GetModuleNamesReq
+	9,  // 51: analysis.v1.Analysis.GetAnalysis:output_type -> analysis.v1.AnalysisReply
+	43, // 52: analysis.v1.Analysis.InstrumentProject:output_type -> analysis.v1.InstrumentProjectReply
+	47, // 53: analysis.v1.Analysis.GetInstrumentTask:output_type -> analysis.v1.GetInstrumentTaskReply
+	12, // 54: analysis.v1.Analysis.GetAnalysisByGID:output_type -> analysis.v1.AnalysisByGIDReply
+	14, // 55: analysis.v1.Analysis.GetAllGIDs:output_type -> analysis.v1.GetAllGIDsReply
+	16, // 56: analysis.v1.Analysis.GetParamsByID:output_type -> analysis.v1.GetParamsByIDReply
+	3,  // 57: analysis.v1.Analysis.GetGidsByFunctionName:output_type -> analysis.v1.GetGidsByFunctionNameReply
+	1,  // 58: analysis.v1.Analysis.VerifyProjectPath:output_type -> analysis.v1.VerifyProjectPathReply
+	22, // 59: analysis.v1.Analysis.GetTracesByParentFunc:output_type -> analysis.v1.GetTracesByParentFuncReply
+	25, // 60: analysis.v1.Analysis.GetParentFunctions:output_type -> analysis.v1.GetParentFunctionsReply
+	27, // 61: analysis.v1.Analysis.GetChildFunctions:output_type -> analysis.v1.GetChildFunctionsReply
+	60, // 62: analysis.v1.Analysis.GetHotFunctions:output_type -> analysis.v1.GetHotFunctionsReply
+	29, // 63: analysis.v1.Analysis.GetGoroutineStats:output_type -> analysis.v1.GetGoroutineStatsReply
+	55, // 64: analysis.v1.Analysis.GetFunctionCallStats:output_type -> analysis.v1.GetFunctionCallStatsReply
+	62, // 65: analysis.v1.Analysis.SearchFunctions:output_type -> analysis.v1.SearchFunctionsReply
+	65, // 66: analysis.v1.Analysis.GetFunctionInfoInGoroutine:output_type -> analysis.v1.GetFunctionInfoInGoroutineReply
+	67, // 67: analysis.v1.Analysis.GetModuleNames:output_type -> analysis.v1.GetModuleNamesReply
+	51, // [51:68] is the sub-list for method output_type
+	34, // [34:51] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_analysis_v1_analysis_proto_init() }
@@ -4472,7 +5243,7 @@ func file_analysis_v1_analysis_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_analysis_v1_analysis_proto_rawDesc), len(file_analysis_v1_analysis_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   69,
+			NumMessages:   77,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	Analysis_GetAnalysis_FullMethodName                = "/analysis.v1.Analysis/GetAnalysis"
 	Analysis_InstrumentProject_FullMethodName          = "/analysis.v1.Analysis/InstrumentProject"
+	Analysis_GetInstrumentTask_FullMethodName          = "/analysis.v1.Analysis/GetInstrumentTask"
 	Analysis_GetAnalysisByGID_FullMethodName           = "/analysis.v1.Analysis/GetAnalysisByGID"
 	Analysis_GetAllGIDs_FullMethodName                 = "/analysis.v1.Analysis/GetAllGIDs"
 	Analysis_GetParamsByID_FullMethodName              = "/analysis.v1.Analysis/GetParamsByID"
@@ -47,6 +48,8 @@ type AnalysisClient interface {
 	GetAnalysis(ctx context.Context, in *AnalysisRequest, opts ...grpc.CallOption) (*AnalysisReply, error)
 	// InstrumentProject 对项目进行插桩
 	InstrumentProject(ctx context.Context, in *InstrumentProjectReq, opts ...grpc.CallOption) (*InstrumentProjectReply, error)
+	// GetInstrumentTask 获取异步插桩任务的状态和结果, 进度通过 /api/static/analysis/{taskId} 推送
+	GetInstrumentTask(ctx context.Context, in *GetInstrumentTaskReq, opts ...grpc.CallOption) (*GetInstrumentTaskReply, error)
 	GetAnalysisByGID(ctx context.Context, in *AnalysisByGIDRequest, opts ...grpc.CallOption) (*AnalysisByGIDReply, error)
 	GetAllGIDs(ctx context.Context, in *GetAllGIDsReq, opts ...grpc.CallOption) (*GetAllGIDsReply, error)
 	GetParamsByID(ctx context.Context, in *GetParamsByIDReq, opts ...grpc.CallOption) (*GetParamsByIDReply, error)
@@ -101,6 +104,16 @@ func (c *analysisClient) InstrumentProject(ctx context.Context, in *InstrumentPr
 	return out, nil
 }
 
+func (c *analysisClient) GetInstrumentTask(ctx context.Context, in *GetInstrumentTaskReq, opts ...grpc.CallOption) (*GetInstrumentTaskReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInstrumentTaskReply)
+	err := c.cc.Invoke(ctx, Analysis_GetInstrumentTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *analysisClient) GetAnalysisByGID(ctx context.Context, in *AnalysisByGIDRequest, opts ...grpc.CallOption) (*AnalysisByGIDReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AnalysisByGIDReply)
@@ -251,6 +264,8 @@ type AnalysisServer interface {
 	GetAnalysis(context.Context, *AnalysisRequest) (*AnalysisReply, error)
 	// InstrumentProject 对项目进行插桩
 	InstrumentProject(context.Context, *InstrumentProjectReq) (*InstrumentProjectReply, error)
+	// GetInstrumentTask 获取异步插桩任务的状态和结果, 进度通过 /api/static/analysis/{taskId} 推送
+	GetInstrumentTask(context.Context, *GetInstrumentTaskReq) (*GetInstrumentTaskReply, error)
 	GetAnalysisByGID(context.Context, *AnalysisByGIDRequest) (*AnalysisByGIDReply, error)
 	GetAllGIDs(context.Context, *GetAllGIDsReq) (*GetAllGIDsReply, error)
 	GetParamsByID(context.Context, *GetParamsByIDReq) (*GetParamsByIDReply, error)
@@ -291,6 +306,9 @@ func (UnimplementedAnalysisServer) GetAnalysis(context.Context, *AnalysisRequest
 func (UnimplementedAnalysisServer) InstrumentProject(context.Context, *InstrumentProjectReq) (*InstrumentProjectReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InstrumentProject not implemented")
 }
+func (UnimplementedAnalysisServer) GetInstrumentTask(context.Context, *GetInstrumentTaskReq) (*GetInstrumentTaskReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstrumentTask not implemented")
+}
 func (UnimplementedAnalysisServer) GetAnalysisByGID(context.Context, *AnalysisByGIDRequest) (*AnalysisByGIDReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAnalysisByGID not implemented")
 }
@@ -390,6 +408,24 @@ func _Analysis_InstrumentProject_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Analysis_GetInstrumentTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstrumentTaskReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalysisServer).GetInstrumentTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Analysis_GetInstrumentTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalysisServer).GetInstrumentTask(ctx, req.(*GetInstrumentTaskReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Analysis_GetAnalysisByGID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AnalysisByGIDRequest)
 	if err := dec(in); err != nil {
@@ -657,6 +693,10 @@ var Analysis_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "InstrumentProject",
 			Handler:    _Analysis_InstrumentProject_Handler,
 		},
+		{
+			MethodName: "GetInstrumentTask",
+			Handler:    _Analysis_GetInstrumentTask_Handler,
+		},
 		{
 			MethodName: "GetAnalysisByGID",
 			Handler:    _Analysis_GetAnalysisByGID_Handler,
@@ -175,10 +175,21 @@ func (x *GetStaticDbFilesResponse) GetFiles() []*DbFileInfo {
 
 // 分析项目路径请求
 type AnalyzeProjectPathRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	Algo          string                 `protobuf:"bytes,2,opt,name=algo,proto3" json:"algo,omitempty"`                                     // 分析算法: "vta", "rta", "cha", "static"
-	IgnoreMethod  string                 `protobuf:"bytes,3,opt,name=ignore_method,json=ignoreMethod,proto3" json:"ignore_method,omitempty"` // 忽略分析特定方法
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Path           string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Algo           string                 `protobuf:"bytes,2,opt,name=algo,proto3" json:"algo,omitempty"`                                             // 分析算法: "vta", "rta", "cha", "static"
+	IgnoreMethod   string                 `protobuf:"bytes,3,opt,name=ignore_method,json=ignoreMethod,proto3" json:"ignore_method,omitempty"`         // 忽略分析特定方法
+	Roots          []string               `protobuf:"bytes,4,rep,name=roots,proto3" json:"roots,omitempty"`                                           // 根函数: "main"(默认), "exported", "init", "tests" 或函数Key, 用于分析没有main包的库
+	BuildTags      []string               `protobuf:"bytes,5,rep,name=build_tags,json=buildTags,proto3" json:"build_tags,omitempty"`                  // 所有构建配置共用的构建标签, 如 "integration"
+	Platforms      []string               `protobuf:"bytes,6,rep,name=platforms,proto3" json:"platforms,omitempty"`                                   // 分析的平台, 如 "linux/amd64", 为空时使用服务所在的平台, 多个平台时进入矩阵模式
+	TagSets        []string               `protobuf:"bytes,7,rep,name=tag_sets,json=tagSets,proto3" json:"tag_sets,omitempty"`                        // 矩阵中的构建标签组合, 每一项为逗号分隔的一组标签, 多组时进入矩阵模式
+	Env            []string               `protobuf:"bytes,8,rep,name=env,proto3" json:"env,omitempty"`                                               // 加载包时额外的环境变量, 如 "CGO_ENABLED=0"
+	StdPackages    []string               `protobuf:"bytes,9,rep,name=std_packages,json=stdPackages,proto3" json:"std_packages,omitempty"`            // 保留调用边的标准库包(包含子包), 如 "net/http", "database/sql"
+	Tolerant       bool                   `protobuf:"varint,10,opt,name=tolerant,proto3" json:"tolerant,omitempty"`                                   // 容错模式: 跳过有加载或类型错误的包, 继续分析其余的包
+	TimeoutSeconds int32                  `protobuf:"varint,11,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"` // 超时时间(秒), 超时后任务失败, 0表示不限制
+	// 堆内存软上限(MB), 超过后任务失败, 0表示不限制.
+	// 比较的是整个服务进程的堆内存, 服务最多同时执行3个分析任务, 其他任务占用的内存同样会导致本任务终止
+	MemoryLimitMb int64 `protobuf:"varint,12,opt,name=memory_limit_mb,json=memoryLimitMb,proto3" json:"memory_limit_mb,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -234,6 +245,69 @@ func (x *AnalyzeProjectPathRequest) GetIgnoreMethod() string {
 	return ""
 }
 
+func (x *AnalyzeProjectPathRequest) GetRoots() []string {
+	if x != nil {
+		return x.Roots
+	}
+	return nil
+}
+
+func (x *AnalyzeProjectPathRequest) GetBuildTags() []string {
+	if x != nil {
+		return x.BuildTags
+	}
+	return nil
+}
+
+func (x *AnalyzeProjectPathRequest) GetPlatforms() []string {
+	if x != nil {
+		return x.Platforms
+	}
+	return nil
+}
+
+func (x *AnalyzeProjectPathRequest) GetTagSets() []string {
+	if x != nil {
+		return x.TagSets
+	}
+	return nil
+}
+
+func (x *AnalyzeProjectPathRequest) GetEnv() []string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *AnalyzeProjectPathRequest) GetStdPackages() []string {
+	if x != nil {
+		return x.StdPackages
+	}
+	return nil
+}
+
+func (x *AnalyzeProjectPathRequest) GetTolerant() bool {
+	if x != nil {
+		return x.Tolerant
+	}
+	return false
+}
+
+func (x *AnalyzeProjectPathRequest) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *AnalyzeProjectPathRequest) GetMemoryLimitMb() int64 {
+	if x != nil {
+		return x.MemoryLimitMb
+	}
+	return 0
+}
+
 // 分析项目路径响应
 type AnalyzeProjectPathResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -343,9 +417,10 @@ func (x *GetAnalysisTaskStatusRequest) GetTaskId() string {
 // 获取分析任务状态响应
 type GetAnalysisTaskStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        int32                  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`      // 状态：0: starting, 1: processing, 2: completed, -1: failed, -2: not_found
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`     // 消息
-	Progress      float32                `protobuf:"fixed32,3,opt,name=progress,proto3" json:"progress,omitempty"` // 进度百分比 (0-100)
+	Status        int32                  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`          // 状态：0: starting, 1: processing, 2: completed, -1: failed, -2: not_found, -3: cancelled
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`         // 消息
+	Progress      float32                `protobuf:"fixed32,3,opt,name=progress,proto3" json:"progress,omitempty"`     // 进度百分比 (0-100)
+	Diagnostics   []*PackageDiagnostic   `protobuf:"bytes,4,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"` // 加载包时的错误
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -401,6 +476,196 @@ func (x *GetAnalysisTaskStatusResponse) GetProgress() float32 {
 	return 0
 }
 
+func (x *GetAnalysisTaskStatusResponse) GetDiagnostics() []*PackageDiagnostic {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
+
+// 取消分析任务请求
+type CancelAnalysisTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelAnalysisTaskRequest) Reset() {
+	*x = CancelAnalysisTaskRequest{}
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAnalysisTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAnalysisTaskRequest) ProtoMessage() {}
+
+func (x *CancelAnalysisTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAnalysisTaskRequest.ProtoReflect.Descriptor instead.
+func (*CancelAnalysisTaskRequest) Descriptor() ([]byte, []int) {
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CancelAnalysisTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// 取消分析任务响应
+type CancelAnalysisTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelAnalysisTaskResponse) Reset() {
+	*x = CancelAnalysisTaskResponse{}
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAnalysisTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAnalysisTaskResponse) ProtoMessage() {}
+
+func (x *CancelAnalysisTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAnalysisTaskResponse.ProtoReflect.Descriptor instead.
+func (*CancelAnalysisTaskResponse) Descriptor() ([]byte, []int) {
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelAnalysisTaskResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelAnalysisTaskResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// 加载包时的诊断信息
+type PackageDiagnostic struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Package       string                 `protobuf:"bytes,1,opt,name=package,proto3" json:"package,omitempty"` // 包路径
+	File          string                 `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`       // 文件, 项目内为相对路径
+	Line          int32                  `protobuf:"varint,3,opt,name=line,proto3" json:"line,omitempty"`
+	Column        int32                  `protobuf:"varint,4,opt,name=column,proto3" json:"column,omitempty"`
+	Kind          string                 `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"` // 类型: "list", "parse", "type", "unknown"
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageDiagnostic) Reset() {
+	*x = PackageDiagnostic{}
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageDiagnostic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageDiagnostic) ProtoMessage() {}
+
+func (x *PackageDiagnostic) ProtoReflect() protoreflect.Message {
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageDiagnostic.ProtoReflect.Descriptor instead.
+func (*PackageDiagnostic) Descriptor() ([]byte, []int) {
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PackageDiagnostic) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+func (x *PackageDiagnostic) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *PackageDiagnostic) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *PackageDiagnostic) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *PackageDiagnostic) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PackageDiagnostic) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 // 分析数据库文件请求
 type AnalyzeDbFileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -411,7 +676,7 @@ type AnalyzeDbFileRequest struct {
 
 func (x *AnalyzeDbFileRequest) Reset() {
 	*x = AnalyzeDbFileRequest{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[7]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -423,7 +688,7 @@ func (x *AnalyzeDbFileRequest) String() string {
 func (*AnalyzeDbFileRequest) ProtoMessage() {}
 
 func (x *AnalyzeDbFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[7]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -436,7 +701,7 @@ func (x *AnalyzeDbFileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AnalyzeDbFileRequest.ProtoReflect.Descriptor instead.
 func (*AnalyzeDbFileRequest) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{7}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AnalyzeDbFileRequest) GetDbPath() string {
@@ -458,7 +723,7 @@ type PackageDependency struct {
 
 func (x *PackageDependency) Reset() {
 	*x = PackageDependency{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[8]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -470,7 +735,7 @@ func (x *PackageDependency) String() string {
 func (*PackageDependency) ProtoMessage() {}
 
 func (x *PackageDependency) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[8]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -483,7 +748,7 @@ func (x *PackageDependency) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PackageDependency.ProtoReflect.Descriptor instead.
 func (*PackageDependency) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{8}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *PackageDependency) GetSource() string {
@@ -520,7 +785,7 @@ type HotFunction struct {
 
 func (x *HotFunction) Reset() {
 	*x = HotFunction{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[9]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -532,7 +797,7 @@ func (x *HotFunction) String() string {
 func (*HotFunction) ProtoMessage() {}
 
 func (x *HotFunction) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[9]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -545,7 +810,7 @@ func (x *HotFunction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HotFunction.ProtoReflect.Descriptor instead.
 func (*HotFunction) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{9}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *HotFunction) GetKey() string {
@@ -590,7 +855,7 @@ type AnalyzeDbFileResponse struct {
 
 func (x *AnalyzeDbFileResponse) Reset() {
 	*x = AnalyzeDbFileResponse{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[10]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -602,7 +867,7 @@ func (x *AnalyzeDbFileResponse) String() string {
 func (*AnalyzeDbFileResponse) ProtoMessage() {}
 
 func (x *AnalyzeDbFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[10]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -615,7 +880,7 @@ func (x *AnalyzeDbFileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AnalyzeDbFileResponse.ProtoReflect.Descriptor instead.
 func (*AnalyzeDbFileResponse) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{10}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *AnalyzeDbFileResponse) GetTotalFunctions() int32 {
@@ -663,7 +928,7 @@ type GetHotFunctionsReq struct {
 
 func (x *GetHotFunctionsReq) Reset() {
 	*x = GetHotFunctionsReq{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[11]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -675,7 +940,7 @@ func (x *GetHotFunctionsReq) String() string {
 func (*GetHotFunctionsReq) ProtoMessage() {}
 
 func (x *GetHotFunctionsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[11]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -688,7 +953,7 @@ func (x *GetHotFunctionsReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetHotFunctionsReq.ProtoReflect.Descriptor instead.
 func (*GetHotFunctionsReq) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{11}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetHotFunctionsReq) GetSortBy() string {
@@ -708,7 +973,7 @@ type GetHotFunctionsReply struct {
 
 func (x *GetHotFunctionsReply) Reset() {
 	*x = GetHotFunctionsReply{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[12]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -720,7 +985,7 @@ func (x *GetHotFunctionsReply) String() string {
 func (*GetHotFunctionsReply) ProtoMessage() {}
 
 func (x *GetHotFunctionsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[12]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -733,7 +998,7 @@ func (x *GetHotFunctionsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetHotFunctionsReply.ProtoReflect.Descriptor instead.
 func (*GetHotFunctionsReply) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{12}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetHotFunctionsReply) GetFunctions() []*GetHotFunctionsReply_HotFunction {
@@ -755,7 +1020,7 @@ type GetFunctionAnalysisReq struct {
 
 func (x *GetFunctionAnalysisReq) Reset() {
 	*x = GetFunctionAnalysisReq{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[13]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -767,7 +1032,7 @@ func (x *GetFunctionAnalysisReq) String() string {
 func (*GetFunctionAnalysisReq) ProtoMessage() {}
 
 func (x *GetFunctionAnalysisReq) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[13]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -780,7 +1045,7 @@ func (x *GetFunctionAnalysisReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFunctionAnalysisReq.ProtoReflect.Descriptor instead.
 func (*GetFunctionAnalysisReq) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{13}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetFunctionAnalysisReq) GetFunctionName() string {
@@ -814,7 +1079,7 @@ type GetFunctionAnalysisReply struct {
 
 func (x *GetFunctionAnalysisReply) Reset() {
 	*x = GetFunctionAnalysisReply{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[14]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -826,7 +1091,7 @@ func (x *GetFunctionAnalysisReply) String() string {
 func (*GetFunctionAnalysisReply) ProtoMessage() {}
 
 func (x *GetFunctionAnalysisReply) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[14]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -839,7 +1104,7 @@ func (x *GetFunctionAnalysisReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFunctionAnalysisReply.ProtoReflect.Descriptor instead.
 func (*GetFunctionAnalysisReply) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{14}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetFunctionAnalysisReply) GetCallData() []*GetFunctionAnalysisReply_FunctionNode {
@@ -852,16 +1117,18 @@ func (x *GetFunctionAnalysisReply) GetCallData() []*GetFunctionAnalysisReply_Fun
 // 获取函数调用关系图的请求
 type GetFunctionCallGraphReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FunctionKey   string                 `protobuf:"bytes,1,opt,name=function_key,json=functionKey,proto3" json:"function_key,omitempty"` // 函数唯一标识符(短格式key)
+	FunctionKey   string                 `protobuf:"bytes,1,opt,name=function_key,json=functionKey,proto3" json:"function_key,omitempty"` // 函数稳定标识, 兼容旧格式key(如n12)
 	Depth         int32                  `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`                               // 调用深度，默认为2
 	Direction     string                 `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`                        // 方向: "caller"(调用者), "callee"(被调用), "both"(双向)
+	DbPath        string                 `protobuf:"bytes,4,opt,name=db_path,json=dbPath,proto3" json:"db_path,omitempty"`                // 静态分析数据库路径
+	CallKinds     []string               `protobuf:"bytes,5,rep,name=call_kinds,json=callKinds,proto3" json:"call_kinds,omitempty"`       // 只沿这些调用类型的边查找, 为空时不过滤
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetFunctionCallGraphReq) Reset() {
 	*x = GetFunctionCallGraphReq{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[15]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -873,7 +1140,7 @@ func (x *GetFunctionCallGraphReq) String() string {
 func (*GetFunctionCallGraphReq) ProtoMessage() {}
 
 func (x *GetFunctionCallGraphReq) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[15]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -886,7 +1153,7 @@ func (x *GetFunctionCallGraphReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFunctionCallGraphReq.ProtoReflect.Descriptor instead.
 func (*GetFunctionCallGraphReq) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{15}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GetFunctionCallGraphReq) GetFunctionKey() string {
@@ -910,6 +1177,20 @@ func (x *GetFunctionCallGraphReq) GetDirection() string {
 	return ""
 }
 
+func (x *GetFunctionCallGraphReq) GetDbPath() string {
+	if x != nil {
+		return x.DbPath
+	}
+	return ""
+}
+
+func (x *GetFunctionCallGraphReq) GetCallKinds() []string {
+	if x != nil {
+		return x.CallKinds
+	}
+	return nil
+}
+
 // 获取函数调用关系图的响应
 type GetFunctionCallGraphReply struct {
 	state         protoimpl.MessageState                 `protogen:"open.v1"`
@@ -921,7 +1202,7 @@ type GetFunctionCallGraphReply struct {
 
 func (x *GetFunctionCallGraphReply) Reset() {
 	*x = GetFunctionCallGraphReply{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[16]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -933,7 +1214,7 @@ func (x *GetFunctionCallGraphReply) String() string {
 func (*GetFunctionCallGraphReply) ProtoMessage() {}
 
 func (x *GetFunctionCallGraphReply) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[16]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -946,7 +1227,7 @@ func (x *GetFunctionCallGraphReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFunctionCallGraphReply.ProtoReflect.Descriptor instead.
 func (*GetFunctionCallGraphReply) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{16}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *GetFunctionCallGraphReply) GetNodes() []*GetFunctionCallGraphReply_GraphNode {
@@ -982,7 +1263,7 @@ type GitLabRepository struct {
 
 func (x *GitLabRepository) Reset() {
 	*x = GitLabRepository{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[17]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -994,7 +1275,7 @@ func (x *GitLabRepository) String() string {
 func (*GitLabRepository) ProtoMessage() {}
 
 func (x *GitLabRepository) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[17]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1007,7 +1288,7 @@ func (x *GitLabRepository) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GitLabRepository.ProtoReflect.Descriptor instead.
 func (*GitLabRepository) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{17}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *GitLabRepository) GetId() int32 {
@@ -1089,7 +1370,7 @@ type ListGitLabRepositoriesRequest struct {
 
 func (x *ListGitLabRepositoriesRequest) Reset() {
 	*x = ListGitLabRepositoriesRequest{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[18]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1101,7 +1382,7 @@ func (x *ListGitLabRepositoriesRequest) String() string {
 func (*ListGitLabRepositoriesRequest) ProtoMessage() {}
 
 func (x *ListGitLabRepositoriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[18]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1114,7 +1395,7 @@ func (x *ListGitLabRepositoriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListGitLabRepositoriesRequest.ProtoReflect.Descriptor instead.
 func (*ListGitLabRepositoriesRequest) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{18}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{21}
 }
 
 // 获取GitLab仓库列表响应
@@ -1127,7 +1408,7 @@ type ListGitLabRepositoriesResponse struct {
 
 func (x *ListGitLabRepositoriesResponse) Reset() {
 	*x = ListGitLabRepositoriesResponse{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[19]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1139,7 +1420,7 @@ func (x *ListGitLabRepositoriesResponse) String() string {
 func (*ListGitLabRepositoriesResponse) ProtoMessage() {}
 
 func (x *ListGitLabRepositoriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[19]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1152,7 +1433,7 @@ func (x *ListGitLabRepositoriesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListGitLabRepositoriesResponse.ProtoReflect.Descriptor instead.
 func (*ListGitLabRepositoriesResponse) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{19}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ListGitLabRepositoriesResponse) GetRepositories() []*GitLabRepository {
@@ -1173,7 +1454,7 @@ type CloneGitLabRepositoryRequest struct {
 
 func (x *CloneGitLabRepositoryRequest) Reset() {
 	*x = CloneGitLabRepositoryRequest{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[20]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1185,7 +1466,7 @@ func (x *CloneGitLabRepositoryRequest) String() string {
 func (*CloneGitLabRepositoryRequest) ProtoMessage() {}
 
 func (x *CloneGitLabRepositoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[20]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1198,7 +1479,7 @@ func (x *CloneGitLabRepositoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloneGitLabRepositoryRequest.ProtoReflect.Descriptor instead.
 func (*CloneGitLabRepositoryRequest) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{20}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *CloneGitLabRepositoryRequest) GetRepoUrl() string {
@@ -1227,7 +1508,7 @@ type CloneGitLabRepositoryResponse struct {
 
 func (x *CloneGitLabRepositoryResponse) Reset() {
 	*x = CloneGitLabRepositoryResponse{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[21]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1239,7 +1520,7 @@ func (x *CloneGitLabRepositoryResponse) String() string {
 func (*CloneGitLabRepositoryResponse) ProtoMessage() {}
 
 func (x *CloneGitLabRepositoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[21]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1252,7 +1533,7 @@ func (x *CloneGitLabRepositoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloneGitLabRepositoryResponse.ProtoReflect.Descriptor instead.
 func (*CloneGitLabRepositoryResponse) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{21}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *CloneGitLabRepositoryResponse) GetSuccess() bool {
@@ -1288,7 +1569,7 @@ type GetPackageDependenciesRequest struct {
 
 func (x *GetPackageDependenciesRequest) Reset() {
 	*x = GetPackageDependenciesRequest{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[22]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1300,7 +1581,7 @@ func (x *GetPackageDependenciesRequest) String() string {
 func (*GetPackageDependenciesRequest) ProtoMessage() {}
 
 func (x *GetPackageDependenciesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[22]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1313,7 +1594,7 @@ func (x *GetPackageDependenciesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPackageDependenciesRequest.ProtoReflect.Descriptor instead.
 func (*GetPackageDependenciesRequest) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{22}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GetPackageDependenciesRequest) GetDbPath() string {
@@ -1351,7 +1632,7 @@ type GetPackageDependenciesResponse struct {
 
 func (x *GetPackageDependenciesResponse) Reset() {
 	*x = GetPackageDependenciesResponse{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[23]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1363,7 +1644,7 @@ func (x *GetPackageDependenciesResponse) String() string {
 func (*GetPackageDependenciesResponse) ProtoMessage() {}
 
 func (x *GetPackageDependenciesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[23]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1376,7 +1657,7 @@ func (x *GetPackageDependenciesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPackageDependenciesResponse.ProtoReflect.Descriptor instead.
 func (*GetPackageDependenciesResponse) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{23}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *GetPackageDependenciesResponse) GetDependencies() []*PackageDependency {
@@ -1426,7 +1707,7 @@ type GetHotFunctionsRequest struct {
 
 func (x *GetHotFunctionsRequest) Reset() {
 	*x = GetHotFunctionsRequest{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[24]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1438,7 +1719,7 @@ func (x *GetHotFunctionsRequest) String() string {
 func (*GetHotFunctionsRequest) ProtoMessage() {}
 
 func (x *GetHotFunctionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[24]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1451,7 +1732,7 @@ func (x *GetHotFunctionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetHotFunctionsRequest.ProtoReflect.Descriptor instead.
 func (*GetHotFunctionsRequest) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{24}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GetHotFunctionsRequest) GetDbPath() string {
@@ -1489,7 +1770,7 @@ type GetHotFunctionsResponse struct {
 
 func (x *GetHotFunctionsResponse) Reset() {
 	*x = GetHotFunctionsResponse{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[25]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1501,7 +1782,7 @@ func (x *GetHotFunctionsResponse) String() string {
 func (*GetHotFunctionsResponse) ProtoMessage() {}
 
 func (x *GetHotFunctionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[25]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1514,7 +1795,7 @@ func (x *GetHotFunctionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetHotFunctionsResponse.ProtoReflect.Descriptor instead.
 func (*GetHotFunctionsResponse) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{25}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *GetHotFunctionsResponse) GetFunctions() []*HotFunction {
@@ -1555,17 +1836,22 @@ func (x *GetHotFunctionsResponse) GetPageCount() int32 {
 // 函数信息
 type FunctionInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`                               // 函数唯一标识
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`                               // 函数稳定标识, 包路径+函数名, 多次分析保持不变
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                             // 函数名称
 	Package       string                 `protobuf:"bytes,3,opt,name=package,proto3" json:"package,omitempty"`                       // 包名
 	CallCount     int32                  `protobuf:"varint,4,opt,name=call_count,json=callCount,proto3" json:"call_count,omitempty"` // 调用次数
+	File          string                 `protobuf:"bytes,5,opt,name=file,proto3" json:"file,omitempty"`                             // 声明所在文件, 项目内的文件为相对项目目录的路径
+	StartLine     int32                  `protobuf:"varint,6,opt,name=start_line,json=startLine,proto3" json:"start_line,omitempty"` // 函数起始行
+	EndLine       int32                  `protobuf:"varint,7,opt,name=end_line,json=endLine,proto3" json:"end_line,omitempty"`       // 函数结束行
+	LegacyKey     string                 `protobuf:"bytes,8,opt,name=legacy_key,json=legacyKey,proto3" json:"legacy_key,omitempty"`  // 旧格式key(如n12), 每次分析都会变化
+	Module        string                 `protobuf:"bytes,9,opt,name=module,proto3" json:"module,omitempty"`                         // 所属模块, 工作区和多模块仓库中区分项目内的模块
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FunctionInfo) Reset() {
 	*x = FunctionInfo{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[26]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1577,7 +1863,7 @@ func (x *FunctionInfo) String() string {
 func (*FunctionInfo) ProtoMessage() {}
 
 func (x *FunctionInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[26]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1590,7 +1876,7 @@ func (x *FunctionInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FunctionInfo.ProtoReflect.Descriptor instead.
 func (*FunctionInfo) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{26}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *FunctionInfo) GetKey() string {
@@ -1621,6 +1907,41 @@ func (x *FunctionInfo) GetCallCount() int32 {
 	return 0
 }
 
+func (x *FunctionInfo) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *FunctionInfo) GetStartLine() int32 {
+	if x != nil {
+		return x.StartLine
+	}
+	return 0
+}
+
+func (x *FunctionInfo) GetEndLine() int32 {
+	if x != nil {
+		return x.EndLine
+	}
+	return 0
+}
+
+func (x *FunctionInfo) GetLegacyKey() string {
+	if x != nil {
+		return x.LegacyKey
+	}
+	return ""
+}
+
+func (x *FunctionInfo) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
 // 模糊搜索函数请求
 type SearchFunctionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1632,7 +1953,7 @@ type SearchFunctionsRequest struct {
 
 func (x *SearchFunctionsRequest) Reset() {
 	*x = SearchFunctionsRequest{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[27]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1644,7 +1965,7 @@ func (x *SearchFunctionsRequest) String() string {
 func (*SearchFunctionsRequest) ProtoMessage() {}
 
 func (x *SearchFunctionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[27]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1657,7 +1978,7 @@ func (x *SearchFunctionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchFunctionsRequest.ProtoReflect.Descriptor instead.
 func (*SearchFunctionsRequest) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{27}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *SearchFunctionsRequest) GetDbPath() string {
@@ -1684,7 +2005,7 @@ type SearchFunctionsResponse struct {
 
 func (x *SearchFunctionsResponse) Reset() {
 	*x = SearchFunctionsResponse{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[28]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1696,7 +2017,7 @@ func (x *SearchFunctionsResponse) String() string {
 func (*SearchFunctionsResponse) ProtoMessage() {}
 
 func (x *SearchFunctionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[28]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1709,7 +2030,7 @@ func (x *SearchFunctionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchFunctionsResponse.ProtoReflect.Descriptor instead.
 func (*SearchFunctionsResponse) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{28}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *SearchFunctionsResponse) GetFunctions() []*FunctionInfo {
@@ -1723,28 +2044,111 @@ func (x *SearchFunctionsResponse) GetFunctions() []*FunctionInfo {
 type GetFunctionUpstreamRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	DbPath          string                 `protobuf:"bytes,1,opt,name=db_path,json=dbPath,proto3" json:"db_path,omitempty"`                            // 数据库路径
-	FunctionKey     string                 `protobuf:"bytes,2,opt,name=function_key,json=functionKey,proto3" json:"function_key,omitempty"`             // 函数唯一标识符(短格式key)
+	FunctionKey     string                 `protobuf:"bytes,2,opt,name=function_key,json=functionKey,proto3" json:"function_key,omitempty"`             // 函数稳定标识(如example.com/demo.(*Server).Handle), 兼容旧格式key(如n12)
 	FunctionPackage string                 `protobuf:"bytes,3,opt,name=function_package,json=functionPackage,proto3" json:"function_package,omitempty"` // 函数包名
 	Depth           int32                  `protobuf:"varint,4,opt,name=depth,proto3" json:"depth,omitempty"`                                           // 查询深度，默认为2
+	CallKinds       []string               `protobuf:"bytes,5,rep,name=call_kinds,json=callKinds,proto3" json:"call_kinds,omitempty"`                   // 只沿这些调用类型的边查找, 为空时不过滤
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GetFunctionUpstreamRequest) Reset() {
 	*x = GetFunctionUpstreamRequest{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[29]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFunctionUpstreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFunctionUpstreamRequest) ProtoMessage() {}
+
+func (x *GetFunctionUpstreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFunctionUpstreamRequest.ProtoReflect.Descriptor instead.
+func (*GetFunctionUpstreamRequest) Descriptor() ([]byte, []int) {
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetFunctionUpstreamRequest) GetDbPath() string {
+	if x != nil {
+		return x.DbPath
+	}
+	return ""
+}
+
+func (x *GetFunctionUpstreamRequest) GetFunctionKey() string {
+	if x != nil {
+		return x.FunctionKey
+	}
+	return ""
+}
+
+func (x *GetFunctionUpstreamRequest) GetFunctionPackage() string {
+	if x != nil {
+		return x.FunctionPackage
+	}
+	return ""
+}
+
+func (x *GetFunctionUpstreamRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *GetFunctionUpstreamRequest) GetCallKinds() []string {
+	if x != nil {
+		return x.CallKinds
+	}
+	return nil
+}
+
+// 图节点
+type GraphNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`                                       // 函数稳定标识, 包路径+函数名, 多次分析保持不变
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                     // 函数名称
+	Package       string                 `protobuf:"bytes,3,opt,name=package,proto3" json:"package,omitempty"`                               // 包名
+	CallCount     int32                  `protobuf:"varint,4,opt,name=call_count,json=callCount,proto3" json:"call_count,omitempty"`         // 调用方数量, 只统计call_kinds中的调用
+	File          string                 `protobuf:"bytes,5,opt,name=file,proto3" json:"file,omitempty"`                                     // 声明所在文件, 项目内的文件为相对项目目录的路径
+	StartLine     int32                  `protobuf:"varint,6,opt,name=start_line,json=startLine,proto3" json:"start_line,omitempty"`         // 函数起始行
+	EndLine       int32                  `protobuf:"varint,7,opt,name=end_line,json=endLine,proto3" json:"end_line,omitempty"`               // 函数结束行
+	LegacyKey     string                 `protobuf:"bytes,8,opt,name=legacy_key,json=legacyKey,proto3" json:"legacy_key,omitempty"`          // 旧格式key(如n12), 每次分析都会变化
+	BuildConfigs  []string               `protobuf:"bytes,9,rep,name=build_configs,json=buildConfigs,proto3" json:"build_configs,omitempty"` // 矩阵模式下存在这个函数的构建配置(如"linux/amd64"), 为空表示非矩阵模式
+	Module        string                 `protobuf:"bytes,10,opt,name=module,proto3" json:"module,omitempty"`                                // 所属模块, 工作区和多模块仓库中区分项目内的模块
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraphNode) Reset() {
+	*x = GraphNode{}
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFunctionUpstreamRequest) String() string {
+func (x *GraphNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFunctionUpstreamRequest) ProtoMessage() {}
+func (*GraphNode) ProtoMessage() {}
 
-func (x *GetFunctionUpstreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[29]
+func (x *GraphNode) ProtoReflect() protoreflect.Message {
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1755,65 +2159,107 @@ func (x *GetFunctionUpstreamRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetFunctionUpstreamRequest.ProtoReflect.Descriptor instead.
-func (*GetFunctionUpstreamRequest) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use GraphNode.ProtoReflect.Descriptor instead.
+func (*GraphNode) Descriptor() ([]byte, []int) {
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *GetFunctionUpstreamRequest) GetDbPath() string {
+func (x *GraphNode) GetKey() string {
 	if x != nil {
-		return x.DbPath
+		return x.Key
 	}
 	return ""
 }
 
-func (x *GetFunctionUpstreamRequest) GetFunctionKey() string {
+func (x *GraphNode) GetName() string {
 	if x != nil {
-		return x.FunctionKey
+		return x.Name
 	}
 	return ""
 }
 
-func (x *GetFunctionUpstreamRequest) GetFunctionPackage() string {
+func (x *GraphNode) GetPackage() string {
 	if x != nil {
-		return x.FunctionPackage
+		return x.Package
 	}
 	return ""
 }
 
-func (x *GetFunctionUpstreamRequest) GetDepth() int32 {
+func (x *GraphNode) GetCallCount() int32 {
 	if x != nil {
-		return x.Depth
+		return x.CallCount
 	}
 	return 0
 }
 
-// 图节点
-type GraphNode struct {
+func (x *GraphNode) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *GraphNode) GetStartLine() int32 {
+	if x != nil {
+		return x.StartLine
+	}
+	return 0
+}
+
+func (x *GraphNode) GetEndLine() int32 {
+	if x != nil {
+		return x.EndLine
+	}
+	return 0
+}
+
+func (x *GraphNode) GetLegacyKey() string {
+	if x != nil {
+		return x.LegacyKey
+	}
+	return ""
+}
+
+func (x *GraphNode) GetBuildConfigs() []string {
+	if x != nil {
+		return x.BuildConfigs
+	}
+	return nil
+}
+
+func (x *GraphNode) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+// 调用点
+type CallSite struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`                               // 函数唯一标识符(短格式key)
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                             // 函数名称
-	Package       string                 `protobuf:"bytes,3,opt,name=package,proto3" json:"package,omitempty"`                       // 包名
-	CallCount     int32                  `protobuf:"varint,4,opt,name=call_count,json=callCount,proto3" json:"call_count,omitempty"` // 调用次数
+	File          string                 `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`                                     // 调用点所在文件
+	Line          int32                  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`                                    // 调用点行号
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`                                     // 调用类型: "static", "interface", "dynamic", "closure", "go", "defer"
+	BuildConfigs  []string               `protobuf:"bytes,4,rep,name=build_configs,json=buildConfigs,proto3" json:"build_configs,omitempty"` // 矩阵模式下存在这个调用点的构建配置, 为空表示非矩阵模式
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GraphNode) Reset() {
-	*x = GraphNode{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[30]
+func (x *CallSite) Reset() {
+	*x = CallSite{}
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GraphNode) String() string {
+func (x *CallSite) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GraphNode) ProtoMessage() {}
+func (*CallSite) ProtoMessage() {}
 
-func (x *GraphNode) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[30]
+func (x *CallSite) ProtoReflect() protoreflect.Message {
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1824,52 +2270,57 @@ func (x *GraphNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GraphNode.ProtoReflect.Descriptor instead.
-func (*GraphNode) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use CallSite.ProtoReflect.Descriptor instead.
+func (*CallSite) Descriptor() ([]byte, []int) {
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *GraphNode) GetKey() string {
+func (x *CallSite) GetFile() string {
 	if x != nil {
-		return x.Key
+		return x.File
 	}
 	return ""
 }
 
-func (x *GraphNode) GetName() string {
+func (x *CallSite) GetLine() int32 {
 	if x != nil {
-		return x.Name
+		return x.Line
 	}
-	return ""
+	return 0
 }
 
-func (x *GraphNode) GetPackage() string {
+func (x *CallSite) GetKind() string {
 	if x != nil {
-		return x.Package
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *GraphNode) GetCallCount() int32 {
+func (x *CallSite) GetBuildConfigs() []string {
 	if x != nil {
-		return x.CallCount
+		return x.BuildConfigs
 	}
-	return 0
+	return nil
 }
 
 // 图边
 type GraphEdge struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"` // 源节点Key
-	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"` // 目标节点Key
-	Value         int32                  `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`  // 边权重
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`                                 // 源节点Key
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`                                 // 目标节点Key
+	Value         int32                  `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`                                  // 边权重, 即调用点个数
+	CallFile      string                 `protobuf:"bytes,4,opt,name=call_file,json=callFile,proto3" json:"call_file,omitempty"`             // 第一个调用点所在文件, 与sites[0]一致
+	CallLine      int32                  `protobuf:"varint,5,opt,name=call_line,json=callLine,proto3" json:"call_line,omitempty"`            // 第一个调用点行号
+	CallKinds     []string               `protobuf:"bytes,6,rep,name=call_kinds,json=callKinds,proto3" json:"call_kinds,omitempty"`          // 这对函数之间出现的调用类型: "static", "interface", "dynamic", "closure", "go", "defer"
+	BuildConfigs  []string               `protobuf:"bytes,7,rep,name=build_configs,json=buildConfigs,proto3" json:"build_configs,omitempty"` // 矩阵模式下存在这对函数调用的构建配置, 为空表示非矩阵模式
+	Sites         []*CallSite            `protobuf:"bytes,8,rep,name=sites,proto3" json:"sites,omitempty"`                                   // 这对函数之间的全部调用点
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GraphEdge) Reset() {
 	*x = GraphEdge{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[31]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1881,7 +2332,7 @@ func (x *GraphEdge) String() string {
 func (*GraphEdge) ProtoMessage() {}
 
 func (x *GraphEdge) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[31]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1894,7 +2345,7 @@ func (x *GraphEdge) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GraphEdge.ProtoReflect.Descriptor instead.
 func (*GraphEdge) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{31}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *GraphEdge) GetSource() string {
@@ -1918,6 +2369,41 @@ func (x *GraphEdge) GetValue() int32 {
 	return 0
 }
 
+func (x *GraphEdge) GetCallFile() string {
+	if x != nil {
+		return x.CallFile
+	}
+	return ""
+}
+
+func (x *GraphEdge) GetCallLine() int32 {
+	if x != nil {
+		return x.CallLine
+	}
+	return 0
+}
+
+func (x *GraphEdge) GetCallKinds() []string {
+	if x != nil {
+		return x.CallKinds
+	}
+	return nil
+}
+
+func (x *GraphEdge) GetBuildConfigs() []string {
+	if x != nil {
+		return x.BuildConfigs
+	}
+	return nil
+}
+
+func (x *GraphEdge) GetSites() []*CallSite {
+	if x != nil {
+		return x.Sites
+	}
+	return nil
+}
+
 // 获取函数上游调用关系响应
 type GetFunctionUpstreamResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1929,7 +2415,7 @@ type GetFunctionUpstreamResponse struct {
 
 func (x *GetFunctionUpstreamResponse) Reset() {
 	*x = GetFunctionUpstreamResponse{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[32]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1941,7 +2427,7 @@ func (x *GetFunctionUpstreamResponse) String() string {
 func (*GetFunctionUpstreamResponse) ProtoMessage() {}
 
 func (x *GetFunctionUpstreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[32]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1954,7 +2440,7 @@ func (x *GetFunctionUpstreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFunctionUpstreamResponse.ProtoReflect.Descriptor instead.
 func (*GetFunctionUpstreamResponse) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{32}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *GetFunctionUpstreamResponse) GetNodes() []*GraphNode {
@@ -1975,16 +2461,17 @@ func (x *GetFunctionUpstreamResponse) GetEdges() []*GraphEdge {
 type GetFunctionDownstreamRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	DbPath          string                 `protobuf:"bytes,1,opt,name=db_path,json=dbPath,proto3" json:"db_path,omitempty"`                            // 数据库路径
-	FunctionKey     string                 `protobuf:"bytes,2,opt,name=function_key,json=functionKey,proto3" json:"function_key,omitempty"`             // 函数唯一标识符(短格式key)
+	FunctionKey     string                 `protobuf:"bytes,2,opt,name=function_key,json=functionKey,proto3" json:"function_key,omitempty"`             // 函数稳定标识(如example.com/demo.(*Server).Handle), 兼容旧格式key(如n12)
 	FunctionPackage string                 `protobuf:"bytes,3,opt,name=function_package,json=functionPackage,proto3" json:"function_package,omitempty"` // 函数包名
 	Depth           int32                  `protobuf:"varint,4,opt,name=depth,proto3" json:"depth,omitempty"`                                           // 查询深度，默认为2
+	CallKinds       []string               `protobuf:"bytes,5,rep,name=call_kinds,json=callKinds,proto3" json:"call_kinds,omitempty"`                   // 只沿这些调用类型的边查找, 为空时不过滤
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GetFunctionDownstreamRequest) Reset() {
 	*x = GetFunctionDownstreamRequest{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[33]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1996,7 +2483,7 @@ func (x *GetFunctionDownstreamRequest) String() string {
 func (*GetFunctionDownstreamRequest) ProtoMessage() {}
 
 func (x *GetFunctionDownstreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[33]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2009,7 +2496,7 @@ func (x *GetFunctionDownstreamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFunctionDownstreamRequest.ProtoReflect.Descriptor instead.
 func (*GetFunctionDownstreamRequest) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{33}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *GetFunctionDownstreamRequest) GetDbPath() string {
@@ -2040,6 +2527,13 @@ func (x *GetFunctionDownstreamRequest) GetDepth() int32 {
 	return 0
 }
 
+func (x *GetFunctionDownstreamRequest) GetCallKinds() []string {
+	if x != nil {
+		return x.CallKinds
+	}
+	return nil
+}
+
 // 获取函数下游调用关系响应
 type GetFunctionDownstreamResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2051,7 +2545,7 @@ type GetFunctionDownstreamResponse struct {
 
 func (x *GetFunctionDownstreamResponse) Reset() {
 	*x = GetFunctionDownstreamResponse{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[34]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2063,7 +2557,7 @@ func (x *GetFunctionDownstreamResponse) String() string {
 func (*GetFunctionDownstreamResponse) ProtoMessage() {}
 
 func (x *GetFunctionDownstreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[34]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2076,7 +2570,7 @@ func (x *GetFunctionDownstreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFunctionDownstreamResponse.ProtoReflect.Descriptor instead.
 func (*GetFunctionDownstreamResponse) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{34}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *GetFunctionDownstreamResponse) GetNodes() []*GraphNode {
@@ -2097,14 +2591,15 @@ func (x *GetFunctionDownstreamResponse) GetEdges() []*GraphEdge {
 type GetFunctionFullChainRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DbPath        string                 `protobuf:"bytes,1,opt,name=db_path,json=dbPath,proto3" json:"db_path,omitempty"`                // 数据库路径
-	FunctionKey   string                 `protobuf:"bytes,2,opt,name=function_key,json=functionKey,proto3" json:"function_key,omitempty"` // 函数唯一标识符(短格式key)
+	FunctionKey   string                 `protobuf:"bytes,2,opt,name=function_key,json=functionKey,proto3" json:"function_key,omitempty"` // 函数稳定标识, 兼容旧格式key(如n12)
+	CallKinds     []string               `protobuf:"bytes,3,rep,name=call_kinds,json=callKinds,proto3" json:"call_kinds,omitempty"`       // 只沿这些调用类型的边查找, 为空时不过滤
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetFunctionFullChainRequest) Reset() {
 	*x = GetFunctionFullChainRequest{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[35]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2116,7 +2611,7 @@ func (x *GetFunctionFullChainRequest) String() string {
 func (*GetFunctionFullChainRequest) ProtoMessage() {}
 
 func (x *GetFunctionFullChainRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[35]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2129,7 +2624,7 @@ func (x *GetFunctionFullChainRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFunctionFullChainRequest.ProtoReflect.Descriptor instead.
 func (*GetFunctionFullChainRequest) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{35}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *GetFunctionFullChainRequest) GetDbPath() string {
@@ -2146,6 +2641,13 @@ func (x *GetFunctionFullChainRequest) GetFunctionKey() string {
 	return ""
 }
 
+func (x *GetFunctionFullChainRequest) GetCallKinds() []string {
+	if x != nil {
+		return x.CallKinds
+	}
+	return nil
+}
+
 // 获取函数全链路调用关系响应
 type GetFunctionFullChainResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2157,7 +2659,7 @@ type GetFunctionFullChainResponse struct {
 
 func (x *GetFunctionFullChainResponse) Reset() {
 	*x = GetFunctionFullChainResponse{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[36]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2169,7 +2671,7 @@ func (x *GetFunctionFullChainResponse) String() string {
 func (*GetFunctionFullChainResponse) ProtoMessage() {}
 
 func (x *GetFunctionFullChainResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[36]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2182,7 +2684,7 @@ func (x *GetFunctionFullChainResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFunctionFullChainResponse.ProtoReflect.Descriptor instead.
 func (*GetFunctionFullChainResponse) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{36}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *GetFunctionFullChainResponse) GetNodes() []*GraphNode {
@@ -2203,14 +2705,14 @@ func (x *GetFunctionFullChainResponse) GetEdges() []*GraphEdge {
 type GetTreeGraphReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DbPath        string                 `protobuf:"bytes,1,opt,name=db_path,json=dbPath,proto3" json:"db_path,omitempty"`                // 数据库路径
-	FunctionKey   string                 `protobuf:"bytes,2,opt,name=function_key,json=functionKey,proto3" json:"function_key,omitempty"` // 函数唯一标识符(短格式key)
+	FunctionKey   string                 `protobuf:"bytes,2,opt,name=function_key,json=functionKey,proto3" json:"function_key,omitempty"` // 函数稳定标识, 兼容旧格式key(如n12)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetTreeGraphReq) Reset() {
 	*x = GetTreeGraphReq{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[37]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2222,7 +2724,7 @@ func (x *GetTreeGraphReq) String() string {
 func (*GetTreeGraphReq) ProtoMessage() {}
 
 func (x *GetTreeGraphReq) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[37]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2235,7 +2737,7 @@ func (x *GetTreeGraphReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTreeGraphReq.ProtoReflect.Descriptor instead.
 func (*GetTreeGraphReq) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{37}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *GetTreeGraphReq) GetDbPath() string {
@@ -2265,7 +2767,7 @@ type TreeNode struct {
 
 func (x *TreeNode) Reset() {
 	*x = TreeNode{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[38]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2277,7 +2779,7 @@ func (x *TreeNode) String() string {
 func (*TreeNode) ProtoMessage() {}
 
 func (x *TreeNode) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[38]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2290,7 +2792,7 @@ func (x *TreeNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TreeNode.ProtoReflect.Descriptor instead.
 func (*TreeNode) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{38}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *TreeNode) GetName() string {
@@ -2331,7 +2833,7 @@ type GetTreeGraphReply struct {
 
 func (x *GetTreeGraphReply) Reset() {
 	*x = GetTreeGraphReply{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[39]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2343,7 +2845,7 @@ func (x *GetTreeGraphReply) String() string {
 func (*GetTreeGraphReply) ProtoMessage() {}
 
 func (x *GetTreeGraphReply) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[39]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2356,7 +2858,7 @@ func (x *GetTreeGraphReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTreeGraphReply.ProtoReflect.Descriptor instead.
 func (*GetTreeGraphReply) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{39}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *GetTreeGraphReply) GetRoot() *TreeNode {
@@ -2379,7 +2881,7 @@ type GetHotFunctionsReply_HotFunction struct {
 
 func (x *GetHotFunctionsReply_HotFunction) Reset() {
 	*x = GetHotFunctionsReply_HotFunction{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[40]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2391,7 +2893,7 @@ func (x *GetHotFunctionsReply_HotFunction) String() string {
 func (*GetHotFunctionsReply_HotFunction) ProtoMessage() {}
 
 func (x *GetHotFunctionsReply_HotFunction) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[40]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2404,7 +2906,7 @@ func (x *GetHotFunctionsReply_HotFunction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetHotFunctionsReply_HotFunction.ProtoReflect.Descriptor instead.
 func (*GetHotFunctionsReply_HotFunction) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{12, 0}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{15, 0}
 }
 
 func (x *GetHotFunctionsReply_HotFunction) GetName() string {
@@ -2456,7 +2958,7 @@ type GetFunctionAnalysisReply_FunctionNode struct {
 
 func (x *GetFunctionAnalysisReply_FunctionNode) Reset() {
 	*x = GetFunctionAnalysisReply_FunctionNode{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[41]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2468,7 +2970,7 @@ func (x *GetFunctionAnalysisReply_FunctionNode) String() string {
 func (*GetFunctionAnalysisReply_FunctionNode) ProtoMessage() {}
 
 func (x *GetFunctionAnalysisReply_FunctionNode) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[41]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2481,7 +2983,7 @@ func (x *GetFunctionAnalysisReply_FunctionNode) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use GetFunctionAnalysisReply_FunctionNode.ProtoReflect.Descriptor instead.
 func (*GetFunctionAnalysisReply_FunctionNode) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{14, 0}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{17, 0}
 }
 
 func (x *GetFunctionAnalysisReply_FunctionNode) GetId() string {
@@ -2528,19 +3030,25 @@ func (x *GetFunctionAnalysisReply_FunctionNode) GetChildren() []*GetFunctionAnal
 
 type GetFunctionCallGraphReply_GraphNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`                               // 函数唯一标识符(短格式key)
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                             // 函数名称
-	Package       string                 `protobuf:"bytes,3,opt,name=package,proto3" json:"package,omitempty"`                       // 包名
-	CallCount     int32                  `protobuf:"varint,4,opt,name=call_count,json=callCount,proto3" json:"call_count,omitempty"` // 调用次数
-	AvgTime       string                 `protobuf:"bytes,5,opt,name=avg_time,json=avgTime,proto3" json:"avg_time,omitempty"`        // 平均耗时
-	NodeType      string                 `protobuf:"bytes,6,opt,name=node_type,json=nodeType,proto3" json:"node_type,omitempty"`     // 节点类型: "root", "caller", "callee"
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`                                        // 函数稳定标识, 包路径+函数名, 多次分析保持不变
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                      // 函数名称
+	Package       string                 `protobuf:"bytes,3,opt,name=package,proto3" json:"package,omitempty"`                                // 包名
+	CallCount     int32                  `protobuf:"varint,4,opt,name=call_count,json=callCount,proto3" json:"call_count,omitempty"`          // 调用方数量, 只统计call_kinds中的调用
+	AvgTime       string                 `protobuf:"bytes,5,opt,name=avg_time,json=avgTime,proto3" json:"avg_time,omitempty"`                 // 平均耗时
+	NodeType      string                 `protobuf:"bytes,6,opt,name=node_type,json=nodeType,proto3" json:"node_type,omitempty"`              // 节点类型: "root", "caller", "callee"
+	File          string                 `protobuf:"bytes,7,opt,name=file,proto3" json:"file,omitempty"`                                      // 声明所在文件, 项目内的文件为相对项目目录的路径
+	StartLine     int32                  `protobuf:"varint,8,opt,name=start_line,json=startLine,proto3" json:"start_line,omitempty"`          // 函数起始行
+	EndLine       int32                  `protobuf:"varint,9,opt,name=end_line,json=endLine,proto3" json:"end_line,omitempty"`                // 函数结束行
+	LegacyKey     string                 `protobuf:"bytes,10,opt,name=legacy_key,json=legacyKey,proto3" json:"legacy_key,omitempty"`          // 旧格式key(如n12), 每次分析都会变化
+	Module        string                 `protobuf:"bytes,11,opt,name=module,proto3" json:"module,omitempty"`                                 // 所属模块, 工作区和多模块仓库中区分项目内的模块
+	BuildConfigs  []string               `protobuf:"bytes,12,rep,name=build_configs,json=buildConfigs,proto3" json:"build_configs,omitempty"` // 矩阵模式下存在这个函数的构建配置(如"linux/amd64"), 为空表示非矩阵模式
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetFunctionCallGraphReply_GraphNode) Reset() {
 	*x = GetFunctionCallGraphReply_GraphNode{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[42]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2552,7 +3060,7 @@ func (x *GetFunctionCallGraphReply_GraphNode) String() string {
 func (*GetFunctionCallGraphReply_GraphNode) ProtoMessage() {}
 
 func (x *GetFunctionCallGraphReply_GraphNode) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[42]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2565,7 +3073,7 @@ func (x *GetFunctionCallGraphReply_GraphNode) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use GetFunctionCallGraphReply_GraphNode.ProtoReflect.Descriptor instead.
 func (*GetFunctionCallGraphReply_GraphNode) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{16, 0}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{19, 0}
 }
 
 func (x *GetFunctionCallGraphReply_GraphNode) GetKey() string {
@@ -2610,19 +3118,66 @@ func (x *GetFunctionCallGraphReply_GraphNode) GetNodeType() string {
 	return ""
 }
 
+func (x *GetFunctionCallGraphReply_GraphNode) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *GetFunctionCallGraphReply_GraphNode) GetStartLine() int32 {
+	if x != nil {
+		return x.StartLine
+	}
+	return 0
+}
+
+func (x *GetFunctionCallGraphReply_GraphNode) GetEndLine() int32 {
+	if x != nil {
+		return x.EndLine
+	}
+	return 0
+}
+
+func (x *GetFunctionCallGraphReply_GraphNode) GetLegacyKey() string {
+	if x != nil {
+		return x.LegacyKey
+	}
+	return ""
+}
+
+func (x *GetFunctionCallGraphReply_GraphNode) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *GetFunctionCallGraphReply_GraphNode) GetBuildConfigs() []string {
+	if x != nil {
+		return x.BuildConfigs
+	}
+	return nil
+}
+
 type GetFunctionCallGraphReply_GraphEdge struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`                     // 源节点Key
-	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`                     // 目标节点Key
-	Label         string                 `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`                       // 边标签
-	EdgeType      string                 `protobuf:"bytes,4,opt,name=edge_type,json=edgeType,proto3" json:"edge_type,omitempty"` // 边类型: "caller_to_root", "root_to_callee"
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`                        // 源节点Key
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`                        // 目标节点Key
+	Label         string                 `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`                          // 边标签
+	EdgeType      string                 `protobuf:"bytes,4,opt,name=edge_type,json=edgeType,proto3" json:"edge_type,omitempty"`    // 边类型: "caller_to_root", "root_to_callee"
+	CallFile      string                 `protobuf:"bytes,5,opt,name=call_file,json=callFile,proto3" json:"call_file,omitempty"`    // 第一个调用点所在文件, 与sites[0]一致
+	CallLine      int32                  `protobuf:"varint,6,opt,name=call_line,json=callLine,proto3" json:"call_line,omitempty"`   // 第一个调用点行号
+	CallKind      string                 `protobuf:"bytes,7,opt,name=call_kind,json=callKind,proto3" json:"call_kind,omitempty"`    // 第一个调用点的调用类型: "static", "interface", "dynamic", "closure", "go", "defer"
+	Sites         []*CallSite            `protobuf:"bytes,8,rep,name=sites,proto3" json:"sites,omitempty"`                          // 这对函数之间的全部调用点
+	CallKinds     []string               `protobuf:"bytes,9,rep,name=call_kinds,json=callKinds,proto3" json:"call_kinds,omitempty"` // 这对函数之间出现的调用类型, 按首次出现的顺序
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetFunctionCallGraphReply_GraphEdge) Reset() {
 	*x = GetFunctionCallGraphReply_GraphEdge{}
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[43]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2634,7 +3189,7 @@ func (x *GetFunctionCallGraphReply_GraphEdge) String() string {
 func (*GetFunctionCallGraphReply_GraphEdge) ProtoMessage() {}
 
 func (x *GetFunctionCallGraphReply_GraphEdge) ProtoReflect() protoreflect.Message {
-	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[43]
+	mi := &file_staticanalysis_v1_staticanalysis_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2647,7 +3202,7 @@ func (x *GetFunctionCallGraphReply_GraphEdge) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use GetFunctionCallGraphReply_GraphEdge.ProtoReflect.Descriptor instead.
 func (*GetFunctionCallGraphReply_GraphEdge) Descriptor() ([]byte, []int) {
-	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{16, 1}
+	return file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP(), []int{19, 1}
 }
 
 func (x *GetFunctionCallGraphReply_GraphEdge) GetSource() string {
@@ -2678,6 +3233,41 @@ func (x *GetFunctionCallGraphReply_GraphEdge) GetEdgeType() string {
 	return ""
 }
 
+func (x *GetFunctionCallGraphReply_GraphEdge) GetCallFile() string {
+	if x != nil {
+		return x.CallFile
+	}
+	return ""
+}
+
+func (x *GetFunctionCallGraphReply_GraphEdge) GetCallLine() int32 {
+	if x != nil {
+		return x.CallLine
+	}
+	return 0
+}
+
+func (x *GetFunctionCallGraphReply_GraphEdge) GetCallKind() string {
+	if x != nil {
+		return x.CallKind
+	}
+	return ""
+}
+
+func (x *GetFunctionCallGraphReply_GraphEdge) GetSites() []*CallSite {
+	if x != nil {
+		return x.Sites
+	}
+	return nil
+}
+
+func (x *GetFunctionCallGraphReply_GraphEdge) GetCallKinds() []string {
+	if x != nil {
+		return x.CallKinds
+	}
+	return nil
+}
+
 var File_staticanalysis_v1_staticanalysis_proto protoreflect.FileDescriptor
 
 const file_staticanalysis_v1_staticanalysis_proto_rawDesc = "" +
@@ -2692,21 +3282,45 @@ const file_staticanalysis_v1_staticanalysis_proto_rawDesc = "" +
 	"\vcreate_time\x18\x04 \x01(\tR\n" +
 	"createTime\"O\n" +
 	"\x18GetStaticDbFilesResponse\x123\n" +
-	"\x05files\x18\x01 \x03(\v2\x1d.staticanalysis.v1.DbFileInfoR\x05files\"h\n" +
+	"\x05files\x18\x01 \x03(\v2\x1d.staticanalysis.v1.DbFileInfoR\x05files\"\xf8\x02\n" +
 	"\x19AnalyzeProjectPathRequest\x12\x12\n" +
 	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
 	"\x04algo\x18\x02 \x01(\tR\x04algo\x12#\n" +
-	"\rignore_method\x18\x03 \x01(\tR\fignoreMethod\"i\n" +
+	"\rignore_method\x18\x03 \x01(\tR\fignoreMethod\x12\x14\n" +
+	"\x05roots\x18\x04 \x03(\tR\x05roots\x12\x1d\n" +
+	"\n" +
+	"build_tags\x18\x05 \x03(\tR\tbuildTags\x12\x1c\n" +
+	"\tplatforms\x18\x06 \x03(\tR\tplatforms\x12\x19\n" +
+	"\btag_sets\x18\a \x03(\tR\atagSets\x12\x10\n" +
+	"\x03env\x18\b \x03(\tR\x03env\x12!\n" +
+	"\fstd_packages\x18\t \x03(\tR\vstdPackages\x12\x1a\n" +
+	"\btolerant\x18\n" +
+	" \x01(\bR\btolerant\x12'\n" +
+	"\x0ftimeout_seconds\x18\v \x01(\x05R\x0etimeoutSeconds\x12&\n" +
+	"\x0fmemory_limit_mb\x18\f \x01(\x03R\rmemoryLimitMb\"i\n" +
 	"\x1aAnalyzeProjectPathResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
 	"\atask_id\x18\x03 \x01(\tR\x06taskId\"7\n" +
 	"\x1cGetAnalysisTaskStatusRequest\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\tR\x06taskId\"m\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"\xb5\x01\n" +
 	"\x1dGetAnalysisTaskStatusResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\x05R\x06status\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
-	"\bprogress\x18\x03 \x01(\x02R\bprogress\"/\n" +
+	"\bprogress\x18\x03 \x01(\x02R\bprogress\x12F\n" +
+	"\vdiagnostics\x18\x04 \x03(\v2$.staticanalysis.v1.PackageDiagnosticR\vdiagnostics\"4\n" +
+	"\x19CancelAnalysisTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"P\n" +
+	"\x1aCancelAnalysisTaskResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x9b\x01\n" +
+	"\x11PackageDiagnostic\x12\x18\n" +
+	"\apackage\x18\x01 \x01(\tR\apackage\x12\x12\n" +
+	"\x04file\x18\x02 \x01(\tR\x04file\x12\x12\n" +
+	"\x04line\x18\x03 \x01(\x05R\x04line\x12\x16\n" +
+	"\x06column\x18\x04 \x01(\x05R\x06column\x12\x12\n" +
+	"\x04kind\x18\x05 \x01(\tR\x04kind\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\"/\n" +
 	"\x14AnalyzeDbFileRequest\x12\x17\n" +
 	"\adb_path\x18\x01 \x01(\tR\x06dbPath\"Y\n" +
 	"\x11PackageDependency\x12\x16\n" +
@@ -2751,14 +3365,17 @@ const file_staticanalysis_v1_staticanalysis_proto_rawDesc = "" +
 	"\n" +
 	"call_count\x18\x04 \x01(\x05R\tcallCount\x12\x19\n" +
 	"\bavg_time\x18\x05 \x01(\tR\aavgTime\x12T\n" +
-	"\bchildren\x18\x06 \x03(\v28.staticanalysis.v1.GetFunctionAnalysisReply.FunctionNodeR\bchildren\"p\n" +
+	"\bchildren\x18\x06 \x03(\v28.staticanalysis.v1.GetFunctionAnalysisReply.FunctionNodeR\bchildren\"\xa8\x01\n" +
 	"\x17GetFunctionCallGraphReq\x12!\n" +
 	"\ffunction_key\x18\x01 \x01(\tR\vfunctionKey\x12\x14\n" +
 	"\x05depth\x18\x02 \x01(\x05R\x05depth\x12\x1c\n" +
-	"\tdirection\x18\x03 \x01(\tR\tdirection\"\xcc\x03\n" +
+	"\tdirection\x18\x03 \x01(\tR\tdirection\x12\x17\n" +
+	"\adb_path\x18\x04 \x01(\tR\x06dbPath\x12\x1d\n" +
+	"\n" +
+	"call_kinds\x18\x05 \x03(\tR\tcallKinds\"\xa0\x06\n" +
 	"\x19GetFunctionCallGraphReply\x12L\n" +
 	"\x05nodes\x18\x01 \x03(\v26.staticanalysis.v1.GetFunctionCallGraphReply.GraphNodeR\x05nodes\x12L\n" +
-	"\x05edges\x18\x02 \x03(\v26.staticanalysis.v1.GetFunctionCallGraphReply.GraphEdgeR\x05edges\x1a\xa2\x01\n" +
+	"\x05edges\x18\x02 \x03(\v26.staticanalysis.v1.GetFunctionCallGraphReply.GraphEdgeR\x05edges\x1a\xcc\x02\n" +
 	"\tGraphNode\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
@@ -2766,12 +3383,27 @@ const file_staticanalysis_v1_staticanalysis_proto_rawDesc = "" +
 	"\n" +
 	"call_count\x18\x04 \x01(\x05R\tcallCount\x12\x19\n" +
 	"\bavg_time\x18\x05 \x01(\tR\aavgTime\x12\x1b\n" +
-	"\tnode_type\x18\x06 \x01(\tR\bnodeType\x1an\n" +
+	"\tnode_type\x18\x06 \x01(\tR\bnodeType\x12\x12\n" +
+	"\x04file\x18\a \x01(\tR\x04file\x12\x1d\n" +
+	"\n" +
+	"start_line\x18\b \x01(\x05R\tstartLine\x12\x19\n" +
+	"\bend_line\x18\t \x01(\x05R\aendLine\x12\x1d\n" +
+	"\n" +
+	"legacy_key\x18\n" +
+	" \x01(\tR\tlegacyKey\x12\x16\n" +
+	"\x06module\x18\v \x01(\tR\x06module\x12#\n" +
+	"\rbuild_configs\x18\f \x03(\tR\fbuildConfigs\x1a\x97\x02\n" +
 	"\tGraphEdge\x12\x16\n" +
 	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
 	"\x06target\x18\x02 \x01(\tR\x06target\x12\x14\n" +
 	"\x05label\x18\x03 \x01(\tR\x05label\x12\x1b\n" +
-	"\tedge_type\x18\x04 \x01(\tR\bedgeType\"\xae\x02\n" +
+	"\tedge_type\x18\x04 \x01(\tR\bedgeType\x12\x1b\n" +
+	"\tcall_file\x18\x05 \x01(\tR\bcallFile\x12\x1b\n" +
+	"\tcall_line\x18\x06 \x01(\x05R\bcallLine\x12\x1b\n" +
+	"\tcall_kind\x18\a \x01(\tR\bcallKind\x121\n" +
+	"\x05sites\x18\b \x03(\v2\x1b.staticanalysis.v1.CallSiteR\x05sites\x12\x1d\n" +
+	"\n" +
+	"call_kinds\x18\t \x03(\tR\tcallKinds\"\xae\x02\n" +
 	"\x10GitLabRepository\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
@@ -2818,47 +3450,80 @@ const file_staticanalysis_v1_staticanalysis_proto_rawDesc = "" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
 	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
-	"page_count\x18\x05 \x01(\x05R\tpageCount\"m\n" +
+	"page_count\x18\x05 \x01(\x05R\tpageCount\"\xf2\x01\n" +
 	"\fFunctionInfo\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
 	"\apackage\x18\x03 \x01(\tR\apackage\x12\x1d\n" +
 	"\n" +
-	"call_count\x18\x04 \x01(\x05R\tcallCount\"G\n" +
+	"call_count\x18\x04 \x01(\x05R\tcallCount\x12\x12\n" +
+	"\x04file\x18\x05 \x01(\tR\x04file\x12\x1d\n" +
+	"\n" +
+	"start_line\x18\x06 \x01(\x05R\tstartLine\x12\x19\n" +
+	"\bend_line\x18\a \x01(\x05R\aendLine\x12\x1d\n" +
+	"\n" +
+	"legacy_key\x18\b \x01(\tR\tlegacyKey\x12\x16\n" +
+	"\x06module\x18\t \x01(\tR\x06module\"G\n" +
 	"\x16SearchFunctionsRequest\x12\x17\n" +
 	"\adb_path\x18\x01 \x01(\tR\x06dbPath\x12\x14\n" +
 	"\x05query\x18\x02 \x01(\tR\x05query\"X\n" +
 	"\x17SearchFunctionsResponse\x12=\n" +
-	"\tfunctions\x18\x01 \x03(\v2\x1f.staticanalysis.v1.FunctionInfoR\tfunctions\"\x99\x01\n" +
+	"\tfunctions\x18\x01 \x03(\v2\x1f.staticanalysis.v1.FunctionInfoR\tfunctions\"\xb8\x01\n" +
 	"\x1aGetFunctionUpstreamRequest\x12\x17\n" +
 	"\adb_path\x18\x01 \x01(\tR\x06dbPath\x12!\n" +
 	"\ffunction_key\x18\x02 \x01(\tR\vfunctionKey\x12)\n" +
 	"\x10function_package\x18\x03 \x01(\tR\x0ffunctionPackage\x12\x14\n" +
-	"\x05depth\x18\x04 \x01(\x05R\x05depth\"j\n" +
+	"\x05depth\x18\x04 \x01(\x05R\x05depth\x12\x1d\n" +
+	"\n" +
+	"call_kinds\x18\x05 \x03(\tR\tcallKinds\"\x94\x02\n" +
 	"\tGraphNode\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
 	"\apackage\x18\x03 \x01(\tR\apackage\x12\x1d\n" +
 	"\n" +
-	"call_count\x18\x04 \x01(\x05R\tcallCount\"Q\n" +
+	"call_count\x18\x04 \x01(\x05R\tcallCount\x12\x12\n" +
+	"\x04file\x18\x05 \x01(\tR\x04file\x12\x1d\n" +
+	"\n" +
+	"start_line\x18\x06 \x01(\x05R\tstartLine\x12\x19\n" +
+	"\bend_line\x18\a \x01(\x05R\aendLine\x12\x1d\n" +
+	"\n" +
+	"legacy_key\x18\b \x01(\tR\tlegacyKey\x12#\n" +
+	"\rbuild_configs\x18\t \x03(\tR\fbuildConfigs\x12\x16\n" +
+	"\x06module\x18\n" +
+	" \x01(\tR\x06module\"k\n" +
+	"\bCallSite\x12\x12\n" +
+	"\x04file\x18\x01 \x01(\tR\x04file\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x05R\x04line\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12#\n" +
+	"\rbuild_configs\x18\x04 \x03(\tR\fbuildConfigs\"\x82\x02\n" +
 	"\tGraphEdge\x12\x16\n" +
 	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
 	"\x06target\x18\x02 \x01(\tR\x06target\x12\x14\n" +
-	"\x05value\x18\x03 \x01(\x05R\x05value\"\x85\x01\n" +
+	"\x05value\x18\x03 \x01(\x05R\x05value\x12\x1b\n" +
+	"\tcall_file\x18\x04 \x01(\tR\bcallFile\x12\x1b\n" +
+	"\tcall_line\x18\x05 \x01(\x05R\bcallLine\x12\x1d\n" +
+	"\n" +
+	"call_kinds\x18\x06 \x03(\tR\tcallKinds\x12#\n" +
+	"\rbuild_configs\x18\a \x03(\tR\fbuildConfigs\x121\n" +
+	"\x05sites\x18\b \x03(\v2\x1b.staticanalysis.v1.CallSiteR\x05sites\"\x85\x01\n" +
 	"\x1bGetFunctionUpstreamResponse\x122\n" +
 	"\x05nodes\x18\x01 \x03(\v2\x1c.staticanalysis.v1.GraphNodeR\x05nodes\x122\n" +
-	"\x05edges\x18\x02 \x03(\v2\x1c.staticanalysis.v1.GraphEdgeR\x05edges\"\x9b\x01\n" +
+	"\x05edges\x18\x02 \x03(\v2\x1c.staticanalysis.v1.GraphEdgeR\x05edges\"\xba\x01\n" +
 	"\x1cGetFunctionDownstreamRequest\x12\x17\n" +
 	"\adb_path\x18\x01 \x01(\tR\x06dbPath\x12!\n" +
 	"\ffunction_key\x18\x02 \x01(\tR\vfunctionKey\x12)\n" +
 	"\x10function_package\x18\x03 \x01(\tR\x0ffunctionPackage\x12\x14\n" +
-	"\x05depth\x18\x04 \x01(\x05R\x05depth\"\x87\x01\n" +
+	"\x05depth\x18\x04 \x01(\x05R\x05depth\x12\x1d\n" +
+	"\n" +
+	"call_kinds\x18\x05 \x03(\tR\tcallKinds\"\x87\x01\n" +
 	"\x1dGetFunctionDownstreamResponse\x122\n" +
 	"\x05nodes\x18\x01 \x03(\v2\x1c.staticanalysis.v1.GraphNodeR\x05nodes\x122\n" +
-	"\x05edges\x18\x02 \x03(\v2\x1c.staticanalysis.v1.GraphEdgeR\x05edges\"Y\n" +
+	"\x05edges\x18\x02 \x03(\v2\x1c.staticanalysis.v1.GraphEdgeR\x05edges\"x\n" +
 	"\x1bGetFunctionFullChainRequest\x12\x17\n" +
 	"\adb_path\x18\x01 \x01(\tR\x06dbPath\x12!\n" +
-	"\ffunction_key\x18\x02 \x01(\tR\vfunctionKey\"\x86\x01\n" +
+	"\ffunction_key\x18\x02 \x01(\tR\vfunctionKey\x12\x1d\n" +
+	"\n" +
+	"call_kinds\x18\x03 \x03(\tR\tcallKinds\"\x86\x01\n" +
 	"\x1cGetFunctionFullChainResponse\x122\n" +
 	"\x05nodes\x18\x01 \x03(\v2\x1c.staticanalysis.v1.GraphNodeR\x05nodes\x122\n" +
 	"\x05edges\x18\x02 \x03(\v2\x1c.staticanalysis.v1.GraphEdgeR\x05edges\"M\n" +
@@ -2871,10 +3536,11 @@ const file_staticanalysis_v1_staticanalysis_proto_rawDesc = "" +
 	"\tcollapsed\x18\x03 \x01(\bR\tcollapsed\x127\n" +
 	"\bchildren\x18\x04 \x03(\v2\x1b.staticanalysis.v1.TreeNodeR\bchildren\"D\n" +
 	"\x11GetTreeGraphReply\x12/\n" +
-	"\x04root\x18\x01 \x01(\v2\x1b.staticanalysis.v1.TreeNodeR\x04root2\xcb\x12\n" +
+	"\x04root\x18\x01 \x01(\v2\x1b.staticanalysis.v1.TreeNodeR\x04root2\xed\x13\n" +
 	"\x0eStaticAnalysis\x12\x88\x01\n" +
 	"\x10GetStaticDbFiles\x12*.staticanalysis.v1.GetStaticDbFilesRequest\x1a+.staticanalysis.v1.GetStaticDbFilesResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/api/static/dbfiles\x12\xa5\x01\n" +
-	"\x15GetAnalysisTaskStatus\x12/.staticanalysis.v1.GetAnalysisTaskStatusRequest\x1a0.staticanalysis.v1.GetAnalysisTaskStatusResponse\")\x82\xd3\xe4\x93\x02#\x12!/api/static/task/{task_id}/status\x12\x96\x01\n" +
+	"\x15GetAnalysisTaskStatus\x12/.staticanalysis.v1.GetAnalysisTaskStatusRequest\x1a0.staticanalysis.v1.GetAnalysisTaskStatusResponse\")\x82\xd3\xe4\x93\x02#\x12!/api/static/task/{task_id}/status\x12\x9f\x01\n" +
+	"\x12CancelAnalysisTask\x12,.staticanalysis.v1.CancelAnalysisTaskRequest\x1a-.staticanalysis.v1.CancelAnalysisTaskResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/api/static/task/{task_id}/cancel\x12\x96\x01\n" +
 	"\x12AnalyzeProjectPath\x12,.staticanalysis.v1.AnalyzeProjectPathRequest\x1a-.staticanalysis.v1.AnalyzeProjectPathResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/static/analyze/path\x12\x82\x01\n" +
 	"\rAnalyzeDbFile\x12'.staticanalysis.v1.AnalyzeDbFileRequest\x1a(.staticanalysis.v1.AnalyzeDbFileResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/api/static/analyze\x12\x97\x01\n" +
 	"\x13GetFunctionAnalysis\x12).staticanalysis.v1.GetFunctionAnalysisReq\x1a+.staticanalysis.v1.GetFunctionAnalysisReply\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/static/function/analysis\x12\xc4\x01\n" +
@@ -2901,7 +3567,7 @@ func file_staticanalysis_v1_staticanalysis_proto_rawDescGZIP() []byte {
 	return file_staticanalysis_v1_staticanalysis_proto_rawDescData
 }
 
-var file_staticanalysis_v1_staticanalysis_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
+var file_staticanalysis_v1_staticanalysis_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
 var file_staticanalysis_v1_staticanalysis_proto_goTypes = []any{
 	(*GetStaticDbFilesRequest)(nil),               // 0: staticanalysis.v1.GetStaticDbFilesRequest
 	(*DbFileInfo)(nil),                            // 1: staticanalysis.v1.DbFileInfo
@@ -2910,100 +3576,109 @@ var file_staticanalysis_v1_staticanalysis_proto_goTypes = []any{
 	(*AnalyzeProjectPathResponse)(nil),            // 4: staticanalysis.v1.AnalyzeProjectPathResponse
 	(*GetAnalysisTaskStatusRequest)(nil),          // 5: staticanalysis.v1.GetAnalysisTaskStatusRequest
 	(*GetAnalysisTaskStatusResponse)(nil),         // 6: staticanalysis.v1.GetAnalysisTaskStatusResponse
-	(*AnalyzeDbFileRequest)(nil),                  // 7: staticanalysis.v1.AnalyzeDbFileRequest
-	(*PackageDependency)(nil),                     // 8: staticanalysis.v1.PackageDependency
-	(*HotFunction)(nil),                           // 9: staticanalysis.v1.HotFunction
-	(*AnalyzeDbFileResponse)(nil),                 // 10: staticanalysis.v1.AnalyzeDbFileResponse
-	(*GetHotFunctionsReq)(nil),                    // 11: staticanalysis.v1.GetHotFunctionsReq
-	(*GetHotFunctionsReply)(nil),                  // 12: staticanalysis.v1.GetHotFunctionsReply
-	(*GetFunctionAnalysisReq)(nil),                // 13: staticanalysis.v1.GetFunctionAnalysisReq
-	(*GetFunctionAnalysisReply)(nil),              // 14: staticanalysis.v1.GetFunctionAnalysisReply
-	(*GetFunctionCallGraphReq)(nil),               // 15: staticanalysis.v1.GetFunctionCallGraphReq
-	(*GetFunctionCallGraphReply)(nil),             // 16: staticanalysis.v1.GetFunctionCallGraphReply
-	(*GitLabRepository)(nil),                      // 17: staticanalysis.v1.GitLabRepository
-	(*ListGitLabRepositoriesRequest)(nil),         // 18: staticanalysis.v1.ListGitLabRepositoriesRequest
-	(*ListGitLabRepositoriesResponse)(nil),        // 19: staticanalysis.v1.ListGitLabRepositoriesResponse
-	(*CloneGitLabRepositoryRequest)(nil),          // 20: staticanalysis.v1.CloneGitLabRepositoryRequest
-	(*CloneGitLabRepositoryResponse)(nil),         // 21: staticanalysis.v1.CloneGitLabRepositoryResponse
-	(*GetPackageDependenciesRequest)(nil),         // 22: staticanalysis.v1.GetPackageDependenciesRequest
-	(*GetPackageDependenciesResponse)(nil),        // 23: staticanalysis.v1.GetPackageDependenciesResponse
-	(*GetHotFunctionsRequest)(nil),                // 24: staticanalysis.v1.GetHotFunctionsRequest
-	(*GetHotFunctionsResponse)(nil),               // 25: staticanalysis.v1.GetHotFunctionsResponse
-	(*FunctionInfo)(nil),                          // 26: staticanalysis.v1.FunctionInfo
-	(*SearchFunctionsRequest)(nil),                // 27: staticanalysis.v1.SearchFunctionsRequest
-	(*SearchFunctionsResponse)(nil),               // 28: staticanalysis.v1.SearchFunctionsResponse
-	(*GetFunctionUpstreamRequest)(nil),            // 29: staticanalysis.v1.GetFunctionUpstreamRequest
-	(*GraphNode)(nil),                             // 30: staticanalysis.v1.GraphNode
-	(*GraphEdge)(nil),                             // 31: staticanalysis.v1.GraphEdge
-	(*GetFunctionUpstreamResponse)(nil),           // 32: staticanalysis.v1.GetFunctionUpstreamResponse
-	(*GetFunctionDownstreamRequest)(nil),          // 33: staticanalysis.v1.GetFunctionDownstreamRequest
-	(*GetFunctionDownstreamResponse)(nil),         // 34: staticanalysis.v1.GetFunctionDownstreamResponse
-	(*GetFunctionFullChainRequest)(nil),           // 35: staticanalysis.v1.GetFunctionFullChainRequest
-	(*GetFunctionFullChainResponse)(nil),          // 36: staticanalysis.v1.GetFunctionFullChainResponse
-	(*GetTreeGraphReq)(nil),                       // 37: staticanalysis.v1.GetTreeGraphReq
-	(*TreeNode)(nil),                              // 38: staticanalysis.v1.TreeNode
-	(*GetTreeGraphReply)(nil),                     // 39: staticanalysis.v1.GetTreeGraphReply
-	(*GetHotFunctionsReply_HotFunction)(nil),      // 40: staticanalysis.v1.GetHotFunctionsReply.HotFunction
-	(*GetFunctionAnalysisReply_FunctionNode)(nil), // 41: staticanalysis.v1.GetFunctionAnalysisReply.FunctionNode
-	(*GetFunctionCallGraphReply_GraphNode)(nil),   // 42: staticanalysis.v1.GetFunctionCallGraphReply.GraphNode
-	(*GetFunctionCallGraphReply_GraphEdge)(nil),   // 43: staticanalysis.v1.GetFunctionCallGraphReply.GraphEdge
+	(*CancelAnalysisTaskRequest)(nil),             // 7: staticanalysis.v1.CancelAnalysisTaskRequest
+	(*CancelAnalysisTaskResponse)(nil),            // 8: staticanalysis.v1.CancelAnalysisTaskResponse
+	(*PackageDiagnostic)(nil),                     // 9: staticanalysis.v1.PackageDiagnostic
+	(*AnalyzeDbFileRequest)(nil),                  // 10: staticanalysis.v1.AnalyzeDbFileRequest
+	(*PackageDependency)(nil),                     // 11: staticanalysis.v1.PackageDependency
+	(*HotFunction)(nil),                           // 12: staticanalysis.v1.HotFunction
+	(*AnalyzeDbFileResponse)(nil),                 // 13: staticanalysis.v1.AnalyzeDbFileResponse
+	(*GetHotFunctionsReq)(nil),                    // 14: staticanalysis.v1.GetHotFunctionsReq
+	(*GetHotFunctionsReply)(nil),                  // 15: staticanalysis.v1.GetHotFunctionsReply
+	(*GetFunctionAnalysisReq)(nil),                // 16: staticanalysis.v1.GetFunctionAnalysisReq
+	(*GetFunctionAnalysisReply)(nil),              // 17: staticanalysis.v1.GetFunctionAnalysisReply
+	(*GetFunctionCallGraphReq)(nil),               // 18: staticanalysis.v1.GetFunctionCallGraphReq
+	(*GetFunctionCallGraphReply)(nil),             // 19: staticanalysis.v1.GetFunctionCallGraphReply
+	(*GitLabRepository)(nil),                      // 20: staticanalysis.v1.GitLabRepository
+	(*ListGitLabRepositoriesRequest)(nil),         // 21: staticanalysis.v1.ListGitLabRepositoriesRequest
+	(*ListGitLabRepositoriesResponse)(nil),        // 22: staticanalysis.v1.ListGitLabRepositoriesResponse
+	(*CloneGitLabRepositoryRequest)(nil),          // 23: staticanalysis.v1.CloneGitLabRepositoryRequest
+	(*CloneGitLabRepositoryResponse)(nil),         // 24: staticanalysis.v1.CloneGitLabRepositoryResponse
+	(*GetPackageDependenciesRequest)(nil),         // 25: staticanalysis.v1.GetPackageDependenciesRequest
+	(*GetPackageDependenciesResponse)(nil),        // 26: staticanalysis.v1.GetPackageDependenciesResponse
+	(*GetHotFunctionsRequest)(nil),                // 27: staticanalysis.v1.GetHotFunctionsRequest
+	(*GetHotFunctionsResponse)(nil),               // 28: staticanalysis.v1.GetHotFunctionsResponse
+	(*FunctionInfo)(nil),                          // 29: staticanalysis.v1.FunctionInfo
+	(*SearchFunctionsRequest)(nil),                // 30: staticanalysis.v1.SearchFunctionsRequest
+	(*SearchFunctionsResponse)(nil),               // 31: staticanalysis.v1.SearchFunctionsResponse
+	(*GetFunctionUpstreamRequest)(nil),            // 32: staticanalysis.v1.GetFunctionUpstreamRequest
+	(*GraphNode)(nil),                             // 33: staticanalysis.v1.GraphNode
+	(*CallSite)(nil),                              // 34: staticanalysis.v1.CallSite
+	(*GraphEdge)(nil),                             // 35: staticanalysis.v1.GraphEdge
+	(*GetFunctionUpstreamResponse)(nil),           // 36: staticanalysis.v1.GetFunctionUpstreamResponse
+	(*GetFunctionDownstreamRequest)(nil),          // 37: staticanalysis.v1.GetFunctionDownstreamRequest
+	(*GetFunctionDownstreamResponse)(nil),         // 38: staticanalysis.v1.GetFunctionDownstreamResponse
+	(*GetFunctionFullChainRequest)(nil),           // 39: staticanalysis.v1.GetFunctionFullChainRequest
+	(*GetFunctionFullChainResponse)(nil),          // 40: staticanalysis.v1.GetFunctionFullChainResponse
+	(*GetTreeGraphReq)(nil),                       // 41: staticanalysis.v1.GetTreeGraphReq
+	(*TreeNode)(nil),                              // 42: staticanalysis.v1.TreeNode
+	(*GetTreeGraphReply)(nil),                     // 43: staticanalysis.v1.GetTreeGraphReply
+	(*GetHotFunctionsReply_HotFunction)(nil),      // 44: staticanalysis.v1.GetHotFunctionsReply.HotFunction
+	(*GetFunctionAnalysisReply_FunctionNode)(nil), // 45: staticanalysis.v1.GetFunctionAnalysisReply.FunctionNode
+	(*GetFunctionCallGraphReply_GraphNode)(nil),   // 46: staticanalysis.v1.GetFunctionCallGraphReply.GraphNode
+	(*GetFunctionCallGraphReply_GraphEdge)(nil),   // 47: staticanalysis.v1.GetFunctionCallGraphReply.GraphEdge
 }
 var file_staticanalysis_v1_staticanalysis_proto_depIdxs = []int32{
 	1,  // 0: staticanalysis.v1.GetStaticDbFilesResponse.files:type_name -> staticanalysis.v1.DbFileInfo
-	8,  // 1: staticanalysis.v1.AnalyzeDbFileResponse.package_dependencies:type_name -> staticanalysis.v1.PackageDependency
-	9,  // 2: staticanalysis.v1.AnalyzeDbFileResponse.hot_functions:type_name -> staticanalysis.v1.HotFunction
-	40, // 3: staticanalysis.v1.GetHotFunctionsReply.functions:type_name -> staticanalysis.v1.GetHotFunctionsReply.HotFunction
-	41, // 4: staticanalysis.v1.GetFunctionAnalysisReply.callData:type_name -> staticanalysis.v1.GetFunctionAnalysisReply.FunctionNode
-	42, // 5: staticanalysis.v1.GetFunctionCallGraphReply.nodes:type_name -> staticanalysis.v1.GetFunctionCallGraphReply.GraphNode
-	43, // 6: staticanalysis.v1.GetFunctionCallGraphReply.edges:type_name -> staticanalysis.v1.GetFunctionCallGraphReply.GraphEdge
-	17, // 7: staticanalysis.v1.ListGitLabRepositoriesResponse.repositories:type_name -> staticanalysis.v1.GitLabRepository
-	8,  // 8: staticanalysis.v1.GetPackageDependenciesResponse.dependencies:type_name -> staticanalysis.v1.PackageDependency
-	9,  // 9: staticanalysis.v1.GetHotFunctionsResponse.functions:type_name -> staticanalysis.v1.HotFunction
-	26, // 10: staticanalysis.v1.SearchFunctionsResponse.functions:type_name -> staticanalysis.v1.FunctionInfo
-	30, // 11: staticanalysis.v1.GetFunctionUpstreamResponse.nodes:type_name -> staticanalysis.v1.GraphNode
-	31, // 12: staticanalysis.v1.GetFunctionUpstreamResponse.edges:type_name -> staticanalysis.v1.GraphEdge
-	30, // 13: staticanalysis.v1.GetFunctionDownstreamResponse.nodes:type_name -> staticanalysis.v1.GraphNode
-	31, // 14: staticanalysis.v1.GetFunctionDownstreamResponse.edges:type_name -> staticanalysis.v1.GraphEdge
-	30, // 15: staticanalysis.v1.GetFunctionFullChainResponse.nodes:type_name -> staticanalysis.v1.GraphNode
-	31, // 16: staticanalysis.v1.GetFunctionFullChainResponse.edges:type_name -> staticanalysis.v1.GraphEdge
-	38, // 17: staticanalysis.v1.TreeNode.children:type_name -> staticanalysis.v1.TreeNode
-	38, // 18: staticanalysis.v1.GetTreeGraphReply.root:type_name -> staticanalysis.v1.TreeNode
-	41, // 19: staticanalysis.v1.GetFunctionAnalysisReply.FunctionNode.children:type_name -> staticanalysis.v1.GetFunctionAnalysisReply.FunctionNode
-	0,  // 20: staticanalysis.v1.StaticAnalysis.GetStaticDbFiles:input_type -> staticanalysis.v1.GetStaticDbFilesRequest
-	5,  // 21: staticanalysis.v1.StaticAnalysis.GetAnalysisTaskStatus:input_type -> staticanalysis.v1.GetAnalysisTaskStatusRequest
-	3,  // 22: staticanalysis.v1.StaticAnalysis.AnalyzeProjectPath:input_type -> staticanalysis.v1.AnalyzeProjectPathRequest
-	7,  // 23: staticanalysis.v1.StaticAnalysis.AnalyzeDbFile:input_type -> staticanalysis.v1.AnalyzeDbFileRequest
-	13, // 24: staticanalysis.v1.StaticAnalysis.GetFunctionAnalysis:input_type -> staticanalysis.v1.GetFunctionAnalysisReq
-	15, // 25: staticanalysis.v1.StaticAnalysis.GetFunctionCallGraph:input_type -> staticanalysis.v1.GetFunctionCallGraphReq
-	18, // 26: staticanalysis.v1.StaticAnalysis.ListGitLabRepositories:input_type -> staticanalysis.v1.ListGitLabRepositoriesRequest
-	20, // 27: staticanalysis.v1.StaticAnalysis.CloneGitLabRepository:input_type -> staticanalysis.v1.CloneGitLabRepositoryRequest
-	22, // 28: staticanalysis.v1.StaticAnalysis.GetPackageDependencies:input_type -> staticanalysis.v1.GetPackageDependenciesRequest
-	24, // 29: staticanalysis.v1.StaticAnalysis.GetHotFunctions:input_type -> staticanalysis.v1.GetHotFunctionsRequest
-	27, // 30: staticanalysis.v1.StaticAnalysis.SearchFunctions:input_type -> staticanalysis.v1.SearchFunctionsRequest
-	29, // 31: staticanalysis.v1.StaticAnalysis.GetFunctionUpstream:input_type -> staticanalysis.v1.GetFunctionUpstreamRequest
-	33, // 32: staticanalysis.v1.StaticAnalysis.GetFunctionDownstream:input_type -> staticanalysis.v1.GetFunctionDownstreamRequest
-	35, // 33: staticanalysis.v1.StaticAnalysis.GetFunctionFullChain:input_type -> staticanalysis.v1.GetFunctionFullChainRequest
-	37, // 34: staticanalysis.v1.StaticAnalysis.GetTreeGraph:input_type -> staticanalysis.v1.GetTreeGraphReq
-	2,  // 35: staticanalysis.v1.StaticAnalysis.GetStaticDbFiles:output_type -> staticanalysis.v1.GetStaticDbFilesResponse
-	6,  // 36: staticanalysis.v1.StaticAnalysis.GetAnalysisTaskStatus:output_type -> staticanalysis.v1.GetAnalysisTaskStatusResponse
-	4,  // 37: staticanalysis.v1.StaticAnalysis.AnalyzeProjectPath:output_type -> staticanalysis.v1.AnalyzeProjectPathResponse
-	10, // 38: staticanalysis.v1.StaticAnalysis.AnalyzeDbFile:output_type -> staticanalysis.v1.AnalyzeDbFileResponse
-	14, // 39: staticanalysis.v1.StaticAnalysis.GetFunctionAnalysis:output_type -> staticanalysis.v1.GetFunctionAnalysisReply
-	16, // 40: staticanalysis.v1.StaticAnalysis.GetFunctionCallGraph:output_type -> staticanalysis.v1.GetFunctionCallGraphReply
-	19, // 41: staticanalysis.v1.StaticAnalysis.ListGitLabRepositories:output_type -> staticanalysis.v1.ListGitLabRepositoriesResponse
-	21, // 42: staticanalysis.v1.StaticAnalysis.CloneGitLabRepository:output_type -> staticanalysis.v1.CloneGitLabRepositoryResponse
-	23, // 43: staticanalysis.v1.StaticAnalysis.GetPackageDependencies:output_type -> staticanalysis.v1.GetPackageDependenciesResponse
-	25, // 44: staticanalysis.v1.StaticAnalysis.GetHotFunctions:output_type -> staticanalysis.v1.GetHotFunctionsResponse
-	28, // 45: staticanalysis.v1.StaticAnalysis.SearchFunctions:output_type -> staticanalysis.v1.SearchFunctionsResponse
-	32, // 46: staticanalysis.v1.StaticAnalysis.GetFunctionUpstream:output_type -> staticanalysis.v1.GetFunctionUpstreamResponse
-	34, // 47: staticanalysis.v1.StaticAnalysis.GetFunctionDownstream:output_type -> staticanalysis.v1.GetFunctionDownstreamResponse
-	36, // 48: staticanalysis.v1.StaticAnalysis.GetFunctionFullChain:output_type -> staticanalysis.v1.GetFunctionFullChainResponse
-	39, // 49: staticanalysis.v1.StaticAnalysis.GetTreeGraph:output_type -> staticanalysis.v1.GetTreeGraphReply
-	35, // [35:50] is the sub-list for method output_type
-	20, // [20:35] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	9,  // 1: staticanalysis.v1.GetAnalysisTaskStatusResponse.diagnostics:type_name -> staticanalysis.v1.PackageDiagnostic
+	11, // 2: staticanalysis.v1.AnalyzeDbFileResponse.package_dependencies:type_name -> staticanalysis.v1.PackageDependency
+	12, // 3: staticanalysis.v1.AnalyzeDbFileResponse.hot_functions:type_name -> staticanalysis.v1.HotFunction
+	44, // 4: staticanalysis.v1.GetHotFunctionsReply.functions:type_name -> staticanalysis.v1.GetHotFunctionsReply.HotFunction
+	45, // 5: staticanalysis.v1.GetFunctionAnalysisReply.callData:type_name -> staticanalysis.v1.GetFunctionAnalysisReply.FunctionNode
+	46, // 6: staticanalysis.v1.GetFunctionCallGraphReply.nodes:type_name -> staticanalysis.v1.GetFunctionCallGraphReply.GraphNode
+	47, // 7: staticanalysis.v1.GetFunctionCallGraphReply.edges:type_name -> staticanalysis.v1.GetFunctionCallGraphReply.GraphEdge
+	20, // 8: staticanalysis.v1.ListGitLabRepositoriesResponse.repositories:type_name -> staticanalysis.v1.GitLabRepository
+	11, // 9: staticanalysis.v1.GetPackageDependenciesResponse.dependencies:type_name -> staticanalysis.v1.PackageDependency
+	12, // 10: staticanalysis.v1.GetHotFunctionsResponse.functions:type_name -> staticanalysis.v1.HotFunction
+	29, // 11: staticanalysis.v1.SearchFunctionsResponse.functions:type_name -> staticanalysis.v1.FunctionInfo
+	34, // 12: staticanalysis.v1.GraphEdge.sites:type_name -> staticanalysis.v1.CallSite
+	33, // 13: staticanalysis.v1.GetFunctionUpstreamResponse.nodes:type_name -> staticanalysis.v1.GraphNode
+	35, // 14: staticanalysis.v1.GetFunctionUpstreamResponse.edges:type_name -> staticanalysis.v1.GraphEdge
+	33, // 15: staticanalysis.v1.GetFunctionDownstreamResponse.nodes:type_name -> staticanalysis.v1.GraphNode
+	35, // 16: staticanalysis.v1.GetFunctionDownstreamResponse.edges:type_name -> staticanalysis.v1.GraphEdge
+	33, // 17: staticanalysis.v1.GetFunctionFullChainResponse.nodes:type_name -> staticanalysis.v1.GraphNode
+	35, // 18: staticanalysis.v1.GetFunctionFullChainResponse.edges:type_name -> staticanalysis.v1.GraphEdge
+	42, // 19: staticanalysis.v1.TreeNode.children:type_name -> staticanalysis.v1.TreeNode
+	42, // 20: staticanalysis.v1.GetTreeGraphReply.root:type_name -> staticanalysis.v1.TreeNode
+	45, // 21: staticanalysis.v1.GetFunctionAnalysisReply.FunctionNode.children:type_name -> staticanalysis.v1.GetFunctionAnalysisReply.FunctionNode
+	34, // 22: staticanalysis.v1.GetFunctionCallGraphReply.GraphEdge.sites:type_name -> staticanalysis.v1.CallSite
+	0,  // 23: staticanalysis.v1.StaticAnalysis.GetStaticDbFiles:input_type -> staticanalysis.v1.GetStaticDbFilesRequest
+	5,  // 24: staticanalysis.v1.StaticAnalysis.GetAnalysisTaskStatus:input_type -> staticanalysis.v1.GetAnalysisTaskStatusRequest
+	7,  // 25: staticanalysis.v1.StaticAnalysis.CancelAnalysisTask:input_type -> staticanalysis.v1.CancelAnalysisTaskRequest
+	3,  // 26: staticanalysis.v1.StaticAnalysis.AnalyzeProjectPath:input_type -> staticanalysis.v1.AnalyzeProjectPathRequest
+	10, // 27: staticanalysis.v1.StaticAnalysis.AnalyzeDbFile:input_type -> staticanalysis.v1.AnalyzeDbFileRequest
+	16, // 28: staticanalysis.v1.StaticAnalysis.GetFunctionAnalysis:input_type -> staticanalysis.v1.GetFunctionAnalysisReq
+	18, // 29: staticanalysis.v1.StaticAnalysis.GetFunctionCallGraph:input_type -> staticanalysis.v1.GetFunctionCallGraphReq
+	21, // 30: staticanalysis.v1.StaticAnalysis.ListGitLabRepositories:input_type -> staticanalysis.v1.ListGitLabRepositoriesRequest
+	23, // 31: staticanalysis.v1.StaticAnalysis.CloneGitLabRepository:input_type -> staticanalysis.v1.CloneGitLabRepositoryRequest
+	25, // 32: staticanalysis.v1.StaticAnalysis.GetPackageDependencies:input_type -> staticanalysis.v1.GetPackageDependenciesRequest
+	27, // 33: staticanalysis.v1.StaticAnalysis.GetHotFunctions:input_type -> staticanalysis.v1.GetHotFunctionsRequest
+	30, // 34: staticanalysis.v1.StaticAnalysis.SearchFunctions:input_type -> staticanalysis.v1.SearchFunctionsRequest
+	32, // 35: staticanalysis.v1.StaticAnalysis.GetFunctionUpstream:input_type -> staticanalysis.v1.GetFunctionUpstreamRequest
+	37, // 36: staticanalysis.v1.StaticAnalysis.GetFunctionDownstream:input_type -> staticanalysis.v1.GetFunctionDownstreamRequest
+	39, // 37: staticanalysis.v1.StaticAnalysis.GetFunctionFullChain:input_type -> staticanalysis.v1.GetFunctionFullChainRequest
+	41, // 38: staticanalysis.v1.StaticAnalysis.GetTreeGraph:input_type -> staticanalysis.v1.GetTreeGraphReq
+	2,  // 39: staticanalysis.v1.StaticAnalysis.GetStaticDbFiles:output_type -> staticanalysis.v1.GetStaticDbFilesResponse
+	6,  // 40: staticanalysis.v1.StaticAnalysis.GetAnalysisTaskStatus:output_type -> staticanalysis.v1.GetAnalysisTaskStatusResponse
+	8,  // 41: staticanalysis.v1.StaticAnalysis.CancelAnalysisTask:output_type -> staticanalysis.v1.CancelAnalysisTaskResponse
+	4,  // 42: staticanalysis.v1.StaticAnalysis.AnalyzeProjectPath:output_type -> staticanalysis.v1.AnalyzeProjectPathResponse
+	13, // 43: staticanalysis.v1.StaticAnalysis.AnalyzeDbFile:output_type -> staticanalysis.v1.AnalyzeDbFileResponse
+	17, // 44: staticanalysis.v1.StaticAnalysis.GetFunctionAnalysis:output_type -> staticanalysis.v1.GetFunctionAnalysisReply
+	19, // 45: staticanalysis.v1.StaticAnalysis.GetFunctionCallGraph:output_type -> staticanalysis.v1.GetFunctionCallGraphReply
+	22, // 46: staticanalysis.v1.StaticAnalysis.ListGitLabRepositories:output_type -> staticanalysis.v1.ListGitLabRepositoriesResponse
+	24, // 47: staticanalysis.v1.StaticAnalysis.CloneGitLabRepository:output_type -> staticanalysis.v1.CloneGitLabRepositoryResponse
+	26, // 48: staticanalysis.v1.StaticAnalysis.GetPackageDependencies:output_type -> staticanalysis.v1.GetPackageDependenciesResponse
+	28, // 49: staticanalysis.v1.StaticAnalysis.GetHotFunctions:output_type -> staticanalysis.v1.GetHotFunctionsResponse
+	31, // 50: staticanalysis.v1.StaticAnalysis.SearchFunctions:output_type -> staticanalysis.v1.SearchFunctionsResponse
+	36, // 51: staticanalysis.v1.StaticAnalysis.GetFunctionUpstream:output_type -> staticanalysis.v1.GetFunctionUpstreamResponse
+	38, // 52: staticanalysis.v1.StaticAnalysis.GetFunctionDownstream:output_type -> staticanalysis.v1.GetFunctionDownstreamResponse
+	40, // 53: staticanalysis.v1.StaticAnalysis.GetFunctionFullChain:output_type -> staticanalysis.v1.GetFunctionFullChainResponse
+	43, // 54: staticanalysis.v1.StaticAnalysis.GetTreeGraph:output_type -> staticanalysis.v1.GetTreeGraphReply
+	39, // [39:55] is the sub-list for method output_type
+	23, // [23:39] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_staticanalysis_v1_staticanalysis_proto_init() }
@@ -3017,7 +3692,7 @@ func file_staticanalysis_v1_staticanalysis_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_staticanalysis_v1_staticanalysis_proto_rawDesc), len(file_staticanalysis_v1_staticanalysis_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   44,
+			NumMessages:   48,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -91,6 +91,48 @@ func local_request_StaticAnalysis_GetAnalysisTaskStatus_0(ctx context.Context, m
 	return msg, metadata, err
 }
 
+func request_StaticAnalysis_CancelAnalysisTask_0(ctx context.Context, marshaler runtime.Marshaler, client StaticAnalysisClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq CancelAnalysisTaskRequest
+		metadata runtime.ServerMetadata
+		err      error
+	)
+	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	val, ok := pathParams["task_id"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "task_id")
+	}
+	protoReq.TaskId, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "task_id", err)
+	}
+	msg, err := client.CancelAnalysisTask(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
+	return msg, metadata, err
+}
+
+func local_request_StaticAnalysis_CancelAnalysisTask_0(ctx context.Context, marshaler runtime.Marshaler, server StaticAnalysisServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq CancelAnalysisTaskRequest
+		metadata runtime.ServerMetadata
+		err      error
+	)
+	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	val, ok := pathParams["task_id"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "task_id")
+	}
+	protoReq.TaskId, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "task_id", err)
+	}
+	msg, err := server.CancelAnalysisTask(ctx, &protoReq)
+	return msg, metadata, err
+}
+
 func request_StaticAnalysis_AnalyzeProjectPath_0(ctx context.Context, marshaler runtime.Marshaler, client StaticAnalysisClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
 	var (
 		protoReq AnalyzeProjectPathRequest
@@ -504,6 +546,26 @@ func RegisterStaticAnalysisHandlerServer(ctx context.Context, mux *runtime.Serve
 		}
 		forward_StaticAnalysis_GetAnalysisTaskStatus_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
+	mux.Handle(http.MethodPost, pattern_StaticAnalysis_CancelAnalysisTask_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, mux, req, "/staticanalysis.v1.StaticAnalysis/CancelAnalysisTask", runtime.WithHTTPPathPattern("/api/static/task/{task_id}/cancel"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_StaticAnalysis_CancelAnalysisTask_0(annotatedContext, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		forward_StaticAnalysis_CancelAnalysisTask_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+	})
 	mux.Handle(http.MethodPost, pattern_StaticAnalysis_AnalyzeProjectPath_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
@@ -858,6 +920,23 @@ func RegisterStaticAnalysisHandlerClient(ctx context.Context, mux *runtime.Serve
 		}
 		forward_StaticAnalysis_GetAnalysisTaskStatus_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
+	mux.Handle(http.MethodPost, pattern_StaticAnalysis_CancelAnalysisTask_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, "/staticanalysis.v1.StaticAnalysis/CancelAnalysisTask", runtime.WithHTTPPathPattern("/api/static/task/{task_id}/cancel"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := request_StaticAnalysis_CancelAnalysisTask_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		forward_StaticAnalysis_CancelAnalysisTask_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+	})
 	mux.Handle(http.MethodPost, pattern_StaticAnalysis_AnalyzeProjectPath_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
@@ -1102,6 +1181,7 @@ func RegisterStaticAnalysisHandlerClient(ctx context.Context, mux *runtime.Serve
 var (
 	pattern_StaticAnalysis_GetStaticDbFiles_0       = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2}, []string{"api", "static", "dbfiles"}, ""))
 	pattern_StaticAnalysis_GetAnalysisTaskStatus_0  = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 1, 0, 4, 1, 5, 3, 2, 4}, []string{"api", "static", "task", "task_id", "status"}, ""))
+	pattern_StaticAnalysis_CancelAnalysisTask_0     = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 1, 0, 4, 1, 5, 3, 2, 4}, []string{"api", "static", "task", "task_id", "cancel"}, ""))
 	pattern_StaticAnalysis_AnalyzeProjectPath_0     = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 2, 3}, []string{"api", "static", "analyze", "path"}, ""))
 	pattern_StaticAnalysis_AnalyzeDbFile_0          = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2}, []string{"api", "static", "analyze"}, ""))
 	pattern_StaticAnalysis_GetFunctionAnalysis_0    = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 2, 3}, []string{"api", "static", "function", "analysis"}, ""))
@@ -1121,6 +1201,7 @@ var (
 var (
 	forward_StaticAnalysis_GetStaticDbFiles_0       = runtime.ForwardResponseMessage
 	forward_StaticAnalysis_GetAnalysisTaskStatus_0  = runtime.ForwardResponseMessage
+	forward_StaticAnalysis_CancelAnalysisTask_0     = runtime.ForwardResponseMessage
 	forward_StaticAnalysis_AnalyzeProjectPath_0     = runtime.ForwardResponseMessage
 	forward_StaticAnalysis_AnalyzeDbFile_0          = runtime.ForwardResponseMessage
 	forward_StaticAnalysis_GetFunctionAnalysis_0    = runtime.ForwardResponseMessage
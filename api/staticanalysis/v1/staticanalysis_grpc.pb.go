@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	StaticAnalysis_GetStaticDbFiles_FullMethodName       = "/staticanalysis.v1.StaticAnalysis/GetStaticDbFiles"
 	StaticAnalysis_GetAnalysisTaskStatus_FullMethodName  = "/staticanalysis.v1.StaticAnalysis/GetAnalysisTaskStatus"
+	StaticAnalysis_CancelAnalysisTask_FullMethodName     = "/staticanalysis.v1.StaticAnalysis/CancelAnalysisTask"
 	StaticAnalysis_AnalyzeProjectPath_FullMethodName     = "/staticanalysis.v1.StaticAnalysis/AnalyzeProjectPath"
 	StaticAnalysis_AnalyzeDbFile_FullMethodName          = "/staticanalysis.v1.StaticAnalysis/AnalyzeDbFile"
 	StaticAnalysis_GetFunctionAnalysis_FullMethodName    = "/staticanalysis.v1.StaticAnalysis/GetFunctionAnalysis"
@@ -46,6 +47,8 @@ type StaticAnalysisClient interface {
 	GetStaticDbFiles(ctx context.Context, in *GetStaticDbFilesRequest, opts ...grpc.CallOption) (*GetStaticDbFilesResponse, error)
 	// 获取分析任务状态
 	GetAnalysisTaskStatus(ctx context.Context, in *GetAnalysisTaskStatusRequest, opts ...grpc.CallOption) (*GetAnalysisTaskStatusResponse, error)
+	// 取消分析任务, 排队中的任务不再执行, 执行中的任务尽快终止
+	CancelAnalysisTask(ctx context.Context, in *CancelAnalysisTaskRequest, opts ...grpc.CallOption) (*CancelAnalysisTaskResponse, error)
 	// 分析项目路径
 	AnalyzeProjectPath(ctx context.Context, in *AnalyzeProjectPathRequest, opts ...grpc.CallOption) (*AnalyzeProjectPathResponse, error)
 	// 分析数据库文件
@@ -102,6 +105,16 @@ func (c *staticAnalysisClient) GetAnalysisTaskStatus(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *staticAnalysisClient) CancelAnalysisTask(ctx context.Context, in *CancelAnalysisTaskRequest, opts ...grpc.CallOption) (*CancelAnalysisTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelAnalysisTaskResponse)
+	err := c.cc.Invoke(ctx, StaticAnalysis_CancelAnalysisTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *staticAnalysisClient) AnalyzeProjectPath(ctx context.Context, in *AnalyzeProjectPathRequest, opts ...grpc.CallOption) (*AnalyzeProjectPathResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AnalyzeProjectPathResponse)
@@ -242,6 +255,8 @@ type StaticAnalysisServer interface {
 	GetStaticDbFiles(context.Context, *GetStaticDbFilesRequest) (*GetStaticDbFilesResponse, error)
 	// 获取分析任务状态
 	GetAnalysisTaskStatus(context.Context, *GetAnalysisTaskStatusRequest) (*GetAnalysisTaskStatusResponse, error)
+	// 取消分析任务, 排队中的任务不再执行, 执行中的任务尽快终止
+	CancelAnalysisTask(context.Context, *CancelAnalysisTaskRequest) (*CancelAnalysisTaskResponse, error)
 	// 分析项目路径
 	AnalyzeProjectPath(context.Context, *AnalyzeProjectPathRequest) (*AnalyzeProjectPathResponse, error)
 	// 分析数据库文件
@@ -284,6 +299,9 @@ func (UnimplementedStaticAnalysisServer) GetStaticDbFiles(context.Context, *GetS
 func (UnimplementedStaticAnalysisServer) GetAnalysisTaskStatus(context.Context, *GetAnalysisTaskStatusRequest) (*GetAnalysisTaskStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAnalysisTaskStatus not implemented")
 }
+func (UnimplementedStaticAnalysisServer) CancelAnalysisTask(context.Context, *CancelAnalysisTaskRequest) (*CancelAnalysisTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelAnalysisTask not implemented")
+}
 func (UnimplementedStaticAnalysisServer) AnalyzeProjectPath(context.Context, *AnalyzeProjectPathRequest) (*AnalyzeProjectPathResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeProjectPath not implemented")
 }
@@ -380,6 +398,24 @@ func _StaticAnalysis_GetAnalysisTaskStatus_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StaticAnalysis_CancelAnalysisTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelAnalysisTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaticAnalysisServer).CancelAnalysisTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaticAnalysis_CancelAnalysisTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaticAnalysisServer).CancelAnalysisTask(ctx, req.(*CancelAnalysisTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StaticAnalysis_AnalyzeProjectPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AnalyzeProjectPathRequest)
 	if err := dec(in); err != nil {
@@ -629,6 +665,10 @@ var StaticAnalysis_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAnalysisTaskStatus",
 			Handler:    _StaticAnalysis_GetAnalysisTaskStatus_Handler,
 		},
+		{
+			MethodName: "CancelAnalysisTask",
+			Handler:    _StaticAnalysis_CancelAnalysisTask_Handler,
+		},
 		{
 			MethodName: "AnalyzeProjectPath",
 			Handler:    _StaticAnalysis_AnalyzeProjectPath_Handler,
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/config"
 	"github.com/go-kratos/kratos/v2/config/file"
@@ -23,10 +25,19 @@ type CallGraphCommand struct {
 	cmdbase.BaseCommand
 	codeDir    string
 	outputPath string
-	cachePath  string
-	isCache    bool
+	cachePath  string // 增量分析使用的静态分析数据库, 为空时按代码目录名生成
+	isCache    bool   // 是否增量分析
 	onlyMethod string
 	algo       string
+	roots      []string      // 根函数集合
+	buildTags  []string      // 构建标签
+	platforms  []string      // 分析的平台, 多个时按矩阵分析
+	tagSets    []string      // 矩阵中的构建标签组合
+	buildEnv   []string      // 加载包时额外的环境变量
+	stdPkgs    []string      // 保留调用边的标准库包
+	tolerant   bool          // 跳过有错误的包继续分析
+	timeout    time.Duration // 分析超时时间, 0表示不限制
+	memLimitMB uint64        // 堆内存软上限(MB), 0表示不限制
 	flagconf   string
 }
 
@@ -46,11 +57,21 @@ func NewCallGraphCommand() *CallGraphCommand {
 func (c *CallGraphCommand) Init() {
 	c.CobraCmd.Flags().StringVarP(&c.codeDir, "dir", "d", "", "code directory")
 	c.CobraCmd.Flags().StringVarP(&c.outputPath, "output", "o", callgraph.DefaultOutput, "Image output path,default: ./default.png")
-	c.CobraCmd.Flags().StringVarP(&c.cachePath, "cache", "c", callgraph.DefaultCache, "FuncNode cache output path,default: ./cache.json")
+	c.CobraCmd.Flags().StringVarP(&c.cachePath, "cache", "c", callgraph.DefaultCache, "Static analysis db to update, default: <file storage path>/<code dir name>")
 	c.CobraCmd.Flags().StringVarP(&c.onlyMethod, "method", "m", "", "Only output relevant package names and method names")
 	c.CobraCmd.Flags().StringVarP(&c.algo, "algo", "a", callgraph.CallGraphTypeRta, fmt.Sprintf("The algorithm used to construct the call graph. Possible values inlcude: %q, %q, %q, %q, default: %q",
 		callgraph.CallGraphTypeVta, callgraph.CallGraphTypeStatic, callgraph.CallGraphTypeCha, callgraph.CallGraphTypeRta, callgraph.CallGraphTypeVta))
-	c.CobraCmd.Flags().BoolVarP(&c.isCache, "isCache", "i", true, "Whether to enable caching, default true")
+	c.CobraCmd.Flags().StringSliceVarP(&c.roots, "roots", "r", nil, fmt.Sprintf("Root functions of the call graph, comma separated. Possible values include: %q, %q, %q, %q or function keys, default: %q",
+		callgraph.RootsMain, callgraph.RootsExported, callgraph.RootsInit, callgraph.RootsTests, callgraph.RootsMain))
+	c.CobraCmd.Flags().StringSliceVarP(&c.buildTags, "tags", "t", nil, "Build tags used by every build config, comma separated, eg: integration,e2e")
+	c.CobraCmd.Flags().StringSliceVar(&c.platforms, "platforms", nil, "GOOS/GOARCH to analyze, comma separated, eg: linux/amd64,windows/amd64. Multiple platforms are merged into one db, default: host platform")
+	c.CobraCmd.Flags().StringArrayVar(&c.tagSets, "tag-set", nil, "Extra build tags of one matrix entry, comma separated, repeatable, eg: --tag-set \"\" --tag-set integration")
+	c.CobraCmd.Flags().StringArrayVar(&c.buildEnv, "env", nil, "Extra environment variable when loading packages, repeatable, eg: --env CGO_ENABLED=0")
+	c.CobraCmd.Flags().DurationVar(&c.timeout, "timeout", 0, "Abort the analysis after the given duration, eg: --timeout 10m, default no limit")
+	c.CobraCmd.Flags().Uint64Var(&c.memLimitMB, "memory-limit", 0, "Soft heap limit in MB, the analysis is aborted when exceeded, default no limit")
+	c.CobraCmd.Flags().BoolVar(&c.tolerant, "tolerant", false, "Skip packages with load or type errors and analyze the rest, errors are logged as diagnostics")
+	c.CobraCmd.Flags().StringSliceVar(&c.stdPkgs, "std", nil, "Standard library packages whose calls are kept in the call graph, including sub packages, comma separated, eg: --std net/http,database/sql")
+	c.CobraCmd.Flags().BoolVarP(&c.isCache, "isCache", "i", true, "Only re-analyze packages changed since the last run of the same db, default true")
 	c.CobraCmd.Flags().StringVar(&c.flagconf, "conf", "./configs", "config path, eg: -conf config.yaml")
 }
 
@@ -91,13 +112,28 @@ func (c *CallGraphCommand) Run(cmd *cobra.Command, args []string) {
 	}
 
 	dbPath := filepath.Join(entity.GetFileStoragePath(bc.Biz.FileStoragePath, false), fileName)
+	if c.cachePath != "" {
+		dbPath = c.cachePath
+	}
 	funcNodeDB, err := db.GetFuncNodeDB(dbPath)
 	if err != nil {
 		panic(err)
 	}
 
 	cg := callgraph.NewProgramAnalysis(c.codeDir, log.NewHelper(log.With(logger, "module", "callgraph")), funcNodeDB, callgraph.WithOutputDir(c.outputPath),
-		callgraph.WithCacheDir(c.cachePath), callgraph.WithOnlyPkg(c.onlyMethod), callgraph.WithAlgo(c.algo), callgraph.WithCacheFlag(c.isCache))
+		callgraph.WithCacheDir(c.cachePath), callgraph.WithOnlyPkg(c.onlyMethod), callgraph.WithAlgo(c.algo), callgraph.WithCacheFlag(c.isCache),
+		callgraph.WithRoots(c.roots...), callgraph.WithBuildTags(c.buildTags...), callgraph.WithPlatforms(c.platforms...),
+		callgraph.WithTagSets(c.tagSets...), callgraph.WithBuildEnv(c.buildEnv...), callgraph.WithStdPackages(c.stdPkgs...),
+		callgraph.WithTolerant(c.tolerant), callgraph.WithMemoryLimit(c.memLimitMB<<20))
+
+	// Ctrl+C 或超时时终止分析
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
 
 	// 创建一个命令行状态通道，用于接收状态更新
 	statusChan := make(chan []byte, 100)
@@ -123,19 +159,13 @@ func (c *CallGraphCommand) Run(cmd *cobra.Command, args []string) {
 	// 启动调用图生成
 	go func() {
 		defer wg.Done()
-		if err := cg.SetTree(statusChan); err != nil {
+		// 生成调用图并保存, 增量分析时只更新变化的包
+		if err := cg.Execute(ctx, statusChan); err != nil {
 			errMsg := fmt.Sprintf("调用图生成失败: %v", err)
 			fmt.Println(errMsg)
 			return
 		}
 
-		// 保存数据
-		if err := cg.SaveData(context.Background(), statusChan); err != nil {
-			errMsg := fmt.Sprintf("保存数据失败: %v", err)
-			fmt.Println(errMsg)
-			return
-		}
-
 		// 标记为完成
 		mu.Lock()
 		completed = true
@@ -1,17 +1,40 @@
 package commands
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"os/signal"
 
+	"github.com/go-kratos/kratos/v2/log"
 	"github.com/spf13/cobra"
 	"github.com/toheart/goanalysis/cmd/cmdbase"
 	"github.com/toheart/goanalysis/internal/biz/rewrite"
+	"github.com/toheart/goanalysis/internal/data"
+	"github.com/toheart/goanalysis/internal/data/sqlite"
 )
 
 // RewriteCommand 代码重写命令
 type RewriteCommand struct {
 	cmdbase.BaseCommand
-	dir string
+	dir      string
+	undo     bool
+	manifest string
+	overlay  string
+	filter   rewrite.FilterConfig
+	tracer   rewrite.Tracer
+	dryRun   bool
+	jobs     int
+	keepLine bool
+	tests    bool
+
+	diffFrom  string // 基准git版本, 非空时只对变更函数插桩
+	diffTo    string
+	root      string // 根函数, 只对静态调用图中从它可达的函数插桩
+	staticDB  string // 静态分析数据库, 用于沿调用图扩展插桩范围
+	depth     int
+	direction string
 }
 
 // NewRewriteCommand 创建代码重写命令
@@ -28,6 +51,38 @@ func NewRewriteCommand() *RewriteCommand {
 // Init 初始化代码重写命令
 func (r *RewriteCommand) Init() {
 	r.CobraCmd.Flags().StringVarP(&r.dir, "dir", "d", "", "specify the directory")
+	r.CobraCmd.Flags().BoolVar(&r.undo, "undo", false, "remove functrace instrumentation from the directory")
+	r.CobraCmd.Flags().StringVar(&r.manifest, "manifest", "", "write the list of files touched by --undo to this json file")
+	r.CobraCmd.Flags().StringVar(&r.overlay, "overlay", "", "write instrumented copies into this directory and generate an overlay file for go build -overlay, the source files are left untouched")
+	r.CobraCmd.Flags().IntVarP(&r.jobs, "jobs", "j", 0, "number of files rewritten concurrently, default GOMAXPROCS")
+	r.CobraCmd.Flags().BoolVar(&r.keepLine, "line-directives", false, "emit //line directives after the inserted code so panics, logs and runtime.Caller keep reporting the original line numbers")
+	r.CobraCmd.Flags().BoolVar(&r.tests, "tests", false, "also instrument _test.go files and generate (or patch) a TestMain per package that flushes traces after m.Run")
+	r.CobraCmd.Flags().BoolVar(&r.dryRun, "dry-run", false, "print a unified diff and instrumentation counts without touching any file")
+	r.CobraCmd.Flags().StringVar(&r.tracer.ImportPath, "tracer-import", "", "import path of the tracing package, default github.com/toheart/functrace")
+	r.CobraCmd.Flags().StringVar(&r.tracer.Alias, "tracer-alias", "", "package name or import alias of the tracing package, default is the last element of --tracer-import")
+	r.CobraCmd.Flags().StringVar(&r.tracer.Func, "tracer-func", "", "exported function of the tracing package to defer, eg: Trace")
+	r.CobraCmd.Flags().StringVar(&r.tracer.Shape, "tracer-shape", "", "call shape: defer-return generates defer pkg.Func(params)(), defer generates defer pkg.Func(params), otel-span starts an OpenTelemetry span and ends it in a defer")
+	r.CobraCmd.Flags().StringVar(&r.tracer.Params, "tracer-params", "", "how params are passed: slice ([]interface{}{a, b}), variadic (a, b) or none")
+	r.CobraCmd.Flags().StringVar(&r.tracer.Fallback, "tracer-fallback", "", "otel-span only: expression giving the parent context for functions without a context.Context param, default context.Background(), skip leaves them untraced")
+	r.CobraCmd.Flags().BoolVar(&r.tracer.Capture, "capture-errors", false, "also record the returned error and recovered panics (re-panicking afterwards), naming result params where needed; requires --tracer-result")
+	r.CobraCmd.Flags().StringVar(&r.tracer.Result, "tracer-result", "", "exported function of the tracing package receiving (err error, recovered interface{}), required by --capture-errors")
+	r.CobraCmd.Flags().StringVar(&r.tracer.Init, "tracer-init", "", "--tests only: exported function of the tracing package called before m.Run")
+	r.CobraCmd.Flags().StringVar(&r.tracer.Flush, "tracer-flush", "", "--tests only: exported function of the tracing package called after m.Run to flush traces, required with the functrace template")
+	r.CobraCmd.Flags().StringVar(&r.tracer.FallbackImport, "tracer-fallback-import", "", "otel-span only: import path required by --tracer-fallback")
+	r.CobraCmd.Flags().StringVar(&r.diffFrom, "diff-from", "", "only instrument functions changed since this git ref, eg: main or HEAD~3")
+	r.CobraCmd.Flags().StringVar(&r.diffTo, "diff-to", "", "git ref to compare --diff-from with, default is the working tree")
+	r.CobraCmd.Flags().StringVar(&r.root, "root", "", "only instrument functions reachable from this function in the static call graph, accepts a node key, full name or name, requires --static-db")
+	r.CobraCmd.Flags().StringVar(&r.staticDB, "static-db", "", "static call graph db generated by the callgraph command, used by --root and --depth")
+	r.CobraCmd.Flags().IntVar(&r.depth, "depth", 0, "levels of callers/callees to follow from --root (0 means unlimited) or from the changed functions of --diff-from")
+	r.CobraCmd.Flags().StringVar(&r.direction, "direction", "", "direction to follow in the call graph: callers (upstream), callees (downstream) or both, default callees for --root and both for --diff-from")
+	r.CobraCmd.Flags().StringSliceVar(&r.filter.IncludePkgs, "include-pkg", nil, "only instrument packages matching these import path globs, eg: github.com/foo/bar/internal/...")
+	r.CobraCmd.Flags().StringSliceVar(&r.filter.ExcludePkgs, "exclude-pkg", nil, "skip packages matching these import path globs")
+	r.CobraCmd.Flags().StringSliceVar(&r.filter.IncludeFiles, "include-file", nil, "only instrument files matching these globs, relative to the module root")
+	r.CobraCmd.Flags().StringSliceVar(&r.filter.ExcludeFiles, "exclude-file", nil, "skip files matching these globs, eg: *.pb.go")
+	r.CobraCmd.Flags().StringSliceVar(&r.filter.IncludeFuncs, "include-func", nil, "only instrument functions whose name or Recv.Name matches these regexps")
+	r.CobraCmd.Flags().StringSliceVar(&r.filter.ExcludeFuncs, "exclude-func", nil, "skip functions whose name or Recv.Name matches these regexps")
+	r.CobraCmd.Flags().StringSliceVar(&r.filter.IncludeRecvs, "include-recv", nil, "only instrument methods of these receiver types")
+	r.CobraCmd.Flags().StringSliceVar(&r.filter.ExcludeRecvs, "exclude-recv", nil, "skip methods of these receiver types")
 }
 
 // Run 执行代码重写命令
@@ -36,5 +91,218 @@ func (r *RewriteCommand) Run(cmd *cobra.Command, args []string) {
 		fmt.Println("请指定目录")
 		return
 	}
-	rewrite.RewriteDir(r.dir)
+	tracer, err := rewrite.NewTracer(&r.tracer)
+	if err != nil {
+		fmt.Printf("插桩模板无效: %v\n", err)
+		os.Exit(1)
+	}
+	if r.undo {
+		r.runUndo(rewrite.WithTracer(tracer))
+		return
+	}
+	filter, err := rewrite.NewFilter(&r.filter)
+	if err != nil {
+		fmt.Printf("过滤规则无效: %v\n", err)
+		os.Exit(1)
+	}
+	defer printSkipped(filter)
+	opts := []rewrite.RewriteOption{rewrite.WithFilter(filter), rewrite.WithTracer(tracer), rewrite.WithConcurrency(r.jobs)}
+	if r.keepLine {
+		opts = append(opts, rewrite.WithLineDirectives())
+	}
+	if r.tests {
+		opts = append(opts, rewrite.WithTests())
+	}
+	switch {
+	case r.root != "" && r.diffFrom != "":
+		fmt.Println("--root 与 --diff-from 不能同时使用")
+		os.Exit(1)
+	case r.root != "":
+		selection, err := r.reachableSelection()
+		if err != nil {
+			fmt.Printf("计算可达函数失败: %v\n", err)
+			os.Exit(1)
+		}
+		opts = append(opts, rewrite.WithSelection(selection))
+	case r.diffFrom != "":
+		selection, err := r.diffSelection()
+		if err != nil {
+			fmt.Printf("计算变更函数失败: %v\n", err)
+			os.Exit(1)
+		}
+		opts = append(opts, rewrite.WithSelection(selection))
+	}
+	if r.dryRun {
+		r.runDryRun(opts...)
+		return
+	}
+	if r.overlay != "" {
+		r.runOverlay(opts...)
+		return
+	}
+	printReport(r.rewriteDir(opts...))
+}
+
+// rewriteDir 并发重写目录, 收到中断信号时不再处理新的文件
+func (r *RewriteCommand) rewriteDir(opts ...rewrite.RewriteOption) *rewrite.Report {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	report, err := rewrite.RewriteDirContext(ctx, r.dir, opts...)
+	if ctx.Err() != nil {
+		fmt.Printf("重写已取消: %v\n", err)
+	}
+	return report
+}
+
+// printReport 输出改写的文件、错误和汇总
+func printReport(report *rewrite.Report) {
+	for _, path := range report.Rewritten {
+		fmt.Printf("rewritten %s\n", path)
+	}
+	for _, e := range report.Errors {
+		fmt.Printf("error %s\n", e)
+	}
+	for _, path := range report.Generated {
+		fmt.Printf("generated %s\n", path)
+	}
+	fmt.Printf("重写完成: %s\n", report)
+}
+
+// diffSelection 根据git差异计算插桩范围, 指定静态分析数据库时沿调用图扩展
+func (r *RewriteCommand) diffSelection() (*rewrite.Selection, error) {
+	direction, err := rewrite.ParseDirection(r.direction)
+	if err != nil {
+		return nil, err
+	}
+	selection, err := rewrite.DiffSelection(r.dir, r.diffFrom, r.diffTo)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("共 %d 个函数有变更\n", selection.Len())
+	if r.depth > 0 {
+		funcNodeDB, err := r.openStaticDB()
+		if err != nil {
+			return nil, err
+		}
+		defer funcNodeDB.Close()
+		added, err := selection.Expand(funcNodeDB, r.depth, direction)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("沿调用图扩展 %d 层(%s), 新增 %d 个函数\n", r.depth, direction, added)
+	}
+	printSelection(selection)
+	return selection, nil
+}
+
+// reachableSelection 从根函数出发沿静态调用图计算插桩范围
+func (r *RewriteCommand) reachableSelection() (*rewrite.Selection, error) {
+	direction := rewrite.DirectionCallees
+	if r.direction != "" {
+		var err error
+		if direction, err = rewrite.ParseDirection(r.direction); err != nil {
+			return nil, err
+		}
+	}
+	funcNodeDB, err := r.openStaticDB()
+	if err != nil {
+		return nil, err
+	}
+	defer funcNodeDB.Close()
+	selection, err := rewrite.ReachableSelection(funcNodeDB, r.root, r.depth, direction)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("从 %s 出发(%s)共 %d 个可达函数\n", r.root, direction, selection.Len())
+	printSelection(selection)
+	return selection, nil
+}
+
+// openStaticDB 打开已有的静态分析数据库
+func (r *RewriteCommand) openStaticDB() (*sqlite.StaticEntDBImpl, error) {
+	if r.staticDB == "" {
+		return nil, fmt.Errorf("--static-db is required")
+	}
+	if _, err := os.Stat(r.staticDB); err != nil {
+		return nil, fmt.Errorf("static db %s: %w", r.staticDB, err)
+	}
+	return data.NewData(log.DefaultLogger).GetFuncNodeDB(r.staticDB)
+}
+
+// printSelection 输出插桩范围内的函数
+func printSelection(selection *rewrite.Selection) {
+	for _, id := range selection.Funcs() {
+		fmt.Printf("select func %s\n", id)
+	}
+}
+
+// printSkipped 输出被过滤规则跳过的文件和函数
+func printSkipped(filter *rewrite.Filter) {
+	skipped := filter.Skipped()
+	if len(skipped) == 0 {
+		return
+	}
+	for _, record := range skipped {
+		if record.Func == "" {
+			fmt.Printf("skip file %s: %s\n", record.File, record.Detail)
+			continue
+		}
+		fmt.Printf("skip func %s in %s: %s\n", record.Func, record.File, record.Detail)
+	}
+	summary := filter.Summary()
+	fmt.Printf("共跳过 %d 项, package: %d, file: %d, func: %d, receiver: %d, directive: %d\n", len(skipped),
+		summary[rewrite.SkipReasonPackage], summary[rewrite.SkipReasonFile],
+		summary[rewrite.SkipReasonFunc], summary[rewrite.SkipReasonReceiver], summary[rewrite.SkipReasonDirective])
+}
+
+// runDryRun 预览插桩结果, 输出unified diff和插桩统计
+func (r *RewriteCommand) runDryRun(opts ...rewrite.RewriteOption) {
+	dryRun := rewrite.NewDryRun()
+	report := r.rewriteDir(append(opts, rewrite.WithDryRun(dryRun))...)
+	fmt.Print(dryRun.Diff())
+	stats := dryRun.Stats()
+	fmt.Printf("预览完成, 共 %d 个文件, 函数: %d, 闭包: %d, goroutine: %d\n",
+		len(dryRun.Files()), stats.Funcs, stats.Closures, stats.Goroutines)
+	printReport(report)
+}
+
+// runOverlay 以overlay模式插桩, 不修改源文件
+func (r *RewriteCommand) runOverlay(opts ...rewrite.RewriteOption) {
+	overlay := rewrite.NewOverlay(r.overlay)
+	report := r.rewriteDir(append(opts, rewrite.WithOverlay(overlay))...)
+	overlayPath, err := overlay.Save()
+	if err != nil {
+		fmt.Printf("生成overlay文件失败: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("overlay生成完成, 共 %d 个插桩文件: %s\n", overlay.Len(), overlayPath)
+	fmt.Printf("构建插桩版本: go build -overlay=%s ./...\n", overlayPath)
+	printReport(report)
+}
+
+// runUndo 撤销插桩并输出被修改文件清单, 有文件失败时仍会写入清单再以非零状态退出
+func (r *RewriteCommand) runUndo(opts ...rewrite.RewriteOption) {
+	manifest, undoErr := rewrite.UndoDir(r.dir, opts...)
+	for _, entry := range manifest.Files {
+		fmt.Printf("path: %s undo success, removed %d defer(s), import removed: %t\n",
+			entry.Path, entry.DefersRemoved, entry.ImportRemoved)
+	}
+	for _, e := range manifest.Errors {
+		fmt.Printf("undo failed: %s\n", e)
+	}
+	fmt.Printf("撤销插桩完成, 共修改 %d 个文件, 失败 %d 个\n", len(manifest.Files), len(manifest.Errors))
+	if r.manifest != "" {
+		data, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			fmt.Printf("生成清单失败: %v\n", err)
+			os.Exit(1)
+		}
+		if err = os.WriteFile(r.manifest, data, 0o644); err != nil {
+			fmt.Printf("写入清单失败: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if undoErr != nil {
+		os.Exit(1)
+	}
 }
@@ -33,7 +33,7 @@ func wireApp(confServer *conf.Server, biz *conf.Biz, confData *conf.Data, logger
 	}
 	fileBiz := filemanager.NewFileBiz(biz, logger, fileRepo)
 	staticAnalysisService := service.NewStaticAnalysisService(staticAnalysisBiz, logger)
-	analysisBiz := analysis.NewAnalysisBiz(biz, dataData, logger)
+	analysisBiz := analysis.NewAnalysisBiz(biz, dataData, channelManager, logger)
 	analysisService := service.NewAnalysisService(analysisBiz, logger)
 	fileManagerService := service.NewFileManagerService(fileBiz, logger)
 	v := service.NewHttpServiceList(staticAnalysisService, analysisService, fileManagerService)
@@ -14,6 +14,8 @@ import (
 	"github.com/pkg/errors"
 	v1 "github.com/toheart/goanalysis/api/analysis/v1"
 	"github.com/toheart/goanalysis/internal/biz/analysis/dos"
+	"github.com/toheart/goanalysis/internal/biz/chanMgr"
+	"github.com/toheart/goanalysis/internal/biz/rewrite"
 	"github.com/toheart/goanalysis/internal/conf"
 	"github.com/toheart/goanalysis/internal/data"
 )
@@ -22,6 +24,9 @@ type AnalysisBiz struct {
 	conf *conf.Biz
 	data *data.Data
 	log  *log.Helper
+
+	globalChan *chanMgr.ChannelManager
+	instrument instrumentTasks
 }
 
 func (a *AnalysisBiz) GetTotalGIDs(dbpath string) (int, error) {
@@ -32,8 +37,35 @@ func (a *AnalysisBiz) GetTotalGIDs(dbpath string) (int, error) {
 	return traceDB.GetTotalGIDs()
 }
 
-func NewAnalysisBiz(conf *conf.Biz, data *data.Data, logger log.Logger) *AnalysisBiz {
-	return &AnalysisBiz{conf: conf, data: data, log: log.NewHelper(logger)}
+func NewAnalysisBiz(conf *conf.Biz, data *data.Data, mgr *chanMgr.ChannelManager, logger log.Logger) *AnalysisBiz {
+	return &AnalysisBiz{
+		conf:       conf,
+		data:       data,
+		log:        log.NewHelper(logger),
+		globalChan: mgr,
+		instrument: instrumentTasks{tasks: make(map[string]*InstrumentTask)},
+	}
+}
+
+// Tracer 返回配置的插桩模板, 未配置时使用functrace
+func (a *AnalysisBiz) Tracer() (*rewrite.Tracer, error) {
+	t := a.conf.GetTracer()
+	if t == nil {
+		return rewrite.DefaultTracer(), nil
+	}
+	return rewrite.NewTracer(&rewrite.Tracer{
+		ImportPath:     t.ImportPath,
+		Alias:          t.Alias,
+		Func:           t.Func,
+		Shape:          t.Shape,
+		Params:         t.Params,
+		Fallback:       t.Fallback,
+		FallbackImport: t.FallbackImport,
+		Capture:        t.Capture,
+		Result:         t.Result,
+		Init:           t.Init,
+		Flush:          t.Flush,
+	})
 }
 
 func (a *AnalysisBiz) GetTracesByGID(req *v1.AnalysisByGIDRequest) ([]dos.TraceData, error) {
@@ -13,6 +13,8 @@ type TraceData struct {
 	ParentId   uint64 `json:"parentId"`   // 父函数ID
 	CreatedAt  string `json:"createdAt"`  // 创建时间
 	Seq        string `json:"seq"`        // 序列号
+	Error      string `json:"error"`      // 返回的错误信息, 开启返回值捕获时记录
+	Panic      string `json:"panic"`      // recover到的panic信息
 }
 
 // FunctionNode 函数调用节点
@@ -0,0 +1,138 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	v1 "github.com/toheart/goanalysis/api/analysis/v1"
+	"github.com/toheart/goanalysis/internal/biz/entity"
+)
+
+const (
+	_instrumentTaskTTL      = time.Hour // 结束的插桩任务保留时长, 超过后查询不到
+	_maxFinishedInstruments = 100       // 最多保留的已结束插桩任务数, 超过时先删除最早结束的
+)
+
+// InstrumentTask 异步插桩任务
+type InstrumentTask struct {
+	ID         string
+	Path       string
+	Status     int                        // entity.TaskStatus*
+	Result     *v1.InstrumentProjectReply // 任务完成后的插桩结果
+	FinishedAt time.Time                  // 任务结束时间, 未结束时为零值
+}
+
+// instrumentTasks 异步插桩任务表, 已结束的任务按保留时长和数量淘汰, 执行中的任务不会被淘汰
+type instrumentTasks struct {
+	mu    sync.RWMutex
+	tasks map[string]*InstrumentTask
+}
+
+// evict 删除超过保留时长的已结束任务, 已结束任务超过上限时删除最早结束的, 调用方需持有写锁
+func (t *instrumentTasks) evict(now time.Time) {
+	var finished []*InstrumentTask
+	for id, task := range t.tasks {
+		if task.FinishedAt.IsZero() {
+			continue
+		}
+		if now.Sub(task.FinishedAt) > _instrumentTaskTTL {
+			delete(t.tasks, id)
+			continue
+		}
+		finished = append(finished, task)
+	}
+	if len(finished) <= _maxFinishedInstruments {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].FinishedAt.Before(finished[j].FinishedAt)
+	})
+	for _, task := range finished[:len(finished)-_maxFinishedInstruments] {
+		delete(t.tasks, task.ID)
+	}
+}
+
+// StartInstrumentTask
+//
+//	@Description: 异步执行插桩, 进度消息写入以任务ID注册的状态通道, 可通过SSE端点订阅
+//	@param path 项目路径
+//	@param run 执行插桩并返回结果, progress为进度通道
+//	@return string 任务ID
+func (a *AnalysisBiz) StartInstrumentTask(path string, run func(progress chan []byte) *v1.InstrumentProjectReply) string {
+	task := &InstrumentTask{
+		ID:     uuid.New().String(),
+		Path:   path,
+		Status: entity.TaskStatusStarting,
+	}
+	a.instrument.mu.Lock()
+	a.instrument.evict(time.Now())
+	a.instrument.tasks[task.ID] = task
+	a.instrument.mu.Unlock()
+
+	// 在返回任务ID前注册通道, 避免客户端订阅时通道尚未创建
+	statusChan := make(chan []byte, 100)
+	a.globalChan.Set(task.ID, statusChan)
+	go func() {
+		defer a.globalChan.Close(task.ID)
+		defer func() {
+			if r := recover(); r != nil {
+				a.log.Errorf("instrument task %s panic: %v", task.ID, r)
+				a.finishInstrumentTask(task.ID, entity.TaskStatusFailed, &v1.InstrumentProjectReply{
+					Success: false,
+					Message: fmt.Sprintf("插桩异常: %v", r),
+					TaskId:  task.ID,
+				})
+			}
+		}()
+		a.setInstrumentStatus(task.ID, entity.TaskStatusProcessing)
+		a.log.Infof("start instrument task %s, project path: %s", task.ID, path)
+		reply := run(statusChan)
+		reply.TaskId = task.ID
+		status := entity.TaskStatusCompleted
+		if !reply.Success {
+			status = entity.TaskStatusFailed
+		}
+		select {
+		case statusChan <- []byte(reply.Message):
+		default:
+		}
+		a.finishInstrumentTask(task.ID, status, reply)
+	}()
+	return task.ID
+}
+
+// GetInstrumentTask 获取插桩任务
+func (a *AnalysisBiz) GetInstrumentTask(id string) (*InstrumentTask, bool) {
+	a.instrument.mu.RLock()
+	defer a.instrument.mu.RUnlock()
+	task, ok := a.instrument.tasks[id]
+	if !ok {
+		return nil, false
+	}
+	copied := *task
+	return &copied, true
+}
+
+// setInstrumentStatus 更新插桩任务状态
+func (a *AnalysisBiz) setInstrumentStatus(id string, status int) {
+	a.instrument.mu.Lock()
+	defer a.instrument.mu.Unlock()
+	if task, ok := a.instrument.tasks[id]; ok {
+		task.Status = status
+	}
+}
+
+// finishInstrumentTask 记录插桩任务的最终状态和结果
+func (a *AnalysisBiz) finishInstrumentTask(id string, status int, result *v1.InstrumentProjectReply) {
+	a.instrument.mu.Lock()
+	defer a.instrument.mu.Unlock()
+	if task, ok := a.instrument.tasks[id]; ok {
+		task.Status = status
+		task.Result = result
+		task.FinishedAt = time.Now()
+	}
+	a.instrument.evict(time.Now())
+}
@@ -0,0 +1,42 @@
+package analysis
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestInstrumentTasks_Evict(t *testing.T) {
+	now := time.Now()
+	tasks := &instrumentTasks{tasks: map[string]*InstrumentTask{
+		"running": {ID: "running"},
+		"expired": {ID: "expired", FinishedAt: now.Add(-_instrumentTaskTTL - time.Second)},
+		"recent":  {ID: "recent", FinishedAt: now.Add(-time.Minute)},
+	}}
+	tasks.evict(now)
+	if _, ok := tasks.tasks["expired"]; ok {
+		t.Error("Expired task should be evicted")
+	}
+	if _, ok := tasks.tasks["running"]; !ok {
+		t.Error("Running task should be kept")
+	}
+	if _, ok := tasks.tasks["recent"]; !ok {
+		t.Error("Recently finished task should be kept")
+	}
+
+	// 超过上限时删除最早结束的任务, 执行中的任务不计入上限
+	for i := 0; i < _maxFinishedInstruments; i++ {
+		id := fmt.Sprintf("task%d", i)
+		tasks.tasks[id] = &InstrumentTask{ID: id, FinishedAt: now.Add(time.Duration(i) * time.Millisecond)}
+	}
+	tasks.evict(now)
+	if len(tasks.tasks) != _maxFinishedInstruments+1 {
+		t.Errorf("Expected %d tasks, got %d", _maxFinishedInstruments+1, len(tasks.tasks))
+	}
+	if _, ok := tasks.tasks["recent"]; ok {
+		t.Error("Oldest finished task should be evicted when over the cap")
+	}
+	if _, ok := tasks.tasks["running"]; !ok {
+		t.Error("Running task should never be evicted")
+	}
+}
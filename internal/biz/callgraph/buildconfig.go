@@ -0,0 +1,168 @@
+package callgraph
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+/**
+构建配置: 文件是否参与分析取决于构建标签(//go:build linux、integration)和GOOS/GOARCH.
+默认使用宿主环境; 指定多个平台或多组构建标签时进入矩阵模式, 依次分析每个配置,
+按稳定Key和声明位置合并为一个调用图, 每个节点和边记录存在于哪些配置中:
+
+	linux/amd64               只有平台
+	linux/amd64:integration   平台和构建标签
+	host:integration          宿主平台和构建标签
+**/
+
+// _hostPlatform 宿主平台, 不设置GOOS/GOARCH
+const _hostPlatform = "host"
+
+// BuildConfig 一个构建配置
+type BuildConfig struct {
+	GOOS   string   // 目标系统, 为空时使用宿主环境
+	GOARCH string   // 目标架构, 为空时使用宿主环境
+	Tags   []string // 构建标签
+	Env    []string // 额外的环境变量, KEY=VALUE
+}
+
+// Name 配置名称, 用于标注节点和边
+func (c BuildConfig) Name() string {
+	name := _hostPlatform
+	if c.GOOS != "" || c.GOARCH != "" {
+		name = c.GOOS + "/" + c.GOARCH
+	}
+	if len(c.Tags) > 0 {
+		name += ":" + strings.Join(c.Tags, ",")
+	}
+	return name
+}
+
+// environ 加载包使用的环境变量, 后面的值覆盖前面的值
+func (c BuildConfig) environ() []string {
+	env := append(os.Environ(), c.Env...)
+	if c.GOOS != "" {
+		env = append(env, "GOOS="+c.GOOS)
+	}
+	if c.GOARCH != "" {
+		env = append(env, "GOARCH="+c.GOARCH)
+	}
+	return env
+}
+
+// buildFlags 加载包使用的构建参数
+func (c BuildConfig) buildFlags() []string {
+	if len(c.Tags) == 0 {
+		return nil
+	}
+	return []string{"-tags=" + strings.Join(c.Tags, ",")}
+}
+
+// WithBuildTags 设置所有配置共用的构建标签
+func WithBuildTags(tags ...string) ProgramOption {
+	cleaned := splitList(tags)
+	return func(p *ProgramAnalysis) {
+		p.buildTags = cleaned
+	}
+}
+
+// WithBuildEnv 设置加载包时额外的环境变量, 每一项为KEY=VALUE
+func WithBuildEnv(env ...string) ProgramOption {
+	return func(p *ProgramAnalysis) {
+		p.buildEnv = append([]string(nil), env...)
+	}
+}
+
+// WithPlatforms 设置分析的平台, 每一项为GOOS/GOARCH, 多个平台时进入矩阵模式
+func WithPlatforms(platforms ...string) ProgramOption {
+	cleaned := splitList(platforms)
+	return func(p *ProgramAnalysis) {
+		p.platforms = cleaned
+	}
+}
+
+// WithTagSets 设置矩阵中的构建标签组合, 每一项为逗号分隔的一组标签, 空字符串表示不加额外标签
+func WithTagSets(sets ...string) ProgramOption {
+	return func(p *ProgramAnalysis) {
+		p.tagSets = append([]string(nil), sets...)
+	}
+}
+
+// buildConfigs
+//
+//	@Description: 平台和构建标签组合的笛卡尔积, 没有设置时只有宿主环境一个配置
+//	@return []BuildConfig 按名称排序的配置
+//	@return error 平台格式错误或环境变量格式错误
+func (p *ProgramAnalysis) buildConfigs() ([]BuildConfig, error) {
+	for _, kv := range p.buildEnv {
+		if !strings.Contains(kv, "=") {
+			return nil, fmt.Errorf("invalid build env %q, expected KEY=VALUE", kv)
+		}
+	}
+	platforms := p.platforms
+	if len(platforms) == 0 {
+		platforms = []string{_hostPlatform}
+	}
+	tagSets := p.tagSets
+	if len(tagSets) == 0 {
+		tagSets = []string{""}
+	}
+
+	seen := make(map[string]bool)
+	var configs []BuildConfig
+	for _, platform := range platforms {
+		config := BuildConfig{Env: p.buildEnv}
+		if platform != _hostPlatform {
+			goos, goarch, ok := strings.Cut(platform, "/")
+			if !ok || goos == "" || goarch == "" {
+				return nil, fmt.Errorf("invalid platform %q, expected GOOS/GOARCH", platform)
+			}
+			config.GOOS, config.GOARCH = goos, goarch
+		}
+		for _, set := range tagSets {
+			config.Tags = mergeTags(p.buildTags, splitList([]string{set}))
+			if name := config.Name(); !seen[name] {
+				seen[name] = true
+				configs = append(configs, config)
+			}
+		}
+	}
+	sort.Slice(configs, func(i, j int) bool { return configs[i].Name() < configs[j].Name() })
+	return configs, nil
+}
+
+// initBuild 计算构建配置, 并将第一个配置设置为当前配置
+func (p *ProgramAnalysis) initBuild() ([]BuildConfig, error) {
+	configs, err := p.buildConfigs()
+	if err != nil {
+		return nil, err
+	}
+	p.build = configs[0]
+	return configs, nil
+}
+
+// mergeTags 合并去重构建标签并排序, 使配置名称稳定
+func mergeTags(base, extra []string) []string {
+	if len(base)+len(extra) == 0 {
+		return nil
+	}
+	tags := append(append([]string(nil), base...), extra...)
+	sort.Strings(tags)
+	return slices.Compact(tags)
+}
+
+// splitList 拆分逗号分隔的列表, 去掉空白和空项
+func splitList(items []string) []string {
+	var cleaned []string
+	for _, item := range items {
+		for _, part := range strings.Split(item, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				cleaned = append(cleaned, part)
+			}
+		}
+	}
+	return cleaned
+}
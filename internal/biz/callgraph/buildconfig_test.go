@@ -0,0 +1,120 @@
+package callgraph
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/toheart/goanalysis/internal/biz/callgraph/dos"
+)
+
+var platformFiles = map[string]string{
+	"go.mod":          "module example.com/demo\n\ngo 1.21\n",
+	"main.go":         "package main\n\nfunc main() {\n\topen()\n}\n",
+	"open_linux.go":   "package main\n\nfunc open() {\n\tlinuxOnly()\n}\n\nfunc linuxOnly() {}\n",
+	"open_windows.go": "package main\n\nfunc open() {\n\twindowsOnly()\n}\n\nfunc windowsOnly() {}\n",
+	"integration.go":  "//go:build integration\n\npackage main\n\nfunc init() {\n\textra()\n}\n\nfunc extra() {}\n",
+}
+
+func TestBuildConfigs(t *testing.T) {
+	p := NewProgramAnalysis(".", log.NewHelper(log.NewStdLogger(io.Discard)), nil,
+		WithBuildTags("b,a"), WithPlatforms("windows/amd64,linux/amd64"), WithTagSets("", "e2e,a"))
+	configs, err := p.buildConfigs()
+	if err != nil {
+		t.Fatalf("buildConfigs failed: %v", err)
+	}
+	var names []string
+	for _, config := range configs {
+		names = append(names, config.Name())
+	}
+	expected := "[linux/amd64:a,b linux/amd64:a,b,e2e windows/amd64:a,b windows/amd64:a,b,e2e]"
+	if fmt.Sprint(names) != expected {
+		t.Errorf("Expected configs %s, got %v", expected, names)
+	}
+
+	for _, opt := range []ProgramOption{WithPlatforms("linux"), WithBuildEnv("CGO_ENABLED")} {
+		p := NewProgramAnalysis(".", log.NewHelper(log.NewStdLogger(io.Discard)), nil, opt)
+		if _, err := p.buildConfigs(); err == nil {
+			t.Error("Expected error for invalid build config")
+		}
+	}
+}
+
+// analyzeModule 使用指定选项分析dir, 返回按函数名索引的节点和按 调用方->被调用方 索引的边
+func analyzeModule(t *testing.T, dir string, opts ...ProgramOption) (map[string]*dos.FuncNode, map[string]*dos.FuncEdge) {
+	t.Helper()
+	_, store, err := runAnalysis(t, context.Background(), dir, filepath.Join(t.TempDir(), "matrix.db"), opts...)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	nodes, err := store.GetAllFuncNodes()
+	if err != nil {
+		t.Fatalf("GetAllFuncNodes failed: %v", err)
+	}
+	edges, err := store.GetAllFuncEdges()
+	if err != nil {
+		t.Fatalf("GetAllFuncEdges failed: %v", err)
+	}
+	byKey := make(map[string]*dos.FuncNode)
+	byName := make(map[string]*dos.FuncNode)
+	for _, node := range nodes {
+		byKey[node.Key] = node
+		byName[node.Name+"@"+node.File] = node
+	}
+	byPair := make(map[string]*dos.FuncEdge)
+	for _, edge := range edges {
+		caller, callee := byKey[edge.CallerKey], byKey[edge.CalleeKey]
+		byPair[caller.Name+"->"+callee.Name+"@"+callee.File] = edge
+	}
+	return byName, byPair
+}
+
+func TestProgramAnalysis_BuildMatrix(t *testing.T) {
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOWORK", "off")
+	dir := t.TempDir()
+	writeModule(t, dir, platformFiles)
+
+	// 单个配置: 只分析windows和integration标签下的文件, 不标注配置
+	nodes, edges := analyzeModule(t, dir, WithPlatforms("windows/amd64"), WithBuildTags("integration"))
+	if edges["open->windowsOnly@open_windows.go"] == nil || edges["init#1->extra@integration.go"] == nil {
+		t.Errorf("Missing windows or integration edges: %v", edges)
+	}
+	if edges["open->linuxOnly@open_linux.go"] != nil {
+		t.Error("Linux only edge should not be analyzed for windows")
+	}
+	if node := nodes["main@main.go"]; node == nil || len(node.Configs) != 0 {
+		t.Errorf("Single build config should not annotate nodes: %+v", node)
+	}
+
+	// 矩阵: 两个平台 x 有无integration标签
+	nodes, edges = analyzeModule(t, dir, WithPlatforms("linux/amd64", "windows/amd64"), WithTagSets("", "integration"))
+	all := "[linux/amd64 linux/amd64:integration windows/amd64 windows/amd64:integration]"
+	expected := map[string]string{
+		"main->open@open_linux.go":          "[linux/amd64 linux/amd64:integration]",
+		"main->open@open_windows.go":        "[windows/amd64 windows/amd64:integration]",
+		"open->linuxOnly@open_linux.go":     "[linux/amd64 linux/amd64:integration]",
+		"open->windowsOnly@open_windows.go": "[windows/amd64 windows/amd64:integration]",
+		"init#1->extra@integration.go":      "[linux/amd64:integration windows/amd64:integration]",
+	}
+	for pair, configs := range expected {
+		edge := edges[pair]
+		if edge == nil {
+			t.Errorf("Missing edge %s in %v", pair, edges)
+			continue
+		}
+		if fmt.Sprint(edge.Configs) != configs {
+			t.Errorf("%s: expected configs %s, got %v", pair, configs, edge.Configs)
+		}
+	}
+	if node := nodes["main@main.go"]; node == nil || fmt.Sprint(node.Configs) != all {
+		t.Errorf("main should exist in all configs %s: %+v", all, node)
+	}
+	linux, windows := nodes["open@open_linux.go"], nodes["open@open_windows.go"]
+	if linux == nil || windows == nil || linux.Key == windows.Key {
+		t.Errorf("Platform specific functions should be separate nodes: %+v %+v", linux, windows)
+	}
+}
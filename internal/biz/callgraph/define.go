@@ -3,7 +3,7 @@ package callgraph
 // 调用图分析算法常量
 const (
 	DefaultOutput       = "./default.png"
-	DefaultCache        = "./cachePath.json"
+	DefaultCache        = "" // 增量分析的数据库路径, 为空时由调用方决定
 	CallGraphTypeStatic = "static"
 	CallGraphTypeCha    = "cha"
 	CallGraphTypeRta    = "rta" // 默认使用
@@ -19,10 +19,20 @@ const (
 // ProgramOption 定义程序分析的配置选项函数类型
 type ProgramOption func(p *ProgramAnalysis)
 
+// SourcePos 源码位置, 项目内的文件为相对项目目录的路径
+type SourcePos struct {
+	File    string
+	Line    int
+	EndLine int // 函数的结束行, 调用点为0
+}
+
 // FilterConfig 过滤配置
 type FilterConfig struct {
 	IgnorePaths []string
 	ModuleName  string
+	Modules     []string        // 项目内的所有模块, 为空时只使用ModuleName
+	StdPackages map[string]bool // 标准库包集合, 为空时按包路径推断
+	IncludeStd  []string        // 保留调用边的标准库包, 包含子包
 }
 
 // ProgressTracker 进度跟踪器
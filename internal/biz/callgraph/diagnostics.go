@@ -0,0 +1,147 @@
+package callgraph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/toheart/goanalysis/internal/biz/callgraph/dos"
+	"golang.org/x/tools/go/packages"
+)
+
+/**
+加载诊断: 包的加载错误、语法错误和类型错误都记录为诊断信息(包、文件:行、消息), 通过回调实时通知调用方.
+默认任一包有错误时终止分析; 容错模式下跳过有错误的包及依赖它们的包, 继续分析其余类型检查通过的包.
+**/
+
+// WithTolerant 设置容错模式, 跳过有错误的包继续分析
+func WithTolerant(flag bool) ProgramOption {
+	return func(p *ProgramAnalysis) {
+		p.tolerant = flag
+	}
+}
+
+// WithDiagnosticHandler 设置诊断信息的回调, 每条诊断只通知一次
+func WithDiagnosticHandler(handler func(dos.Diagnostic)) ProgramOption {
+	return func(p *ProgramAnalysis) {
+		p.onDiagnostic = handler
+	}
+}
+
+// Diagnostics 返回分析过程中记录的所有诊断信息
+func (p *ProgramAnalysis) Diagnostics() []dos.Diagnostic {
+	return p.diagnostics
+}
+
+// addDiagnostic 记录诊断信息, 矩阵模式下多个配置中相同的诊断只记录一次
+func (p *ProgramAnalysis) addDiagnostic(diag dos.Diagnostic) {
+	if p.seenDiagnostics == nil {
+		p.seenDiagnostics = make(map[dos.Diagnostic]bool)
+	}
+	if p.seenDiagnostics[diag] {
+		return
+	}
+	p.seenDiagnostics[diag] = true
+	p.diagnostics = append(p.diagnostics, diag)
+	p.log.Warnf("package diagnostic: %s", diag)
+	if p.onDiagnostic != nil {
+		p.onDiagnostic(diag)
+	}
+}
+
+// checkPackages
+//
+//	@Description: 记录所有包的错误, 返回可以分析的包
+//	@param pkgs 加载的包
+//	@return []*packages.Package 自身和依赖都没有错误的包, 非容错模式下为pkgs
+//	@return error 非容错模式下有错误, 或容错模式下没有可以分析的包
+func (p *ProgramAnalysis) checkPackages(pkgs []*packages.Package) ([]*packages.Package, error) {
+	// Visit后序遍历, 依赖先于导入方访问
+	broken := make(map[*packages.Package]bool)
+	var first *dos.Diagnostic
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			diag := p.newDiagnostic(pkg, err)
+			if first == nil {
+				first = &diag
+			}
+			p.addDiagnostic(diag)
+			broken[pkg] = true
+		}
+		for _, imp := range pkg.Imports {
+			if broken[imp] {
+				broken[pkg] = true
+			}
+		}
+	})
+	if first == nil {
+		return pkgs, nil
+	}
+	if !p.tolerant {
+		return nil, fmt.Errorf("packages contain errors: %s", first)
+	}
+
+	valid := make([]*packages.Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		if !broken[pkg] {
+			valid = append(valid, pkg)
+		}
+	}
+	if len(valid) == 0 {
+		return nil, fmt.Errorf("all packages contain errors: %s", first)
+	}
+	p.log.Warnf("tolerant mode: skip %d of %d packages with errors", len(pkgs)-len(valid), len(pkgs))
+	return valid, nil
+}
+
+// newDiagnostic 将包错误转换为诊断信息
+func (p *ProgramAnalysis) newDiagnostic(pkg *packages.Package, err packages.Error) dos.Diagnostic {
+	file, line, column := splitPos(err.Pos)
+	diag := dos.Diagnostic{
+		Package: pkg.PkgPath,
+		File:    p.relFile(file),
+		Line:    line,
+		Column:  column,
+		Message: err.Msg,
+	}
+	switch err.Kind {
+	case packages.ListError:
+		diag.Kind = dos.DiagnosticKindList
+	case packages.ParseError:
+		diag.Kind = dos.DiagnosticKindParse
+	case packages.TypeError:
+		diag.Kind = dos.DiagnosticKindType
+	default:
+		diag.Kind = dos.DiagnosticKindUnknown
+	}
+	return diag
+}
+
+// splitPos 拆分 file:line:column 或 file:line 格式的位置, 没有位置时为"-"或空
+func splitPos(pos string) (string, int, int) {
+	if pos == "" || pos == "-" {
+		return "", 0, 0
+	}
+	var nums []int
+	file := pos
+	// 最多从右侧取两段数字, Windows路径中的盘符不会被当作行号
+	for len(nums) < 2 {
+		i := strings.LastIndex(file, ":")
+		if i < 0 {
+			break
+		}
+		n, err := strconv.Atoi(file[i+1:])
+		if err != nil {
+			break
+		}
+		nums = append([]int{n}, nums...)
+		file = file[:i]
+	}
+	switch len(nums) {
+	case 2:
+		return file, nums[0], nums[1]
+	case 1:
+		return file, nums[0], 0
+	}
+	return file, 0, 0
+}
@@ -0,0 +1,71 @@
+package callgraph
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/toheart/goanalysis/internal/biz/callgraph/dos"
+)
+
+var brokenFiles = map[string]string{
+	"go.mod":       "module example.com/demo\n\ngo 1.21\n",
+	"main.go":      "package main\n\nimport \"example.com/demo/good\"\n\nfunc main() {\n\tgood.Do()\n}\n",
+	"good/good.go": "package good\n\nfunc Do() {\n\thelper()\n}\n\nfunc helper() {}\n",
+	"bad/bad.go":   "package bad\n\nfunc Bad() int {\n\treturn \"x\"\n}\n",
+	"user/user.go": "package user\n\nimport \"example.com/demo/bad\"\n\nfunc Use() {\n\tbad.Bad()\n}\n",
+}
+
+func TestProgramAnalysis_Diagnostics(t *testing.T) {
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOWORK", "off")
+	dir := t.TempDir()
+	writeModule(t, dir, brokenFiles)
+	expected := dos.Diagnostic{Package: "example.com/demo/bad", File: "bad/bad.go", Line: 4, Column: 9, Kind: dos.DiagnosticKindType}
+
+	// 默认模式: 终止分析, 错误中包含诊断位置
+	if _, _, err := runAnalysis(t, context.Background(), dir, filepath.Join(t.TempDir(), "strict.db")); err == nil || !strings.Contains(err.Error(), "bad/bad.go:4:9") {
+		t.Errorf("Expected error with diagnostic position, got %v", err)
+	}
+
+	// 容错模式: 跳过bad和导入bad的user, 通过回调收到诊断
+	var received []dos.Diagnostic
+	_, edges := analyzeModule(t, dir, WithTolerant(true), WithDiagnosticHandler(func(diag dos.Diagnostic) {
+		received = append(received, diag)
+	}))
+	if edges["main->Do@good/good.go"] == nil || edges["Do->helper@good/good.go"] == nil {
+		t.Errorf("Missing edges of valid packages: %v", edges)
+	}
+	if edges["Use->Bad@bad/bad.go"] != nil {
+		t.Error("Packages depending on broken packages should be skipped")
+	}
+	if len(received) != 1 {
+		t.Fatalf("Expected 1 diagnostic, got %v", received)
+	}
+	diag := received[0]
+	diag.Message = ""
+	if diag != expected {
+		t.Errorf("Expected diagnostic %+v, got %+v", expected, received[0])
+	}
+}
+
+func TestSplitPos(t *testing.T) {
+	tests := []struct {
+		pos          string
+		file         string
+		line, column int
+	}{
+		{pos: "/a/b.go:3:9", file: "/a/b.go", line: 3, column: 9},
+		{pos: "/a/b.go:3", file: "/a/b.go", line: 3},
+		{pos: `C:\a\b.go:3:9`, file: `C:\a\b.go`, line: 3, column: 9},
+		{pos: "-"},
+		{pos: ""},
+	}
+	for _, tt := range tests {
+		file, line, column := splitPos(tt.pos)
+		if file != tt.file || line != tt.line || column != tt.column {
+			t.Errorf("splitPos(%q) = %q, %d, %d", tt.pos, file, line, column)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package dos
+
+import "fmt"
+
+// 诊断类型, 与 packages.ErrorKind 对应
+const (
+	DiagnosticKindList    = "list"    // go list 错误, 如找不到导入的包
+	DiagnosticKindParse   = "parse"   // 语法错误
+	DiagnosticKindType    = "type"    // 类型检查错误
+	DiagnosticKindUnknown = "unknown" // 其他错误
+)
+
+// Diagnostic 加载包时的错误
+type Diagnostic struct {
+	Package string `json:"package"` // 包路径
+	File    string `json:"file"`    // 文件, 项目内为相对路径, 没有位置时为空
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+// String 格式为 file:line:column: message
+func (d Diagnostic) String() string {
+	switch {
+	case d.File == "":
+		return fmt.Sprintf("%s: %s", d.Package, d.Message)
+	case d.Line == 0:
+		return fmt.Sprintf("%s: %s", d.File, d.Message)
+	case d.Column == 0:
+		return fmt.Sprintf("%s:%d: %s", d.File, d.Line, d.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, d.Message)
+}
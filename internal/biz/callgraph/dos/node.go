@@ -1,16 +1,51 @@
 package dos
 
+// CallKind 调用类型, 由调用点的SSA指令推导
+type CallKind string
+
+const (
+	CallKindStatic    CallKind = "static"    // 静态调用具名函数或方法
+	CallKindInterface CallKind = "interface" // 接口方法动态派发
+	CallKindDynamic   CallKind = "dynamic"   // 通过函数值动态调用
+	CallKindClosure   CallKind = "closure"   // 直接调用匿名函数
+	CallKindGo        CallKind = "go"        // go语句, 被调用函数在新的goroutine中运行
+	CallKindDefer     CallKind = "defer"     // defer语句
+)
+
+// CallKinds 所有调用类型
+var CallKinds = []CallKind{CallKindStatic, CallKindInterface, CallKindDynamic, CallKindClosure, CallKindGo, CallKindDefer}
+
+// Valid 判断是否为已知的调用类型
+func (k CallKind) Valid() bool {
+	for _, kind := range CallKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
 type FuncEdge struct {
-	CallerKey string `json:"caller_key"`
-	CalleeKey string `json:"callee_key"`
+	CallerKey string   `json:"caller_key"`
+	CalleeKey string   `json:"callee_key"`
+	CallFile  string   `json:"call_file"`     // 调用点所在文件
+	CallLine  int      `json:"call_line"`     // 调用点行号, 没有调用点时为0
+	CallKind  CallKind `json:"call_kind"`     // 调用类型, 没有调用点时为空
+	Configs   []string `json:"build_configs"` // 矩阵模式下存在这条边的构建配置, 如 "linux/amd64", 非矩阵模式为空
 }
 
 // FuncNode 表示函数节点
 type FuncNode struct {
-	Key       string      `json:"key"`       // 短格式唯一标识，如 "n6796"
-	FullName  string      `json:"full_name"` // 完整的函数路径，如 "crypto/hmac.New$1"
-	Pkg       string      `json:"pkg"`       // 包名
-	Name      string      `json:"name"`      // 函数名
-	Parents   []*FuncNode `json:"parents"`   // 父节点
-	Childrens []*FuncNode `json:"childrens"` // 子节点
+	Key       string      `json:"key"`           // 稳定唯一标识, 包路径+函数名, 如 "crypto/hmac.New$1"
+	LegacyKey string      `json:"legacy_key"`    // 调用图节点ID生成的旧格式标识, 如 "n6796", 每次分析都会变化
+	FullName  string      `json:"full_name"`     // 完整的函数路径，如 "crypto/hmac.New$1"
+	Pkg       string      `json:"pkg"`           // 包名
+	Module    string      `json:"module"`        // 所属模块, 标准库为空
+	Name      string      `json:"name"`          // 函数名
+	File      string      `json:"file"`          // 声明所在文件, 项目内的文件为相对项目目录的路径
+	StartLine int         `json:"start_line"`    // 起始行, 合成函数(如包装函数)为0
+	EndLine   int         `json:"end_line"`      // 结束行
+	Configs   []string    `json:"build_configs"` // 矩阵模式下存在这个函数的构建配置, 非矩阵模式为空
+	Parents   []*FuncNode `json:"parents"`       // 父节点
+	Childrens []*FuncNode `json:"childrens"`     // 子节点
 }
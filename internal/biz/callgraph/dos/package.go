@@ -0,0 +1,7 @@
+package dos
+
+// PackageHash 增量分析时记录的包摘要
+type PackageHash struct {
+	Pkg  string `json:"pkg"`  // 包路径
+	Hash string `json:"hash"` // 包源码、依赖版本和分析配置的摘要
+}
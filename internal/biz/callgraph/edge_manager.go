@@ -7,12 +7,21 @@ import (
 // EdgeManager 边管理器，负责边的创建和关系管理
 type EdgeManager struct {
 	edgeChan chan *dos.FuncEdge
+	seen     map[edgeKey]bool // 已发送的边, 合并节点后可能产生重复的边
+}
+
+// edgeKey 边的唯一标识
+type edgeKey struct {
+	caller, callee string
+	site           SourcePos
+	kind           dos.CallKind
 }
 
 // NewEdgeManager 创建新的边管理器
 func NewEdgeManager() *EdgeManager {
 	return &EdgeManager{
 		edgeChan: make(chan *dos.FuncEdge, 100),
+		seen:     make(map[edgeKey]bool),
 	}
 }
 
@@ -21,23 +30,32 @@ func (em *EdgeManager) GetEdgeChan() <-chan *dos.FuncEdge {
 	return em.edgeChan
 }
 
-// AddEdge 添加边
-func (em *EdgeManager) AddEdge(callerKey, calleeKey string) {
+// AddEdge 添加边, site为调用点位置, kind为调用类型, configs为矩阵模式下存在这条边的构建配置
+func (em *EdgeManager) AddEdge(callerKey, calleeKey string, site SourcePos, kind dos.CallKind, configs ...string) {
+	key := edgeKey{caller: callerKey, callee: calleeKey, site: site, kind: kind}
+	if em.seen[key] {
+		return
+	}
+	em.seen[key] = true
 	em.edgeChan <- &dos.FuncEdge{
 		CallerKey: callerKey,
 		CalleeKey: calleeKey,
+		CallFile:  site.File,
+		CallLine:  site.Line,
+		CallKind:  kind,
+		Configs:   configs,
 	}
 }
 
 // BuildRelationship 建立节点间的父子关系
-func (em *EdgeManager) BuildRelationship(caller, callee *dos.FuncNode) {
+func (em *EdgeManager) BuildRelationship(caller, callee *dos.FuncNode, site SourcePos, kind dos.CallKind, configs ...string) {
 	if caller != nil && callee != nil {
 		// 建立父子关系
 		caller.Childrens = append(caller.Childrens, callee)
 		callee.Parents = append(callee.Parents, caller)
 
 		// 添加边到通道
-		em.AddEdge(caller.Key, callee.Key)
+		em.AddEdge(caller.Key, callee.Key, site, kind, configs...)
 	}
 }
 
@@ -29,18 +29,42 @@ func (f *Filter) ShouldIgnore(node *callgraph.Node) bool {
 
 // IsStandardLibrary 检查节点是否为标准库
 func (f *Filter) IsStandardLibrary(node *callgraph.Node) bool {
-	return isStdPkgPath(node.Func.Pkg.Pkg.Path())
+	pkg := funcPackage(node.Func)
+	if pkg == nil {
+		return false
+	}
+	if f.config.StdPackages != nil {
+		return f.config.StdPackages[pkg.Path()]
+	}
+	modules := f.config.Modules
+	if len(modules) == 0 && f.config.ModuleName != "" {
+		modules = []string{f.config.ModuleName}
+	}
+	return isStdPkgPath(pkg.Path(), modules)
+}
+
+// IsIncludedStd 检查标准库节点是否在需要保留的包中
+func (f *Filter) IsIncludedStd(node *callgraph.Node) bool {
+	pkg := funcPackage(node.Func)
+	return pkg != nil && inModules(pkg.Path(), f.config.IncludeStd)
 }
 
 // IsInternal 检查节点是否为内部模块
 func (f *Filter) IsInternal(node *callgraph.Node) bool {
 	// 增加nil检查以提高健壮性
-	if node.Func == nil || node.Func.Pkg == nil || node.Func.Pkg.Pkg == nil {
+	if node.Func == nil {
 		return false
 	}
-	// node.Func.Pkg.Pkg.Path() 返回纯包路径，如 "github.com/toheart/goanalysis/internal/biz"
-	// 使用 HasPrefix 匹配模块名，更精确
-	return strings.HasPrefix(node.Func.Pkg.Pkg.Path(), f.config.ModuleName)
+	pkg := funcPackage(node.Func)
+	if pkg == nil {
+		return false
+	}
+	// pkg.Path() 返回纯包路径，如 "github.com/toheart/goanalysis/internal/biz"
+	// 工作区和多模块仓库中所有模块都是内部模块
+	if len(f.config.Modules) > 0 {
+		return inModules(pkg.Path(), f.config.Modules)
+	}
+	return strings.HasPrefix(pkg.Path(), f.config.ModuleName)
 }
 
 // ShouldProcessEdge 检查边是否应该被处理
@@ -58,8 +82,8 @@ func (f *Filter) ShouldProcessEdge(edge *callgraph.Edge) bool {
 		return false
 	}
 
-	// 规则2：被调用者不能是标准库函数 (排除对 builtin 和 std lib 的调用)
-	if f.IsStandardLibrary(callee) {
+	// 规则2：被调用者不能是标准库函数 (排除对 builtin 和 std lib 的调用), 指定保留的标准库包除外
+	if f.IsStandardLibrary(callee) && !f.IsIncludedStd(callee) {
 		return false
 	}
 
@@ -72,54 +96,22 @@ func (f *Filter) ShouldProcessEdge(edge *callgraph.Edge) bool {
 	// 这个逻辑精确地保留了以下两种调用关系：
 	// 1. internal -> internal
 	// 2. internal -> third-party
+	// 3. internal -> 指定保留的标准库
 	return true
 }
 
-// isSynthetic 检查边是否为合成边
+// isSynthetic 检查边是否为合成边, 泛型实例对应源码中的泛型函数, 不视为合成
 func isSynthetic(edge *callgraph.Edge) bool {
-	return edge.Caller.Func.Pkg == nil ||
-		edge.Callee.Func.Pkg == nil ||
-		edge.Callee.Func.Synthetic != ""
+	return funcPackage(edge.Caller.Func) == nil ||
+		funcPackage(edge.Callee.Func) == nil ||
+		(edge.Callee.Func.Synthetic != "" && edge.Callee.Func.Origin() == nil)
 }
 
-// isStdPkgPath 检查包路径是否为标准库
-func isStdPkgPath(path string) bool {
-	// 标准库包路径特征：
-	// 1. 不包含域名（没有点号）
-	// 2. 常见的标准库包前缀
-
-	// 如果包含点号，通常是第三方包
-	if strings.Contains(path, ".") {
+// isStdPkgPath 没有加载标准库包集合时按包路径推断: 第一段不包含点号, 且不属于项目模块(如 module myapp)
+func isStdPkgPath(path string, modules []string) bool {
+	if path == "" || path == "command-line-arguments" || inModules(path, modules) {
 		return false
 	}
-
-	// 空路径或特殊路径
-	if path == "" || path == "command-line-arguments" {
-		return false
-	}
-
-	// 常见的标准库包
-	stdPkgs := []string{
-		"fmt", "os", "io", "net", "http", "time", "strings", "bytes",
-		"context", "errors", "log", "math", "sort", "sync", "unsafe",
-		"runtime", "reflect", "encoding", "crypto", "database", "go",
-		"html", "image", "index", "mime", "path", "regexp", "strconv",
-		"testing", "text", "unicode", "archive", "bufio", "builtin",
-		"compress", "container", "debug", "expvar", "flag", "hash",
-		"heap", "plugin", "syscall",
-	}
-
-	// 检查是否是标准库包或其子包
-	for _, stdPkg := range stdPkgs {
-		if path == stdPkg || strings.HasPrefix(path, stdPkg+"/") {
-			return true
-		}
-	}
-
-	// 其他判断：不包含斜杠的单个词通常是标准库
-	if !strings.Contains(path, "/") && path != "" {
-		return true
-	}
-
-	return false
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
 }
@@ -0,0 +1,311 @@
+package callgraph
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/toheart/goanalysis/internal/biz/callgraph/dos"
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/packages"
+)
+
+/**
+增量分析: 数据库中记录每个包的摘要(源码、所有模块的go.mod/go.sum、go.work、算法和忽略路径), 再次分析时:
+1. 只加载包的文件列表和导入关系, 计算摘要, 与数据库对比得到变化的包, 没有变化时直接结束;
+2. 变化的包及其(传递)反向依赖为受影响的包, 删除调用方属于这些包的边, 只重新生成这些包中函数发出的边;
+3. static算法只构建受影响包的SSA; 其余算法的接口、函数值调用依赖整个程序, 所有动态派发边都重新生成;
+4. 删除不再被任何边引用的节点, 保存本次的包摘要.
+数据库中没有摘要(首次分析或旧版本数据库)、配置了根函数(可达范围取决于整个程序)或矩阵模式时清空后全量分析.
+删除边和清空数据库都在调用图构建成功之后执行, 分析失败时数据库保持上次的结果.
+**/
+
+// _dispatchKinds 依赖整个程序的调用类型, 非static算法增量分析时总是重新生成
+var _dispatchKinds = []dos.CallKind{dos.CallKindInterface, dos.CallKindDynamic, dos.CallKindGo, dos.CallKindDefer}
+
+// incrementalState 增量分析状态
+type incrementalState struct {
+	hashes   []*dos.PackageHash // 本次分析的包摘要
+	affected map[string]bool    // 需要重新生成调用边的包, nil表示全量重建
+	full     bool               // 全量重建, 生成新数据之前清空数据库
+}
+
+// prepareIncremental
+//
+//	@Description: 计算包摘要并与数据库对比, 确定需要重新分析的包
+//	@param ctx 用于取消加载包
+//	@param reporter 状态报告器
+//	@return bool 没有包变化, 不需要重新分析
+//	@return error
+func (p *ProgramAnalysis) prepareIncremental(ctx context.Context, reporter *StatusReporter) (bool, error) {
+	configs, err := p.initBuild()
+	if err != nil {
+		return false, err
+	}
+	if err := p.discoverModules(); err != nil {
+		return false, err
+	}
+	pkgs, err := p.loadPackageFiles(ctx, configs)
+	if err != nil {
+		return false, fmt.Errorf("load package files failed: %w", err)
+	}
+	hashes, err := p.packageHashes(pkgs, configs)
+	if err != nil {
+		return false, err
+	}
+	stored, err := p.data.GetPackageHashes()
+	if err != nil {
+		return false, err
+	}
+	p.incremental = &incrementalState{hashes: hashes}
+	if len(stored) == 0 {
+		reporter.ReportStatus("No package hashes in database, running full analysis")
+		p.incremental.full = true
+		return false, nil
+	}
+
+	changed := changedPackages(hashes, stored)
+	if len(changed) == 0 {
+		reporter.ReportStatus(fmt.Sprintf("Incremental analysis: none of %d packages changed", len(hashes)))
+		return true, nil
+	}
+	if len(p.roots) > 0 || len(configs) > 1 {
+		// 任何包的变化都可能改变从根函数的可达范围, 矩阵模式的标注需要所有配置的结果
+		reporter.ReportStatus(fmt.Sprintf("Incremental analysis: %d packages changed, root functions or build matrix configured, running full analysis", len(changed)))
+		p.incremental.full = true
+		return false, nil
+	}
+	p.incremental.affected = reverseDependencies(pkgs, changed)
+	reporter.ReportStatus(fmt.Sprintf("Incremental analysis: %d of %d packages changed, %d affected",
+		len(changed), len(hashes), len(p.incremental.affected)))
+	return false, nil
+}
+
+// purgeAffected 删除受影响包发出的边, 全量重建时清空数据库, 在调用图构建成功后、生成新数据之前执行
+func (p *ProgramAnalysis) purgeAffected() error {
+	if p.incremental == nil {
+		return nil
+	}
+	if p.incremental.full {
+		if err := p.data.ClearCallGraph(); err != nil {
+			return fmt.Errorf("clear call graph failed: %w", err)
+		}
+		return nil
+	}
+	if p.incremental.affected == nil {
+		return nil
+	}
+	pkgs := make([]string, 0, len(p.incremental.affected))
+	for pkg := range p.incremental.affected {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+	var kinds []dos.CallKind
+	if p.algo != CallGraphTypeStatic {
+		kinds = _dispatchKinds
+	}
+	deleted, err := p.data.DeleteFuncEdges(pkgs, kinds)
+	if err != nil {
+		return fmt.Errorf("delete affected edges failed: %w", err)
+	}
+	p.log.Infof("incremental analysis: deleted %d edges of %d packages", deleted, len(pkgs))
+	return nil
+}
+
+// finishIncremental 清理孤立节点并保存本次的包摘要
+func (p *ProgramAnalysis) finishIncremental() error {
+	if p.incremental == nil {
+		return nil
+	}
+	deleted, err := p.data.DeleteOrphanFuncNodes()
+	if err != nil {
+		return fmt.Errorf("delete orphan nodes failed: %w", err)
+	}
+	p.log.Infof("incremental analysis: deleted %d orphan nodes", deleted)
+	if err = p.data.SavePackageHashes(p.incremental.hashes); err != nil {
+		return fmt.Errorf("save package hashes failed: %w", err)
+	}
+	return nil
+}
+
+// isAffected 判断包是否需要重新生成, 非增量分析时总是返回true
+func (p *ProgramAnalysis) isAffected(pkgPath string) bool {
+	return p.incremental == nil || p.incremental.affected == nil || p.incremental.affected[pkgPath]
+}
+
+// shouldEmit 只生成从根函数可达的边; 增量分析时只生成受影响包发出的边, 以及依赖整个程序的动态派发边
+func (p *ProgramAnalysis) shouldEmit(edge *callgraph.Edge) bool {
+	if p.reachable != nil && !p.reachable[edge.Caller.Func] {
+		return false
+	}
+	if p.isAffected(funcPackage(edge.Caller.Func).Path()) {
+		return true
+	}
+	if p.algo == CallGraphTypeStatic {
+		return false
+	}
+	kind := callKind(edge)
+	for _, dispatch := range _dispatchKinds {
+		if kind == dispatch {
+			return true
+		}
+	}
+	return false
+}
+
+// loadPackageFiles 只加载包的文件列表和导入关系, 用于计算摘要, 多个构建配置时合并所有配置中的包
+func (p *ProgramAnalysis) loadPackageFiles(ctx context.Context, configs []BuildConfig) ([]*packages.Package, error) {
+	seen := make(map[string]bool)
+	var result []*packages.Package
+	for _, config := range configs {
+		cfg := &packages.Config{
+			Context:    ctx,
+			Mode:       packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedModule,
+			Dir:        p.Dir,
+			Env:        p.loadEnv(config),
+			BuildFlags: config.buildFlags(),
+		}
+		pkgs, err := packages.Load(cfg, p.loadPatterns()...)
+		if err != nil {
+			return nil, err
+		}
+		// 错误在分析时记录为诊断信息, 容错模式下有错误的包同样计算摘要
+		if !p.tolerant {
+			for _, pkg := range pkgs {
+				if len(pkg.Errors) > 0 {
+					return nil, fmt.Errorf("packages contain errors: %s", p.newDiagnostic(pkg, pkg.Errors[0]))
+				}
+			}
+		}
+		for _, pkg := range pkgs {
+			if !seen[pkg.PkgPath] {
+				seen[pkg.PkgPath] = true
+				result = append(result, pkg)
+			}
+		}
+	}
+	return result, nil
+}
+
+// packageHashes 计算每个包的摘要, 分析配置和依赖版本变化时所有包的摘要都会变化
+func (p *ProgramAnalysis) packageHashes(pkgs []*packages.Package, configs []BuildConfig) ([]*dos.PackageHash, error) {
+	common := sha256.New()
+	fmt.Fprintf(common, "algo=%s\nignore=%q\nroots=%q\nstd=%q\n", p.algo, p.ignorePaths, p.roots, p.includeStd)
+	for _, config := range configs {
+		fmt.Fprintf(common, "config=%s\nenv=%q\n", config.Name(), config.Env)
+	}
+	// 工作区中任一模块的依赖变化都可能影响所有包
+	var files []string
+	if work := p.lookupEnv("GOWORK"); work != "" && work != "off" {
+		files = append(files, work, work+".sum")
+	} else if work := findUp(p.Dir, "go.work"); work != "" {
+		files = append(files, work, work+".sum")
+	}
+	for _, module := range p.modules {
+		fmt.Fprintf(common, "module=%s\n", module.Path)
+		files = append(files, filepath.Join(module.Dir, "go.mod"), filepath.Join(module.Dir, "go.sum"))
+	}
+	for _, file := range files {
+		if err := hashFile(common, file); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	base := common.Sum(nil)
+
+	hashes := make([]*dos.PackageHash, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		h := sha256.New()
+		h.Write(base)
+		fmt.Fprintf(h, "pkg=%s\n", pkg.PkgPath)
+		// 被构建约束排除的文件在其他配置下可能参与分析
+		files := append(append([]string(nil), pkg.GoFiles...), pkg.IgnoredFiles...)
+		if p.needTests() && len(pkg.GoFiles) > 0 {
+			tests, err := filepath.Glob(filepath.Join(filepath.Dir(pkg.GoFiles[0]), "*_test.go"))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, tests...)
+		}
+		sort.Strings(files)
+		files = slices.Compact(files)
+		for _, file := range files {
+			fmt.Fprintf(h, "file=%s\n", filepath.Base(file))
+			if err := hashFile(h, file); err != nil {
+				return nil, err
+			}
+		}
+		hashes = append(hashes, &dos.PackageHash{Pkg: pkg.PkgPath, Hash: hex.EncodeToString(h.Sum(nil))})
+	}
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i].Pkg < hashes[j].Pkg })
+	return hashes, nil
+}
+
+// hashFile 将文件内容写入摘要
+func hashFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// changedPackages 对比包摘要, 返回新增、修改和删除的包
+func changedPackages(current, stored []*dos.PackageHash) map[string]bool {
+	old := make(map[string]string, len(stored))
+	for _, hash := range stored {
+		old[hash.Pkg] = hash.Hash
+	}
+	changed := make(map[string]bool)
+	for _, hash := range current {
+		if old[hash.Pkg] != hash.Hash {
+			changed[hash.Pkg] = true
+		}
+		delete(old, hash.Pkg)
+	}
+	for pkg := range old {
+		changed[pkg] = true
+	}
+	return changed
+}
+
+// reverseDependencies 返回变化的包及所有直接或间接导入它们的项目内的包
+func reverseDependencies(pkgs []*packages.Package, changed map[string]bool) map[string]bool {
+	// 未加载依赖时导入的包只有ID
+	paths := make(map[string]string, len(pkgs))
+	for _, pkg := range pkgs {
+		paths[pkg.ID] = pkg.PkgPath
+	}
+	importers := make(map[string][]string)
+	for _, pkg := range pkgs {
+		for _, imp := range pkg.Imports {
+			if path, ok := paths[imp.ID]; ok {
+				importers[path] = append(importers[path], pkg.PkgPath)
+			}
+		}
+	}
+	affected := make(map[string]bool, len(changed))
+	var queue []string
+	for pkg := range changed {
+		affected[pkg] = true
+		queue = append(queue, pkg)
+	}
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		for _, importer := range importers[pkg] {
+			if !affected[importer] {
+				affected[importer] = true
+				queue = append(queue, importer)
+			}
+		}
+	}
+	return affected
+}
@@ -0,0 +1,153 @@
+package callgraph
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/toheart/goanalysis/internal/biz/repo"
+	"github.com/toheart/goanalysis/internal/data"
+)
+
+var incrementalFiles = map[string]string{
+	"go.mod":  "module example.com/demo\n\ngo 1.21\n",
+	"main.go": "package main\n\nimport (\n\t\"example.com/demo/a\"\n\t\"example.com/demo/c\"\n)\n\nfunc main() {\n\ta.A()\n\tc.C()\n}\n",
+	"a/a.go":  "package a\n\nimport \"example.com/demo/b\"\n\ntype Runner interface{ Run() }\n\nfunc A() {\n\tb.B()\n\tvar r Runner = b.T{}\n\tr.Run()\n}\n",
+	"b/b.go":  "package b\n\ntype T struct{}\n\nfunc (T) Run() {}\n\nfunc B() {\n\thelper()\n}\n\nfunc helper() {}\n",
+	"c/c.go":  "package c\n\nfunc C() {\n\td()\n}\n\nfunc d() {}\n",
+}
+
+// writeModule 在dir下写入示例模块
+func writeModule(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+// runAnalysis 使用ctx和选项分析dir并写入dbPath, 默认使用static算法, 返回分析实例、数据库和Execute的错误
+func runAnalysis(t *testing.T, ctx context.Context, dir, dbPath string, opts ...ProgramOption) (*ProgramAnalysis, repo.StaticDBStore, error) {
+	t.Helper()
+	store, err := data.NewData(log.NewStdLogger(io.Discard)).GetFuncNodeDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open db: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	p := NewProgramAnalysis(dir, log.NewHelper(log.NewStdLogger(io.Discard)), store, append([]ProgramOption{WithAlgo(CallGraphTypeStatic)}, opts...)...)
+	return p, store, p.Execute(ctx, nil)
+}
+
+// executeAnalysis 分析dir并写入dbPath, 返回分析实例
+func executeAnalysis(t *testing.T, dir, dbPath, algo string, incremental bool) (*ProgramAnalysis, repo.StaticDBStore) {
+	t.Helper()
+	p, store, err := runAnalysis(t, context.Background(), dir, dbPath, WithAlgo(algo), WithCacheFlag(incremental))
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	return p, store
+}
+
+// graphSnapshot 将数据库中的节点和边转换为可比较的字符串
+func graphSnapshot(t *testing.T, store repo.StaticDBStore) []string {
+	t.Helper()
+	nodes, err := store.GetAllFuncNodes()
+	if err != nil {
+		t.Fatalf("GetAllFuncNodes failed: %v", err)
+	}
+	edges, err := store.GetAllFuncEdges()
+	if err != nil {
+		t.Fatalf("GetAllFuncEdges failed: %v", err)
+	}
+	var snapshot []string
+	for _, node := range nodes {
+		snapshot = append(snapshot, fmt.Sprintf("node %s %s:%d-%d", node.Key, node.File, node.StartLine, node.EndLine))
+	}
+	for _, edge := range edges {
+		snapshot = append(snapshot, fmt.Sprintf("edge %s -> %s %s:%d %s", edge.CallerKey, edge.CalleeKey, edge.CallFile, edge.CallLine, edge.CallKind))
+	}
+	sort.Strings(snapshot)
+	return snapshot
+}
+
+func TestProgramAnalysis_Incremental(t *testing.T) {
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOWORK", "off")
+
+	for _, algo := range []string{CallGraphTypeStatic, CallGraphTypeCha} {
+		t.Run(algo, func(t *testing.T) {
+			dir, dbDir := t.TempDir(), t.TempDir()
+			writeModule(t, dir, incrementalFiles)
+			dbPath := filepath.Join(dbDir, "incremental.db")
+			executeAnalysis(t, dir, dbPath, algo, true)
+
+			// b变化, a导入b也受影响, c和main不受影响
+			writeModule(t, dir, map[string]string{
+				"b/b.go": "package b\n\ntype T struct{}\n\nfunc (T) Run() { work() }\n\n// B 调用work\nfunc B() {\n\twork()\n}\n\nfunc work() {}\n",
+			})
+			p, store := executeAnalysis(t, dir, dbPath, algo, true)
+			affected := p.incremental.affected
+			if !affected["example.com/demo/a"] || !affected["example.com/demo/b"] || affected["example.com/demo/c"] {
+				t.Errorf("Unexpected affected packages: %v", affected)
+			}
+			got := graphSnapshot(t, store)
+
+			_, fullStore := executeAnalysis(t, dir, filepath.Join(dbDir, "full.db"), algo, false)
+			expected := graphSnapshot(t, fullStore)
+			if len(expected) == 0 {
+				t.Fatal("Full analysis produced an empty call graph")
+			}
+			if fmt.Sprint(got) != fmt.Sprint(expected) {
+				t.Errorf("Incremental result differs from full analysis:\ngot:\n%v\nexpected:\n%v", got, expected)
+			}
+
+			// 没有变化时不重新分析
+			p, store = executeAnalysis(t, dir, dbPath, algo, true)
+			if p.callGraph != nil {
+				t.Error("Unchanged packages should not be analyzed again")
+			}
+			if fmt.Sprint(graphSnapshot(t, store)) != fmt.Sprint(expected) {
+				t.Error("Unchanged run should keep the call graph")
+			}
+		})
+	}
+}
+
+func TestProgramAnalysis_IncrementalKeepsGraphOnFailure(t *testing.T) {
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOWORK", "off")
+	dir, dbDir := t.TempDir(), t.TempDir()
+	writeModule(t, dir, incrementalFiles)
+	dbPath := filepath.Join(dbDir, "incremental.db")
+	_, store := executeAnalysis(t, dir, dbPath, CallGraphTypeStatic, true)
+	expected := graphSnapshot(t, store)
+	if len(expected) == 0 {
+		t.Fatal("Analysis produced an empty call graph")
+	}
+
+	// 配置了根函数时需要全量分析, 调用图构建失败时不应清空上次的结果
+	writeModule(t, dir, map[string]string{
+		"b/b.go": "package b\n\nfunc B() {\n\tundefined()\n}\n",
+	})
+	p := NewProgramAnalysis(dir, log.NewHelper(log.NewStdLogger(io.Discard)), store,
+		WithAlgo(CallGraphTypeStatic), WithCacheFlag(true), WithRoots("example.com/demo.main"))
+	if err := p.Execute(context.Background(), nil); err == nil {
+		t.Fatal("Expected analysis to fail")
+	}
+	if !p.incremental.full {
+		t.Error("Expected a full rebuild to be planned")
+	}
+	if got := graphSnapshot(t, store); fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Failed analysis should keep the previous call graph:\ngot:\n%v\nexpected:\n%v", got, expected)
+	}
+}
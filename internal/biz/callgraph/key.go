@@ -0,0 +1,135 @@
+package callgraph
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+/**
+稳定的函数标识: 旧的节点Key(n6796)来自SSA调用图的节点ID, 每次分析、每种算法都不同, 两个数据库无法关联.
+稳定Key由包路径和函数相对包的名称(RelString)组成, 同一份代码多次分析结果相同:
+
+	example.com/demo.Run
+	example.com/demo.(*Server).Handle
+	example.com/demo.main$1          闭包按在外层函数中出现的顺序编号
+	example.com/demo.Map[int]        泛型实例带上类型实参
+	example.com/demo.Map$1[int]      泛型实例中的闭包
+
+泛型实例及其中的闭包在SSA中没有所属包, 按泛型函数所在的包处理.
+仍然重名的函数(如不同构建配置中声明在不同文件的同名函数)全部追加声明位置区分, 与分析时的遍历顺序无关:
+
+	example.com/demo.open@open_linux.go:10
+	example.com/demo.open@open_windows.go:12
+
+位置也相同的(如不同函数中同名局部类型实例化的泛型)按类型实参的声明位置排序, 再追加序号:
+
+	example.com/demo.Map[example.com/demo.item]@demo.go:3#1
+	example.com/demo.Map[example.com/demo.item]@demo.go:3#2
+**/
+
+// funcPackage 返回函数所属的包, 泛型实例返回泛型函数所在的包, 包装函数等共享的合成函数返回nil
+func funcPackage(fn *ssa.Function) *types.Package {
+	for fn != nil {
+		if fn.Pkg != nil {
+			return fn.Pkg.Pkg
+		}
+		if origin := fn.Origin(); origin != nil {
+			fn = origin
+		} else {
+			fn = fn.Parent()
+		}
+	}
+	return nil
+}
+
+// funcKey 生成函数的稳定Key, 没有所属包的合成函数使用完整名称
+func funcKey(fn *ssa.Function) string {
+	pkg := funcPackage(fn)
+	if pkg == nil {
+		return fn.String()
+	}
+	return pkg.Path() + "." + fn.RelString(pkg)
+}
+
+// legacyKey 生成基于调用图节点ID的旧格式Key
+func legacyKey(nodeID int) string {
+	return fmt.Sprintf("n%d", nodeID)
+}
+
+// disambiguate 为重名的稳定Key追加声明位置
+func disambiguate(key string, pos SourcePos) string {
+	if pos.File == "" {
+		return key
+	}
+	return fmt.Sprintf("%s@%s:%d", key, pos.File, pos.Line)
+}
+
+// funcVariant 返回区分同名同位置函数的信息, 即泛型实例(包括其中的闭包)类型实参的声明位置
+func funcVariant(fn *ssa.Function) string {
+	var parts []string
+	for ; fn != nil; fn = fn.Parent() {
+		for _, arg := range fn.TypeArgs() {
+			part := types.TypeString(arg, nil)
+			if named, ok := arg.(*types.Named); ok && named.Obj().Pos().IsValid() {
+				// 补齐偏移量的位数, 按字符串比较时与源码顺序一致
+				pos := fn.Prog.Fset.Position(named.Obj().Pos())
+				part += fmt.Sprintf("@%s:%010d", pos.Filename, pos.Offset)
+			}
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// keyCandidate 分配稳定Key的函数, 三项都相同的视为同一个函数(如测试变体中的同一函数)
+type keyCandidate struct {
+	key     string
+	pos     SourcePos
+	variant string
+}
+
+// assignKeys 为函数分配稳定Key, 重名的函数全部追加声明位置, 位置也相同的按类型实参排序后追加序号
+func assignKeys(candidates []keyCandidate) map[keyCandidate]string {
+	groups := make(map[string][]keyCandidate)
+	keys := make(map[keyCandidate]string, len(candidates))
+	for _, c := range candidates {
+		if _, ok := keys[c]; ok {
+			continue
+		}
+		keys[c] = c.key
+		groups[c.key] = append(groups[c.key], c)
+	}
+	for _, group := range groups {
+		if len(group) == 1 {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool {
+			a, b := group[i], group[j]
+			if a.pos.File != b.pos.File {
+				return a.pos.File < b.pos.File
+			}
+			if a.pos.Line != b.pos.Line {
+				return a.pos.Line < b.pos.Line
+			}
+			return a.variant < b.variant
+		})
+		counts := make(map[string]int)
+		for _, c := range group {
+			counts[disambiguate(c.key, c.pos)]++
+		}
+		seen := make(map[string]int)
+		for _, c := range group {
+			key := disambiguate(c.key, c.pos)
+			if counts[key] > 1 {
+				seen[key]++
+				key = fmt.Sprintf("%s#%d", key, seen[key])
+			}
+			keys[c] = key
+		}
+	}
+	return keys
+}
@@ -0,0 +1,80 @@
+package callgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/metrics"
+	"time"
+)
+
+/**
+资源限制: 分析通过ctx取消, 调用方可以设置超时; 内存上限是软限制, 后台定期采样进程的堆内存,
+超过上限时先执行一次GC, 仍然超过则以ErrMemoryLimit取消分析, 由调用方把任务标记为失败, 避免整个服务被OOM杀掉.
+构建SSA和调用图的过程无法中断, 取消在加载包、各阶段之间和遍历调用图时生效.
+**/
+
+// _heapMetric 堆上对象占用的内存, 读取时不需要STW
+const _heapMetric = "/memory/classes/heap/objects:bytes"
+
+// _memoryCheckInterval 内存采样间隔
+var _memoryCheckInterval = 500 * time.Millisecond
+
+// ErrMemoryLimit 堆内存超过上限
+var ErrMemoryLimit = errors.New("memory limit exceeded")
+
+// WithMemoryLimit 设置堆内存的软上限(字节), 0表示不限制; 堆内存为进程级别, 并发执行的任务共享
+func WithMemoryLimit(limit uint64) ProgramOption {
+	return func(p *ProgramAnalysis) {
+		p.memoryLimit = limit
+	}
+}
+
+// watchMemory 返回超过内存上限时取消的ctx, 没有设置上限时直接返回ctx
+func (p *ProgramAnalysis) watchMemory(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.memoryLimit == 0 {
+		return ctx, func() {}
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	go func() {
+		ticker := time.NewTicker(_memoryCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if heap := heapBytes(); heap > p.memoryLimit {
+				// 超过上限时先回收一次, 排除还没有回收的垃圾
+				runtime.GC()
+				if heap = heapBytes(); heap > p.memoryLimit {
+					err := fmt.Errorf("%w: heap %d MB, limit %d MB", ErrMemoryLimit, heap>>20, p.memoryLimit>>20)
+					p.log.Errorf("abort analysis: %v", err)
+					cancel(err)
+					return
+				}
+			}
+		}
+	}()
+	return ctx, func() { cancel(nil) }
+}
+
+// heapBytes 当前堆上对象占用的内存
+func heapBytes() uint64 {
+	sample := []metrics.Sample{{Name: _heapMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+// canceled ctx已取消时返回取消原因(超时、超过内存上限或调用方指定的原因), 否则返回nil
+func canceled(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	return context.Cause(ctx)
+}
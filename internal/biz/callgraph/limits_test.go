@@ -0,0 +1,49 @@
+package callgraph
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestProgramAnalysis_Cancel(t *testing.T) {
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOWORK", "off")
+	dir := t.TempDir()
+	writeModule(t, dir, platformFiles)
+
+	// 取消原因透传给调用方
+	cause := errors.New("stopped by test")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(cause)
+	if _, _, err := runAnalysis(t, ctx, dir, filepath.Join(t.TempDir(), "limits.db")); !errors.Is(err, cause) {
+		t.Errorf("Expected cancel cause, got %v", err)
+	}
+
+	ctx, stop := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer stop()
+	<-ctx.Done()
+	if _, _, err := runAnalysis(t, ctx, dir, filepath.Join(t.TempDir(), "limits.db")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestProgramAnalysis_MemoryLimit(t *testing.T) {
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOWORK", "off")
+	dir := t.TempDir()
+	writeModule(t, dir, platformFiles)
+
+	interval := _memoryCheckInterval
+	_memoryCheckInterval = time.Millisecond
+	defer func() { _memoryCheckInterval = interval }()
+
+	if _, _, err := runAnalysis(t, context.Background(), dir, filepath.Join(t.TempDir(), "limits.db"), WithMemoryLimit(1)); !errors.Is(err, ErrMemoryLimit) {
+		t.Errorf("Expected memory limit error, got %v", err)
+	}
+	if _, _, err := runAnalysis(t, context.Background(), dir, filepath.Join(t.TempDir(), "limits.db"), WithMemoryLimit(1<<40)); err != nil {
+		t.Errorf("Analysis under memory limit failed: %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package callgraph
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/toheart/goanalysis/internal/biz/callgraph/dos"
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/ssa"
+)
+
+// matrixNode 合并后的函数节点
+type matrixNode struct {
+	key      string // 稳定Key
+	fullName string
+	pkg      string
+	module   string
+	name     string
+	pos      SourcePos
+	variant  string   // 区分同名同位置函数的类型实参信息
+	configs  []string // 存在这个函数的构建配置
+}
+
+// matrixEdge 合并后的调用边
+type matrixEdge struct {
+	caller, callee string // 节点的合并标识
+	site           SourcePos
+	kind           dos.CallKind
+	configs        []string // 存在这条边的构建配置
+}
+
+// matrixMerger 按稳定Key和声明位置合并多个构建配置的调用图
+type matrixMerger struct {
+	nodes map[string]*matrixNode
+	edges map[edgeKey]*matrixEdge
+}
+
+// newMatrixMerger 创建合并器
+func newMatrixMerger() *matrixMerger {
+	return &matrixMerger{
+		nodes: make(map[string]*matrixNode),
+		edges: make(map[edgeKey]*matrixEdge),
+	}
+}
+
+// addNode 记录函数在config中存在, 返回节点的合并标识
+func (m *matrixMerger) addNode(p *ProgramAnalysis, fn *ssa.Function, config string) string {
+	pos := p.funcPos(fn)
+	key := funcKey(fn)
+	// 不同配置中同名函数可能声明在不同文件(如 open_linux.go 和 open_windows.go), 按位置区分
+	variant := funcVariant(fn)
+	id := disambiguate(key, pos)
+	if variant != "" {
+		id += "|" + variant
+	}
+	node, ok := m.nodes[id]
+	if !ok {
+		pkg := funcPackage(fn)
+		node = &matrixNode{key: key, fullName: fn.String(), pkg: pkg.Path(), module: p.packageModule(pkg.Path()), name: fn.RelString(pkg), pos: pos, variant: variant}
+		m.nodes[id] = node
+	}
+	node.configs = appendConfig(node.configs, config)
+	return id
+}
+
+// addEdge 记录调用边在config中存在
+func (m *matrixMerger) addEdge(p *ProgramAnalysis, edge *callgraph.Edge, config string) {
+	caller := m.addNode(p, edge.Caller.Func, config)
+	callee := m.addNode(p, edge.Callee.Func, config)
+	key := edgeKey{caller: caller, callee: callee, site: p.sitePos(edge), kind: callKind(edge)}
+	merged, ok := m.edges[key]
+	if !ok {
+		merged = &matrixEdge{caller: caller, callee: callee, site: key.site, kind: key.kind}
+		m.edges[key] = merged
+	}
+	merged.configs = appendConfig(merged.configs, config)
+}
+
+// emit 按合并标识的顺序生成节点和边, 节点ID按顺序分配
+func (m *matrixMerger) emit(nm *NodeManager, em *EdgeManager) (int, int) {
+	ids := make([]string, 0, len(m.nodes))
+	for id := range m.nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	candidates := make([]keyCandidate, 0, len(ids))
+	for _, id := range ids {
+		merged := m.nodes[id]
+		candidates = append(candidates, keyCandidate{key: merged.key, pos: merged.pos, variant: merged.variant})
+	}
+	keys := assignKeys(candidates)
+	created := make(map[string]*dos.FuncNode, len(ids))
+	for i, id := range ids {
+		merged := m.nodes[id]
+		node := nm.CreateNode(i, keys[candidates[i]], merged.fullName, merged.pkg, merged.module, merged.name, merged.pos)
+		node.Configs = merged.configs
+		nm.AddNode(node)
+		created[id] = node
+	}
+
+	edges := make([]*matrixEdge, 0, len(m.edges))
+	for _, edge := range m.edges {
+		edges = append(edges, edge)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.caller != b.caller {
+			return a.caller < b.caller
+		}
+		if a.callee != b.callee {
+			return a.callee < b.callee
+		}
+		if a.site != b.site {
+			return a.site.File < b.site.File || a.site.File == b.site.File && a.site.Line < b.site.Line
+		}
+		return a.kind < b.kind
+	})
+	for _, edge := range edges {
+		em.BuildRelationship(created[edge.caller], created[edge.callee], edge.site, edge.kind, edge.configs...)
+	}
+	return len(ids), len(edges)
+}
+
+// appendConfig 追加配置名称, 配置按顺序分析, 只需要和最后一项比较
+func appendConfig(configs []string, config string) []string {
+	if n := len(configs); n > 0 && configs[n-1] == config {
+		return configs
+	}
+	return append(configs, config)
+}
+
+// produceMatrix
+//
+//	@Description: 矩阵模式, 依次分析每个构建配置, 合并后生成节点和边
+//	@param ctx 取消或超过内存上限时终止
+//	@param statusChan 状态通道
+//	@param configs 构建配置
+//	@return error 任一配置分析失败
+func (p *ProgramAnalysis) produceMatrix(ctx context.Context, statusChan chan []byte, configs []BuildConfig) error {
+	p.reporter = NewStatusReporter(statusChan)
+	merger := newMatrixMerger()
+	for i, config := range configs {
+		p.build = config
+		p.isVisited = make(map[string]bool)
+		p.reporter.ReportStatus(fmt.Sprintf("Analyzing build config %s (%d/%d), using algorithm: %s", config.Name(), i+1, len(configs), p.algo))
+		if err := p.Analysis(ctx); err != nil {
+			return fmt.Errorf("build config %s: %w", config.Name(), err)
+		}
+		p.filter = p.newFilter()
+		p.tracker.TotalNodes = len(p.callGraph.Nodes)
+		p.tracker.ProcessedNodes = 0
+
+		err := callgraph.GraphVisitEdges(p.callGraph, func(edge *callgraph.Edge) error {
+			if err := canceled(ctx); err != nil {
+				return err
+			}
+			if callerKey := legacyKey(edge.Caller.ID); !p.isVisited[callerKey] {
+				p.isVisited[callerKey] = true
+				p.tracker.ProcessedNodes++
+			}
+			if p.filter.ShouldProcessEdge(edge) && p.shouldEmit(edge) {
+				merger.addEdge(p, edge, config.Name())
+			}
+			return nil
+		})
+		if err != nil {
+			p.reporter.ReportStatus(fmt.Sprintf("Call graph build error: %v", err))
+			return err
+		}
+	}
+
+	// 所有配置的调用图构建成功后再清空数据库
+	if err := p.purgeAffected(); err != nil {
+		return err
+	}
+	nodeCount, edgeCount := merger.emit(p.nodeManager, p.edgeManager)
+	p.reporter.ReportStatus(fmt.Sprintf("Call graph data production completed, merged %d build configs, %d nodes, %d edges", len(configs), nodeCount, edgeCount))
+	return nil
+}
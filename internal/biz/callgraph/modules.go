@@ -0,0 +1,367 @@
+package callgraph
+
+import (
+	"bufio"
+	"fmt"
+	"go/version"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+/**
+多模块项目: go.work工作区或包含多个go.mod的仓库中, 所有模块都属于项目内部, 模块之间的调用不会被当作第三方调用:
+1. 环境变量GOWORK指定的文件, 或从项目目录向上找到的go.work, 使用其中use的模块;
+2. 没有go.work时, 使用包含项目目录的模块和项目目录下嵌套的所有模块, 多于一个模块时生成临时go.work一起加载;
+3. GOWORK=off时只使用包含项目目录的模块.
+每个节点记录所属的模块, 标准库函数为空.
+**/
+
+// _workspaceSkipDirs 查找嵌套模块时跳过的目录, 与go命令匹配./...时的规则一致
+var _workspaceSkipDirs = map[string]bool{"vendor": true, "testdata": true, "node_modules": true}
+
+// Module 项目中的一个模块
+type Module struct {
+	Path      string // 模块路径
+	Dir       string // 模块目录
+	GoVersion string // go.mod中声明的Go版本
+}
+
+// discoverModules 查找项目的所有模块, 主模块为包含项目目录的模块
+func (p *ProgramAnalysis) discoverModules() error {
+	if p.modules != nil {
+		return nil
+	}
+	var (
+		modules []Module
+		err     error
+	)
+	switch gowork := p.lookupEnv("GOWORK"); {
+	case gowork == "off":
+		var module Module
+		module, err = enclosingModule(p.Dir)
+		modules = []Module{module}
+	case gowork != "":
+		modules, err = workspaceModules(gowork)
+	default:
+		if work := findUp(p.Dir, "go.work"); work != "" {
+			modules, err = workspaceModules(work)
+		} else if modules, err = nestedModules(p.Dir); err == nil && len(modules) > 1 {
+			p.workFile, err = writeWorkspace(modules)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(modules) == 0 {
+		return fmt.Errorf("no modules found in %s", p.Dir)
+	}
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Path < modules[j].Path })
+
+	p.modules = modules
+	p.moduleName = mainModule(p.Dir, modules).Path
+	return nil
+}
+
+// lookupEnv 查找加载包时使用的环境变量, WithBuildEnv中的值优先
+func (p *ProgramAnalysis) lookupEnv(key string) string {
+	for i := len(p.buildEnv) - 1; i >= 0; i-- {
+		if k, v, ok := strings.Cut(p.buildEnv[i], "="); ok && k == key {
+			return v
+		}
+	}
+	return os.Getenv(key)
+}
+
+// loadEnv 加载包使用的环境变量, 生成了临时工作区时指向该工作区
+func (p *ProgramAnalysis) loadEnv(config BuildConfig) []string {
+	env := config.environ()
+	if p.workFile != "" {
+		env = append(env, "GOWORK="+p.workFile)
+	}
+	return env
+}
+
+// cleanupWorkspace 删除生成的临时工作区
+func (p *ProgramAnalysis) cleanupWorkspace() {
+	if p.workFile == "" {
+		return
+	}
+	if err := os.Remove(p.workFile); err != nil {
+		p.log.Warnf("remove workspace file %s failed: %v", p.workFile, err)
+	}
+	if err := os.Remove(p.workFile + ".sum"); err != nil && !os.IsNotExist(err) {
+		p.log.Warnf("remove workspace sum file failed: %v", err)
+	}
+	p.workFile = ""
+}
+
+// loadPatterns 加载包使用的模式, 项目目录不在模块中时(如工作区根目录)./...无法匹配, 逐个列出其下的模块
+func (p *ProgramAnalysis) loadPatterns() []string {
+	root, err := filepath.Abs(p.Dir)
+	if err != nil || len(p.modules) <= 1 {
+		return []string{"./..."}
+	}
+	var patterns []string
+	for _, module := range p.modules {
+		rel, err := filepath.Rel(root, module.Dir)
+		switch {
+		case err != nil:
+		case rel == ".":
+			patterns = append(patterns, "./...")
+		case rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)):
+			// 模块包含项目目录时匹配项目目录下的包, 不在项目目录下的模块作为依赖加载
+			if !strings.HasPrefix(root, module.Dir+string(filepath.Separator)) {
+				continue
+			}
+			patterns = append(patterns, "./...")
+		default:
+			patterns = append(patterns, "./"+filepath.ToSlash(rel)+"/...")
+		}
+	}
+	if len(patterns) == 0 {
+		return []string{"./..."}
+	}
+	return slices.Compact(slices.Sorted(slices.Values(patterns)))
+}
+
+// modulePaths 项目内所有模块的路径
+func (p *ProgramAnalysis) modulePaths() []string {
+	paths := make([]string, 0, len(p.modules))
+	for _, module := range p.modules {
+		paths = append(paths, module.Path)
+	}
+	if len(paths) == 0 && p.moduleName != "" {
+		paths = append(paths, p.moduleName)
+	}
+	return paths
+}
+
+// recordPackageModules 记录每个包所属的模块
+func (p *ProgramAnalysis) recordPackageModules(pkgs []*packages.Package) {
+	p.pkgModules = make(map[string]string)
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if pkg.Module != nil {
+			p.pkgModules[pkg.PkgPath] = pkg.Module.Path
+		}
+	})
+}
+
+// packageModule 返回包所属的模块, 标准库和未知的包返回空
+func (p *ProgramAnalysis) packageModule(pkgPath string) string {
+	return p.pkgModules[pkgPath]
+}
+
+// inModules 判断包路径是否属于modules中的某个模块
+func inModules(pkgPath string, modules []string) bool {
+	for _, module := range modules {
+		if pkgPath == module || strings.HasPrefix(pkgPath, module+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// mainModule 返回包含dir的模块, 多个模块嵌套时取最内层的模块
+func mainModule(dir string, modules []Module) Module {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	main := modules[0]
+	depth := -1
+	for _, module := range modules {
+		rel, err := filepath.Rel(module.Dir, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if d := len(module.Dir); d > depth {
+			main, depth = module, d
+		}
+	}
+	return main
+}
+
+// findUp 从dir开始向上查找文件, 没有找到时返回空
+func findUp(dir, name string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// enclosingModule 从dir开始向上查找包含dir的模块
+func enclosingModule(dir string) (Module, error) {
+	path := findUp(dir, "go.mod")
+	if path == "" {
+		return Module{}, fmt.Errorf("go.mod not found in any parent directory")
+	}
+	return readGoMod(path)
+}
+
+// nestedModules 包含dir的模块和dir下嵌套的所有模块
+func nestedModules(dir string) ([]Module, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	var modules []Module
+	seen := make(map[string]bool)
+	if module, err := enclosingModule(root); err == nil {
+		modules = append(modules, module)
+		seen[module.Dir] = true
+	}
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || _workspaceSkipDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != "go.mod" || seen[filepath.Dir(path)] {
+			return nil
+		}
+		module, err := readGoMod(path)
+		if err != nil {
+			return err
+		}
+		seen[module.Dir] = true
+		modules = append(modules, module)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("go.mod not found in any parent directory")
+	}
+	return modules, nil
+}
+
+// readGoMod 读取go.mod中的模块路径和Go版本
+func readGoMod(path string) (Module, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Module{}, err
+	}
+	defer file.Close()
+
+	module := Module{Dir: filepath.Dir(path)}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "module "):
+			module.Path = unquote(strings.TrimSpace(strings.TrimPrefix(line, "module ")))
+		case strings.HasPrefix(line, "go "):
+			module.GoVersion = strings.TrimSpace(strings.TrimPrefix(line, "go "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Module{}, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	if module.Path == "" {
+		return Module{}, fmt.Errorf("module declaration not found in %s", path)
+	}
+	return module, nil
+}
+
+// workspaceModules 读取go.work中use的所有模块
+func workspaceModules(path string) ([]Module, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open workspace file failed: %w", err)
+	}
+	defer file.Close()
+
+	var dirs []string
+	inUse := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case inUse && line == ")":
+			inUse = false
+		case inUse && line != "":
+			dirs = append(dirs, unquote(line))
+		case line == "use (":
+			inUse = true
+		case strings.HasPrefix(line, "use "):
+			dirs = append(dirs, unquote(strings.TrimSpace(strings.TrimPrefix(line, "use "))))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	modules := make([]Module, 0, len(dirs))
+	for _, dir := range dirs {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(path), dir)
+		}
+		module, err := readGoMod(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return nil, err
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
+
+// writeWorkspace 为嵌套的模块生成临时go.work, Go版本取所有模块中最高的版本
+func writeWorkspace(modules []Module) (string, error) {
+	goVersion := "1.18"
+	var builder strings.Builder
+	builder.WriteString("use (\n")
+	for _, module := range modules {
+		fmt.Fprintf(&builder, "\t%s\n", strconv.Quote(module.Dir))
+		if module.GoVersion != "" && version.Compare("go"+module.GoVersion, "go"+goVersion) > 0 {
+			goVersion = module.GoVersion
+		}
+	}
+	builder.WriteString(")\n")
+
+	// go命令要求工作区文件以.work结尾
+	file, err := os.CreateTemp("", "goanalysis-*.work")
+	if err != nil {
+		return "", fmt.Errorf("create workspace file failed: %w", err)
+	}
+	defer file.Close()
+	if _, err := fmt.Fprintf(file, "go %s\n\n%s", goVersion, builder.String()); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("write workspace file failed: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// unquote 去掉go.mod和go.work中路径的引号
+func unquote(s string) string {
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		return unquoted
+	}
+	return s
+}
@@ -0,0 +1,80 @@
+package callgraph
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var workspaceFiles = map[string]string{
+	"app/go.mod":  "module example.com/app\n\ngo 1.21\n",
+	"app/main.go": "package main\n\nimport \"example.com/lib\"\n\nfunc main() {\n\tlib.Do()\n}\n",
+	"lib/go.mod":  "module example.com/lib\n\ngo 1.21\n",
+	"lib/lib.go":  "package lib\n\nfunc Do() {\n\thelper()\n}\n\nfunc helper() {}\n",
+}
+
+func TestProgramAnalysis_Workspace(t *testing.T) {
+	// 工作区模式不支持 -mod=mod
+	t.Setenv("GOFLAGS", "")
+	t.Setenv("GOWORK", "")
+
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{name: "go.work", files: map[string]string{"go.work": "go 1.21\n\nuse (\n\t./app\n\t./lib // 被app导入\n)\n"}},
+		{name: "nested modules"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeModule(t, dir, workspaceFiles)
+			writeModule(t, dir, tt.files)
+
+			nodes, edges := analyzeModule(t, dir)
+			// 模块之间的调用和被导入模块内部的调用都是项目内的调用
+			for _, pair := range []string{"main->Do@lib/lib.go", "Do->helper@lib/lib.go"} {
+				if edges[pair] == nil {
+					t.Errorf("Missing edge %s in %v", pair, edges)
+				}
+			}
+			expected := map[string]string{"main@app/main.go": "example.com/app", "Do@lib/lib.go": "example.com/lib"}
+			for name, module := range expected {
+				if node := nodes[name]; node == nil || node.Module != module {
+					t.Errorf("%s: expected module %s, got %+v", name, module, node)
+				}
+			}
+
+			matches, err := filepath.Glob(filepath.Join(os.TempDir(), "goanalysis-*.work"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, match := range matches {
+				if content, err := os.ReadFile(match); err == nil && strings.Contains(string(content), dir) {
+					t.Errorf("Generated workspace %s was not removed", match)
+				}
+			}
+		})
+	}
+}
+
+func TestProgramAnalysis_WorkspaceOff(t *testing.T) {
+	dir := t.TempDir()
+	writeModule(t, dir, map[string]string{
+		"go.mod":         "module example.com/root\n\ngo 1.21\n",
+		"main.go":        "package main\n\nfunc main() {}\n",
+		"tools/go.mod":   "module example.com/root/tools\n\ngo 1.21\n",
+		"tools/tools.go": "package tools\n",
+	})
+	p := NewProgramAnalysis(dir, log.NewHelper(log.NewStdLogger(io.Discard)), nil, WithBuildEnv("GOWORK=off"))
+	if err := p.discoverModules(); err != nil {
+		t.Fatalf("discoverModules failed: %v", err)
+	}
+	if len(p.modules) != 1 || p.moduleName != "example.com/root" {
+		t.Errorf("GOWORK=off should only use the enclosing module, got %+v", p.modules)
+	}
+}
@@ -1,14 +1,13 @@
 package callgraph
 
 import (
-	"fmt"
-
 	"github.com/toheart/goanalysis/internal/biz/callgraph/dos"
 )
 
 // NodeManager 节点管理器，负责节点的创建和管理
 type NodeManager struct {
-	tree     map[string]*dos.FuncNode
+	tree     map[string]*dos.FuncNode // 按旧格式Key(调用图节点ID)索引
+	keys     map[string]string        // 已分配的稳定Key -> 旧格式Key
 	nodeChan chan *dos.FuncNode
 }
 
@@ -16,6 +15,7 @@ type NodeManager struct {
 func NewNodeManager() *NodeManager {
 	return &NodeManager{
 		tree:     make(map[string]*dos.FuncNode),
+		keys:     make(map[string]string),
 		nodeChan: make(chan *dos.FuncNode, 100),
 	}
 }
@@ -25,45 +25,57 @@ func (nm *NodeManager) GetNodeChan() <-chan *dos.FuncNode {
 	return nm.nodeChan
 }
 
-// NodeExists 检查节点是否存在
+// NodeExists 检查节点是否存在, key为旧格式Key
 func (nm *NodeManager) NodeExists(key string) bool {
 	_, exists := nm.tree[key]
 	return exists
 }
 
-// GetNode 获取节点
+// GetNode 获取节点, key为旧格式Key
 func (nm *NodeManager) GetNode(key string) *dos.FuncNode {
 	return nm.tree[key]
 }
 
-// CreateNode 创建节点
-func (nm *NodeManager) CreateNode(nodeID int, fullName, pkg, name string) *dos.FuncNode {
-	// 生成短格式Key
-	key := fmt.Sprintf("n%d", nodeID)
+// CreateNode 创建节点, module为所属模块, key为assignKeys分配的稳定Key
+func (nm *NodeManager) CreateNode(nodeID int, key, fullName, pkg, module, name string, pos SourcePos) *dos.FuncNode {
+	legacy := legacyKey(nodeID)
+	nm.keys[key] = legacy
 
 	return &dos.FuncNode{
-		Key:      key,
-		FullName: fullName,
-		Pkg:      pkg,
-		Name:     name,
+		Key:       key,
+		LegacyKey: legacy,
+		FullName:  fullName,
+		Pkg:       pkg,
+		Module:    module,
+		Name:      name,
+		File:      pos.File,
+		StartLine: pos.Line,
+		EndLine:   pos.EndLine,
 	}
 }
 
 // AddNode 添加节点
 func (nm *NodeManager) AddNode(node *dos.FuncNode) {
-	nm.tree[node.Key] = node
+	nm.tree[node.LegacyKey] = node
 	nm.nodeChan <- node
 }
 
-// GetOrCreateNode 获取或创建节点
-func (nm *NodeManager) GetOrCreateNode(nodeID int, fullName, pkg, name string) *dos.FuncNode {
-	key := fmt.Sprintf("n%d", nodeID)
+// GetOrCreateNode 获取或创建节点, key为assignKeys分配的稳定Key
+func (nm *NodeManager) GetOrCreateNode(nodeID int, key, fullName, pkg, module, name string, pos SourcePos) *dos.FuncNode {
+	legacy := legacyKey(nodeID)
+
+	if nm.NodeExists(legacy) {
+		return nm.GetNode(legacy)
+	}
 
-	if nm.NodeExists(key) {
-		return nm.GetNode(key)
+	// 加载测试文件时同一个包有测试变体, 稳定Key相同的函数合并为一个节点
+	if owner, ok := nm.keys[key]; ok {
+		node := nm.tree[owner]
+		nm.tree[legacy] = node
+		return node
 	}
 
-	node := nm.CreateNode(nodeID, fullName, pkg, name)
+	node := nm.CreateNode(nodeID, key, fullName, pkg, module, name, pos)
 	nm.AddNode(node)
 	return node
 }
@@ -1,13 +1,11 @@
 package callgraph
 
 import (
-	"bufio"
 	"context"
 	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
-	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -40,16 +38,35 @@ type ProgramAnalysis struct {
 	ignorePaths []string // 需要忽略的路径
 	onlyMethod  string   // 只分析特定方法
 	cachePath   string   // 缓存文件路径
-	isCache     bool     // 是否使用缓存
+	isCache     bool     // 是否增量分析
 	outputPath  string   // 输出文件路径
+	roots       []string // 根函数集合, 为空时使用main函数
+	buildTags   []string // 所有构建配置共用的构建标签
+	buildEnv    []string // 加载包时额外的环境变量
+	platforms   []string // 分析的平台(GOOS/GOARCH), 为空时使用宿主环境
+	tagSets     []string // 矩阵中的构建标签组合
+	includeStd  []string // 保留调用边的标准库包
+	tolerant    bool     // 容错模式, 跳过有错误的包
+	memoryLimit uint64   // 堆内存软上限(字节), 0表示不限制
+
+	// 诊断信息
+	onDiagnostic    func(dos.Diagnostic)
+	diagnostics     []dos.Diagnostic
+	seenDiagnostics map[dos.Diagnostic]bool
 
 	// 依赖注入
 	log  *log.Helper
 	data repo.StaticDBStore // 数据存储
 
 	// 分析结果
-	callGraph  *callgraph.Graph // 调用图
-	moduleName string           // 模块名
+	callGraph  *callgraph.Graph       // 调用图
+	moduleName string                 // 主模块名, 包含项目目录的模块
+	modules    []Module               // 项目内的所有模块
+	workFile   string                 // 为嵌套模块生成的临时go.work, 没有生成时为空
+	pkgModules map[string]string      // 包路径 -> 所属模块路径
+	stdPkgs    map[string]bool        // 当前构建配置的标准库包, nil表示未知
+	reachable  map[*ssa.Function]bool // 从根函数可达的函数, nil表示不过滤
+	build      BuildConfig            // 当前分析的构建配置
 
 	// 组件
 	nodeManager *NodeManager
@@ -59,7 +76,8 @@ type ProgramAnalysis struct {
 	tracker     *ProgressTracker
 
 	// 状态跟踪
-	isVisited map[string]bool // 是否访问过
+	isVisited   map[string]bool   // 是否访问过
+	incremental *incrementalState // 增量分析状态, 非增量分析时为nil
 }
 
 // NewProgramAnalysis 创建新的程序分析实例
@@ -113,34 +131,53 @@ func (p *ProgramAnalysis) GetNode(key string) *dos.FuncNode {
 // 这是对外提供的主要接口，内聚了所有内部操作
 func (p *ProgramAnalysis) Execute(ctx context.Context, statusChan chan []byte) error {
 	p.log.Info("execute call graph analysis")
+	defer p.cleanupWorkspace()
+	ctx, stop := p.watchMemory(ctx)
+	defer stop()
 
 	// 初始化数据库表
 	if err := p.data.InitTable(); err != nil {
 		return fmt.Errorf("failed to init database table: %w", err)
 	}
 
+	// 增量分析时没有包变化则直接结束
+	if p.isCache {
+		unchanged, err := p.prepareIncremental(ctx, NewStatusReporter(statusChan))
+		if err != nil {
+			return fmt.Errorf("failed to prepare incremental analysis: %w", err)
+		}
+		if unchanged {
+			p.log.Info("no package changed, skip call graph analysis")
+			return nil
+		}
+	}
+
 	// 启动数据消费者（并发执行）
 	errChan := make(chan error)
 	go func() {
 		errChan <- p.consumeData(ctx, statusChan)
 	}()
 
-	// 生产数据到channels
-	if err := p.produceData(statusChan); err != nil {
+	// 生产数据到channels, 完成或失败后关闭channels，通知消费者数据生产完毕
+	err := p.produceData(ctx, statusChan)
+	p.nodeManager.Close()
+	p.edgeManager.Close()
+	consumeErr := <-errChan
+	if err != nil {
 		p.log.Errorf("failed to produce data: %v", err)
 		return fmt.Errorf("failed to produce data: %w", err)
 	}
 
-	// 关闭channels，通知消费者数据生产完毕
-	p.nodeManager.Close()
-	p.edgeManager.Close()
-
 	// 等待数据消费完成
-	if err := <-errChan; err != nil {
+	if err := consumeErr; err != nil {
 		p.log.Errorf("failed to consume data: %v", err)
 		return fmt.Errorf("failed to consume data: %w", err)
 	}
 
+	if err := p.finishIncremental(); err != nil {
+		return err
+	}
+
 	p.log.Info("call graph analysis completed successfully")
 	return nil
 }
@@ -148,50 +185,28 @@ func (p *ProgramAnalysis) Execute(ctx context.Context, statusChan chan []byte) e
 // GetModuleName 从go.mod文件中获取模块名
 func (p *ProgramAnalysis) GetModuleName() (string, error) {
 	// 从当前目录开始向上查找go.mod文件
-	dir := p.Dir
-	for {
-		modPath := filepath.Join(dir, "go.mod")
-		file, err := os.Open(modPath)
-		if err == nil {
-			defer file.Close()
-			// 读取go.mod文件查找module名
-			scanner := bufio.NewScanner(file)
-			for scanner.Scan() {
-				line := scanner.Text()
-				if strings.HasPrefix(line, "module ") {
-					return strings.TrimSpace(strings.TrimPrefix(line, "module ")), nil
-				}
-			}
-			if err := scanner.Err(); err != nil {
-				return "", fmt.Errorf("error reading go.mod: %w", err)
-			}
-			return "", fmt.Errorf("module declaration not found in go.mod")
-		}
-
-		// 获取父目录
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			// 已经到达根目录
-			return "", fmt.Errorf("go.mod not found in any parent directory")
-		}
-		dir = parent
+	module, err := enclosingModule(p.Dir)
+	if err != nil {
+		return "", err
 	}
+	return module.Path, nil
 }
 
-// Analysis 执行程序分析
-func (p *ProgramAnalysis) Analysis() error {
+// Analysis 执行程序分析, 取消只在加载包和各阶段之间生效, 构建SSA和调用图的过程无法中断
+func (p *ProgramAnalysis) Analysis(ctx context.Context) error {
 	p.log.Info("analysis")
-	// 获取模块名
-	moduleName, err := p.GetModuleName()
-	if err != nil {
-		p.log.Errorf("get module name failed: %v", err)
-		return fmt.Errorf("get module name failed: %w", err)
+	// 查找项目的所有模块
+	if err := p.discoverModules(); err != nil {
+		p.log.Errorf("discover modules failed: %v", err)
+		return fmt.Errorf("discover modules failed: %w", err)
 	}
-	p.moduleName = moduleName
-	p.log.Infof("analyzing module: %s", p.moduleName)
+	p.log.Infof("analyzing module: %s, modules: %v", p.moduleName, p.modulePaths())
 
-	pkgs, err := p.loadPackages()
+	pkgs, err := p.loadPackages(ctx)
 	if err != nil {
+		if cause := canceled(ctx); cause != nil {
+			return cause
+		}
 		p.log.Error("load packages failed: %w", err)
 		return fmt.Errorf("load packages failed: %w", err)
 	}
@@ -201,36 +216,58 @@ func (p *ProgramAnalysis) Analysis() error {
 		p.log.Error("buildSSA failed: %w", err)
 		return fmt.Errorf("buildSSA failed: %w", err)
 	}
+	if err := canceled(ctx); err != nil {
+		return err
+	}
 
 	if err := p.buildCallGraph(prog); err != nil {
 		p.log.Error("build call graph failed: %w", err)
 		return fmt.Errorf("build call graph failed: %w", err)
 	}
 
-	return nil
+	return canceled(ctx)
 }
 
 // loadPackages 加载项目包
-func (p *ProgramAnalysis) loadPackages() ([]*packages.Package, error) {
+func (p *ProgramAnalysis) loadPackages(ctx context.Context) ([]*packages.Package, error) {
+	tests := p.needTests()
 	cfg := &packages.Config{
-		Mode:  packages.LoadAllSyntax,
-		Tests: false,
-		Dir:   p.Dir,
+		Context:    ctx,
+		Mode:       packages.LoadAllSyntax | packages.NeedModule,
+		Tests:      tests,
+		Dir:        p.Dir,
+		Env:        p.loadEnv(p.build),
+		BuildFlags: p.build.buildFlags(),
 		ParseFile: func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
-			if strings.HasSuffix(filename, "_test.go") {
+			if !tests && strings.HasSuffix(filename, "_test.go") {
 				return nil, nil
 			}
 			return parser.ParseFile(fset, filename, src, parser.ParseComments)
 		},
 	}
 
-	initial, err := packages.Load(cfg, "./...")
+	initial, err := packages.Load(cfg, p.loadPatterns()...)
 	if err != nil {
 		return nil, err
 	}
 
-	if packages.PrintErrors(initial) > 0 {
-		return nil, fmt.Errorf("packages contain errors")
+	p.recordPackageModules(initial)
+	if initial, err = p.checkPackages(initial); err != nil {
+		return nil, err
+	}
+	if err := p.loadStdPackages(ctx); err != nil {
+		return nil, err
+	}
+
+	if tests {
+		// 去掉go test生成的main包, 测试函数由根函数集合指定
+		pkgs := initial[:0]
+		for _, pkg := range initial {
+			if !strings.HasSuffix(pkg.ID, ".test") {
+				pkgs = append(pkgs, pkg)
+			}
+		}
+		initial = pkgs
 	}
 
 	return initial, nil
@@ -239,6 +276,15 @@ func (p *ProgramAnalysis) loadPackages() ([]*packages.Package, error) {
 // buildSSA 构建SSA形式的程序表示
 func (p *ProgramAnalysis) buildSSA(pkgs []*packages.Package) (*ssa.Program, error) {
 	prog, _ := ssautil.AllPackages(pkgs, ssa.InstantiateGenerics)
+	// static算法的调用边只取决于调用方函数体, 增量分析时只构建受影响的包
+	if p.algo == CallGraphTypeStatic && p.incremental != nil && p.incremental.affected != nil {
+		for _, pkg := range prog.AllPackages() {
+			if p.isAffected(pkg.Pkg.Path()) {
+				pkg.Build()
+			}
+		}
+		return prog, nil
+	}
 	prog.Build()
 	return prog, nil
 }
@@ -252,7 +298,7 @@ func (p *ProgramAnalysis) buildCallGraph(prog *ssa.Program) error {
 	case CallGraphTypeCha:
 		p.callGraph = cha.CallGraph(prog)
 	case CallGraphTypeRta:
-		roots, err := p.getMainFunctions(prog)
+		roots, err := p.rootFunctions(prog)
 		if err != nil {
 			return err
 		}
@@ -268,6 +314,16 @@ func (p *ProgramAnalysis) buildCallGraph(prog *ssa.Program) error {
 		return fmt.Errorf("failed to build call graph")
 	}
 
+	// RTA只分析从根函数可达的部分, 其余算法按根函数过滤调用边
+	if len(p.roots) > 0 && p.algo != CallGraphTypeRta {
+		roots, err := p.rootFunctions(prog)
+		if err != nil {
+			return err
+		}
+		p.reachable = reachableFrom(p.callGraph, roots)
+		p.log.Infof("%d root functions reach %d functions", len(roots), len(p.reachable))
+	}
+
 	return nil
 }
 
@@ -289,18 +345,32 @@ func (p *ProgramAnalysis) getMainFunctions(prog *ssa.Program) ([]*ssa.Function,
 }
 
 // setTree 构建调用图树结构（内部方法）
-func (p *ProgramAnalysis) setTree(statusChan chan []byte) error {
+func (p *ProgramAnalysis) setTree(ctx context.Context, statusChan chan []byte) error {
 	p.log.Info("set tree")
-	if err := p.Analysis(); err != nil {
+	defer p.cleanupWorkspace()
+	ctx, stop := p.watchMemory(ctx)
+	defer stop()
+	// 失败时同样关闭管理器, 避免SaveData一直等待
+	defer func() {
+		p.nodeManager.Close()
+		p.edgeManager.Close()
+	}()
+
+	// 多个构建配置时分别分析后合并, 与Execute一致
+	configs, err := p.initBuild()
+	if err != nil {
+		return err
+	}
+	if len(configs) > 1 {
+		return p.produceMatrix(ctx, statusChan, configs)
+	}
+	if err := p.Analysis(ctx); err != nil {
 		return err
 	}
 
 	// 初始化组件
 	p.reporter = NewStatusReporter(statusChan)
-	p.filter = NewFilter(&FilterConfig{
-		IgnorePaths: p.ignorePaths,
-		ModuleName:  p.moduleName,
-	})
+	p.filter = p.newFilter()
 
 	// 发送分析开始状态
 	p.reporter.ReportStatus(fmt.Sprintf("Starting to build call graph, using algorithm: %s", p.algo))
@@ -310,18 +380,22 @@ func (p *ProgramAnalysis) setTree(statusChan chan []byte) error {
 	edgeCount := 0
 	p.tracker.TotalNodes = len(p.callGraph.Nodes)
 
-	err := callgraph.GraphVisitEdges(p.callGraph, func(edge *callgraph.Edge) error {
+	keys := p.stableKeys()
+	err = callgraph.GraphVisitEdges(p.callGraph, func(edge *callgraph.Edge) error {
+		if err := canceled(ctx); err != nil {
+			return err
+		}
 		caller := edge.Caller
 		callee := edge.Callee
 
-		callerKey := fmt.Sprintf("n%d", caller.ID)
+		callerKey := legacyKey(caller.ID)
 		if !p.isVisited[callerKey] {
 			p.isVisited[callerKey] = true
 			p.tracker.ProcessedNodes++
 		}
 
 		// 使用过滤器检查是否应该处理这条边
-		if !p.filter.ShouldProcessEdge(edge) {
+		if !p.filter.ShouldProcessEdge(edge) || !p.shouldEmit(edge) {
 			return nil
 		}
 
@@ -329,8 +403,8 @@ func (p *ProgramAnalysis) setTree(statusChan chan []byte) error {
 
 		// 处理caller节点
 		callerFullName := caller.String()
-		callerPkg := caller.Func.Pkg.Pkg.Path()
-		callerName := caller.Func.RelString(caller.Func.Pkg.Pkg)
+		callerPkg := funcPackage(caller.Func).Path()
+		callerName := caller.Func.RelString(funcPackage(caller.Func))
 		if !p.nodeManager.NodeExists(callerKey) {
 			nodeCount++
 			// 每处理10个节点发送一次状态更新
@@ -338,14 +412,14 @@ func (p *ProgramAnalysis) setTree(statusChan chan []byte) error {
 				p.reporter.ReportStatus(fmt.Sprintf("Processed %d nodes, %d edges", nodeCount, edgeCount))
 			}
 		}
-		callerNode := p.nodeManager.GetOrCreateNode(caller.ID, callerFullName, callerPkg, callerName)
+		callerNode := p.nodeManager.GetOrCreateNode(caller.ID, keys[caller.Func], callerFullName, callerPkg, p.packageModule(callerPkg), callerName, p.funcPos(caller.Func))
 
 		// 处理callee节点
 		calleeFullName := callee.String()
-		calleePkg := callee.Func.Pkg.Pkg.Path()
-		calleeName := callee.Func.RelString(callee.Func.Pkg.Pkg)
+		calleePkg := funcPackage(callee.Func).Path()
+		calleeName := callee.Func.RelString(funcPackage(callee.Func))
 
-		calleeKey := fmt.Sprintf("n%d", callee.ID)
+		calleeKey := legacyKey(callee.ID)
 		if !p.nodeManager.NodeExists(calleeKey) {
 			nodeCount++
 			// 每处理10个节点发送一次状态更新
@@ -353,10 +427,10 @@ func (p *ProgramAnalysis) setTree(statusChan chan []byte) error {
 				p.reporter.ReportStatus(fmt.Sprintf("Processed %d nodes, %d edges", nodeCount, edgeCount))
 			}
 		}
-		calleeNode := p.nodeManager.GetOrCreateNode(callee.ID, calleeFullName, calleePkg, calleeName)
+		calleeNode := p.nodeManager.GetOrCreateNode(callee.ID, keys[callee.Func], calleeFullName, calleePkg, p.packageModule(calleePkg), calleeName, p.funcPos(callee.Func))
 
 		// 建立边关系 - 使用EdgeManager封装逻辑
-		p.edgeManager.BuildRelationship(callerNode, calleeNode)
+		p.edgeManager.BuildRelationship(callerNode, calleeNode, p.sitePos(edge), callKind(edge))
 		edgeCount++
 
 		// 每处理20条边发送一次状态更新
@@ -374,10 +448,6 @@ func (p *ProgramAnalysis) setTree(statusChan chan []byte) error {
 		return err
 	}
 
-	// 关闭管理器
-	p.nodeManager.Close()
-	p.edgeManager.Close()
-
 	// 发送完成状态
 	p.reporter.ReportStatus(fmt.Sprintf("Call graph build completed, processed %d nodes, %d edges", nodeCount, edgeCount))
 
@@ -385,6 +455,93 @@ func (p *ProgramAnalysis) setTree(statusChan chan []byte) error {
 	return nil
 }
 
+// stableKeys 在遍历调用边之前为调用图中的所有函数分配稳定Key, 重名函数的Key与遍历顺序无关
+func (p *ProgramAnalysis) stableKeys() map[*ssa.Function]string {
+	candidates := make(map[*ssa.Function]keyCandidate, len(p.callGraph.Nodes))
+	list := make([]keyCandidate, 0, len(p.callGraph.Nodes))
+	for fn := range p.callGraph.Nodes {
+		if fn == nil {
+			continue
+		}
+		c := keyCandidate{key: funcKey(fn), pos: p.funcPos(fn), variant: funcVariant(fn)}
+		candidates[fn] = c
+		list = append(list, c)
+	}
+	assigned := assignKeys(list)
+	keys := make(map[*ssa.Function]string, len(candidates))
+	for fn, c := range candidates {
+		keys[fn] = assigned[c]
+	}
+	return keys
+}
+
+// funcPos 返回函数声明的文件和起止行, 没有源码的合成函数(包装函数、init等)只返回Pos所在位置
+func (p *ProgramAnalysis) funcPos(fn *ssa.Function) SourcePos {
+	if fn == nil || fn.Prog == nil {
+		return SourcePos{}
+	}
+	fset := fn.Prog.Fset
+	if syntax := fn.Syntax(); syntax != nil {
+		start, end := fset.Position(syntax.Pos()), fset.Position(syntax.End())
+		return SourcePos{File: p.relFile(start.Filename), Line: start.Line, EndLine: end.Line}
+	}
+	if !fn.Pos().IsValid() {
+		return SourcePos{}
+	}
+	pos := fset.Position(fn.Pos())
+	return SourcePos{File: p.relFile(pos.Filename), Line: pos.Line, EndLine: pos.Line}
+}
+
+// sitePos 返回调用点的文件和行号
+func (p *ProgramAnalysis) sitePos(edge *callgraph.Edge) SourcePos {
+	if edge.Site == nil || !edge.Pos().IsValid() {
+		return SourcePos{}
+	}
+	pos := edge.Caller.Func.Prog.Fset.Position(edge.Pos())
+	return SourcePos{File: p.relFile(pos.Filename), Line: pos.Line}
+}
+
+// callKind 根据调用点指令推导调用类型, go和defer优先于调用方式; 没有调用点的合成边返回空
+func callKind(edge *callgraph.Edge) dos.CallKind {
+	if edge.Site == nil {
+		return ""
+	}
+	switch edge.Site.(type) {
+	case *ssa.Go:
+		return dos.CallKindGo
+	case *ssa.Defer:
+		return dos.CallKindDefer
+	}
+	common := edge.Site.Common()
+	if common.IsInvoke() {
+		return dos.CallKindInterface
+	}
+	callee := common.StaticCallee()
+	if callee == nil {
+		return dos.CallKindDynamic
+	}
+	if callee.Parent() != nil {
+		return dos.CallKindClosure
+	}
+	return dos.CallKindStatic
+}
+
+// relFile 项目内的文件转换为相对项目目录的路径, 其余(标准库、依赖)保持绝对路径
+func (p *ProgramAnalysis) relFile(filename string) string {
+	if filename == "" {
+		return ""
+	}
+	dir, err := filepath.Abs(p.Dir)
+	if err != nil {
+		return filename
+	}
+	rel, err := filepath.Rel(dir, filename)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filename
+	}
+	return filepath.ToSlash(rel)
+}
+
 func (p *ProgramAnalysis) GetProgress() float64 {
 	return float64(p.tracker.ProcessedNodes) / float64(p.tracker.TotalNodes)
 }
@@ -404,6 +561,10 @@ func (p *ProgramAnalysis) saveData(ctx context.Context, statusChan chan []byte)
 		defer wg.Done()
 		nodeCount := 0
 		for node := range p.nodeManager.GetNodeChan() {
+			// 取消后继续读取channel但不再保存, 避免生产者阻塞
+			if ctx.Err() != nil {
+				continue
+			}
 			p.log.Infof("save node: %s", node.Key)
 			if err := p.data.SaveFuncNode(node); err != nil {
 				p.log.Error("save node failed: %v", err, "node", node)
@@ -423,6 +584,9 @@ func (p *ProgramAnalysis) saveData(ctx context.Context, statusChan chan []byte)
 		defer wg.Done()
 		edgeCount := 0
 		for edge := range p.edgeManager.GetEdgeChan() {
+			if ctx.Err() != nil {
+				continue
+			}
 			p.log.Infof("save edge: %s --> %s", edge.CallerKey, edge.CalleeKey)
 			if err := p.data.SaveFuncEdge(edge); err != nil {
 				p.log.Error("save edge failed: %v", err, "edge", edge)
@@ -437,6 +601,9 @@ func (p *ProgramAnalysis) saveData(ctx context.Context, statusChan chan []byte)
 	}()
 
 	wg.Wait()
+	if err := canceled(ctx); err != nil {
+		return err
+	}
 
 	if statusChan != nil {
 		statusChan <- []byte("Data saving completed")
@@ -451,9 +618,9 @@ func (p *ProgramAnalysis) SaveData(ctx context.Context, statusChan chan []byte)
 	return p.saveData(ctx, statusChan)
 }
 
-// SetTree 构建调用图树结构（向后兼容，建议使用Execute方法）
-func (p *ProgramAnalysis) SetTree(statusChan chan []byte) error {
-	return p.setTree(statusChan)
+// SetTree 构建调用图树结构（向后兼容，建议使用Execute方法）, ctx取消或超过内存上限时终止
+func (p *ProgramAnalysis) SetTree(ctx context.Context, statusChan chan []byte) error {
+	return p.setTree(ctx, statusChan)
 }
 
 // Configuration option functions
@@ -495,6 +662,7 @@ func WithCacheDir(cache string) ProgramOption {
 	}
 }
 
+// WithCacheFlag 开启增量分析, 只重新分析变化的包及其反向依赖
 func WithCacheFlag(flag bool) ProgramOption {
 	return func(p *ProgramAnalysis) {
 		p.isCache = flag
@@ -502,20 +670,31 @@ func WithCacheFlag(flag bool) ProgramOption {
 }
 
 // produceData 生产调用图数据到channels（内部方法）
-func (p *ProgramAnalysis) produceData(statusChan chan []byte) error {
+func (p *ProgramAnalysis) produceData(ctx context.Context, statusChan chan []byte) error {
 	p.log.Info("produce call graph data")
 
+	// 多个构建配置时分别分析后合并
+	configs, err := p.initBuild()
+	if err != nil {
+		return err
+	}
+	if len(configs) > 1 {
+		return p.produceMatrix(ctx, statusChan, configs)
+	}
+
 	// 执行分析
-	if err := p.Analysis(); err != nil {
+	if err := p.Analysis(ctx); err != nil {
+		return err
+	}
+
+	// 调用图构建成功后再删除需要重新生成的边
+	if err := p.purgeAffected(); err != nil {
 		return err
 	}
 
 	// 初始化组件
 	p.reporter = NewStatusReporter(statusChan)
-	p.filter = NewFilter(&FilterConfig{
-		IgnorePaths: p.ignorePaths,
-		ModuleName:  p.moduleName,
-	})
+	p.filter = p.newFilter()
 
 	// 发送分析开始状态
 	p.reporter.ReportStatus(fmt.Sprintf("Starting to build call graph, using algorithm: %s", p.algo))
@@ -525,26 +704,30 @@ func (p *ProgramAnalysis) produceData(statusChan chan []byte) error {
 	edgeCount := 0
 	p.tracker.TotalNodes = len(p.callGraph.Nodes)
 
-	err := callgraph.GraphVisitEdges(p.callGraph, func(edge *callgraph.Edge) error {
+	keys := p.stableKeys()
+	err = callgraph.GraphVisitEdges(p.callGraph, func(edge *callgraph.Edge) error {
+		if err := canceled(ctx); err != nil {
+			return err
+		}
 		caller := edge.Caller
 		callee := edge.Callee
 
-		callerKey := fmt.Sprintf("n%d", caller.ID)
+		callerKey := legacyKey(caller.ID)
 		if !p.isVisited[callerKey] {
 			p.isVisited[callerKey] = true
 			p.tracker.ProcessedNodes++
 		}
 
 		// 使用过滤器检查是否应该处理这条边
-		if !p.filter.ShouldProcessEdge(edge) {
+		if !p.filter.ShouldProcessEdge(edge) || !p.shouldEmit(edge) {
 			return nil
 		}
 		p.log.Infof("caller: %s, callee: %s", caller.String(), callee.String())
 
 		// 处理caller节点
 		callerFullName := caller.String()
-		callerPkg := caller.Func.Pkg.Pkg.Path()
-		callerName := caller.Func.RelString(caller.Func.Pkg.Pkg)
+		callerPkg := funcPackage(caller.Func).Path()
+		callerName := caller.Func.RelString(funcPackage(caller.Func))
 		if !p.nodeManager.NodeExists(callerKey) {
 			nodeCount++
 			// 每处理10个节点发送一次状态更新
@@ -552,14 +735,14 @@ func (p *ProgramAnalysis) produceData(statusChan chan []byte) error {
 				p.reporter.ReportStatus(fmt.Sprintf("Processed %d nodes, %d edges", nodeCount, edgeCount))
 			}
 		}
-		callerNode := p.nodeManager.GetOrCreateNode(caller.ID, callerFullName, callerPkg, callerName)
+		callerNode := p.nodeManager.GetOrCreateNode(caller.ID, keys[caller.Func], callerFullName, callerPkg, p.packageModule(callerPkg), callerName, p.funcPos(caller.Func))
 
 		// 处理callee节点
 		calleeFullName := callee.String()
-		calleePkg := callee.Func.Pkg.Pkg.Path()
-		calleeName := callee.Func.RelString(callee.Func.Pkg.Pkg)
+		calleePkg := funcPackage(callee.Func).Path()
+		calleeName := callee.Func.RelString(funcPackage(callee.Func))
 
-		calleeKey := fmt.Sprintf("n%d", callee.ID)
+		calleeKey := legacyKey(callee.ID)
 		if !p.nodeManager.NodeExists(calleeKey) {
 			nodeCount++
 			// 每处理10个节点发送一次状态更新
@@ -567,10 +750,10 @@ func (p *ProgramAnalysis) produceData(statusChan chan []byte) error {
 				p.reporter.ReportStatus(fmt.Sprintf("Processed %d nodes, %d edges", nodeCount, edgeCount))
 			}
 		}
-		calleeNode := p.nodeManager.GetOrCreateNode(callee.ID, calleeFullName, calleePkg, calleeName)
+		calleeNode := p.nodeManager.GetOrCreateNode(callee.ID, keys[callee.Func], calleeFullName, calleePkg, p.packageModule(calleePkg), calleeName, p.funcPos(callee.Func))
 
 		// 建立边关系 - 使用EdgeManager封装逻辑
-		p.edgeManager.BuildRelationship(callerNode, calleeNode)
+		p.edgeManager.BuildRelationship(callerNode, calleeNode, p.sitePos(edge), callKind(edge))
 		edgeCount++
 
 		// 每处理20条边发送一次状态更新
@@ -610,6 +793,10 @@ func (p *ProgramAnalysis) consumeData(ctx context.Context, statusChan chan []byt
 		defer wg.Done()
 		nodeCount := 0
 		for node := range p.nodeManager.GetNodeChan() {
+			// 取消后继续读取channel但不再保存, 避免生产者阻塞
+			if ctx.Err() != nil {
+				continue
+			}
 			p.log.Infof("save node: %s", node.Key)
 			if err := p.data.SaveFuncNode(node); err != nil {
 				p.log.Error("save node failed: %v", err, "node", node)
@@ -629,6 +816,9 @@ func (p *ProgramAnalysis) consumeData(ctx context.Context, statusChan chan []byt
 		defer wg.Done()
 		edgeCount := 0
 		for edge := range p.edgeManager.GetEdgeChan() {
+			if ctx.Err() != nil {
+				continue
+			}
 			p.log.Infof("save edge: %s --> %s", edge.CallerKey, edge.CalleeKey)
 			if err := p.data.SaveFuncEdge(edge); err != nil {
 				p.log.Error("save edge failed: %v", err, "edge", edge)
@@ -643,6 +833,9 @@ func (p *ProgramAnalysis) consumeData(ctx context.Context, statusChan chan []byt
 	}()
 
 	wg.Wait()
+	if err := canceled(ctx); err != nil {
+		return err
+	}
 
 	if statusChan != nil {
 		statusChan <- []byte("Data saving completed")
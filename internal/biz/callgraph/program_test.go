@@ -0,0 +1,282 @@
+package callgraph
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/toheart/goanalysis/internal/biz/callgraph/dos"
+)
+
+const positionSource = `package main
+
+func main() {
+	run(1)
+	func() {
+		run(2)
+	}()
+}
+
+func run(n int) int {
+	if n > 1 {
+		return n
+	}
+	return helper(n)
+}
+
+func helper(n int) int { return n + 1 }
+`
+
+// analyzeSource 对单文件程序执行调用图分析, 返回生成的节点和边
+func analyzeSource(t *testing.T, src, algo string) ([]*dos.FuncNode, []*dos.FuncEdge) {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module example.com/demo\n\ngo 1.21\n",
+		"main.go": src,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOWORK", "off")
+
+	p := NewProgramAnalysis(dir, log.NewHelper(log.NewStdLogger(os.Stderr)), nil, WithAlgo(algo))
+	// 通道缓冲足够容纳小程序的全部节点和边, 不需要并发消费
+	if err := p.produceData(context.Background(), nil); err != nil {
+		t.Fatalf("produceData failed: %v", err)
+	}
+	p.nodeManager.Close()
+	p.edgeManager.Close()
+	var nodes []*dos.FuncNode
+	for node := range p.nodeManager.GetNodeChan() {
+		nodes = append(nodes, node)
+	}
+	var edges []*dos.FuncEdge
+	for edge := range p.edgeManager.GetEdgeChan() {
+		edges = append(edges, edge)
+	}
+	return nodes, edges
+}
+
+func TestProgramAnalysis_Positions(t *testing.T) {
+	nodes, edges := analyzeSource(t, positionSource, CallGraphTypeStatic)
+
+	byName := make(map[string]*dos.FuncNode)
+	for _, node := range nodes {
+		byName[node.Name] = node
+	}
+	expected := map[string][2]int{
+		"main":   {3, 8},
+		"main$1": {5, 7},
+		"run":    {10, 15},
+		"helper": {17, 17},
+	}
+	for name, lines := range expected {
+		node, ok := byName[name]
+		if !ok {
+			t.Errorf("Missing node %s in %v", name, byName)
+			continue
+		}
+		if node.File != "main.go" || node.StartLine != lines[0] || node.EndLine != lines[1] {
+			t.Errorf("%s: expected main.go:%d-%d, got %s:%d-%d", name, lines[0], lines[1], node.File, node.StartLine, node.EndLine)
+		}
+	}
+
+	sites := make(map[string]int)
+	for _, edge := range edges {
+		if edge.CallFile != "main.go" {
+			t.Errorf("Expected call file main.go, got %q", edge.CallFile)
+		}
+		sites[edge.CallerKey+"->"+edge.CalleeKey] = edge.CallLine
+	}
+	expectedSites := map[[2]string]int{
+		{"main", "run"}:    4,
+		{"main", "main$1"}: 7,
+		{"main$1", "run"}:  6,
+		{"run", "helper"}:  14,
+	}
+	for pair, line := range expectedSites {
+		caller, callee := byName[pair[0]], byName[pair[1]]
+		if caller == nil || callee == nil {
+			continue
+		}
+		if got := sites[caller.Key+"->"+callee.Key]; got != line {
+			t.Errorf("%s -> %s: expected call line %d, got %d", pair[0], pair[1], line, got)
+		}
+	}
+}
+
+const callKindSource = `package main
+
+type runner interface{ Run() }
+
+type task struct{}
+
+func (task) Run() {}
+
+func work() {}
+
+func apply(f func()) {
+	f()
+}
+
+func main() {
+	work()
+	var r runner = task{}
+	r.Run()
+	apply(work)
+	func() {}()
+	go work()
+	defer work()
+}
+`
+
+func TestProgramAnalysis_CallKinds(t *testing.T) {
+	// 静态算法不解析接口和函数值调用, 使用CHA
+	_, edges := analyzeSource(t, callKindSource, CallGraphTypeCha)
+
+	kinds := make(map[int]dos.CallKind)
+	for _, edge := range edges {
+		kinds[edge.CallLine] = edge.CallKind
+	}
+	expected := map[int]dos.CallKind{
+		12: dos.CallKindDynamic,
+		16: dos.CallKindStatic,
+		18: dos.CallKindInterface,
+		20: dos.CallKindClosure,
+		21: dos.CallKindGo,
+		22: dos.CallKindDefer,
+	}
+	for line, kind := range expected {
+		if kinds[line] != kind {
+			t.Errorf("Line %d: expected call kind %q, got %q", line, kind, kinds[line])
+		}
+	}
+}
+
+const stableKeySource = `package main
+
+type server struct{}
+
+func (s *server) handle() int { return apply(1, func(n int) int { return n * 2 }) }
+
+func apply[T any](v T, f func(T) T) T { return f(v) }
+
+func main() {
+	s := &server{}
+	s.handle()
+	apply("a", func(v string) string { return v })
+}
+`
+
+func TestProgramAnalysis_StableKeys(t *testing.T) {
+	keys := func(algo string) map[string]string {
+		nodes, edges := analyzeSource(t, stableKeySource, algo)
+		result := make(map[string]string)
+		for _, node := range nodes {
+			if node.LegacyKey == "" || node.LegacyKey[0] != 'n' {
+				t.Errorf("Unexpected legacy key %q for %s", node.LegacyKey, node.Key)
+			}
+			result[node.Key] = node.Name
+		}
+		for _, edge := range edges {
+			if _, ok := result[edge.CallerKey]; !ok {
+				t.Errorf("Edge caller %s is not a stable node key", edge.CallerKey)
+			}
+		}
+		return result
+	}
+	static, cha := keys(CallGraphTypeStatic), keys(CallGraphTypeCha)
+	for _, want := range []string{
+		"example.com/demo.main",
+		"example.com/demo.(*server).handle",
+		"example.com/demo.apply[int]",
+		"example.com/demo.apply[string]",
+	} {
+		if _, ok := static[want]; !ok {
+			t.Errorf("Missing stable key %s in %v", want, static)
+		}
+		if _, ok := cha[want]; !ok {
+			t.Errorf("Stable key %s differs between algorithms: %v", want, cha)
+		}
+	}
+	// 作为函数值传入的闭包只有CHA能解析到调用
+	for _, want := range []string{"example.com/demo.(*server).handle$1", "example.com/demo.main$1"} {
+		if _, ok := cha[want]; !ok {
+			t.Errorf("Missing closure key %s in %v", want, cha)
+		}
+	}
+}
+
+const collisionSource = `package main
+
+func apply[T any](v T) T { return v }
+
+func first() {
+	type item struct{}
+	apply(item{})
+}
+
+func second() {
+	type item struct{}
+	apply(item{})
+}
+
+func main() {
+	second()
+	first()
+}
+`
+
+func TestProgramAnalysis_KeyCollisions(t *testing.T) {
+	callers := func(algo string) map[string]string {
+		nodes, edges := analyzeSource(t, collisionSource, algo)
+		names := make(map[string]string)
+		for _, node := range nodes {
+			names[node.Key] = node.Name
+		}
+		result := make(map[string]string)
+		for _, edge := range edges {
+			if names[edge.CalleeKey] != "" && names[edge.CallerKey] != "main" {
+				result[names[edge.CallerKey]] = edge.CalleeKey
+			}
+		}
+		return result
+	}
+	// 同名同位置的泛型实例全部追加位置和按类型实参声明位置排列的序号, 与遍历顺序和算法无关
+	expected := map[string]string{
+		"first":  "example.com/demo.apply[example.com/demo.item]@main.go:3#1",
+		"second": "example.com/demo.apply[example.com/demo.item]@main.go:3#2",
+	}
+	for _, algo := range []string{CallGraphTypeStatic, CallGraphTypeCha} {
+		got := callers(algo)
+		for caller, want := range expected {
+			if got[caller] != want {
+				t.Errorf("%s: expected %s to call %s, got %v", algo, caller, want, got)
+			}
+		}
+	}
+}
+
+func TestAssignKeys(t *testing.T) {
+	linux := keyCandidate{key: "example.com/demo.open", pos: SourcePos{File: "open_linux.go", Line: 10}}
+	windows := keyCandidate{key: "example.com/demo.open", pos: SourcePos{File: "open_windows.go", Line: 12}}
+	single := keyCandidate{key: "example.com/demo.main", pos: SourcePos{File: "main.go", Line: 3}}
+	// 测试变体中的同一函数重复出现, 不算重名
+	keys := assignKeys([]keyCandidate{windows, single, linux, single})
+	expected := map[keyCandidate]string{
+		linux:   "example.com/demo.open@open_linux.go:10",
+		windows: "example.com/demo.open@open_windows.go:12",
+		single:  "example.com/demo.main",
+	}
+	for c, want := range expected {
+		if keys[c] != want {
+			t.Errorf("Expected key %s, got %s", want, keys[c])
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package callgraph
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/ssa"
+)
+
+/**
+根函数集合: 库没有main包, RTA无法分析, 其余算法生成的是整个程序的调用图, 没有有意义的入口.
+通过 WithRoots 指定根函数后, RTA从这些函数开始分析, 其余算法只保留从这些函数可达的调用边.
+每一项是下列集合名之一, 或者是函数的稳定Key(如 example.com/demo.(*Server).Handle):
+
+	main      main包的main和init函数(默认)
+	exported  项目内所有导出的函数和导出类型的导出方法
+	init      项目内所有包的init函数
+	tests     项目内的测试函数(Test/Benchmark/Fuzz/Example)和TestMain
+**/
+
+// 根函数集合名称
+const (
+	RootsMain     = "main"
+	RootsExported = "exported"
+	RootsInit     = "init"
+	RootsTests    = "tests"
+)
+
+// _testFuncPrefixes 测试函数名前缀
+var _testFuncPrefixes = []string{"Test", "Benchmark", "Fuzz", "Example"}
+
+// WithRoots 设置调用图的根函数, 每一项为集合名或函数的稳定Key
+func WithRoots(roots ...string) ProgramOption {
+	cleaned := splitList(roots)
+	return func(p *ProgramAnalysis) {
+		p.roots = cleaned
+	}
+}
+
+// needTests 根函数包含测试函数时需要加载测试文件
+func (p *ProgramAnalysis) needTests() bool {
+	for _, root := range p.roots {
+		if root == RootsTests {
+			return true
+		}
+	}
+	return false
+}
+
+// isModulePackage 判断包是否属于当前项目的某个模块
+func (p *ProgramAnalysis) isModulePackage(pkg *types.Package) bool {
+	return pkg != nil && inModules(pkg.Path(), p.modulePaths())
+}
+
+// rootFunctions
+//
+//	@Description: 按配置收集根函数, 没有配置时使用main包的main函数
+//	@param prog SSA程序
+//	@return []*ssa.Function 去重并按稳定Key排序的根函数
+//	@return error 集合名未知、显式指定的函数不存在或没有找到任何根函数
+func (p *ProgramAnalysis) rootFunctions(prog *ssa.Program) ([]*ssa.Function, error) {
+	if len(p.roots) == 0 {
+		return p.getMainFunctions(prog)
+	}
+
+	seen := make(map[*ssa.Function]bool)
+	var roots []*ssa.Function
+	add := func(fn *ssa.Function) {
+		if fn != nil && !seen[fn] && fn.TypeParams().Len() == 0 {
+			seen[fn] = true
+			roots = append(roots, fn)
+		}
+	}
+	var explicit []string
+	for _, root := range p.roots {
+		switch root {
+		case RootsMain:
+			mains, err := p.getMainFunctions(prog)
+			if err != nil {
+				return nil, err
+			}
+			for _, fn := range mains {
+				add(fn)
+				add(fn.Pkg.Func("init"))
+			}
+		case RootsExported:
+			p.exportedFunctions(prog, add)
+		case RootsInit:
+			for _, pkg := range prog.AllPackages() {
+				if p.isModulePackage(pkg.Pkg) {
+					add(pkg.Func("init"))
+				}
+			}
+		case RootsTests:
+			p.testFunctions(prog, add)
+		default:
+			if !strings.Contains(root, ".") {
+				return nil, fmt.Errorf("unknown root set %q, expected %s, %s, %s, %s or a function key",
+					root, RootsMain, RootsExported, RootsInit, RootsTests)
+			}
+			explicit = append(explicit, root)
+		}
+	}
+	if len(explicit) > 0 {
+		if err := explicitFunctions(prog, explicit, add); err != nil {
+			return nil, err
+		}
+	}
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("no root functions found for %v", p.roots)
+	}
+	sort.Slice(roots, func(i, j int) bool { return funcKey(roots[i]) < funcKey(roots[j]) })
+	return roots, nil
+}
+
+// exportedFunctions 收集项目内导出的函数和导出类型的导出方法
+func (p *ProgramAnalysis) exportedFunctions(prog *ssa.Program, add func(*ssa.Function)) {
+	for _, pkg := range prog.AllPackages() {
+		if !p.isModulePackage(pkg.Pkg) || pkg.Pkg.Name() == "main" {
+			continue
+		}
+		for name, member := range pkg.Members {
+			if !token.IsExported(name) {
+				continue
+			}
+			switch m := member.(type) {
+			case *ssa.Function:
+				add(m)
+			case *ssa.Type:
+				for _, fn := range declaredMethods(prog, m.Type()) {
+					if token.IsExported(fn.Name()) {
+						add(fn)
+					}
+				}
+			}
+		}
+	}
+}
+
+// testFunctions 收集测试文件中的测试函数和TestMain
+func (p *ProgramAnalysis) testFunctions(prog *ssa.Program, add func(*ssa.Function)) {
+	for _, pkg := range prog.AllPackages() {
+		if !p.isModulePackage(pkg.Pkg) {
+			continue
+		}
+		for name, member := range pkg.Members {
+			fn, ok := member.(*ssa.Function)
+			if !ok || !strings.HasSuffix(prog.Fset.Position(fn.Pos()).Filename, "_test.go") {
+				continue
+			}
+			if name == "TestMain" {
+				add(fn)
+				continue
+			}
+			for _, prefix := range _testFuncPrefixes {
+				if strings.HasPrefix(name, prefix) {
+					add(fn)
+					break
+				}
+			}
+		}
+	}
+}
+
+// explicitFunctions 按稳定Key查找显式指定的根函数
+func explicitFunctions(prog *ssa.Program, keys []string, add func(*ssa.Function)) error {
+	wanted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		wanted[key] = true
+	}
+	for _, pkg := range prog.AllPackages() {
+		for _, member := range pkg.Members {
+			switch m := member.(type) {
+			case *ssa.Function:
+				if wanted[funcKey(m)] {
+					delete(wanted, funcKey(m))
+					add(m)
+				}
+			case *ssa.Type:
+				for _, fn := range declaredMethods(prog, m.Type()) {
+					if wanted[funcKey(fn)] {
+						delete(wanted, funcKey(fn))
+						add(fn)
+					}
+				}
+			}
+		}
+	}
+	if len(wanted) > 0 {
+		missing := make([]string, 0, len(wanted))
+		for key := range wanted {
+			missing = append(missing, key)
+		}
+		sort.Strings(missing)
+		return fmt.Errorf("root functions not found: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// declaredMethods 返回类型声明的方法(包括值接收者和指针接收者), 不包括编译器生成的包装函数
+func declaredMethods(prog *ssa.Program, typ types.Type) []*ssa.Function {
+	named, ok := typ.(*types.Named)
+	if !ok || named.TypeParams().Len() > 0 || types.IsInterface(named) {
+		return nil
+	}
+	var methods []*ssa.Function
+	for i := 0; i < named.NumMethods(); i++ {
+		if fn := prog.FuncValue(named.Method(i)); fn != nil {
+			methods = append(methods, fn)
+		}
+	}
+	return methods
+}
+
+// reachableFrom 返回从根函数沿调用图可达的函数
+func reachableFrom(graph *callgraph.Graph, roots []*ssa.Function) map[*ssa.Function]bool {
+	reachable := make(map[*ssa.Function]bool)
+	var queue []*callgraph.Node
+	for _, fn := range roots {
+		if node := graph.Nodes[fn]; node != nil && !reachable[fn] {
+			reachable[fn] = true
+			queue = append(queue, node)
+		}
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, edge := range node.Out {
+			if !reachable[edge.Callee.Func] {
+				reachable[edge.Callee.Func] = true
+				queue = append(queue, edge.Callee)
+			}
+		}
+	}
+	return reachable
+}
@@ -0,0 +1,132 @@
+package callgraph
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var libraryFiles = map[string]string{
+	"go.mod": "module example.com/lib\n\ngo 1.21\n",
+	"lib.go": `package lib
+
+var registry = map[string]int{}
+
+func init() { register("a") }
+
+func register(name string) { registry[name] = len(registry) }
+
+type Client struct{}
+
+func (c *Client) Do() { c.send() }
+
+func (c *Client) send() { encode() }
+
+func Parse() { decode() }
+
+func encode() {}
+
+func decode() {}
+
+func unused() { encode() }
+`,
+	// Example函数不需要导入testing
+	"lib_test.go": `package lib
+
+func ExampleParse() { Parse() }
+
+func helperForTest() { unused() }
+`,
+}
+
+// rootEdges 使用指定的根函数分析库, 返回调用边(调用方->被调用方, 只保留函数名)
+func rootEdges(t *testing.T, dir, algo string, roots ...string) (map[string]bool, error) {
+	t.Helper()
+	_, store, err := runAnalysis(t, context.Background(), dir, filepath.Join(t.TempDir(), "roots.db"), WithAlgo(algo), WithRoots(roots...))
+	if err != nil {
+		return nil, err
+	}
+	edges, err := store.GetAllFuncEdges()
+	if err != nil {
+		t.Fatalf("GetAllFuncEdges failed: %v", err)
+	}
+	result := make(map[string]bool)
+	for _, edge := range edges {
+		result[strings.TrimPrefix(edge.CallerKey, "example.com/lib.")+"->"+strings.TrimPrefix(edge.CalleeKey, "example.com/lib.")] = true
+	}
+	return result, nil
+}
+
+func TestProgramAnalysis_Roots(t *testing.T) {
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOWORK", "off")
+	dir := t.TempDir()
+	writeModule(t, dir, libraryFiles)
+
+	tests := []struct {
+		name     string
+		algo     string
+		roots    []string
+		expected []string
+		excluded []string
+	}{
+		{
+			name:     "rta exported",
+			algo:     CallGraphTypeRta,
+			roots:    []string{RootsExported},
+			expected: []string{"(*Client).Do->(*Client).send", "(*Client).send->encode", "Parse->decode"},
+			excluded: []string{"unused->encode", "init#1->register"},
+		},
+		{
+			name:     "static exported and init",
+			algo:     CallGraphTypeStatic,
+			roots:    []string{RootsExported + "," + RootsInit},
+			expected: []string{"(*Client).Do->(*Client).send", "Parse->decode", "init#1->register"},
+			excluded: []string{"unused->encode"},
+		},
+		{
+			name:     "explicit key",
+			algo:     CallGraphTypeCha,
+			roots:    []string{"example.com/lib.(*Client).Do"},
+			expected: []string{"(*Client).Do->(*Client).send", "(*Client).send->encode"},
+			excluded: []string{"Parse->decode"},
+		},
+		{
+			name:     "tests",
+			algo:     CallGraphTypeRta,
+			roots:    []string{RootsTests},
+			expected: []string{"ExampleParse->Parse", "Parse->decode"},
+			excluded: []string{"helperForTest->unused"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edges, err := rootEdges(t, dir, tt.algo, tt.roots...)
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			for _, edge := range tt.expected {
+				if !edges[edge] {
+					t.Errorf("Missing edge %s in %v", edge, edges)
+				}
+			}
+			for _, edge := range tt.excluded {
+				if edges[edge] {
+					t.Errorf("Unexpected edge %s", edge)
+				}
+			}
+		})
+	}
+
+	// 库没有main包, 默认根函数和未知的集合名都会失败
+	if _, err := rootEdges(t, dir, CallGraphTypeRta); err == nil {
+		t.Error("Expected error for library without main package")
+	}
+	if _, err := rootEdges(t, dir, CallGraphTypeRta, "public"); err == nil || !strings.Contains(err.Error(), "unknown root set") {
+		t.Errorf("Expected unknown root set error, got %v", err)
+	}
+	if _, err := rootEdges(t, dir, CallGraphTypeRta, "example.com/lib.Missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected missing root error, got %v", err)
+	}
+}
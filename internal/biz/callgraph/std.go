@@ -0,0 +1,47 @@
+package callgraph
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// WithStdPackages 设置需要保留调用边的标准库包(如 net/http、database/sql), 包含子包
+func WithStdPackages(pkgs ...string) ProgramOption {
+	cleaned := splitList(pkgs)
+	return func(p *ProgramAnalysis) {
+		p.includeStd = cleaned
+	}
+}
+
+// loadStdPackages 使用go list std获取当前构建配置下的标准库包集合, 包括 vendor/golang.org/x/... 等标准库内部依赖
+func (p *ProgramAnalysis) loadStdPackages(ctx context.Context) error {
+	cfg := &packages.Config{
+		Context:    ctx,
+		Mode:       packages.NeedName,
+		Dir:        p.Dir,
+		Env:        p.loadEnv(p.build),
+		BuildFlags: p.build.buildFlags(),
+	}
+	pkgs, err := packages.Load(cfg, "std")
+	if err != nil {
+		return fmt.Errorf("list std packages failed: %w", err)
+	}
+	p.stdPkgs = make(map[string]bool, len(pkgs))
+	for _, pkg := range pkgs {
+		p.stdPkgs[pkg.PkgPath] = true
+	}
+	return nil
+}
+
+// newFilter 使用当前的模块和标准库包集合创建过滤器
+func (p *ProgramAnalysis) newFilter() *Filter {
+	return NewFilter(&FilterConfig{
+		IgnorePaths: p.ignorePaths,
+		ModuleName:  p.moduleName,
+		Modules:     p.modulePaths(),
+		StdPackages: p.stdPkgs,
+		IncludeStd:  p.includeStd,
+	})
+}
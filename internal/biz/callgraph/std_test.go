@@ -0,0 +1,67 @@
+package callgraph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/toheart/goanalysis/internal/biz/callgraph/dos"
+)
+
+func TestProgramAnalysis_StdPackages(t *testing.T) {
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOWORK", "off")
+	dir := t.TempDir()
+	// 模块路径没有点号, 包名和标准库的slices、maps相似
+	writeModule(t, dir, map[string]string{
+		"go.mod":       "module myapp\n\ngo 1.23\n",
+		"main.go":      "package main\n\nimport (\n\t\"maps\"\n\t\"slices\"\n\t\"strings\"\n\n\t\"myapp/util\"\n)\n\nfunc main() {\n\tm := map[string]int{}\n\t_ = slices.Sorted(maps.Keys(m))\n\t_ = strings.ToUpper(util.Name())\n\trun()\n}\n\nfunc run() {}\n",
+		"util/util.go": "package util\n\nimport \"strings\"\n\nfunc Name() string {\n\treturn strings.Repeat(\"a\", 2)\n}\n",
+	})
+
+	// callees 返回所有被调用函数的名称
+	callees := func(edges map[string]*dos.FuncEdge) map[string]bool {
+		names := make(map[string]bool)
+		for pair := range edges {
+			_, callee, _ := strings.Cut(pair, "->")
+			name, _, _ := strings.Cut(callee, "@")
+			names[name] = true
+		}
+		return names
+	}
+
+	_, edges := analyzeModule(t, dir)
+	for _, pair := range []string{"main->run@main.go", "main->Name@util/util.go"} {
+		if edges[pair] == nil {
+			t.Errorf("Missing project edge %s in %v", pair, edges)
+		}
+	}
+	names := callees(edges)
+	for _, std := range []string{"Sorted", "Keys", "ToUpper", "Repeat"} {
+		if names[std] {
+			t.Errorf("Standard library callee %s should be filtered: %v", std, edges)
+		}
+	}
+
+	// 保留strings包的调用边
+	_, edges = analyzeModule(t, dir, WithStdPackages("strings"))
+	names = callees(edges)
+	if names["Sorted"] || names["Keys"] || !names["ToUpper"] || !names["Repeat"] {
+		t.Errorf("Only strings callees should be kept: %v", edges)
+	}
+}
+
+func TestIsStdPkgPath(t *testing.T) {
+	tests := map[string]bool{
+		"iter":                   true,
+		"log/slog":               true,
+		"myapp":                  false,
+		"myapp/util":             false,
+		"github.com/x/y":         false,
+		"command-line-arguments": false,
+	}
+	for path, expected := range tests {
+		if got := isStdPkgPath(path, []string{"myapp"}); got != expected {
+			t.Errorf("isStdPkgPath(%q) = %v, expected %v", path, got, expected)
+		}
+	}
+}
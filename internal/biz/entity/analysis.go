@@ -3,6 +3,8 @@ package entity
 import (
 	"strings"
 	"time"
+
+	"github.com/toheart/goanalysis/internal/biz/callgraph/dos"
 )
 
 const (
@@ -10,6 +12,7 @@ const (
 	AnalysisEventProcessing
 	AnalysisEventCompleted
 	AnalysisEventFailed
+	AnalysisEventDiagnostic
 )
 
 // AnalysisTask 分析任务
@@ -22,8 +25,17 @@ type AnalysisTask struct {
 
 // AnalysisOptions 分析选项
 type AnalysisOptions struct {
-	Algo         string // 分析算法
-	IgnoreMethod string // 忽略分析特定方法
+	Algo         string        // 分析算法
+	IgnoreMethod string        // 忽略分析特定方法
+	Roots        []string      // 根函数集合, 为空时使用main函数
+	BuildTags    []string      // 构建标签
+	Platforms    []string      // 分析的平台(GOOS/GOARCH), 多个时进入矩阵模式
+	TagSets      []string      // 矩阵中的构建标签组合
+	Env          []string      // 加载包时额外的环境变量
+	StdPackages  []string      // 保留调用边的标准库包
+	Tolerant     bool          // 容错模式, 跳过有错误的包继续分析
+	Timeout      time.Duration // 超时时间, 0表示不限制
+	MemoryLimit  uint64        // 堆内存软上限(字节), 0表示不限制; 比较的是进程的堆内存, 包含并发执行的其他任务
 }
 
 // TaskStatus 任务状态
@@ -33,13 +45,15 @@ const (
 	TaskStatusCompleted  = 2  // 已完成
 	TaskStatusFailed     = -1 // 失败
 	TaskStatusNotFound   = -2 // 未找到
+	TaskStatusCancelled  = -3 // 已取消
 )
 
 // AnalysisTaskStatus 分析任务状态
 type AnalysisTaskStatus struct {
-	Status   int     // 状态
-	Progress float64 // 进度
-	Message  string  // 消息
+	Status      int              // 状态
+	Progress    float64          // 进度
+	Message     string           // 消息
+	Diagnostics []dos.Diagnostic // 加载包时的诊断信息
 }
 
 // DbFileInfo 数据库文件信息
@@ -51,8 +65,9 @@ type DbFileInfo struct {
 }
 
 type AnalysisEvent struct {
-	Type    int    // 类型
-	Message string // 消息
+	Type       int             // 类型
+	Message    string          // 消息
+	Diagnostic *dos.Diagnostic `json:",omitempty"` // 诊断信息, 类型为AnalysisEventDiagnostic时有值
 }
 
 type Function struct {
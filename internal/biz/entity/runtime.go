@@ -51,21 +51,47 @@ type FunctionNode struct {
 
 // FunctionGraphNode 函数调用关系图节点
 type FunctionGraphNode struct {
-	ID        string // 节点ID
-	Name      string // 函数名称
-	Package   string // 包名
-	CallCount int    // 调用次数
-	AvgTime   string // 平均耗时
-	NodeType  string // 节点类型: "root", "caller", "callee"
+	ID        string   // 节点ID
+	Name      string   // 函数名称
+	Package   string   // 包名
+	CallCount int      // 调用次数
+	AvgTime   string   // 平均耗时
+	NodeType  string   // 节点类型: "root", "caller", "callee"
+	File      string   // 声明所在文件
+	StartLine int      // 函数起始行
+	EndLine   int      // 函数结束行
+	LegacyKey string   // 旧格式Key, 每次分析都会变化
+	Module    string   // 所属模块
+	Configs   []string // 矩阵模式下存在这个函数的构建配置, 非矩阵模式为空
 }
 
 // FunctionGraphEdge 函数调用关系图边
 type FunctionGraphEdge struct {
-	Source   string // 源节点ID
-	Target   string // 目标节点ID
-	Value    int    // 调用次数
-	Label    string // 边标签
-	EdgeType string // 边类型: "caller_to_root", "root_to_callee"
+	Source    string     // 源节点ID
+	Target    string     // 目标节点ID
+	Value     int        // 调用次数
+	Label     string     // 边标签
+	EdgeType  string     // 边类型: "caller_to_root", "root_to_callee"
+	CallFile  string     // 第一个调用点所在文件, 与Sites[0]一致
+	CallLine  int        // 第一个调用点行号
+	CallKind  string     // 第一个调用点的调用类型
+	CallKinds []string   // 这对函数之间出现的调用类型
+	Sites     []CallSite // 这对函数之间的全部调用点
+}
+
+// CallSite 函数调用点
+type CallSite struct {
+	File    string   // 调用点所在文件
+	Line    int      // 调用点行号
+	Kind    string   // 调用类型
+	Configs []string // 矩阵模式下存在这个调用点的构建配置, 非矩阵模式为空
+}
+
+// CallPair 一对函数之间的调用点汇总
+type CallPair struct {
+	Sites   []CallSite // 有位置的调用点, 按出现的顺序
+	Kinds   []string   // 出现过的调用类型, 按首次出现的顺序
+	Configs []string   // 矩阵模式下出现过的构建配置, 按首次出现的顺序
 }
 
 // FunctionInfo 函数在Goroutine中的信息
@@ -21,6 +21,15 @@ type StaticDBStore interface {
 	// GetCalleeEdges 获取该函数调用的所有边
 	GetCalleeEdges(callerKey string) ([]*dos.FuncNode, error)
 
+	// GetFuncNodesByKeys 批量获取函数节点, 同时支持稳定Key和旧格式Key, 不加载调用关系
+	GetFuncNodesByKeys(keys []string) ([]*dos.FuncNode, error)
+
+	// GetFuncEdgesByCallers 获取调用方属于callerKeys的边
+	GetFuncEdgesByCallers(callerKeys []string) ([]*dos.FuncEdge, error)
+
+	// GetFuncEdgesByCallees 获取被调用方属于calleeKeys的边
+	GetFuncEdgesByCallees(calleeKeys []string) ([]*dos.FuncEdge, error)
+
 	// GetAllFuncNodes 获取所有函数节点
 	GetAllFuncNodes() ([]*dos.FuncNode, error)
 
@@ -32,4 +41,19 @@ type StaticDBStore interface {
 
 	// InitTable 初始化数据库表
 	InitTable() error
+
+	// GetPackageHashes 获取增量分析记录的包摘要
+	GetPackageHashes() ([]*dos.PackageHash, error)
+
+	// SavePackageHashes 用本次分析的包摘要替换已有记录
+	SavePackageHashes(hashes []*dos.PackageHash) error
+
+	// DeleteFuncEdges 删除调用方属于pkgs中的包, 或调用类型属于kinds的边, 返回删除的数量
+	DeleteFuncEdges(pkgs []string, kinds []dos.CallKind) (int, error)
+
+	// DeleteOrphanFuncNodes 删除不再出现在任何边中的节点, 返回删除的数量
+	DeleteOrphanFuncNodes() (int, error)
+
+	// ClearCallGraph 清空所有节点、边和包摘要
+	ClearCallGraph() error
 }
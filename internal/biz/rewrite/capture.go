@@ -0,0 +1,206 @@
+package rewrite
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+)
+
+/**
+返回值捕获模式, 在追踪语句之后插入(Result为TraceResult时):
+
+	defer func() {
+		_r := recover()
+		functrace.TraceResult(_err, _r)
+		if _r != nil {
+			panic(_r)
+		}
+	}()
+
+最后一个返回值为error时为其命名(未命名时命名为_err, 其余返回值命名为_), 使defer中能读取到最终返回的错误;
+recover到的panic被记录后重新抛出, 不改变原函数的行为. defer后进先出, 记录先于追踪函数的退出回调执行.
+
+接收函数没有默认值, 使用functrace模板时也必须通过Result(--tracer-result)指定, 避免生成引用不存在的函数、
+无法编译的代码. 被插桩项目依赖的追踪包必须导出该函数, 签名为 func(err error, recovered interface{}).
+接收到的结果写入追踪数据库traceData表的error和panic列; 没有这两列的旧数据库读取时为空, 数据库文件不会被修改.
+**/
+
+const (
+	_errResultName  = "_err"
+	_recoverVarName = "_r"
+)
+
+// _errResultPattern 匹配插桩时生成的error返回值名称
+var _errResultPattern = regexp.MustCompile(`^_err\d*$`)
+
+// validateResult 校验返回值接收函数, 捕获返回值时必须指定
+func (t *Tracer) validateResult() error {
+	if t.Result != "" && (!token.IsIdentifier(t.Result) || !token.IsExported(t.Result)) {
+		return fmt.Errorf("invalid tracer result func %q, must be an exported identifier", t.Result)
+	}
+	if t.Capture && t.Result == "" {
+		return fmt.Errorf("tracer result func is required to capture results")
+	}
+	return nil
+}
+
+// freeName 选择在节点中未被使用的标识符, 依次尝试base, base2, base3...
+func freeName(base string, nodes ...ast.Node) string {
+	used := make(map[string]bool)
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		ast.Inspect(node, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+			return true
+		})
+	}
+	name := base
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	return name
+}
+
+// isErrorType 判断类型表达式是否为内置error
+func isErrorType(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "error"
+}
+
+// errorResult 返回最后一个error返回值的名称, 需要时为返回值命名; 没有error返回值时返回空字符串
+func errorResult(funcType *ast.FuncType, body *ast.BlockStmt) string {
+	results := funcType.Results
+	if results == nil || len(results.List) == 0 {
+		return ""
+	}
+	last := results.List[len(results.List)-1]
+	if !isErrorType(last.Type) {
+		return ""
+	}
+	if len(last.Names) > 0 {
+		name := last.Names[len(last.Names)-1]
+		if name.Name == "_" {
+			name.Name = freeName(_errResultName, funcType, body)
+		}
+		return name.Name
+	}
+	// 返回值要么全部命名要么全部不命名
+	name := freeName(_errResultName, funcType, body)
+	for _, field := range results.List[:len(results.List)-1] {
+		field.Names = []*ast.Ident{ast.NewIdent("_")}
+	}
+	last.Names = []*ast.Ident{ast.NewIdent(name)}
+	return name
+}
+
+// genCapture 生成记录返回错误和panic的defer语句, name为文件中追踪包的引用名, errName为空时错误记录为nil
+func (t *Tracer) genCapture(name, errName string) *ast.DeferStmt {
+	var errArg ast.Expr = ast.NewIdent("nil")
+	if errName != "" {
+		errArg = ast.NewIdent(errName)
+	}
+	body := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent(_recoverVarName)},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{Fun: ast.NewIdent("recover")}},
+		},
+		&ast.ExprStmt{X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent(name),
+				Sel: ast.NewIdent(t.Result),
+			},
+			Args: []ast.Expr{errArg, ast.NewIdent(_recoverVarName)},
+		}},
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent(_recoverVarName),
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.ExprStmt{X: &ast.CallExpr{
+					Fun:  ast.NewIdent("panic"),
+					Args: []ast.Expr{ast.NewIdent(_recoverVarName)},
+				}},
+			}},
+		},
+	}
+	return &ast.DeferStmt{Call: &ast.CallExpr{
+		Fun: &ast.FuncLit{
+			Type: &ast.FuncType{Params: &ast.FieldList{}},
+			Body: &ast.BlockStmt{List: body},
+		},
+	}}
+}
+
+// isCaptureCall 判断语句是否为genCapture生成的defer语句
+func (t *Tracer) isCaptureCall(stmt ast.Stmt, name string) bool {
+	if t.Result == "" {
+		return false
+	}
+	ds, ok := stmt.(*ast.DeferStmt)
+	if !ok || len(ds.Call.Args) != 0 {
+		return false
+	}
+	lit, ok := ds.Call.Fun.(*ast.FuncLit)
+	if !ok || len(lit.Body.List) < 2 {
+		return false
+	}
+	es, ok := lit.Body.List[1].(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	call, ok := es.X.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	se, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	x, ok := se.X.(*ast.Ident)
+	return ok && x.Name == name && se.Sel.Name == t.Result
+}
+
+// resultNameSpans 计算撤销插桩时需要改回的返回值名称:
+// 插桩生成的_err及其余的_全部删除(单个返回值连同括号), 其余返回值已命名时将_err改回_
+func resultNameSpans(tf *token.File, funcType *ast.FuncType) []span {
+	results := funcType.Results
+	if results == nil || len(results.List) == 0 {
+		return nil
+	}
+	last := results.List[len(results.List)-1]
+	if !isErrorType(last.Type) || len(last.Names) == 0 {
+		return nil
+	}
+	errName := last.Names[len(last.Names)-1]
+	if !_errResultPattern.MatchString(errName.Name) {
+		return nil
+	}
+	generated := len(last.Names) == 1
+	for _, field := range results.List[:len(results.List)-1] {
+		if len(field.Names) != 1 || field.Names[0].Name != "_" {
+			generated = false
+		}
+	}
+	if !generated {
+		start := tf.Offset(errName.Pos())
+		return []span{{start: start, end: start + len(errName.Name), text: "_"}}
+	}
+	var cuts []span
+	for _, field := range results.List {
+		cuts = append(cuts, span{start: tf.Offset(field.Names[0].Pos()), end: tf.Offset(field.Type.Pos())})
+	}
+	// 单个返回值还原为不带括号的形式, 如 (_err error) 还原为 error
+	if len(results.List) == 1 && results.Opening.IsValid() && results.Closing.IsValid() {
+		opening, closing := tf.Offset(results.Opening), tf.Offset(results.Closing)
+		cuts = append(cuts, span{start: opening, end: opening + 1}, span{start: closing, end: closing + 1})
+	}
+	return cuts
+}
@@ -0,0 +1,110 @@
+package rewrite
+
+import (
+	"go/ast"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const captureSource = `package demo
+
+// Open 打开文件
+func Open(name string) (*File, error) {
+	f, err := open(name)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *Server) Close() (err error) {
+	run(func() error {
+		return s.close()
+	})
+	return nil
+}
+
+func check(n int, _ error) {
+	println(n)
+}
+
+func run(f func() error) {
+	_ = f()
+}
+`
+
+func TestRewriteFile_CaptureResults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "demo.go")
+	if err := os.WriteFile(path, []byte(captureSource), 0644); err != nil {
+		t.Fatalf("Failed to write demo.go: %v", err)
+	}
+	tracer, err := NewTracer(&Tracer{Capture: true, Result: "TraceResult"})
+	if err != nil {
+		t.Fatalf("NewTracer failed: %v", err)
+	}
+	r, err := NewRewrite(path, WithTracer(tracer))
+	if err != nil {
+		t.Fatalf("NewRewrite failed: %v", err)
+	}
+	r.RewriteFile()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read demo.go: %v", err)
+	}
+	expected := []string{
+		"func Open(name string) (_ *File, _err error) {",
+		"functrace.TraceResult(_err, _r)",
+		"func (s *Server) Close() (err error) {",
+		"functrace.TraceResult(err, _r)",
+		"run(func() (_err error) {",
+		"functrace.TraceResult(nil, _r)",
+		"\t\tif _r != nil {\n\t\t\tpanic(_r)\n\t\t}\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Expected %q in:\n%s", want, content)
+		}
+	}
+	if n := strings.Count(string(content), "_r := recover()"); n != 5 {
+		t.Errorf("Expected 5 capture defers, got %d:\n%s", n, content)
+	}
+
+	entry, err := UndoFile(path, WithTracer(tracer))
+	if err != nil || entry == nil {
+		t.Fatalf("UndoFile failed: %v, %v", entry, err)
+	}
+	restored, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read demo.go: %v", err)
+	}
+	if string(restored) != captureSource {
+		t.Errorf("Undo should restore the original file:\n%s", restored)
+	}
+}
+
+func TestErrorResult(t *testing.T) {
+	tests := []struct {
+		src      string
+		name     string
+		expected string
+	}{
+		{"func f() {}", "", "func()"},
+		{"func f() (int, error) { _err := 1; return _err, nil }", "_err2", "func() (_ int, _err2 error)"},
+		{"func f() (n int, _ error) { return }", "_err", "func() (n int, _err error)"},
+		{"func f() (error, int) { return nil, 0 }", "", "func() (error, int)"},
+	}
+	for _, tt := range tests {
+		r := parseTestFile(t, "package p\n"+tt.src)
+		fd := r.f.Decls[0].(*ast.FuncDecl)
+		if got := errorResult(fd.Type, fd.Body); got != tt.name {
+			t.Errorf("%s: expected %q, got %q", tt.src, tt.name, got)
+		}
+		if got := types.ExprString(fd.Type); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.src, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package rewrite
+
+// RewriteOption 定义重写器的配置选项函数类型
+type RewriteOption func(r *Rewrite)
+
+// WithOverlay 将插桩结果写入overlay目录而不是覆盖源文件
+func WithOverlay(overlay *Overlay) RewriteOption {
+	return func(r *Rewrite) {
+		r.overlay = overlay
+	}
+}
+
+// WithFilter 设置插桩过滤器, 只对通过过滤规则的文件和函数插桩
+func WithFilter(filter *Filter) RewriteOption {
+	return func(r *Rewrite) {
+		r.filter = filter
+	}
+}
+
+// WithDryRun 预览模式, 只收集diff和插桩统计, 不修改任何文件
+func WithDryRun(dryRun *DryRun) RewriteOption {
+	return func(r *Rewrite) {
+		r.dryRun = dryRun
+	}
+}
+
+// WithSelection 限定插桩范围, 只对选中的函数和闭包插桩, 如DiffSelection生成的范围
+func WithSelection(selection *Selection) RewriteOption {
+	return func(r *Rewrite) {
+		r.selection = selection
+	}
+}
+
+// WithTracer 设置插桩模板, 为空时使用functrace
+func WithTracer(tracer *Tracer) RewriteOption {
+	return func(r *Rewrite) {
+		r.tracer = tracer
+	}
+}
+
+// WithProgress RewriteDir每处理完一个文件向通道发送一条进度消息, 通道已满时丢弃
+func WithProgress(progress chan []byte) RewriteOption {
+	return func(r *Rewrite) {
+		r.progress = progress
+	}
+}
+
+// WithConcurrency 设置RewriteDir并发处理的文件数, 小于等于0时为GOMAXPROCS
+func WithConcurrency(n int) RewriteOption {
+	return func(r *Rewrite) {
+		r.concurrency = n
+	}
+}
+
+// WithLineDirectives 在插入的代码之后写入 //line 指令, 使编译后的panic堆栈、日志和runtime.Caller仍指向原始行号
+func WithLineDirectives() RewriteOption {
+	return func(r *Rewrite) {
+		r.lineDirectives = true
+	}
+}
+
+// WithTests 同时对测试文件插桩, 并为测试包生成或修改TestMain, 在测试结束后调用Tracer.Flush落盘
+func WithTests() RewriteOption {
+	return func(r *Rewrite) {
+		r.tests = true
+	}
+}
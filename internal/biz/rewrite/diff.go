@@ -0,0 +1,178 @@
+package rewrite
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext unified diff中每个变更块前后保留的上下文行数
+const diffContext = 3
+
+// diffOp 行级编辑操作
+type diffOp struct {
+	kind byte // ' ' 保留, '-' 删除, '+' 新增
+	text string
+}
+
+// unifiedDiff 生成两个文本之间的unified diff, 内容相同时返回空字符串
+func unifiedDiff(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+	ops := diffLines(splitLines(oldText), splitLines(newText))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+
+	// 按上下文范围将编辑操作分组为变更块
+	oldLine, newLine := 1, 1
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			oldLine++
+			newLine++
+			i++
+			continue
+		}
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		// 找到变更块结束位置: 之后连续保留行超过2倍上下文
+		end := i
+		for j := i; j < len(ops); j++ {
+			if ops[j].kind != ' ' {
+				end = j + 1
+				continue
+			}
+			if j-end >= 2*diffContext {
+				break
+			}
+		}
+		stop := end + diffContext
+		if stop > len(ops) {
+			stop = len(ops)
+		}
+
+		hunkOld, hunkNew := oldLine-(i-start), newLine-(i-start)
+		var oldCount, newCount int
+		var body strings.Builder
+		for _, op := range ops[start:stop] {
+			switch op.kind {
+			case ' ':
+				oldCount++
+				newCount++
+			case '-':
+				oldCount++
+			case '+':
+				newCount++
+			}
+			body.WriteByte(op.kind)
+			body.WriteString(op.text)
+			body.WriteByte('\n')
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(hunkOld, oldCount), hunkRange(hunkNew, newCount))
+		sb.WriteString(body.String())
+
+		for _, op := range ops[i:stop] {
+			if op.kind != '+' {
+				oldLine++
+			}
+			if op.kind != '-' {
+				newLine++
+			}
+		}
+		i = stop
+	}
+	return sb.String()
+}
+
+// hunkRange 格式化变更块的行范围
+func hunkRange(start, count int) string {
+	if count == 0 {
+		start--
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+func splitLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines 使用Myers算法计算最短编辑序列, 插桩只会新增少量行, 编辑距离很小
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	maxD := n + m
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	var trace [][]int
+
+	for d := 0; d <= maxD; d++ {
+		// 只保存本轮可能访问到的对角线 [-d-1, d+1]
+		snapshot := make([]int, 2*d+3)
+		copy(snapshot, v[offset-d-1:offset+d+2])
+		trace = append(trace, snapshot)
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack 根据每一轮的V数组回溯出编辑序列
+func backtrack(trace [][]int, a, b []string) []diffOp {
+	var ops []diffOp
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		offset := d + 1
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, diffOp{kind: ' ', text: a[x]})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			ops = append(ops, diffOp{kind: '+', text: b[y]})
+		} else {
+			x--
+			ops = append(ops, diffOp{kind: '-', text: a[x]})
+		}
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
@@ -0,0 +1,171 @@
+package rewrite
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+/**
+源码指令, 用于在代码中控制插桩范围:
+
+	//goanalysis:notrace           不插桩
+	//goanalysis:trace             强制插桩, 忽略过滤规则
+	//goanalysis:notrace package   作用于整个包
+	//goanalysis:trace package
+
+与go指令一样, // 与指令之间不能有空格, 指令之后的其它内容视为说明文字.
+作用域由指令所在位置决定:
+  - 函数的文档注释中: 作用于该函数及其内部的闭包
+  - package子句之前(文件头或包文档注释): 作用于当前文件
+  - package子句之前且带package参数: 作用于同目录下同名包的所有文件
+范围越小优先级越高, 即函数级 > 文件级 > 包级.
+**/
+
+const (
+	directivePrefix  = "//goanalysis:"
+	directiveScopePk = "package"
+)
+
+// directive 指令类型
+type directive int
+
+const (
+	directiveNone directive = iota
+	directiveNoTrace
+	directiveTrace
+)
+
+// String 返回指令文本
+func (d directive) String() string {
+	switch d {
+	case directiveNoTrace:
+		return "goanalysis:notrace"
+	case directiveTrace:
+		return "goanalysis:trace"
+	}
+	return ""
+}
+
+// parseDirective 解析单行注释, 返回指令以及是否作用于整个包
+func parseDirective(text string) (directive, bool) {
+	if !strings.HasPrefix(text, directivePrefix) {
+		return directiveNone, false
+	}
+	fields := strings.Fields(strings.TrimPrefix(text, "//"))
+	var d directive
+	switch fields[0] {
+	case "goanalysis:notrace":
+		d = directiveNoTrace
+	case "goanalysis:trace":
+		d = directiveTrace
+	default:
+		return directiveNone, false
+	}
+	return d, len(fields) > 1 && fields[1] == directiveScopePk
+}
+
+// groupDirective 返回注释组中最后出现的指令, pkgScope为true时只返回带package参数的指令
+func groupDirective(groups []*ast.CommentGroup, pkgScope bool) directive {
+	result := directiveNone
+	for _, cg := range groups {
+		if cg == nil {
+			continue
+		}
+		for _, c := range cg.List {
+			d, isPkg := parseDirective(c.Text)
+			if d != directiveNone && isPkg == pkgScope {
+				result = d
+			}
+		}
+	}
+	return result
+}
+
+// headerComments 返回package子句之前的注释组
+func headerComments(f *ast.File) []*ast.CommentGroup {
+	var groups []*ast.CommentGroup
+	for _, cg := range f.Comments {
+		if cg.End() >= f.Package {
+			break
+		}
+		groups = append(groups, cg)
+	}
+	return groups
+}
+
+// funcDirective 函数文档注释中的指令
+func funcDirective(decl *ast.FuncDecl) directive {
+	if decl.Doc == nil {
+		return directiveNone
+	}
+	return groupDirective([]*ast.CommentGroup{decl.Doc}, false)
+}
+
+// fileDirective 文件的生效指令, 文件级指令优先于包级指令
+func (r *Rewrite) fileDirective() directive {
+	header := headerComments(r.f)
+	if d := groupDirective(header, false); d != directiveNone {
+		return d
+	}
+	if r.directives != nil {
+		return r.directives.packageDirective(filepath.Dir(r.fullPath), r.f.Name.Name)
+	}
+	return scanPackageDirective(filepath.Dir(r.fullPath), r.f.Name.Name)
+}
+
+// directiveCache 按目录缓存包级指令, 避免每个文件都扫描同目录的全部文件
+type directiveCache struct {
+	mu   sync.Mutex
+	pkgs map[string]directive
+}
+
+func newDirectiveCache() *directiveCache {
+	return &directiveCache{pkgs: make(map[string]directive)}
+}
+
+// withDirectiveCache 设置包级指令缓存, 由RewriteDir在遍历目录时共享
+func withDirectiveCache(cache *directiveCache) RewriteOption {
+	return func(r *Rewrite) {
+		r.directives = cache
+	}
+}
+
+func (c *directiveCache) packageDirective(dir, pkgName string) directive {
+	key := dir + "\x00" + pkgName
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d, ok := c.pkgs[key]; ok {
+		return d
+	}
+	d := scanPackageDirective(dir, pkgName)
+	c.pkgs[key] = d
+	return d
+}
+
+// scanPackageDirective 扫描目录下同名包的文件头, 查找带package参数的指令
+func scanPackageDirective(dir, pkgName string) directive {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return directiveNone
+	}
+	result := directiveNone
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.PackageClauseOnly|parser.ParseComments)
+		if err != nil || f.Name.Name != pkgName {
+			continue
+		}
+		if d := groupDirective(headerComments(f), true); d != directiveNone {
+			result = d
+		}
+	}
+	return result
+}
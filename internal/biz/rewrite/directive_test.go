@@ -0,0 +1,194 @@
+package rewrite
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseDirective(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected directive
+		pkgScope bool
+	}{
+		{"//goanalysis:notrace", directiveNoTrace, false},
+		{"//goanalysis:trace", directiveTrace, false},
+		{"//goanalysis:notrace package", directiveNoTrace, true},
+		{"//goanalysis:notrace hot path, too noisy", directiveNoTrace, false},
+		{"// goanalysis:notrace", directiveNone, false},
+		{"//goanalysis:unknown", directiveNone, false},
+		{"// 普通注释", directiveNone, false},
+	}
+	for _, tt := range tests {
+		d, pkgScope := parseDirective(tt.text)
+		if d != tt.expected || pkgScope != tt.pkgScope {
+			t.Errorf("parseDirective(%q) = (%v, %v), want (%v, %v)", tt.text, d, pkgScope, tt.expected, tt.pkgScope)
+		}
+	}
+}
+
+// instrumentedFuncs 重写文件并返回各函数是否被插桩
+func instrumentedFuncs(t *testing.T, path string, opts ...RewriteOption) map[string]bool {
+	t.Helper()
+	r, err := NewRewrite(path, opts...)
+	if err != nil {
+		t.Fatalf("NewRewrite failed: %v", err)
+	}
+	r.RewriteFile()
+
+	r, err = NewRewrite(path)
+	if err != nil {
+		t.Fatalf("NewRewrite failed: %v", err)
+	}
+	result := make(map[string]bool)
+	for _, decl := range r.f.Decls {
+		if fd, ok := decl.(*ast.FuncDecl); ok {
+			result[fd.Name.Name] = r.HasSameDefer(fd)
+		}
+	}
+	return result
+}
+
+func TestRewriteFile_FuncDirective(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+	content := `package main
+
+//goanalysis:notrace
+func hot() {
+	println("hot")
+}
+
+// String 默认不插桩, 通过指令强制插桩
+//
+//goanalysis:trace
+func (r *Receiver) String() string {
+	return "receiver"
+}
+
+//goanalysis:trace
+func debugDump() {
+	println("dump")
+}
+
+func normal() {
+	println("normal")
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	filter, err := NewFilter(&FilterConfig{ExcludeFuncs: []string{"^debug"}})
+	if err != nil {
+		t.Fatalf("NewFilter failed: %v", err)
+	}
+	got := instrumentedFuncs(t, testFile, WithFilter(filter))
+	expected := map[string]bool{"hot": false, "String": true, "debugDump": true, "normal": true}
+	for name, want := range expected {
+		if got[name] != want {
+			t.Errorf("func %s instrumented = %v, want %v", name, got[name], want)
+		}
+	}
+	if filter.Summary()[SkipReasonDirective] != 1 {
+		t.Errorf("Expected 1 directive skip, got %d", filter.Summary()[SkipReasonDirective])
+	}
+}
+
+func TestRewriteFile_FileDirective(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+	content := `//goanalysis:notrace
+
+package main
+
+func skipped() {
+	println("skipped")
+}
+
+//goanalysis:trace
+func forced() {
+	println("forced")
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	got := instrumentedFuncs(t, testFile)
+	if got["skipped"] {
+		t.Error("skipped should not be instrumented in a notrace file")
+	}
+	if !got["forced"] {
+		t.Error("forced should be instrumented despite file level notrace")
+	}
+}
+
+func TestRewriteDir_PackageDirective(t *testing.T) {
+	tmpDir := t.TempDir()
+	pkgDir := filepath.Join(tmpDir, "pkg")
+	if err := os.Mkdir(pkgDir, 0755); err != nil {
+		t.Fatalf("Failed to create pkg dir: %v", err)
+	}
+	files := map[string]string{
+		filepath.Join(pkgDir, "doc.go"): `// Package pkg 不需要插桩
+//
+//goanalysis:notrace package
+package pkg
+`,
+		filepath.Join(pkgDir, "a.go"): `package pkg
+
+func A() {
+	println("a")
+}
+`,
+		filepath.Join(pkgDir, "b.go"): `//goanalysis:trace
+
+package pkg
+
+func B() {
+	println("b")
+}
+`,
+		filepath.Join(tmpDir, "main.go"): `package main
+
+func main() {
+	println("main")
+}
+`,
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create file %s: %v", path, err)
+		}
+	}
+
+	RewriteDir(tmpDir)
+
+	expected := map[string]bool{
+		filepath.Join(pkgDir, "a.go"):    false,
+		filepath.Join(pkgDir, "b.go"):    true, // 文件级指令优先于包级指令
+		filepath.Join(tmpDir, "main.go"): true,
+	}
+	for path, want := range expected {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		r, err := NewRewrite(path)
+		if err != nil {
+			t.Fatalf("NewRewrite failed: %v", err)
+		}
+		got := false
+		for _, decl := range r.f.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && r.HasSameDefer(fd) {
+				got = true
+			}
+		}
+		if got != want {
+			t.Errorf("%s instrumented = %v, want %v:\n%s", path, got, want, content)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package rewrite
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Stats 插桩数量统计
+type Stats struct {
+	Funcs      int `json:"funcs"`      // 函数和方法声明
+	Closures   int `json:"closures"`   // go语句之外的函数字面量
+	Goroutines int `json:"goroutines"` // go语句直接启动的函数字面量
+}
+
+// Add 累加统计
+func (s *Stats) Add(other Stats) {
+	s.Funcs += other.Funcs
+	s.Closures += other.Closures
+	s.Goroutines += other.Goroutines
+}
+
+// Total 插桩总数
+func (s Stats) Total() int {
+	return s.Funcs + s.Closures + s.Goroutines
+}
+
+// FileDiff 单个文件的预览结果
+type FileDiff struct {
+	Path  string `json:"path"`
+	Diff  string `json:"diff"` // unified diff
+	Stats Stats  `json:"stats"`
+}
+
+// DryRun
+//
+//	@Description: 预览模式, 只收集每个文件的unified diff和插桩统计, 不写入磁盘
+type DryRun struct {
+	mu    sync.Mutex
+	files []*FileDiff
+}
+
+// NewDryRun 创建预览结果收集器
+func NewDryRun() *DryRun {
+	return &DryRun{}
+}
+
+// record 对比源文件与插桩结果并记录diff
+func (d *DryRun) record(fullPath string, content []byte, stats Stats) error {
+	// 新生成的文件与空文件对比
+	original, err := os.ReadFile(fullPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	diff := unifiedDiff("a"+fullPath, "b"+fullPath, string(original), string(content))
+	if diff == "" {
+		return nil
+	}
+	d.mu.Lock()
+	d.files = append(d.files, &FileDiff{Path: fullPath, Diff: diff, Stats: stats})
+	d.mu.Unlock()
+	return nil
+}
+
+// Files 返回按路径排序的文件预览结果
+func (d *DryRun) Files() []*FileDiff {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	files := make([]*FileDiff, len(d.files))
+	copy(files, d.files)
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
+
+// Diff 返回所有文件合并后的unified diff
+func (d *DryRun) Diff() string {
+	var sb strings.Builder
+	for _, file := range d.Files() {
+		sb.WriteString(file.Diff)
+	}
+	return sb.String()
+}
+
+// Stats 返回所有文件的插桩统计
+func (d *DryRun) Stats() Stats {
+	var total Stats
+	for _, file := range d.Files() {
+		total.Add(file.Stats)
+	}
+	return total
+}
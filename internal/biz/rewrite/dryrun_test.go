@@ -0,0 +1,92 @@
+package rewrite
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	oldText := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	newText := "a\nb\nX\nc\nd\ne\nf\ng\nh\ni\nY\n"
+
+	diff := unifiedDiff("a/f.go", "b/f.go", oldText, newText)
+	expected := `--- a/f.go
++++ b/f.go
+@@ -1,5 +1,6 @@
+ a
+ b
++X
+ c
+ d
+ e
+@@ -7,4 +8,4 @@
+ g
+ h
+ i
+-j
++Y
+`
+	if diff != expected {
+		t.Errorf("Unexpected diff:\n%s\nwant:\n%s", diff, expected)
+	}
+
+	if unifiedDiff("a", "b", oldText, oldText) != "" {
+		t.Error("Identical text should produce empty diff")
+	}
+}
+
+func TestRewriteDir_DryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainFile := filepath.Join(tmpDir, "main.go")
+	content := `package main
+
+func main() {
+	go func() {
+		println("goroutine")
+	}()
+	run(func() {
+		println("closure")
+	})
+}
+
+func (s *Server) run(f func()) {
+	f()
+}
+`
+	if err := os.WriteFile(mainFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create main.go: %v", err)
+	}
+
+	dryRun := NewDryRun()
+	RewriteDir(tmpDir, WithDryRun(dryRun))
+
+	unchanged, err := os.ReadFile(mainFile)
+	if err != nil {
+		t.Fatalf("Failed to read main.go: %v", err)
+	}
+	if string(unchanged) != content {
+		t.Error("Dry run should not modify files")
+	}
+
+	files := dryRun.Files()
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 file diff, got %d", len(files))
+	}
+	diff := files[0].Diff
+	if !strings.Contains(diff, "+\tdefer functrace.Trace([]interface{}{})()") {
+		t.Errorf("Diff should contain added defer:\n%s", diff)
+	}
+	if !strings.Contains(diff, `+import "github.com/toheart/functrace"`) {
+		t.Errorf("Diff should contain added import:\n%s", diff)
+	}
+
+	stats := dryRun.Stats()
+	if stats.Funcs != 2 || stats.Closures != 1 || stats.Goroutines != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if stats.Total() != 4 {
+		t.Errorf("Expected 4 instrumented functions, got %d", stats.Total())
+	}
+}
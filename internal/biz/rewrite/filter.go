@@ -0,0 +1,258 @@
+package rewrite
+
+import (
+	"fmt"
+	"go/ast"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 跳过插桩的原因
+const (
+	SkipReasonPackage   = "package"
+	SkipReasonFile      = "file"
+	SkipReasonFunc      = "func"
+	SkipReasonReceiver  = "receiver"
+	SkipReasonDirective = "directive"
+)
+
+// FilterConfig 插桩过滤规则, 同一维度中exclude优先于include, include为空表示不限制
+type FilterConfig struct {
+	IncludePkgs  []string // 包路径glob, 支持 * 和 ..., 如 github.com/foo/bar/internal/...
+	ExcludePkgs  []string
+	IncludeFiles []string // 文件glob, 相对于模块根目录, 支持 **; 不含/时匹配文件名
+	ExcludeFiles []string
+	IncludeFuncs []string // 函数名正则, 同时匹配 Name 和 Recv.Name
+	ExcludeFuncs []string
+	IncludeRecvs []string // 接收器类型名, 如 Server 或 *Server
+	ExcludeRecvs []string
+}
+
+// SkipRecord 被跳过的文件或函数
+type SkipRecord struct {
+	File   string `json:"file"`
+	Func   string `json:"func,omitempty"`
+	Reason string `json:"reason"`
+	Detail string `json:"detail"`
+}
+
+// Filter
+//
+//	@Description: 按包路径、文件、函数名和接收器类型过滤插桩目标, 并记录跳过原因
+type Filter struct {
+	cfg          *FilterConfig
+	includeFuncs []*regexp.Regexp
+	excludeFuncs []*regexp.Regexp
+
+	modules *moduleCache
+	mu      sync.Mutex
+	skipped []*SkipRecord
+}
+
+// NewFilter 创建过滤器, 函数名正则非法时返回错误
+func NewFilter(cfg *FilterConfig) (*Filter, error) {
+	if cfg == nil {
+		cfg = &FilterConfig{}
+	}
+	f := &Filter{
+		cfg:     cfg,
+		modules: newModuleCache(),
+	}
+	var err error
+	if f.includeFuncs, err = compileRegexps(cfg.IncludeFuncs); err != nil {
+		return nil, err
+	}
+	if f.excludeFuncs, err = compileRegexps(cfg.ExcludeFuncs); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Skipped 返回按文件和函数排序的跳过记录
+func (f *Filter) Skipped() []*SkipRecord {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	records := make([]*SkipRecord, len(f.skipped))
+	copy(records, f.skipped)
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].File != records[j].File {
+			return records[i].File < records[j].File
+		}
+		return records[i].Func < records[j].Func
+	})
+	return records
+}
+
+// Summary 按原因统计跳过数量
+func (f *Filter) Summary() map[string]int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	summary := make(map[string]int)
+	for _, record := range f.skipped {
+		summary[record.Reason]++
+	}
+	return summary
+}
+
+// AllowFile 判断文件是否需要插桩, 不需要时记录原因
+func (f *Filter) AllowFile(fullPath string) bool {
+	mod := f.modules.moduleOf(filepath.Dir(fullPath))
+
+	pkgPath := mod.pkgPath(filepath.Dir(fullPath))
+	if ok, detail := matchRule(pkgPath, f.cfg.IncludePkgs, f.cfg.ExcludePkgs, matchPkgGlob); !ok {
+		f.record(&SkipRecord{File: fullPath, Reason: SkipReasonPackage, Detail: fmt.Sprintf("package %s %s", pkgPath, detail)})
+		return false
+	}
+
+	relPath := filepath.ToSlash(fullPath)
+	if rel, err := filepath.Rel(mod.root, fullPath); err == nil && mod.root != "" {
+		relPath = filepath.ToSlash(rel)
+	}
+	if ok, detail := matchRule(relPath, f.cfg.IncludeFiles, f.cfg.ExcludeFiles, matchFileGlob); !ok {
+		f.record(&SkipRecord{File: fullPath, Reason: SkipReasonFile, Detail: fmt.Sprintf("file %s %s", relPath, detail)})
+		return false
+	}
+	return true
+}
+
+// AllowFunc 判断函数声明是否需要插桩, 不需要时记录原因
+func (f *Filter) AllowFunc(fullPath string, decl *ast.FuncDecl) bool {
+	name := decl.Name.Name
+	recv := recvTypeName(decl.Recv)
+	qualified := name
+	if recv != "" {
+		qualified = recv + "." + name
+		if ok, detail := matchRule(recv, f.cfg.IncludeRecvs, f.cfg.ExcludeRecvs, matchRecv); !ok {
+			f.record(&SkipRecord{File: fullPath, Func: qualified, Reason: SkipReasonReceiver, Detail: fmt.Sprintf("receiver %s %s", recv, detail)})
+			return false
+		}
+	} else if len(f.cfg.IncludeRecvs) > 0 {
+		// 指定了接收器时, 普通函数不插桩
+		f.record(&SkipRecord{File: fullPath, Func: qualified, Reason: SkipReasonReceiver, Detail: "not a method of included receivers"})
+		return false
+	}
+
+	for _, re := range f.excludeFuncs {
+		if re.MatchString(name) || re.MatchString(qualified) {
+			f.record(&SkipRecord{File: fullPath, Func: qualified, Reason: SkipReasonFunc, Detail: fmt.Sprintf("excluded by %q", re.String())})
+			return false
+		}
+	}
+	if len(f.includeFuncs) == 0 {
+		return true
+	}
+	for _, re := range f.includeFuncs {
+		if re.MatchString(name) || re.MatchString(qualified) {
+			return true
+		}
+	}
+	f.record(&SkipRecord{File: fullPath, Func: qualified, Reason: SkipReasonFunc, Detail: "not matched by include patterns"})
+	return false
+}
+
+func (f *Filter) record(record *SkipRecord) {
+	f.mu.Lock()
+	f.skipped = append(f.skipped, record)
+	f.mu.Unlock()
+}
+
+// matchRule 依次检查exclude和include规则, 返回是否通过以及未通过的原因
+func matchRule(target string, includes, excludes []string, match func(pattern, target string) bool) (bool, string) {
+	for _, pattern := range excludes {
+		if match(pattern, target) {
+			return false, fmt.Sprintf("excluded by %q", pattern)
+		}
+	}
+	if len(includes) == 0 {
+		return true, ""
+	}
+	for _, pattern := range includes {
+		if match(pattern, target) {
+			return true, ""
+		}
+	}
+	return false, "not matched by include patterns"
+}
+
+// matchPkgGlob 匹配包路径, ... 等价于 **
+func matchPkgGlob(pattern, pkgPath string) bool {
+	return matchSegments(strings.Split(strings.ReplaceAll(pattern, "...", "**"), "/"), strings.Split(pkgPath, "/"))
+}
+
+// matchFileGlob 匹配文件路径, 不含/的模式只匹配文件名
+func matchFileGlob(pattern, relPath string) bool {
+	pattern = filepath.ToSlash(pattern)
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(relPath))
+		return ok
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(relPath, "/"))
+}
+
+// matchSegments 逐段匹配路径, ** 匹配零个或多个路径段
+func matchSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], name[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], name[1:])
+}
+
+// matchRecv 匹配接收器类型名, 忽略指针标记
+func matchRecv(pattern, recv string) bool {
+	ok, _ := path.Match(strings.TrimPrefix(pattern, "*"), recv)
+	return ok
+}
+
+// recvTypeName 返回接收器的类型名, 去掉指针和类型参数
+func recvTypeName(recv *ast.FieldList) string {
+	if recv == nil || len(recv.List) == 0 {
+		return ""
+	}
+	expr := recv.List[0].Type
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.ParenExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.IndexListExpr:
+			expr = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+func compileRegexps(patterns []string) ([]*regexp.Regexp, error) {
+	var res []*regexp.Regexp
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid func pattern %q: %w", pattern, err)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
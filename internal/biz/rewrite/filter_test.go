@@ -0,0 +1,156 @@
+package rewrite
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMatchPkgGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		pkgPath  string
+		expected bool
+	}{
+		{"example.com/app/internal/...", "example.com/app/internal/biz", true},
+		{"example.com/app/internal/...", "example.com/app/internal", true},
+		{"example.com/app/internal/...", "example.com/app/cmd", false},
+		{"example.com/app/*/biz", "example.com/app/internal/biz", true},
+		{"example.com/app/*", "example.com/app/internal/biz", false},
+	}
+	for _, tt := range tests {
+		if got := matchPkgGlob(tt.pattern, tt.pkgPath); got != tt.expected {
+			t.Errorf("matchPkgGlob(%q, %q) = %v, want %v", tt.pattern, tt.pkgPath, got, tt.expected)
+		}
+	}
+}
+
+func TestMatchFileGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		relPath  string
+		expected bool
+	}{
+		{"*.pb.go", "api/v1/service.pb.go", true},
+		{"*.pb.go", "api/v1/service.go", false},
+		{"internal/**/*_gen.go", "internal/biz/model_gen.go", true},
+		{"internal/**/*_gen.go", "internal/model_gen.go", true},
+		{"internal/*.go", "internal/biz/model.go", false},
+	}
+	for _, tt := range tests {
+		if got := matchFileGlob(tt.pattern, tt.relPath); got != tt.expected {
+			t.Errorf("matchFileGlob(%q, %q) = %v, want %v", tt.pattern, tt.relPath, got, tt.expected)
+		}
+	}
+}
+
+func TestNewFilter_InvalidRegexp(t *testing.T) {
+	if _, err := NewFilter(&FilterConfig{ExcludeFuncs: []string{"("}}); err == nil {
+		t.Error("Expected error for invalid func pattern")
+	}
+}
+
+func TestRewriteDir_Filter(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		filepath.Join(tmpDir, "go.mod"): "module example.com/app\n",
+		filepath.Join(tmpDir, "main.go"): `package main
+
+func main() {
+	go func() {
+		println("closure")
+	}()
+}
+
+func debugDump() {
+	println("dump")
+}
+
+func (s *Server) Handle() {
+	println("handle")
+}
+
+func (c Cache) Get() {
+	println("get")
+}
+`,
+		filepath.Join(tmpDir, "gen", "model_gen.go"): `package gen
+
+func Generated() {
+	println("generated")
+}
+`,
+		filepath.Join(tmpDir, "internal", "biz", "biz.go"): `package biz
+
+func Biz() {
+	println("biz")
+}
+`,
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create file %s: %v", path, err)
+		}
+	}
+
+	filter, err := NewFilter(&FilterConfig{
+		ExcludePkgs:  []string{"example.com/app/internal/..."},
+		ExcludeFiles: []string{"*_gen.go"},
+		ExcludeFuncs: []string{"^debug"},
+		ExcludeRecvs: []string{"Cache"},
+	})
+	if err != nil {
+		t.Fatalf("NewFilter failed: %v", err)
+	}
+	RewriteDir(tmpDir, WithFilter(filter))
+
+	mainContent, err := os.ReadFile(filepath.Join(tmpDir, "main.go"))
+	if err != nil {
+		t.Fatalf("Failed to read main.go: %v", err)
+	}
+	r, err := NewRewrite(filepath.Join(tmpDir, "main.go"))
+	if err != nil {
+		t.Fatalf("NewRewrite failed: %v", err)
+	}
+	instrumented := make(map[string]bool)
+	for _, decl := range r.f.Decls {
+		if fd, ok := decl.(*ast.FuncDecl); ok {
+			instrumented[fd.Name.Name] = r.HasSameDefer(fd)
+		}
+	}
+	expected := map[string]bool{"main": true, "debugDump": false, "Handle": true, "Get": false}
+	for name, want := range expected {
+		if instrumented[name] != want {
+			t.Errorf("func %s instrumented = %v, want %v\n%s", name, instrumented[name], want, mainContent)
+		}
+	}
+	// 闭包跟随外层函数插桩
+	if strings.Count(string(mainContent), "defer functrace.Trace") != 3 {
+		t.Errorf("Expected 3 trace defers in main.go, got:\n%s", mainContent)
+	}
+
+	for _, name := range []string{filepath.Join("gen", "model_gen.go"), filepath.Join("internal", "biz", "biz.go")} {
+		content, err := os.ReadFile(filepath.Join(tmpDir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if strings.Contains(string(content), "defer functrace.Trace") {
+			t.Errorf("%s should not be instrumented", name)
+		}
+	}
+
+	summary := filter.Summary()
+	for reason, count := range map[string]int{SkipReasonPackage: 1, SkipReasonFile: 1, SkipReasonFunc: 1, SkipReasonReceiver: 1} {
+		if summary[reason] != count {
+			t.Errorf("Expected %d skipped by %s, got %d", count, reason, summary[reason])
+		}
+	}
+	if len(filter.Skipped()) != 4 {
+		t.Errorf("Expected 4 skip records, got %d", len(filter.Skipped()))
+	}
+}
@@ -0,0 +1,62 @@
+package rewrite
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+)
+
+// FuncID 函数标识, 与静态调用图中FuncNode的Pkg和Name一致
+//
+//	Name 使用ssa的RelString格式: 普通函数为 F, 方法为 T.M 或 (*T).M,
+//	闭包按出现顺序编号为 F$1, F$1$1
+type FuncID struct {
+	Pkg  string `json:"pkg"`
+	Name string `json:"name"`
+}
+
+// String 返回完整函数名, 与ssa.Function.String()一致
+func (id FuncID) String() string {
+	return id.Pkg + "." + id.Name
+}
+
+// funcDeclName 返回函数声明的ssa名称
+func funcDeclName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name
+	}
+	recv := decl.Recv.List[0].Type
+	if paren, ok := recv.(*ast.ParenExpr); ok {
+		recv = paren.X
+	}
+	if star, ok := recv.(*ast.StarExpr); ok {
+		return fmt.Sprintf("(*%s).%s", types.ExprString(star.X), decl.Name.Name)
+	}
+	return types.ExprString(recv) + "." + decl.Name.Name
+}
+
+// funcLitNames 按ssa的命名规则为函数声明中的所有闭包命名,
+// 同一层的闭包按源码顺序从1编号, 嵌套闭包在外层闭包名称后继续编号
+func funcLitNames(decl *ast.FuncDecl) map[*ast.FuncLit]string {
+	names := make(map[*ast.FuncLit]string)
+	if decl.Body == nil {
+		return names
+	}
+	nameFuncLits(decl.Body, funcDeclName(decl), names)
+	return names
+}
+
+func nameFuncLits(body ast.Node, parent string, names map[*ast.FuncLit]string) {
+	count := 0
+	ast.Inspect(body, func(n ast.Node) bool {
+		lit, ok := n.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		count++
+		name := fmt.Sprintf("%s$%d", parent, count)
+		names[lit] = name
+		nameFuncLits(lit.Body, name, names)
+		return false
+	})
+}
@@ -0,0 +1,210 @@
+package rewrite
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// changedLines 文件在新版本中的变更位置
+type changedLines struct {
+	lines map[int]bool // 新增或修改的行
+	gaps  []int        // 删除发生在该行之前
+}
+
+// touches 判断行范围[start, end]内是否有变更
+func (c *changedLines) touches(start, end int) bool {
+	for line := start; line <= end; line++ {
+		if c.lines[line] {
+			return true
+		}
+	}
+	for _, gap := range c.gaps {
+		// 删除位于首行之前或末行之后时不属于该范围
+		if gap > start && gap <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// diffChangedLines 计算新版本文本中的变更位置
+func diffChangedLines(oldText, newText string) *changedLines {
+	changed := &changedLines{lines: make(map[int]bool)}
+	newLine := 1
+	for _, op := range diffLines(splitLines(oldText), splitLines(newText)) {
+		switch op.kind {
+		case '+':
+			changed.lines[newLine] = true
+			newLine++
+		case '-':
+			changed.gaps = append(changed.gaps, newLine)
+		default:
+			newLine++
+		}
+	}
+	return changed
+}
+
+// DiffSelection
+//
+//	@Description: 根据两个git版本之间的差异生成插桩范围, 选中行范围有变更的函数声明和闭包
+//	@param dir 插桩目录, 需位于git仓库中, 只统计该目录下的文件
+//	@param fromRef 基准版本, 如 main、v1.2.0、HEAD~3
+//	@param toRef 目标版本, 为空时与工作区比较
+//	@return *Selection
+//	@return error
+func DiffSelection(dir, fromRef, toRef string) (*Selection, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	repository, err := git.PlainOpenWithOptions(absDir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("open git repository failed: %w", err)
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("get worktree failed: %w", err)
+	}
+	root := worktree.Filesystem.Root()
+
+	fromTree, err := resolveTree(repository, fromRef)
+	if err != nil {
+		return nil, err
+	}
+	var toTree *object.Tree
+	if toRef != "" {
+		if toTree, err = resolveTree(repository, toRef); err != nil {
+			return nil, err
+		}
+	}
+
+	paths, err := changedPaths(repository, worktree, fromTree, toTree)
+	if err != nil {
+		return nil, err
+	}
+
+	selection := NewSelection()
+	for _, name := range paths {
+		fullPath := filepath.Join(root, filepath.FromSlash(name))
+		if !strings.HasPrefix(fullPath, absDir+string(filepath.Separator)) {
+			continue
+		}
+		oldText, err := treeFileContent(fromTree, name)
+		if err != nil {
+			return nil, err
+		}
+		var newText string
+		if toTree != nil {
+			newText, err = treeFileContent(toTree, name)
+		} else {
+			var data []byte
+			data, err = os.ReadFile(fullPath)
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			newText = string(data)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if oldText == newText || newText == "" {
+			continue
+		}
+		if err = selection.addChangedFile(fullPath, oldText, newText); err != nil {
+			return nil, err
+		}
+	}
+	return selection, nil
+}
+
+// addChangedFile 解析新版本文件, 将与变更行相交的函数加入插桩范围
+func (s *Selection) addChangedFile(fullPath, oldText, newText string) error {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, fullPath, newText, parser.SkipObjectResolution)
+	if err != nil {
+		return fmt.Errorf("parse %s failed: %w", fullPath, err)
+	}
+	changed := diffChangedLines(oldText, newText)
+	s.addFile(fullPath, f, func(node ast.Node) bool {
+		return changed.touches(fset.Position(node.Pos()).Line, fset.Position(node.End()).Line)
+	})
+	return nil
+}
+
+// resolveTree 解析版本号对应的目录树
+func resolveTree(repository *git.Repository, ref string) (*object.Tree, error) {
+	hash, err := repository.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("resolve revision %s failed: %w", ref, err)
+	}
+	commit, err := repository.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("get commit %s failed: %w", ref, err)
+	}
+	return commit.Tree()
+}
+
+// changedPaths 返回两个版本之间有变更的go源文件(包括测试文件, 是否插桩由改写器的tests选项决定), toTree为空时比较基准版本与工作区
+func changedPaths(repository *git.Repository, worktree *git.Worktree, fromTree, toTree *object.Tree) ([]string, error) {
+	seen := make(map[string]bool)
+	var paths []string
+	add := func(name string) {
+		if name == "" || seen[name] || !strings.HasSuffix(name, ".go") {
+			return
+		}
+		seen[name] = true
+		paths = append(paths, name)
+	}
+
+	target := toTree
+	if target == nil {
+		// 工作区 = HEAD + 未提交的改动
+		head, err := repository.Head()
+		if err != nil {
+			return nil, fmt.Errorf("get HEAD failed: %w", err)
+		}
+		if target, err = resolveTree(repository, head.Hash().String()); err != nil {
+			return nil, err
+		}
+		status, err := worktree.Status()
+		if err != nil {
+			return nil, fmt.Errorf("get worktree status failed: %w", err)
+		}
+		for name, fileStatus := range status {
+			if fileStatus.Worktree != git.Unmodified || fileStatus.Staging != git.Unmodified {
+				add(name)
+			}
+		}
+	}
+	changes, err := object.DiffTree(fromTree, target)
+	if err != nil {
+		return nil, fmt.Errorf("diff tree failed: %w", err)
+	}
+	for _, change := range changes {
+		add(change.To.Name)
+	}
+	return paths, nil
+}
+
+// treeFileContent 读取目录树中的文件内容, 文件不存在时返回空字符串
+func treeFileContent(tree *object.Tree, name string) (string, error) {
+	file, err := tree.File(name)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read %s failed: %w", name, err)
+	}
+	return file.Contents()
+}
@@ -0,0 +1,250 @@
+package rewrite
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestFuncDeclName(t *testing.T) {
+	content := `package p
+
+func F() {
+	a := func() {
+		_ = func() {}
+	}
+	go func() {}()
+	_ = a
+}
+
+func (s Server) Value() {}
+
+func (s *Server) Ptr() {}
+
+func (l *List[T]) Push(v T) {}
+`
+	r := parseTestFile(t, content)
+	var names []string
+	litNames := make(map[string]bool)
+	for _, decl := range r.f.Decls {
+		if fd, ok := decl.(*ast.FuncDecl); ok {
+			names = append(names, funcDeclName(fd))
+			for _, name := range funcLitNames(fd) {
+				litNames[name] = true
+			}
+		}
+	}
+	expected := []string{"F", "Server.Value", "(*Server).Ptr", "(*List[T]).Push"}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("Expected %s, got %s", expected[i], names[i])
+		}
+	}
+	for _, name := range []string{"F$1", "F$1$1", "F$2"} {
+		if !litNames[name] {
+			t.Errorf("Missing closure name %s in %v", name, litNames)
+		}
+	}
+}
+
+func parseTestFile(t *testing.T, content string) *Rewrite {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.go")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	r, err := NewRewrite(path)
+	if err != nil {
+		t.Fatalf("NewRewrite failed: %v", err)
+	}
+	return r
+}
+
+// commitFiles 写入文件并提交
+func commitFiles(t *testing.T, repository *git.Repository, dir string, files map[string]string) {
+	t.Helper()
+	worktree, err := repository.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree failed: %v", err)
+	}
+	for name, content := range files {
+		if err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		if _, err = worktree.Add(name); err != nil {
+			t.Fatalf("Add %s failed: %v", name, err)
+		}
+	}
+	_, err = worktree.Commit("update", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+}
+
+const diffBase = `package main
+
+func main() {
+	run(func() {
+		println("closure")
+	})
+	unchanged()
+}
+
+func unchanged() {
+	println("unchanged")
+}
+
+func run(f func()) {
+	f()
+}
+
+func removed() {
+	println("a")
+	println("b")
+}
+`
+
+const diffHead = `package main
+
+func main() {
+	run(func() {
+		println("closure changed")
+	})
+	unchanged()
+}
+
+func unchanged() {
+	println("unchanged")
+}
+
+func run(f func()) {
+	f()
+}
+
+func removed() {
+	println("a")
+}
+`
+
+func TestDiffSelection(t *testing.T) {
+	dir := t.TempDir()
+	repository, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("PlainInit failed: %v", err)
+	}
+	commitFiles(t, repository, dir, map[string]string{
+		"go.mod":  "module example.com/demo\n",
+		"main.go": diffBase,
+	})
+	commitFiles(t, repository, dir, map[string]string{"main.go": diffHead})
+
+	selection, err := DiffSelection(dir, "HEAD~1", "HEAD")
+	if err != nil {
+		t.Fatalf("DiffSelection failed: %v", err)
+	}
+	expected := map[string]bool{"main": true, "main$1": true, "removed": true, "unchanged": false, "run": false}
+	for name, want := range expected {
+		if got := selection.Has(FuncID{Pkg: "example.com/demo", Name: name}); got != want {
+			t.Errorf("%s selected = %v, want %v", name, got, want)
+		}
+	}
+
+	// 未提交的改动与工作区比较
+	content := diffHead + "\nfunc added() {}\n"
+	if err = os.WriteFile(filepath.Join(dir, "main.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write main.go: %v", err)
+	}
+	selection, err = DiffSelection(dir, "HEAD", "")
+	if err != nil {
+		t.Fatalf("DiffSelection failed: %v", err)
+	}
+	if selection.Len() != 1 || !selection.Has(FuncID{Pkg: "example.com/demo", Name: "added"}) {
+		t.Errorf("Expected only added to be selected, got %v", selection.Funcs())
+	}
+}
+
+func TestDiffSelection_TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	repository, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("PlainInit failed: %v", err)
+	}
+	testBase := "package main\n\nimport \"testing\"\n\nfunc TestRun(t *testing.T) {\n\tprintln(\"a\")\n}\n"
+	commitFiles(t, repository, dir, map[string]string{
+		"go.mod":       "module example.com/demo\n",
+		"main.go":      diffHead,
+		"main_test.go": testBase,
+	})
+	commitFiles(t, repository, dir, map[string]string{"main_test.go": strings.Replace(testBase, `"a"`, `"b"`, 1)})
+
+	selection, err := DiffSelection(dir, "HEAD~1", "HEAD")
+	if err != nil {
+		t.Fatalf("DiffSelection failed: %v", err)
+	}
+	if selection.Len() != 1 || !selection.Has(FuncID{Pkg: "example.com/demo", Name: "TestRun"}) {
+		t.Fatalf("Expected only TestRun to be selected, got %v", selection.Funcs())
+	}
+
+	// 是否对测试文件插桩由tests选项决定
+	testPath := filepath.Join(dir, "main_test.go")
+	original, err := os.ReadFile(testPath)
+	if err != nil {
+		t.Fatalf("Failed to read main_test.go: %v", err)
+	}
+	if err = RewriteDir(dir, WithSelection(selection)).Err(); err != nil {
+		t.Fatalf("RewriteDir failed: %v", err)
+	}
+	if content, _ := os.ReadFile(testPath); string(content) != string(original) {
+		t.Errorf("Expected main_test.go to be skipped without tests, got:\n%s", content)
+	}
+	tracer, err := NewTracer(&Tracer{Flush: "CloseTraceInstance"})
+	if err != nil {
+		t.Fatalf("NewTracer failed: %v", err)
+	}
+	if err = RewriteDir(dir, WithSelection(selection), WithTests(), WithTracer(tracer)).Err(); err != nil {
+		t.Fatalf("RewriteDir failed: %v", err)
+	}
+	if content, _ := os.ReadFile(testPath); string(content) == string(original) {
+		t.Error("Expected main_test.go to be instrumented with tests")
+	}
+}
+
+func TestRewriteFile_Selection(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/demo\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	path := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(path, []byte(diffHead), 0644); err != nil {
+		t.Fatalf("Failed to write main.go: %v", err)
+	}
+
+	selection := NewSelection()
+	selection.Add(FuncID{Pkg: "example.com/demo", Name: "main$1"})
+	selection.Add(FuncID{Pkg: "example.com/demo", Name: "run"})
+	got := instrumentedFuncs(t, path, WithSelection(selection))
+	expected := map[string]bool{"main": false, "run": true, "unchanged": false, "removed": false}
+	for name, want := range expected {
+		if got[name] != want {
+			t.Errorf("func %s instrumented = %v, want %v", name, got[name], want)
+		}
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read main.go: %v", err)
+	}
+	if !strings.Contains(string(content), "run(func() {\n\t\tdefer functrace.Trace") {
+		t.Errorf("Closure main$1 should be instrumented:\n%s", content)
+	}
+}
@@ -0,0 +1,164 @@
+package rewrite
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+/**
+包级变量初始化表达式中的闭包, 如:
+
+	var h = func() {}
+	var s = S{F: func() {}}
+	var x = f(func() {})
+
+ssa把所有包级变量的初始化放在合成的init函数中, 闭包按初始化顺序在整个包内编号为 init$1, init$2...
+初始化顺序由变量之间的依赖决定(types.Info.InitOrder), 与单个文件的源码顺序不一定相同,
+因此需要对同一个包的所有文件做类型检查才能得到与静态调用图一致的名称.
+类型检查不解析导入的包, 导入相关的错误不影响包内变量之间的依赖关系.
+**/
+
+const _pkgInitFunc = "init"
+
+// initLitCache 按包缓存包级闭包的ssa名称, 由RewriteDir在改写任何文件之前预先计算
+type initLitCache struct {
+	mu   sync.Mutex
+	pkgs map[string]map[string]string // 包 -> "文件名:偏移" -> 闭包名称
+}
+
+func newInitLitCache() *initLitCache {
+	return &initLitCache{pkgs: make(map[string]map[string]string)}
+}
+
+// withInitLitCache 设置包级闭包名称缓存, 由RewriteDir共享
+func withInitLitCache(cache *initLitCache) RewriteOption {
+	return func(r *Rewrite) {
+		r.initLits = cache
+	}
+}
+
+// preload 计算files所在包的闭包名称, 必须在改写文件之前调用, 否则会读到已插桩的源码
+func (c *initLitCache) preload(files []string) {
+	for _, file := range files {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, file, nil, parser.PackageClauseOnly)
+		if err != nil {
+			continue
+		}
+		c.names(filepath.Dir(file), f.Name.Name, isTestFile(file))
+	}
+}
+
+// names 返回包中闭包的名称, test表示测试文件所在的包(内部测试包包含非测试文件)
+func (c *initLitCache) names(dir, pkgName string, test bool) map[string]string {
+	key := fmt.Sprintf("%s\x00%s\x00%v", dir, pkgName, test)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if names, ok := c.pkgs[key]; ok {
+		return names
+	}
+	names := scanInitLitNames(dir, pkgName, test)
+	c.pkgs[key] = names
+	return names
+}
+
+// initLitNames 返回当前文件包级变量初始化表达式中的闭包名称
+func (r *Rewrite) initLitNames() map[*ast.FuncLit]string {
+	dir := filepath.Dir(r.fullPath)
+	var pkgNames map[string]string
+	if r.initLits != nil {
+		pkgNames = r.initLits.names(dir, r.f.Name.Name, isTestFile(r.fullPath))
+	} else {
+		pkgNames = scanInitLitNames(dir, r.f.Name.Name, isTestFile(r.fullPath))
+	}
+	names := make(map[*ast.FuncLit]string)
+	base := filepath.Base(r.fullPath)
+	for _, decl := range r.f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		ast.Inspect(gen, func(n ast.Node) bool {
+			lit, ok := n.(*ast.FuncLit)
+			if !ok {
+				return true
+			}
+			if name, ok := pkgNames[initLitKey(base, r.fset.Position(lit.Pos()).Offset)]; ok {
+				names[lit] = name
+				nameFuncLits(lit.Body, name, names)
+			}
+			return false
+		})
+	}
+	return names
+}
+
+// scanInitLitNames 对目录下同一个包的文件做类型检查, 按初始化顺序为包级变量中的闭包编号
+func scanInitLitNames(dir, pkgName string, test bool) map[string]string {
+	names := make(map[string]string)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return names
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || (!test && isTestFile(name)) {
+			continue
+		}
+		if match, err := build.Default.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil || f.Name.Name != pkgName {
+			continue
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return names
+	}
+
+	info := &types.Info{}
+	conf := types.Config{
+		Importer:    importerFunc(func(path string) (*types.Package, error) { return nil, fmt.Errorf("skip import %s", path) }),
+		Error:       func(error) {},
+		FakeImportC: true,
+	}
+	_, _ = conf.Check(pkgName, fset, files, info)
+
+	count := 0
+	for _, init := range info.InitOrder {
+		ast.Inspect(init.Rhs, func(n ast.Node) bool {
+			lit, ok := n.(*ast.FuncLit)
+			if !ok {
+				return true
+			}
+			count++
+			pos := fset.Position(lit.Pos())
+			names[initLitKey(filepath.Base(pos.Filename), pos.Offset)] = fmt.Sprintf("%s$%d", _pkgInitFunc, count)
+			return false
+		})
+	}
+	return names
+}
+
+func initLitKey(file string, offset int) string {
+	return fmt.Sprintf("%s:%d", file, offset)
+}
+
+// importerFunc 函数形式的types.Importer
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
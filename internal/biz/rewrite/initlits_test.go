@@ -0,0 +1,159 @@
+package rewrite
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// X 依赖 b.go 中的 Y, 初始化顺序(H, Y, X, Z, _)与源码顺序不同
+var initLitFiles = map[string]string{
+	"a.go": `package p
+
+var X = apply(func() int {
+	return Y
+})
+
+var H = func() {
+	_ = func() {}
+}
+
+func apply(f func() int) int {
+	return f()
+}
+`,
+	"b.go": `package p
+
+type S struct {
+	F func()
+}
+
+var Y = apply(func() int { return 2 })
+
+var Z = S{F: func() {}}
+
+var _ = func() {}
+
+func run() {
+	_ = func() {}
+}
+`,
+}
+
+func TestInitLitNames(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range initLitFiles {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	// 期望的名称来自ssa: 闭包位置 -> 名称
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range []string{"a.go", "b.go"} {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", name, err)
+		}
+		files = append(files, f)
+	}
+	pkg, _, err := ssautil.BuildPackage(&types.Config{}, fset, types.NewPackage("example.com/p", "p"), files, ssa.BuilderMode(0))
+	if err != nil {
+		t.Fatalf("BuildPackage failed: %v", err)
+	}
+	expected := make(map[string]string)
+	var collect func(fn *ssa.Function)
+	collect = func(fn *ssa.Function) {
+		for _, anon := range fn.AnonFuncs {
+			pos := fset.Position(anon.Pos())
+			expected[initLitKey(filepath.Base(pos.Filename), pos.Offset)] = anon.Name()
+			collect(anon)
+		}
+	}
+	collect(pkg.Func("init"))
+	if len(expected) != 6 {
+		t.Fatalf("Expected 6 package level closures from ssa, got %v", expected)
+	}
+
+	got := make(map[string]string)
+	for _, name := range []string{"a.go", "b.go"} {
+		r, err := NewRewrite(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("NewRewrite failed: %v", err)
+		}
+		for lit, litName := range r.initLitNames() {
+			got[initLitKey(name, r.fset.Position(lit.Pos()).Offset)] = litName
+		}
+	}
+	if sortedPairs(got) != sortedPairs(expected) {
+		t.Errorf("Expected closure names %s, got %s", sortedPairs(expected), sortedPairs(got))
+	}
+}
+
+func TestRewriteDir_InitLits(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/p\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	for name, content := range initLitFiles {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	tracer, err := NewTracer(&Tracer{Shape: ShapeOTelSpan})
+	if err != nil {
+		t.Fatalf("NewTracer failed: %v", err)
+	}
+	report := RewriteDir(dir, WithTracer(tracer))
+	if err = report.Err(); err != nil {
+		t.Fatalf("RewriteDir failed: %v", err)
+	}
+
+	var spans []string
+	for _, name := range []string{"a.go", "b.go"} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		for _, line := range strings.Split(string(content), "\n") {
+			if i := strings.Index(line, `"example.com/p.`); i >= 0 {
+				spans = append(spans, strings.Trim(line[i:], `")`))
+			}
+		}
+	}
+	sort.Strings(spans)
+	expected := []string{
+		"example.com/p.apply",
+		"example.com/p.init$1",
+		"example.com/p.init$1$1",
+		"example.com/p.init$2",
+		"example.com/p.init$3",
+		"example.com/p.init$4",
+		"example.com/p.init$5",
+		"example.com/p.run",
+		"example.com/p.run$1",
+	}
+	if strings.Join(spans, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected spans %v, got %v", expected, spans)
+	}
+}
+
+func sortedPairs(m map[string]string) string {
+	var pairs []string
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
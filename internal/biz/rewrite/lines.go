@@ -0,0 +1,82 @@
+package rewrite
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+/**
+行号保持模式: 插入的defer语句会使其后的代码整体下移, 编译后panic堆栈、日志和runtime.Caller报告的行号与源码不一致.
+对比原始源码与插桩结果, 在每段新增代码之后写入 //line 指令, 使后续的原始代码映射回原来的行号:
+
+	func Open(name string) (*File, error) {
+		defer functrace.Trace([]interface{}{name})()
+	//line demo.go:5
+		f, err := open(name)
+
+指令使用文件名而不是路径, 编译器按当前文件所在目录解析, 原地改写和overlay模式下都指向原始源文件.
+被改写的行(如命名返回值的函数签名)紧接在保留行之后, 行号本身就是正确的, 只有新增行会使行号偏移.
+**/
+
+// _lineDirectivePattern 匹配插桩时写入的 //line 指令, 子匹配为文件名
+var _lineDirectivePattern = regexp.MustCompile(`^//line ([^:]+):\d+$`)
+
+// lineDirective 生成将下一行映射为原始文件第line行的指令
+func lineDirective(fullPath string, line int) string {
+	return fmt.Sprintf("//line %s:%d", filepath.Base(fullPath), line)
+}
+
+// addLineDirectives
+//
+//	@Description: 在插桩结果中新增代码之后写入 //line 指令, 使保留的原始代码行号不变
+//	@param fullPath 源文件路径
+//	@param src 原始源码
+//	@param out 插桩后的源码
+//	@return []byte 带有行号指令的源码
+func addLineDirectives(fullPath string, src, out []byte) []byte {
+	ops := diffLines(splitLines(string(src)), splitLines(string(out)))
+
+	var sb strings.Builder
+	// oldLine为下一条保留行在原始文件中的行号, mapped为下一行在编译器看来的行号
+	oldLine, mapped := 1, 1
+	for _, op := range ops {
+		switch op.kind {
+		case ' ':
+			if mapped != oldLine {
+				sb.WriteString(lineDirective(fullPath, oldLine))
+				sb.WriteByte('\n')
+				mapped = oldLine
+			}
+			oldLine++
+			mapped++
+		case '-':
+			oldLine++
+			continue
+		case '+':
+			mapped++
+		}
+		sb.WriteString(op.text)
+		sb.WriteByte('\n')
+	}
+	return []byte(sb.String())
+}
+
+// lineDirectiveSpans 定位插桩时写入的 //line 指令, 只匹配指向本文件的指令, 不影响代码生成器留下的指令
+func lineDirectiveSpans(tf *token.File, src []byte, f *ast.File, fullPath string) []span {
+	base := filepath.Base(fullPath)
+	var cuts []span
+	for _, group := range f.Comments {
+		for _, c := range group.List {
+			m := _lineDirectivePattern.FindStringSubmatch(c.Text)
+			if m == nil || m[1] != base {
+				continue
+			}
+			cuts = append(cuts, lineSpan(tf, src, c.Pos(), c.End()))
+		}
+	}
+	return cuts
+}
@@ -0,0 +1,138 @@
+package rewrite
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAddLineDirectives(t *testing.T) {
+	src := "package p\n\nfunc f() {\n\tg()\n}\n\nfunc h() (int, error) { return 0, nil }\n"
+	out := "package p\n\nimport \"x\"\n\nfunc f() {\n\tdefer x.T()()\n\tg()\n}\n\nfunc h() (_ int, _err error) {\n\tdefer x.T()()\n\treturn 0, nil\n}\n"
+	expected := "package p\n\nimport \"x\"\n\n//line demo.go:3\nfunc f() {\n\tdefer x.T()()\n//line demo.go:4\n\tg()\n}\n\n" +
+		"func h() (_ int, _err error) {\n\tdefer x.T()()\n\treturn 0, nil\n}\n"
+	if got := string(addLineDirectives("/tmp/demo.go", []byte(src), []byte(out))); got != expected {
+		t.Errorf("Unexpected line directives:\n%s", got)
+	}
+}
+
+const callerTracer = `package tr
+
+func Trace(params ...interface{}) func() { return func() {} }
+`
+
+const callerSource = `package main
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func line() int {
+	_, _, n, _ := runtime.Caller(1)
+	return n
+}
+
+func add(a, b int) (int, error) {
+	fmt.Println("add", line())
+	return a + b, nil
+}
+
+type counter struct{ n int }
+
+func (c *counter) inc() { fmt.Println("inc", line()) }
+
+func main() {
+	fmt.Println("main", line())
+	add(1, 2)
+	(&counter{}).inc()
+	func() {
+		fmt.Println("closure", line())
+	}()
+	defer func() {
+		r := recover()
+		_, _, n, _ := runtime.Caller(2)
+		fmt.Println("panic", n, r)
+	}()
+	var m map[string]int
+	m["x"] = 1
+}
+`
+
+// runCallerDemo 编译运行示例程序, 返回其输出的行号
+func runCallerDemo(t *testing.T, dir string) string {
+	cmd := exec.Command("go", "run", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod", "GOPROXY=off")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go run failed: %v\n%s", err, out)
+	}
+	return string(out)
+}
+
+func TestRewriteFile_LineDirectives(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go run in short mode")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":   "module example.com/demo\n\ngo 1.21\n",
+		"tr/tr.go": callerTracer,
+		"main.go":  callerSource,
+	})
+	expected := runCallerDemo(t, dir)
+
+	tracer, err := NewTracer(&Tracer{ImportPath: "example.com/demo/tr", Func: "Trace", Shape: ShapeDeferReturn, Params: ParamsVariadic})
+	if err != nil {
+		t.Fatalf("NewTracer failed: %v", err)
+	}
+	mainPath := filepath.Join(dir, "main.go")
+	rewriteMain := func(opts ...RewriteOption) {
+		if err := os.WriteFile(mainPath, []byte(callerSource), 0644); err != nil {
+			t.Fatalf("Failed to write main.go: %v", err)
+		}
+		r, err := NewRewrite(mainPath, append(opts, WithTracer(tracer))...)
+		if err != nil {
+			t.Fatalf("NewRewrite failed: %v", err)
+		}
+		if err := r.RewriteFile(); err != nil {
+			t.Fatalf("RewriteFile failed: %v", err)
+		}
+	}
+
+	// 不写入指令时行号发生偏移, 保证下面的对比有意义
+	rewriteMain()
+	if got := runCallerDemo(t, dir); got == expected {
+		t.Fatalf("Expected shifted lines without directives:\n%s", got)
+	}
+
+	rewriteMain(WithLineDirectives())
+	content, err := os.ReadFile(mainPath)
+	if err != nil {
+		t.Fatalf("Failed to read main.go: %v", err)
+	}
+	if !strings.Contains(string(content), "//line main.go:") {
+		t.Fatalf("Expected line directives in:\n%s", content)
+	}
+	if got := runCallerDemo(t, dir); got != expected {
+		t.Errorf("Expected original lines:\n%s\ngot:\n%s\nsource:\n%s", expected, got, content)
+	}
+
+	entry, err := UndoFile(mainPath, WithTracer(tracer))
+	if err != nil || entry == nil {
+		t.Fatalf("UndoFile failed: %v, %v", entry, err)
+	}
+	restored, err := os.ReadFile(mainPath)
+	if err != nil {
+		t.Fatalf("Failed to read main.go: %v", err)
+	}
+	if string(restored) != callerSource {
+		t.Errorf("Undo should restore the original file:\n%s", restored)
+	}
+}
@@ -0,0 +1,89 @@
+package rewrite
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// moduleInfo 文件所属模块的根目录和模块路径
+type moduleInfo struct {
+	root string
+	path string
+}
+
+// pkgPath 推导目录对应的包导入路径, 找不到go.mod时使用目录路径
+func (m moduleInfo) pkgPath(dir string) string {
+	if m.root == "" {
+		return filepath.ToSlash(dir)
+	}
+	rel, err := filepath.Rel(m.root, dir)
+	if err != nil || rel == "." {
+		return m.path
+	}
+	return m.path + "/" + filepath.ToSlash(rel)
+}
+
+// moduleCache 按目录缓存所属模块, 供过滤规则和函数标识推导包路径
+type moduleCache struct {
+	mu      sync.Mutex
+	modules map[string]moduleInfo // 目录 -> 所属模块
+}
+
+func newModuleCache() *moduleCache {
+	return &moduleCache{modules: make(map[string]moduleInfo)}
+}
+
+// withModuleCache 设置模块缓存, 由RewriteDir在遍历目录时共享
+func withModuleCache(cache *moduleCache) RewriteOption {
+	return func(r *Rewrite) {
+		r.modules = cache
+	}
+}
+
+// moduleOf 向上查找目录所属的go.mod
+func (c *moduleCache) moduleOf(dir string) moduleInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if mod, ok := c.modules[dir]; ok {
+		return mod
+	}
+	var mod moduleInfo
+	for cur := dir; ; {
+		if name, ok := readModulePath(filepath.Join(cur, "go.mod")); ok {
+			mod = moduleInfo{root: cur, path: name}
+			break
+		}
+		parent := filepath.Dir(cur)
+		if parent == cur {
+			break
+		}
+		cur = parent
+	}
+	c.modules[dir] = mod
+	return mod
+}
+
+// pkgPath 返回目录对应的包导入路径
+func (c *moduleCache) pkgPath(dir string) string {
+	return c.moduleOf(dir).pkgPath(dir)
+}
+
+// readModulePath 读取go.mod中的module声明
+func readModulePath(modPath string) (string, bool) {
+	file, err := os.Open(modPath)
+	if err != nil {
+		return "", false
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,207 @@
+package rewrite
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"strconv"
+)
+
+/**
+OpenTelemetry span模式, 为函数插入:
+
+	ctx, span := otel.Tracer("github.com/foo/bar").Start(ctx, "github.com/foo/bar.(*Server).Handle")
+	defer span.End()
+
+带有context.Context参数的函数以该参数为父context并将span context重新绑定到该参数,
+其余函数使用Fallback表达式(默认context.Background())作为父context.
+**/
+
+const (
+	_otelImport      = "go.opentelemetry.io/otel"
+	_contextImport   = "context"
+	_defaultFallback = "context.Background()"
+
+	// FallbackSkip 没有context参数的函数不插桩
+	FallbackSkip = "skip"
+)
+
+// OTelTracer 返回使用otel全局TracerProvider的span模板
+func OTelTracer() *Tracer {
+	return &Tracer{
+		ImportPath:     _otelImport,
+		Alias:          "otel",
+		Func:           "Tracer",
+		Shape:          ShapeOTelSpan,
+		Params:         ParamsNone,
+		Fallback:       _defaultFallback,
+		FallbackImport: _contextImport,
+	}
+}
+
+// newOTelTracer 补全otel-span模板的默认值
+func newOTelTracer(t *Tracer) (*Tracer, error) {
+	tracer := *OTelTracer()
+	if t.ImportPath != "" {
+		tracer.ImportPath = t.ImportPath
+		tracer.Alias = path.Base(t.ImportPath)
+	}
+	if t.Alias != "" {
+		tracer.Alias = t.Alias
+	}
+	if t.Func != "" {
+		tracer.Func = t.Func
+	}
+	if t.Fallback != "" {
+		tracer.Fallback = t.Fallback
+		tracer.FallbackImport = t.FallbackImport
+	}
+	if !token.IsIdentifier(tracer.Alias) || tracer.Alias == "_" {
+		return nil, fmt.Errorf("invalid tracer alias %q", tracer.Alias)
+	}
+	if !token.IsIdentifier(tracer.Func) || !token.IsExported(tracer.Func) {
+		return nil, fmt.Errorf("invalid tracer func %q, must be an exported identifier", tracer.Func)
+	}
+	if tracer.Fallback != FallbackSkip {
+		if _, err := parser.ParseExpr(tracer.Fallback); err != nil {
+			return nil, fmt.Errorf("invalid tracer fallback %q: %w", tracer.Fallback, err)
+		}
+	}
+	return &tracer, nil
+}
+
+// contextParam 返回函数中第一个context.Context参数的名称, ctxName为文件中context包的引用名
+func contextParam(funcType *ast.FuncType, ctxName string) (string, bool) {
+	if funcType.Params == nil {
+		return "", false
+	}
+	for _, field := range funcType.Params.List {
+		se, ok := field.Type.(*ast.SelectorExpr)
+		if !ok || se.Sel.Name != "Context" {
+			continue
+		}
+		if x, ok := se.X.(*ast.Ident); !ok || x.Name != ctxName {
+			continue
+		}
+		// 未命名的context参数无法引用, 按没有context处理
+		if len(field.Names) == 0 || field.Names[0].Name == "_" {
+			return "", false
+		}
+		return field.Names[0].Name, true
+	}
+	return "", false
+}
+
+// importName 返回文件中导入路径对应的引用名, 未导入时返回路径最后一段
+func importName(f *ast.File, importPath string) string {
+	for _, spec := range f.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || p != importPath {
+			continue
+		}
+		if spec.Name != nil && spec.Name.Name != "_" && spec.Name.Name != "." {
+			return spec.Name.Name
+		}
+	}
+	return path.Base(importPath)
+}
+
+// spanVarName 选择函数中未被使用的span变量名
+func spanVarName(nodes ...ast.Node) string {
+	return freeName("span", nodes...)
+}
+
+// genSpan 生成开始span和结束span的两条语句
+//
+//	name 文件中otel包的引用名, scope 为Tracer名称, spanName 为span名称,
+//	ctxVar 为空时使用Fallback表达式并丢弃返回的context
+func (t *Tracer) genSpan(name, scope, spanName, ctxVar, spanVar string) ([]ast.Stmt, error) {
+	var parent ast.Expr
+	ctxLhs := ast.NewIdent("_")
+	if ctxVar != "" {
+		parent = ast.NewIdent(ctxVar)
+		ctxLhs = ast.NewIdent(ctxVar)
+	} else {
+		expr, err := parser.ParseExpr(t.Fallback)
+		if err != nil {
+			return nil, err
+		}
+		parent = expr
+	}
+	start := &ast.AssignStmt{
+		Lhs: []ast.Expr{ctxLhs, ast.NewIdent(spanVar)},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X: &ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent(name),
+						Sel: ast.NewIdent(t.Func),
+					},
+					Args: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(scope)}},
+				},
+				Sel: ast.NewIdent("Start"),
+			},
+			Args: []ast.Expr{parent, &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(spanName)}},
+		}},
+	}
+	end := &ast.DeferStmt{
+		Call: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent(spanVar),
+				Sel: ast.NewIdent("End"),
+			},
+		},
+	}
+	return []ast.Stmt{start, end}, nil
+}
+
+// isSpanStart 判断语句是否为 _, span := otel.Tracer(...).Start(...) 形式
+func (t *Tracer) isSpanStart(stmt ast.Stmt, name string) bool {
+	as, ok := stmt.(*ast.AssignStmt)
+	if !ok || as.Tok != token.DEFINE || len(as.Lhs) != 2 || len(as.Rhs) != 1 {
+		return false
+	}
+	call, ok := as.Rhs[0].(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	se, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || se.Sel.Name != "Start" {
+		return false
+	}
+	inner, ok := se.X.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	tracerSel, ok := inner.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	x, ok := tracerSel.X.(*ast.Ident)
+	return ok && x.Name == name && tracerSel.Sel.Name == t.Func
+}
+
+// isSpanEnd 判断语句是否为结束start所创建span的 defer span.End()
+func isSpanEnd(start, stmt ast.Stmt) bool {
+	as, ok := start.(*ast.AssignStmt)
+	if !ok || len(as.Lhs) != 2 {
+		return false
+	}
+	spanVar, ok := as.Lhs[1].(*ast.Ident)
+	if !ok {
+		return false
+	}
+	ds, ok := stmt.(*ast.DeferStmt)
+	if !ok || len(ds.Call.Args) != 0 {
+		return false
+	}
+	se, ok := ds.Call.Fun.(*ast.SelectorExpr)
+	if !ok || se.Sel.Name != "End" {
+		return false
+	}
+	x, ok := se.X.(*ast.Ident)
+	return ok && x.Name == spanVar.Name
+}
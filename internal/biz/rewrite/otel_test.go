@@ -0,0 +1,165 @@
+package rewrite
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const otelSource = `package demo
+
+import (
+	"context"
+)
+
+// Handle 处理请求
+func (s *Server) Handle(ctx context.Context, req string) error {
+	run(func() {
+		println(req)
+	})
+	return nil
+}
+
+func helper(span int) {
+	println(span)
+}
+
+func run(f func()) {
+	f()
+}
+`
+
+func TestRewriteFile_OTelSpan(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/demo\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	path := filepath.Join(dir, "demo.go")
+	if err := os.WriteFile(path, []byte(otelSource), 0644); err != nil {
+		t.Fatalf("Failed to write demo.go: %v", err)
+	}
+
+	tracer, err := NewTracer(&Tracer{Shape: ShapeOTelSpan})
+	if err != nil {
+		t.Fatalf("NewTracer failed: %v", err)
+	}
+	r, err := NewRewrite(path, WithTracer(tracer))
+	if err != nil {
+		t.Fatalf("NewRewrite failed: %v", err)
+	}
+	r.RewriteFile()
+	if stats := r.Stats(); stats.Funcs != 3 || stats.Closures != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read demo.go: %v", err)
+	}
+	expected := []string{
+		`"go.opentelemetry.io/otel"`,
+		`ctx, span := otel.Tracer("example.com/demo").Start(ctx, "example.com/demo.(*Server).Handle")`,
+		`_, span := otel.Tracer("example.com/demo").Start(context.Background(), "example.com/demo.(*Server).Handle$1")`,
+		`_, span2 := otel.Tracer("example.com/demo").Start(context.Background(), "example.com/demo.helper")`,
+		"defer span2.End()",
+	}
+	for _, want := range expected {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Expected %q in:\n%s", want, content)
+		}
+	}
+	if strings.Count(string(content), "defer span.End()") != 3 {
+		t.Errorf("Expected 3 span.End defers:\n%s", content)
+	}
+
+	// 再次插桩不应重复插入
+	r, err = NewRewrite(path, WithTracer(tracer))
+	if err != nil {
+		t.Fatalf("NewRewrite failed: %v", err)
+	}
+	r.RewriteFile()
+	if r.Stats().Total() != 0 {
+		t.Errorf("Rewrite should be idempotent, got %+v", r.Stats())
+	}
+
+	entry, err := UndoFile(path, WithTracer(tracer))
+	if err != nil || entry == nil {
+		t.Fatalf("UndoFile failed: %v, %v", entry, err)
+	}
+	if entry.DefersRemoved != 4 || !entry.ImportRemoved {
+		t.Errorf("Unexpected undo entry: %+v", entry)
+	}
+	restored, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read demo.go: %v", err)
+	}
+	if string(restored) != otelSource {
+		t.Errorf("Undo should restore the original file:\n%s", restored)
+	}
+}
+
+func TestRewriteFile_OTelFallbackSkip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	content := `package main
+
+func main() {
+	println("main")
+}
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write main.go: %v", err)
+	}
+	tracer, err := NewTracer(&Tracer{Shape: ShapeOTelSpan, Fallback: FallbackSkip})
+	if err != nil {
+		t.Fatalf("NewTracer failed: %v", err)
+	}
+	r, err := NewRewrite(path, WithTracer(tracer))
+	if err != nil {
+		t.Fatalf("NewRewrite failed: %v", err)
+	}
+	r.RewriteFile()
+	result, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read main.go: %v", err)
+	}
+	if string(result) != content {
+		t.Errorf("Functions without context should be skipped:\n%s", result)
+	}
+
+	if _, err = NewTracer(&Tracer{Shape: ShapeOTelSpan, Fallback: "context.("}); err == nil {
+		t.Error("Expected error for invalid fallback expression")
+	}
+}
+
+func TestRewriteDir_OTelSpanError(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/demo\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "demo.go"), []byte(otelSource), 0644); err != nil {
+		t.Fatalf("Failed to write demo.go: %v", err)
+	}
+	// 未经NewTracer校验的模板, fallback表达式无法解析
+	tracer := *OTelTracer()
+	tracer.Fallback = "context.("
+	report := RewriteDir(dir, WithTracer(&tracer))
+
+	// helper和run没有context参数, 生成span失败; Handle正常插桩
+	if len(report.Rewritten) != 1 || report.Stats.Funcs != 1 {
+		t.Errorf("Expected Handle to be instrumented, got %s", report)
+	}
+	var errs []string
+	for _, e := range report.Errors {
+		errs = append(errs, fmt.Sprintf("%d %s", e.Line, strings.SplitN(e.Message, ": ", 2)[0]))
+	}
+	expected := []string{
+		"9 generate span for example.com/demo.(*Server).Handle$1",
+		"15 generate span for example.com/demo.helper",
+		"19 generate span for example.com/demo.run",
+	}
+	if strings.Join(errs, ",") != strings.Join(expected, ",") || report.Err() == nil {
+		t.Errorf("Expected span errors %v, got %v", expected, report.Errors)
+	}
+}
@@ -0,0 +1,101 @@
+package rewrite
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// OverlayFileName overlay描述文件名
+const OverlayFileName = "overlay.json"
+
+// Overlay
+//
+//	@Description: 非破坏式插桩, 插桩后的文件写入临时目录, 并生成go build -overlay可用的映射文件
+type Overlay struct {
+	dir     string
+	mu      sync.Mutex
+	replace map[string]string
+}
+
+// overlayJSON go build -overlay 要求的文件格式
+type overlayJSON struct {
+	Replace map[string]string `json:"Replace"`
+}
+
+// NewOverlay 创建overlay, dir为存放插桩副本和overlay.json的临时目录
+func NewOverlay(dir string) *Overlay {
+	return &Overlay{
+		dir:     dir,
+		replace: make(map[string]string),
+	}
+}
+
+// WriteFile 将源文件的插桩副本写入overlay目录, 并记录映射关系
+func (o *Overlay) WriteFile(fullPath string, content []byte) error {
+	dst := o.copyPath(fullPath)
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(dst, content, 0o644); err != nil {
+		return err
+	}
+	o.mu.Lock()
+	o.replace[fullPath] = dst
+	o.mu.Unlock()
+	return nil
+}
+
+// Save 写入overlay.json并返回其路径
+func (o *Overlay) Save() (string, error) {
+	absDir, err := filepath.Abs(o.dir)
+	if err != nil {
+		return "", err
+	}
+	if err = os.MkdirAll(absDir, 0o755); err != nil {
+		return "", err
+	}
+	o.mu.Lock()
+	data, err := json.MarshalIndent(overlayJSON{Replace: o.replace}, "", "  ")
+	o.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	overlayPath := filepath.Join(absDir, OverlayFileName)
+	if err = os.WriteFile(overlayPath, data, 0o644); err != nil {
+		return "", err
+	}
+	return overlayPath, nil
+}
+
+// Len 返回已记录的插桩文件数量
+func (o *Overlay) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.replace)
+}
+
+// IsDir 判断path是否为overlay目录
+func (o *Overlay) IsDir(path string) bool {
+	absDir, err := filepath.Abs(o.dir)
+	if err != nil {
+		return false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	return absDir == absPath
+}
+
+// copyPath 插桩副本路径, 在overlay目录下按源文件绝对路径镜像存放以避免重名
+func (o *Overlay) copyPath(fullPath string) string {
+	absDir, err := filepath.Abs(o.dir)
+	if err != nil {
+		absDir = o.dir
+	}
+	rel := strings.TrimPrefix(fullPath, filepath.VolumeName(fullPath))
+	return filepath.Join(absDir, "src", rel)
+}
@@ -0,0 +1,80 @@
+package rewrite
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRewriteDir_Overlay(t *testing.T) {
+	tmpDir := t.TempDir()
+	projectDir := filepath.Join(tmpDir, "project")
+	overlayDir := filepath.Join(projectDir, ".overlay")
+	if err := os.Mkdir(projectDir, 0755); err != nil {
+		t.Fatalf("Failed to create project dir: %v", err)
+	}
+
+	mainFile := filepath.Join(projectDir, "main.go")
+	content := `package main
+
+func main() {
+	println("main")
+}
+`
+	if err := os.WriteFile(mainFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create main.go: %v", err)
+	}
+	emptyFile := filepath.Join(projectDir, "types.go")
+	if err := os.WriteFile(emptyFile, []byte("package main\n\ntype T struct{}\n"), 0644); err != nil {
+		t.Fatalf("Failed to create types.go: %v", err)
+	}
+
+	overlay := NewOverlay(overlayDir)
+	RewriteDir(projectDir, WithOverlay(overlay))
+	overlayPath, err := overlay.Save()
+	if err != nil {
+		t.Fatalf("Save overlay failed: %v", err)
+	}
+
+	// 源文件不应被修改
+	original, err := os.ReadFile(mainFile)
+	if err != nil {
+		t.Fatalf("Failed to read main.go: %v", err)
+	}
+	if string(original) != content {
+		t.Error("main.go should not be modified in overlay mode")
+	}
+
+	data, err := os.ReadFile(overlayPath)
+	if err != nil {
+		t.Fatalf("Failed to read overlay file: %v", err)
+	}
+	var ov struct {
+		Replace map[string]string
+	}
+	if err = json.Unmarshal(data, &ov); err != nil {
+		t.Fatalf("Invalid overlay json: %v", err)
+	}
+
+	absMain, _ := filepath.Abs(mainFile)
+	copyPath, ok := ov.Replace[absMain]
+	if !ok {
+		t.Fatalf("Expected overlay entry for %s, got %v", absMain, ov.Replace)
+	}
+	if len(ov.Replace) != 1 {
+		t.Errorf("Only instrumented files should be listed, got %d entries", len(ov.Replace))
+	}
+	if !strings.HasPrefix(copyPath, overlayDir) {
+		t.Errorf("Instrumented copy should live under overlay dir, got %s", copyPath)
+	}
+
+	instrumented, err := os.ReadFile(copyPath)
+	if err != nil {
+		t.Fatalf("Failed to read instrumented copy: %v", err)
+	}
+	if !strings.Contains(string(instrumented), "defer functrace.Trace") {
+		t.Error("Instrumented copy should contain defer functrace.Trace")
+	}
+}
@@ -0,0 +1,81 @@
+package rewrite
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/toheart/goanalysis/internal/biz/callgraph/dos"
+	"github.com/toheart/goanalysis/internal/biz/repo"
+)
+
+// ReachableSelection
+//
+//	@Description: 以静态调用图中的一个函数为根, 生成其可达函数组成的插桩范围
+//	@param store 静态分析数据库
+//	@param root 根函数, 支持稳定Key、旧格式Key(如n12)、完整函数名(如github.com/foo/bar.(*Server).Handle)或函数名(如(*Server).Handle)
+//	@param depth 遍历层数, 小于等于0时不限制
+//	@param direction 遍历方向, callees为下游, callers为上游
+//	@return *Selection
+//	@return error
+func ReachableSelection(store repo.StaticDBStore, root string, depth int, direction Direction) (*Selection, error) {
+	node, err := findRootNode(store, root)
+	if err != nil {
+		return nil, err
+	}
+	if depth <= 0 {
+		depth = -1
+	}
+	selection := NewSelection()
+	selection.Add(FuncID{Pkg: node.Pkg, Name: node.Name})
+	if err = selection.walk(store, []string{node.Key}, depth, direction); err != nil {
+		return nil, err
+	}
+	return selection, nil
+}
+
+// findRootNode 在静态调用图中查找根函数, 按函数名匹配到多个节点时返回错误
+func findRootNode(store repo.StaticDBStore, root string) (*dos.FuncNode, error) {
+	root = strings.TrimSpace(root)
+	if root == "" {
+		return nil, fmt.Errorf("root function is empty")
+	}
+	node, err := store.GetFuncNodeByKey(root)
+	if err != nil {
+		return nil, err
+	}
+	if node != nil {
+		return node, nil
+	}
+
+	nodes, err := store.GetAllFuncNodes()
+	if err != nil {
+		return nil, fmt.Errorf("load func nodes failed: %w", err)
+	}
+	var matched []*dos.FuncNode
+	for _, n := range nodes {
+		// FullName 为 callgraph.Node.String(), 形如 n12:github.com/foo/bar.Func
+		fullName := n.FullName
+		if idx := strings.Index(fullName, ":"); idx >= 0 && strings.HasPrefix(fullName, "n") {
+			fullName = fullName[idx+1:]
+		}
+		if root == fullName || root == n.FullName || root == (FuncID{Pkg: n.Pkg, Name: n.Name}).String() {
+			return n, nil
+		}
+		if root == n.Name {
+			matched = append(matched, n)
+		}
+	}
+	switch len(matched) {
+	case 0:
+		return nil, fmt.Errorf("root function %s not found in static db", root)
+	case 1:
+		return matched[0], nil
+	}
+	var candidates []string
+	for _, n := range matched {
+		candidates = append(candidates, FuncID{Pkg: n.Pkg, Name: n.Name}.String())
+	}
+	sort.Strings(candidates)
+	return nil, fmt.Errorf("root function %s is ambiguous, use one of: %s", root, strings.Join(candidates, ", "))
+}
@@ -0,0 +1,129 @@
+package rewrite
+
+import (
+	"errors"
+	"fmt"
+	"go/scanner"
+	"sort"
+	"sync"
+)
+
+// FileError 重写单个文件时的错误, 语法错误带有行列位置
+type FileError struct {
+	Path    string `json:"path"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// Error 实现error接口
+func (e *FileError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s", e.Path, e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// fileErrors 将错误转换为FileError, 解析错误按位置展开
+func fileErrors(path string, err error) []*FileError {
+	var list scanner.ErrorList
+	if errors.As(err, &list) {
+		result := make([]*FileError, 0, len(list))
+		for _, e := range list {
+			result = append(result, &FileError{Path: path, Line: e.Pos.Line, Column: e.Pos.Column, Message: e.Msg})
+		}
+		return result
+	}
+	var fe *FileError
+	if errors.As(err, &fe) {
+		return []*FileError{fe}
+	}
+	return []*FileError{{Path: path, Message: err.Error()}}
+}
+
+// Report
+//
+//	@Description: RewriteDir的执行结果, 记录被改写、被跳过的文件、错误以及插桩统计
+type Report struct {
+	mu sync.Mutex
+
+	Dir       string       `json:"dir"`
+	Rewritten []string     `json:"rewritten"` // 有插桩改动的文件
+	Skipped   []string     `json:"skipped"`   // 没有改动的文件, 包括被过滤规则排除的文件
+	Generated []string     `json:"generated"` // 新生成的文件, 如测试包的TestMain
+	Errors    []*FileError `json:"errors"`
+	Stats     Stats        `json:"stats"`
+}
+
+// NewReport 创建目录的重写报告
+func NewReport(dir string) *Report {
+	return &Report{Dir: dir}
+}
+
+// addFile 记录单个文件的重写结果
+func (rp *Report) addFile(path string, stats Stats) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	if stats.Total() == 0 {
+		rp.Skipped = append(rp.Skipped, path)
+		return
+	}
+	rp.Rewritten = append(rp.Rewritten, path)
+	rp.Stats.Add(stats)
+}
+
+// addGenerated 记录新生成的文件
+func (rp *Report) addGenerated(path string) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	rp.Generated = append(rp.Generated, path)
+}
+
+// addError 记录文件的错误
+func (rp *Report) addError(path string, err error) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	rp.Errors = append(rp.Errors, fileErrors(path, err)...)
+}
+
+// sort 按路径排序, 使报告与遍历顺序无关
+func (rp *Report) sort() {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	sort.Strings(rp.Rewritten)
+	sort.Strings(rp.Skipped)
+	sort.Strings(rp.Generated)
+	sort.SliceStable(rp.Errors, func(i, j int) bool {
+		a, b := rp.Errors[i], rp.Errors[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+}
+
+// Err 返回所有文件错误的合并, 没有错误时为nil
+func (rp *Report) Err() error {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	errs := make([]error, 0, len(rp.Errors))
+	for _, e := range rp.Errors {
+		errs = append(errs, e)
+	}
+	return errors.Join(errs...)
+}
+
+// String 返回报告摘要
+func (rp *Report) String() string {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	summary := fmt.Sprintf("%d files rewritten, %d skipped, %d errors, %d funcs, %d closures, %d goroutines",
+		len(rp.Rewritten), len(rp.Skipped), len(rp.Errors), rp.Stats.Funcs, rp.Stats.Closures, rp.Stats.Goroutines)
+	if len(rp.Generated) > 0 {
+		summary += fmt.Sprintf(", %d files generated", len(rp.Generated))
+	}
+	return summary
+}
@@ -2,6 +2,7 @@ package rewrite
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"go/ast"
 	"go/format"
@@ -10,8 +11,10 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
+	"github.com/sourcegraph/conc/pool"
 	"golang.org/x/tools/go/ast/astutil"
 )
 
@@ -24,16 +27,80 @@ import (
 
 const _defaultImport = "github.com/toheart/functrace"
 
-var debug = false
-
 // RewriteDir
 //
-//	@Description: 对目录中所有文件进行重写
+//	@Description: 对目录中所有文件进行重写, 单个文件的错误不会中断遍历
+//	@param dir
+//	@param opts 重写选项, 例如WithOverlay
+//	@return *Report 被改写和跳过的文件、错误及插桩统计
+func RewriteDir(dir string, opts ...RewriteOption) *Report {
+	report, _ := RewriteDirContext(context.Background(), dir, opts...)
+	return report
+}
+
+// RewriteDirContext
+//
+//	@Description: 并发重写目录中的所有文件, 并发数由WithConcurrency指定;
+//	报告按路径排序, 与调度顺序无关
+//	@param ctx 取消后不再处理新的文件, 已开始的文件会处理完
 //	@param dir
-func RewriteDir(dir string) {
+//	@param opts 重写选项
+//	@return *Report 已处理文件的报告
+//	@return error ctx取消时返回ctx.Err(), 否则为所有文件错误的合并
+func RewriteDirContext(ctx context.Context, dir string, opts ...RewriteOption) (*Report, error) {
+	// overlay目录可能位于项目内, 遍历时需要跳过
+	probe := &Rewrite{}
+	for _, opt := range opts {
+		opt(probe)
+	}
+	report := NewReport(dir)
+	if probe.tests {
+		if err := probe.traceTemplate().validateTests(); err != nil {
+			report.addError(dir, err)
+			return report, err
+		}
+	}
+	initLits := newInitLitCache()
+	opts = append(opts, withDirectiveCache(newDirectiveCache()), withModuleCache(newModuleCache()), withInitLitCache(initLits))
+	files, err := goFiles(ctx, dir, probe.overlay, probe.tests, report)
+	if err != nil {
+		report.sort()
+		return report, err
+	}
+	// 并发改写会修改同一个包的其他文件, 先按原始源码计算包级闭包名称
+	initLits.preload(files)
+
+	p := pool.New().WithContext(ctx).WithMaxGoroutines(probe.workers())
+	for _, fullPath := range files {
+		fullPath := fullPath
+		p.Go(func(ctx context.Context) error {
+			if ctx.Err() != nil {
+				return nil
+			}
+			probe.rewriteOne(fullPath, report, opts)
+			return nil
+		})
+	}
+	_ = p.Wait()
+	if probe.tests && ctx.Err() == nil {
+		probe.generateTestMains(files, report)
+	}
+	report.sort()
+	if err = ctx.Err(); err != nil {
+		return report, err
+	}
+	return report, report.Err()
+}
+
+// goFiles 收集目录中需要重写的go文件, 跳过vendor、overlay目录, tests为false时跳过测试文件
+func goFiles(ctx context.Context, dir string, overlay *Overlay, tests bool, report *Report) ([]string, error) {
+	var files []string
 	err := filepath.Walk(dir, func(path string, info fs.FileInfo, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		if err != nil {
-			fmt.Printf("path: %s, walk found err: %s \n ", path, err.Error())
+			report.addError(path, err)
 			return nil
 		}
 		if info.IsDir() {
@@ -41,49 +108,128 @@ func RewriteDir(dir string) {
 			if info.Name() == "vendor" {
 				return filepath.SkipDir
 			}
+			if overlay != nil && overlay.IsDir(path) {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 		// 排除
-		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+		if !strings.HasSuffix(path, ".go") || (!tests && isTestFile(path)) {
 			return nil
 		}
 		fullPath, err := filepath.Abs(path)
 		if err != nil {
-			fmt.Printf("path: %s, get abs filepath err: %s \n ", path, err.Error())
-			return err
-		}
-		r, err := NewRewrite(fullPath)
-		if err != nil {
-			fmt.Printf("path: %s, get abs filepath err: %s \n ", path, err.Error())
-			return err
+			report.addError(path, err)
+			return nil
 		}
-		r.RewriteFile()
-		fmt.Printf("path: %s rewrite success \n", path)
+		files = append(files, fullPath)
 		return nil
 	})
+	return files, err
+}
+
+// rewriteOne 重写单个文件并记录到报告中, probe为携带目录级选项的重写器
+func (r *Rewrite) rewriteOne(fullPath string, report *Report, opts []RewriteOption) {
+	rw, err := NewRewrite(fullPath, opts...)
 	if err != nil {
-		fmt.Printf("filepath walk found err:%s \n", err.Error())
+		report.addError(fullPath, err)
+		r.sendProgress("%s: parse failed: %v", fullPath, err)
+		return
+	}
+	if err = rw.RewriteFile(); err != nil {
+		report.addError(fullPath, err)
+		r.sendProgress("%s: rewrite failed: %v", fullPath, err)
+		return
+	}
+	report.addFile(fullPath, rw.Stats())
+	for _, e := range rw.Errors() {
+		report.addError(fullPath, e)
+		r.sendProgress("%s", e)
 	}
+	r.sendProgress("%s: %d instrumented", fullPath, rw.Stats().Total())
 }
 
-func NewRewrite(fullPath string) (*Rewrite, error) {
+// workers 返回并发重写的goroutine数量, 未设置时为GOMAXPROCS
+func (r *Rewrite) workers() int {
+	if r.concurrency > 0 {
+		return r.concurrency
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// sendProgress 发送进度消息, 通道已满时丢弃, 避免没有消费者时阻塞重写
+func (r *Rewrite) sendProgress(format string, args ...interface{}) {
+	if r.progress == nil {
+		return
+	}
+	select {
+	case r.progress <- []byte(fmt.Sprintf(format, args...)):
+	default:
+	}
+}
+
+func NewRewrite(fullPath string, opts ...RewriteOption) (*Rewrite, error) {
+	src, err := os.ReadFile(fullPath)
+	if err != nil {
+		return nil, err
+	}
 	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, fullPath, nil, parser.ParseComments)
+	f, err := parser.ParseFile(fset, fullPath, src, parser.ParseComments)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Rewrite{
+	r := &Rewrite{
 		fullPath: fullPath,
+		src:      src,
 		fset:     fset,
 		f:        f,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.modules == nil {
+		r.modules = newModuleCache()
+	}
+	return r, nil
 }
 
 type Rewrite struct {
 	fullPath string
+	src      []byte // 原始源码
 	fset     *token.FileSet
 	f        *ast.File
+	overlay  *Overlay // 非空时插桩结果写入overlay目录, 不修改源文件
+	filter   *Filter  // 非空时只对通过过滤规则的文件和函数插桩
+
+	directives *directiveCache // 包级指令缓存
+	dryRun     *DryRun         // 非空时只生成diff预览, 不写入磁盘
+	stats      Stats           // 本文件的插桩统计
+	errs       []*FileError    // 没有中断改写的函数级错误, 如生成span失败
+	tracer     *Tracer         // 插桩模板, 为空时使用functrace
+	modules    *moduleCache    // 模块缓存, 用于推导包导入路径
+
+	selection *Selection              // 非空时只对选中的函数和闭包插桩
+	pkgPath   string                  // 本文件的包导入路径
+	litNames  map[*ast.FuncLit]string // 当前函数中闭包的ssa名称
+	initLits  *initLitCache           // 包级闭包名称缓存
+	forced    bool                    // 当前函数带有trace指令, 不受selection限制
+	fallback  bool                    // 插入了依赖Fallback表达式的span, 需要导入FallbackImport
+	progress  chan []byte             // 非空时RewriteDir逐个文件发送进度消息
+
+	concurrency    int  // RewriteDir并发处理的文件数, 小于等于0时为GOMAXPROCS
+	lineDirectives bool // 在插入的代码之后写入 //line 指令, 保持原始代码的行号
+	tests          bool // 同时对测试文件插桩, 并为测试包生成TestMain
+}
+
+// Stats 返回本文件的插桩统计
+func (r *Rewrite) Stats() Stats {
+	return r.stats
+}
+
+// Errors 返回改写时跳过的函数的错误, 文件的其余部分仍会正常插桩
+func (r *Rewrite) Errors() []*FileError {
+	return r.errs
 }
 
 func (r *Rewrite) genTraceParams(funcType *ast.FuncType, recv *ast.FieldList) []ast.Expr {
@@ -133,81 +279,83 @@ func (r *Rewrite) genTraceParams(funcType *ast.FuncType, recv *ast.FieldList) []
 //	@param elts
 //	@return *ast.DeferStmt
 func (r *Rewrite) genDefer(elts []ast.Expr) *ast.DeferStmt {
-	// 创建字符串切片参数
-	sliceParams := &ast.CompositeLit{
-		Type: &ast.ArrayType{
-			Elt: &ast.InterfaceType{ // 空接口
-				Methods: &ast.FieldList{
-					List: []*ast.Field{},
-				},
-			},
-		},
-		Elts: elts,
-	}
-
-	// 创建函数调用表达式
-	callExpr := &ast.CallExpr{
-		Fun: &ast.CallExpr{
-			Fun: &ast.SelectorExpr{
-				X:   ast.NewIdent("functrace"),
-				Sel: ast.NewIdent("Trace"),
-			},
-			Args: []ast.Expr{
-				sliceParams,
-			},
-		},
-	}
-	return &ast.DeferStmt{
-		Call: callExpr,
+	return r.traceTemplate().genDefer(r.traceName(), elts)
+}
+
+// anchorStmt 将生成的语句定位到函数体左括号处, 避免打印时注释被挤入生成的语句中
+func anchorStmt(stmt ast.Stmt, pos token.Pos) {
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.DeferStmt:
+			node.Defer = pos
+		case *ast.AssignStmt:
+			node.TokPos = pos
+		case *ast.CallExpr:
+			node.Lparen, node.Rparen = pos, pos
+		case *ast.Ident:
+			node.NamePos = pos
+		case *ast.BasicLit:
+			node.ValuePos = pos
+		case *ast.CompositeLit:
+			node.Lbrace, node.Rbrace = pos, pos
+		case *ast.ArrayType:
+			node.Lbrack = pos
+		case *ast.InterfaceType:
+			node.Interface = pos
+		case *ast.FieldList:
+			node.Opening, node.Closing = pos, pos
+		case *ast.ParenExpr:
+			node.Lparen, node.Rparen = pos, pos
+		case *ast.StarExpr:
+			node.Star = pos
+		case *ast.UnaryExpr:
+			node.OpPos = pos
+		case *ast.IndexExpr:
+			node.Lbrack, node.Rbrack = pos, pos
+		}
+		return true
+	})
+}
+
+// traceTemplate 返回插桩模板, 未设置时使用functrace
+func (r *Rewrite) traceTemplate() *Tracer {
+	if r.tracer == nil {
+		return DefaultTracer()
 	}
+	return r.tracer
 }
 
+// traceName 返回本文件中引用追踪包的名称
+func (r *Rewrite) traceName() string {
+	if r.f == nil {
+		return r.traceTemplate().Alias
+	}
+	return r.traceTemplate().localName(r.f)
+}
+
+// ImportFunctrace 导入插桩模板中的追踪包, 已导入时不做改动
 func (r *Rewrite) ImportFunctrace() {
-	importFlag := false
-	for _, item := range r.f.Imports {
-		if item.Path.Value == _defaultImport {
-			importFlag = true
-			break
-		}
+	tracer := r.traceTemplate()
+	if r.fallback && tracer.FallbackImport != "" {
+		astutil.AddImport(r.fset, r.f, tracer.FallbackImport)
+	}
+	if tracer.isImported(r.f) {
+		return
 	}
-	// 如果没有导入包, 则将functrace包导入
-	if !importFlag {
-		astutil.AddImport(r.fset, r.f, _defaultImport)
+	if tracer.needsName() {
+		astutil.AddNamedImport(r.fset, r.f, tracer.Alias, tracer.ImportPath)
+		return
 	}
+	astutil.AddImport(r.fset, r.f, tracer.ImportPath)
 }
 
+// HasSameDefer 判断函数是否已按插桩模板插入defer语句
 func (r *Rewrite) HasSameDefer(decl *ast.FuncDecl) bool {
 	// 检查函数是否有函数体
 	if decl.Body == nil {
 		return false
 	}
-
-	for _, stmt := range decl.Body.List {
-		// 判断是否为defer 函数
-		ds, ok := stmt.(*ast.DeferStmt)
-		if !ok {
-			continue
-		}
-
-		ce, ok := ds.Call.Fun.(*ast.CallExpr)
-		if !ok {
-			continue
-		}
-		se, ok := ce.Fun.(*ast.SelectorExpr)
-		if !ok {
-			continue
-		}
-
-		x, ok := se.X.(*ast.Ident)
-		if !ok {
-			continue
-		}
-		if (x.Name == "functrace") && (se.Sel.Name == "Trace") {
-			// 已经存在直接返回
-			return true
-		}
-	}
-	return false
+	return r.hasSameDeferInBody(decl.Body)
 }
 
 // hasSameDeferInBody 通用的defer检查函数，用于检查任何函数体中是否已有相同的defer语句
@@ -216,26 +364,9 @@ func (r *Rewrite) hasSameDeferInBody(body *ast.BlockStmt) bool {
 		return false
 	}
 
+	tracer, name := r.traceTemplate(), r.traceName()
 	for _, stmt := range body.List {
-		ds, ok := stmt.(*ast.DeferStmt)
-		if !ok {
-			continue
-		}
-
-		ce, ok := ds.Call.Fun.(*ast.CallExpr)
-		if !ok {
-			continue
-		}
-		se, ok := ce.Fun.(*ast.SelectorExpr)
-		if !ok {
-			continue
-		}
-
-		x, ok := se.X.(*ast.Ident)
-		if !ok {
-			continue
-		}
-		if (x.Name == "functrace") && (se.Sel.Name == "Trace") {
+		if tracer.isTraceCall(stmt, name) {
 			return true
 		}
 	}
@@ -244,6 +375,11 @@ func (r *Rewrite) hasSameDeferInBody(body *ast.BlockStmt) bool {
 
 // addDeferToBody 通用的添加defer语句函数
 func (r *Rewrite) addDeferToBody(body *ast.BlockStmt, funcType *ast.FuncType, recv *ast.FieldList) bool {
+	return r.addTraceToBody(body, funcType, recv, "")
+}
+
+// addTraceToBody 按插桩模板在函数体开头插入追踪语句, name为函数或闭包的ssa名称
+func (r *Rewrite) addTraceToBody(body *ast.BlockStmt, funcType *ast.FuncType, recv *ast.FieldList, name string) bool {
 	if body == nil {
 		return false
 	}
@@ -253,143 +389,175 @@ func (r *Rewrite) addDeferToBody(body *ast.BlockStmt, funcType *ast.FuncType, re
 		return false
 	}
 
+	if r.traceTemplate().Shape == ShapeOTelSpan {
+		return r.addSpanToBody(body, funcType, name)
+	}
+
 	// 生成defer语句
 	elts := r.genTraceParams(funcType, recv)
-	deferStmt := r.genDefer(elts)
+	stmts := []ast.Stmt{r.genDefer(elts)}
+	if tracer := r.traceTemplate(); tracer.Capture {
+		stmts = append(stmts, tracer.genCapture(r.traceName(), errorResult(funcType, body)))
+	}
+	for _, stmt := range stmts {
+		anchorStmt(stmt, body.Lbrace)
+	}
 
 	// 将defer语句添加到函数体的开头
-	body.List = append([]ast.Stmt{deferStmt}, body.List...)
+	body.List = append(stmts, body.List...)
+	return true
+}
+
+// addSpanToBody 在函数体开头插入otel span的开始和结束语句
+func (r *Rewrite) addSpanToBody(body *ast.BlockStmt, funcType *ast.FuncType, name string) bool {
+	tracer := r.traceTemplate()
+	ctxVar, hasCtx := contextParam(funcType, importName(r.f, _contextImport))
+	if !hasCtx && tracer.Fallback == FallbackSkip {
+		return false
+	}
+	if name == "" {
+		name = "func"
+	}
+	spanName := FuncID{Pkg: r.pkgPath, Name: name}.String()
+	stmts, err := tracer.genSpan(r.traceName(), r.pkgPath, spanName, ctxVar, spanVarName(funcType, body))
+	if err != nil {
+		pos := r.fset.Position(body.Lbrace)
+		r.errs = append(r.errs, &FileError{Path: r.fullPath, Line: pos.Line, Column: pos.Column,
+			Message: fmt.Sprintf("generate span for %s: %v", spanName, err)})
+		return false
+	}
+	if !hasCtx {
+		r.fallback = true
+	}
+	for _, stmt := range stmts {
+		anchorStmt(stmt, body.Lbrace)
+	}
+	body.List = append(stmts, body.List...)
 	return true
 }
 
 // processFuncLit 处理函数字面量，添加defer语句
 func (r *Rewrite) processFuncLit(funcLit *ast.FuncLit) bool {
-	return r.addDeferToBody(funcLit.Body, funcLit.Type, nil)
+	if !r.selected(r.litNames[funcLit]) {
+		return false
+	}
+	return r.addTraceToBody(funcLit.Body, funcLit.Type, nil, r.litNames[funcLit])
 }
 
-// processGoStmt 处理go语句
-func (r *Rewrite) processGoStmt(goStmt *ast.GoStmt) bool {
-	// 检查go语句中的函数是否为函数字面量
-	if funcLit, ok := goStmt.Call.Fun.(*ast.FuncLit); ok {
-		return r.processFuncLit(funcLit)
+// selected 判断函数或闭包是否在插桩范围内
+func (r *Rewrite) selected(name string) bool {
+	if r.selection == nil || r.forced {
+		return true
 	}
-
-	// 处理go语句中的函数调用参数，查找其中的函数字面量
-	return r.processCallExpr(goStmt.Call)
+	return name != "" && r.selection.Has(FuncID{Pkg: r.pkgPath, Name: name})
 }
 
-// processCallExpr 处理函数调用表达式，查找其中的函数字面量参数
-func (r *Rewrite) processCallExpr(callExpr *ast.CallExpr) bool {
+// processFuncLits
+//
+//	@Description: 遍历函数体中的所有函数字面量并插桩, 包括赋值给变量、作为返回值、
+//	位于复合字面量以及defer/go语句中的闭包
+//	@param body 函数体或包级变量声明
+//	@return bool 是否有闭包被插桩
+func (r *Rewrite) processFuncLits(body ast.Node) bool {
 	modified := false
-
-	// 检查函数调用的参数中是否有函数字面量
-	for _, arg := range callExpr.Args {
-		if funcLit, ok := arg.(*ast.FuncLit); ok {
-			if r.processFuncLit(funcLit) {
-				modified = true
+	goLits := make(map[*ast.FuncLit]bool)
+	astutil.Apply(body, func(c *astutil.Cursor) bool {
+		switch node := c.Node().(type) {
+		case *ast.GoStmt:
+			if lit, ok := node.Call.Fun.(*ast.FuncLit); ok {
+				goLits[lit] = true
 			}
-		} else if nestedCall, ok := arg.(*ast.CallExpr); ok {
-			// 递归处理嵌套的函数调用
-			if r.processCallExpr(nestedCall) {
-				modified = true
+		case *ast.FuncLit:
+			// 没有名称的闭包是插桩时生成的, 如返回值捕获语句
+			if _, ok := r.litNames[node]; !ok {
+				return true
+			}
+			if !r.processFuncLit(node) {
+				return true
 			}
-		}
-	}
-
-	// 递归处理函数调用本身（如果它也是一个函数字面量）
-	if funcLit, ok := callExpr.Fun.(*ast.FuncLit); ok {
-		if r.processFuncLit(funcLit) {
 			modified = true
+			if goLits[node] {
+				r.stats.Goroutines++
+			} else {
+				r.stats.Closures++
+			}
 		}
-	}
-
+		return true
+	}, nil)
 	return modified
 }
 
-// processStmtList 递归处理语句列表
-func (r *Rewrite) processStmtList(stmts []ast.Stmt) bool {
+// processInitLits 对包级变量初始化表达式中的闭包插桩
+func (r *Rewrite) processInitLits() bool {
+	r.forced = false
+	r.litNames = nil
 	modified := false
-	for _, stmt := range stmts {
-		if r.processStmt(stmt) {
+	for _, decl := range r.f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		if r.litNames == nil {
+			r.litNames = r.initLitNames()
+		}
+		if r.processFuncLits(gen) {
 			modified = true
 		}
 	}
 	return modified
 }
 
-// processStmt 递归处理语句，查找go语句和函数字面量
-func (r *Rewrite) processStmt(stmt ast.Stmt) bool {
-	modified := false
+// allowFile 根据文件/包级指令和过滤规则判断文件是否插桩
+func (r *Rewrite) allowFile() bool {
+	switch r.fileDirective() {
+	case directiveNoTrace:
+		r.recordDirectiveSkip("", "file or package marked with //"+directiveNoTrace.String())
+		return false
+	case directiveTrace:
+		return true
+	}
+	return r.filter == nil || r.filter.AllowFile(r.fullPath)
+}
 
-	switch s := stmt.(type) {
-	case *ast.GoStmt:
-		if r.processGoStmt(s) {
-			modified = true
-		}
-	case *ast.ExprStmt:
-		// 处理表达式语句中的函数调用
-		if callExpr, ok := s.X.(*ast.CallExpr); ok {
-			modified = r.processCallExpr(callExpr)
-		}
-	case *ast.BlockStmt:
-		modified = r.processStmtList(s.List)
-	case *ast.IfStmt:
-		if s.Init != nil {
-			modified = r.processStmt(s.Init) || modified
-		}
-		if s.Body != nil {
-			modified = r.processStmtList(s.Body.List) || modified
-		}
-		if s.Else != nil {
-			modified = r.processStmt(s.Else) || modified
-		}
-	case *ast.ForStmt:
-		if s.Init != nil {
-			modified = r.processStmt(s.Init) || modified
-		}
-		if s.Body != nil {
-			modified = r.processStmtList(s.Body.List) || modified
-		}
-	case *ast.RangeStmt:
-		if s.Body != nil {
-			modified = r.processStmtList(s.Body.List) || modified
-		}
-	case *ast.SwitchStmt:
-		if s.Init != nil {
-			modified = r.processStmt(s.Init) || modified
-		}
-		if s.Body != nil {
-			for _, caseClause := range s.Body.List {
-				if cc, ok := caseClause.(*ast.CaseClause); ok {
-					modified = r.processStmtList(cc.Body) || modified
-				}
-			}
-		}
-	case *ast.TypeSwitchStmt:
-		if s.Init != nil {
-			modified = r.processStmt(s.Init) || modified
-		}
-		if s.Body != nil {
-			for _, caseClause := range s.Body.List {
-				if cc, ok := caseClause.(*ast.CaseClause); ok {
-					modified = r.processStmtList(cc.Body) || modified
-				}
-			}
-		}
-	case *ast.SelectStmt:
-		if s.Body != nil {
-			for _, commClause := range s.Body.List {
-				if cc, ok := commClause.(*ast.CommClause); ok {
-					modified = r.processStmtList(cc.Body) || modified
-				}
-			}
-		}
+// allowFunc 判断函数是否插桩, 函数级指令优先于文件级结果和过滤规则
+func (r *Rewrite) allowFunc(decl *ast.FuncDecl, fileAllowed bool) bool {
+	switch funcDirective(decl) {
+	case directiveTrace:
+		return true
+	case directiveNoTrace:
+		r.recordDirectiveSkip(decl.Name.Name, "func marked with //"+directiveNoTrace.String())
+		return false
 	}
+	if !fileAllowed {
+		return false
+	}
+	if strings.ToLower(decl.Name.Name) == "string" {
+		return false
+	}
+	return r.filter == nil || r.filter.AllowFunc(r.fullPath, decl)
+}
 
-	return modified
+// recordDirectiveSkip 记录因指令跳过的文件或函数
+func (r *Rewrite) recordDirectiveSkip(funcName, detail string) {
+	if r.filter == nil {
+		return
+	}
+	r.filter.record(&SkipRecord{File: r.fullPath, Func: funcName, Reason: SkipReasonDirective, Detail: detail})
 }
 
-func (r *Rewrite) RewriteFile() {
+// RewriteFile 对单个文件插桩, 返回格式化或写入文件时的错误
+func (r *Rewrite) RewriteFile() error {
+	// 生成的TestMain文件不插桩
+	if isGeneratedTestMain(r.src) {
+		return nil
+	}
+	fileAllowed := r.allowFile()
+	r.pkgPath = r.modules.pkgPath(filepath.Dir(r.fullPath))
+	if strings.HasSuffix(r.f.Name.Name, "_test") {
+		// 外部测试包的导入路径带有_test后缀
+		r.pkgPath += "_test"
+	}
+	testFile := isTestFile(r.fullPath)
 	flag := false
 	// 插入defer函数
 	for _, item := range r.f.Decls {
@@ -397,25 +565,48 @@ func (r *Rewrite) RewriteFile() {
 		if !ok {
 			continue
 		}
-		if strings.ToLower(funcDel.Name.Name) == "string" {
-			continue
-		}
 
 		// 检查函数是否有函数体，没有函数体的函数（如接口方法声明）跳过
 		if funcDel.Body == nil {
 			continue
 		}
 
+		// TestMain中os.Exit会跳过defer, 不插桩, 改为在m.Run()之后落盘
+		if testFile && funcDel.Recv == nil && funcDel.Name.Name == _testMainFunc {
+			if r.augmentTestMain(funcDel) {
+				flag = true
+			}
+			continue
+		}
+
+		// 被排除的函数, 其内部的闭包也不插桩
+		if !r.allowFunc(funcDel, fileAllowed) {
+			continue
+		}
+
+		r.forced = funcDirective(funcDel) == directiveTrace
+		r.litNames = funcLitNames(funcDel)
+
 		// 为函数声明添加defer语句
-		if r.addDeferToBody(funcDel.Body, funcDel.Type, funcDel.Recv) {
+		name := funcDeclName(funcDel)
+		if r.selected(name) && r.addTraceToBody(funcDel.Body, funcDel.Type, funcDel.Recv, name) {
+			r.stats.Funcs++
 			flag = true
 		}
 
-		// 递归处理函数体中的所有语句，查找go语句
-		if r.processStmtList(funcDel.Body.List) {
+		// 处理函数体中的所有闭包
+		if r.processFuncLits(funcDel.Body) {
 			flag = true
 		}
 	}
+	// 包级变量初始化表达式中的闭包, 名称与ssa的init$N一致
+	if fileAllowed && r.processInitLits() {
+		flag = true
+	}
+	// 整个文件被排除且没有强制插桩的函数时, 不改动文件
+	if !fileAllowed && !flag {
+		return nil
+	}
 	if flag {
 		// 插入import
 		r.ImportFunctrace()
@@ -423,15 +614,29 @@ func (r *Rewrite) RewriteFile() {
 	buf := &bytes.Buffer{}
 	err := format.Node(buf, r.fset, r.f)
 	if err != nil {
-		fmt.Printf("rewrite found err:%s \n", err)
-		return
+		return fmt.Errorf("format: %w", err)
 	}
-	if debug {
-		fmt.Println(buf.String())
-		return
+	if r.lineDirectives && flag {
+		buf = bytes.NewBuffer(addLineDirectives(r.fullPath, r.src, buf.Bytes()))
+	}
+	if r.dryRun != nil {
+		if err = r.dryRun.record(r.fullPath, buf.Bytes(), r.stats); err != nil {
+			return fmt.Errorf("dry run: %w", err)
+		}
+		return nil
+	}
+	if r.overlay != nil {
+		// overlay模式下只记录有改动的文件
+		if !flag {
+			return nil
+		}
+		if err = r.overlay.WriteFile(r.fullPath, buf.Bytes()); err != nil {
+			return fmt.Errorf("write overlay: %w", err)
+		}
+		return nil
 	}
 	if err = os.WriteFile(r.fullPath, buf.Bytes(), 0o666); err != nil {
-		fmt.Printf("write %s error: %v\n", r.fullPath, err)
-		return
+		return fmt.Errorf("write: %w", err)
 	}
+	return nil
 }
@@ -1,6 +1,9 @@
 package rewrite
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"go/ast"
 	"go/token"
 	"os"
@@ -404,129 +407,57 @@ func TestProcessFuncLit(t *testing.T) {
 	}
 }
 
-func TestProcessGoStmt(t *testing.T) {
-	rewrite := &Rewrite{}
-
+func TestProcessFuncLits(t *testing.T) {
 	tests := []struct {
 		name     string
-		goStmt   *ast.GoStmt
-		expected bool
+		body     string
+		expected Stats
+		names    []string
 	}{
 		{
-			name: "direct func literal",
-			goStmt: &ast.GoStmt{
-				Call: &ast.CallExpr{
-					Fun: &ast.FuncLit{
-						Type: &ast.FuncType{},
-						Body: &ast.BlockStmt{
-							List: []ast.Stmt{},
-						},
-					},
-				},
-			},
-			expected: true,
+			name:     "go statement and call argument",
+			body:     "go func() {}()\n\tgo run(func() {})",
+			expected: Stats{Closures: 1, Goroutines: 1},
+			names:    []string{"f$1", "f$2"},
 		},
 		{
-			name: "function call with func literal parameter",
-			goStmt: &ast.GoStmt{
-				Call: &ast.CallExpr{
-					Fun: &ast.Ident{Name: "someFunc"},
-					Args: []ast.Expr{
-						&ast.FuncLit{
-							Type: &ast.FuncType{},
-							Body: &ast.BlockStmt{
-								List: []ast.Stmt{},
-							},
-						},
-					},
-				},
-			},
-			expected: true,
+			name:     "assigned, returned and struct literal",
+			body:     "h := func() {}\n\t_ = T{F: func() {}}\n\treturn func() {}",
+			expected: Stats{Closures: 3},
+			names:    []string{"f$1", "f$2", "f$3"},
 		},
 		{
-			name: "function call without func literal",
-			goStmt: &ast.GoStmt{
-				Call: &ast.CallExpr{
-					Fun: &ast.Ident{Name: "someFunc"},
-					Args: []ast.Expr{
-						&ast.BasicLit{Kind: token.STRING, Value: `"test"`},
-					},
-				},
-			},
-			expected: false,
+			name:     "defer and nested closures",
+			body:     "defer func() {\n\t\tg := func() {}\n\t\tg()\n\t}()",
+			expected: Stats{Closures: 2},
+			names:    []string{"f$1", "f$1$1"},
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := rewrite.processGoStmt(tt.goStmt)
-			if result != tt.expected {
-				t.Errorf("Expected %v, got %v", tt.expected, result)
-			}
-		})
-	}
-}
-
-func TestProcessCallExpr(t *testing.T) {
-	rewrite := &Rewrite{}
-
-	tests := []struct {
-		name     string
-		callExpr *ast.CallExpr
-		expected bool
-	}{
 		{
-			name: "no func literal parameters",
-			callExpr: &ast.CallExpr{
-				Fun: &ast.Ident{Name: "someFunc"},
-				Args: []ast.Expr{
-					&ast.BasicLit{Kind: token.STRING, Value: `"test"`},
-				},
-			},
-			expected: false,
-		},
-		{
-			name: "with func literal parameter",
-			callExpr: &ast.CallExpr{
-				Fun: &ast.Ident{Name: "someFunc"},
-				Args: []ast.Expr{
-					&ast.FuncLit{
-						Type: &ast.FuncType{},
-						Body: &ast.BlockStmt{
-							List: []ast.Stmt{},
-						},
-					},
-				},
-			},
-			expected: true,
-		},
-		{
-			name: "nested function call",
-			callExpr: &ast.CallExpr{
-				Fun: &ast.Ident{Name: "outerFunc"},
-				Args: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.Ident{Name: "innerFunc"},
-						Args: []ast.Expr{
-							&ast.FuncLit{
-								Type: &ast.FuncType{},
-								Body: &ast.BlockStmt{
-									List: []ast.Stmt{},
-								},
-							},
-						},
-					},
-				},
-			},
-			expected: true,
+			name:     "no func literal",
+			body:     "run(nil)",
+			expected: Stats{},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := rewrite.processCallExpr(tt.callExpr)
-			if result != tt.expected {
-				t.Errorf("Expected %v, got %v", tt.expected, result)
+			r := parseTestFile(t, "package p\n\nfunc f() func() {\n\t"+tt.body+"\n\treturn nil\n}\n")
+			fd := r.f.Decls[0].(*ast.FuncDecl)
+			r.litNames = funcLitNames(fd)
+			modified := r.processFuncLits(fd.Body)
+			if modified != (tt.expected.Total() > 0) || r.Stats() != tt.expected {
+				t.Errorf("Expected %+v, got %+v (modified %v)", tt.expected, r.Stats(), modified)
+			}
+
+			var traced []string
+			ast.Inspect(fd.Body, func(n ast.Node) bool {
+				if lit, ok := n.(*ast.FuncLit); ok && r.hasSameDeferInBody(lit.Body) {
+					traced = append(traced, r.litNames[lit])
+				}
+				return true
+			})
+			if strings.Join(traced, ",") != strings.Join(tt.names, ",") {
+				t.Errorf("Expected traced closures %v, got %v", tt.names, traced)
 			}
 		})
 	}
@@ -764,3 +695,80 @@ func TestMain(t *testing.T) {
 		t.Error("test_test.go should not be modified")
 	}
 }
+
+func TestRewriteDir_Report(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"main.go":   "package main\n\nfunc main() {\n\tgo func() {}()\n}\n",
+		"types.go":  "package main\n\ntype T struct{}\n",
+		"broken.go": "package main\n\nfunc broken( {\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create file %s: %v", name, err)
+		}
+	}
+
+	progress := make(chan []byte, 10)
+	report := RewriteDir(tmpDir, WithProgress(progress))
+	if len(report.Rewritten) != 1 || filepath.Base(report.Rewritten[0]) != "main.go" {
+		t.Errorf("Unexpected rewritten files: %v", report.Rewritten)
+	}
+	if len(report.Skipped) != 1 || filepath.Base(report.Skipped[0]) != "types.go" {
+		t.Errorf("Unexpected skipped files: %v", report.Skipped)
+	}
+	if len(report.Errors) == 0 || filepath.Base(report.Errors[0].Path) != "broken.go" || report.Errors[0].Line != 3 {
+		t.Errorf("Expected parse error with position in broken.go, got %v", report.Errors)
+	}
+	if report.Stats != (Stats{Funcs: 1, Goroutines: 1}) {
+		t.Errorf("Unexpected stats: %+v", report.Stats)
+	}
+	if len(progress) != 3 {
+		t.Errorf("Expected one progress message per file, got %d", len(progress))
+	}
+}
+
+func TestRewriteDirContext(t *testing.T) {
+	tmpDir := t.TempDir()
+	for i := 0; i < 40; i++ {
+		pkgDir := filepath.Join(tmpDir, fmt.Sprintf("pkg%d", i%4))
+		if err := os.MkdirAll(pkgDir, 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		content := fmt.Sprintf("package pkg\n\nfunc f%d(n int) {\n\tgo func() {}()\n}\n", i)
+		if err := os.WriteFile(filepath.Join(pkgDir, fmt.Sprintf("f%d.go", i)), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create file: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "broken.go"), []byte("package pkg\n\nfunc {"), 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
+	// 并发结果应与串行一致
+	serial, parallel := NewDryRun(), NewDryRun()
+	serialReport, err := RewriteDirContext(context.Background(), tmpDir, WithDryRun(serial), WithConcurrency(1))
+	if err == nil || !strings.Contains(err.Error(), "broken.go") {
+		t.Errorf("Expected aggregated error for broken.go, got %v", err)
+	}
+	parallelReport, _ := RewriteDirContext(context.Background(), tmpDir, WithDryRun(parallel), WithConcurrency(8))
+	if serial.Diff() != parallel.Diff() {
+		t.Error("Parallel dry run diff should equal serial diff")
+	}
+	if strings.Join(serialReport.Rewritten, ",") != strings.Join(parallelReport.Rewritten, ",") || len(parallelReport.Rewritten) != 40 {
+		t.Errorf("Unexpected rewritten files: %d", len(parallelReport.Rewritten))
+	}
+	if parallelReport.Stats != (Stats{Funcs: 40, Goroutines: 40}) {
+		t.Errorf("Unexpected stats: %+v", parallelReport.Stats)
+	}
+
+	// 已取消的context不处理任何文件
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report, err := RewriteDirContext(ctx, tmpDir, WithConcurrency(4))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if len(report.Rewritten) != 0 {
+		t.Errorf("No file should be rewritten after cancel, got %v", report.Rewritten)
+	}
+}
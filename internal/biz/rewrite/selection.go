@@ -0,0 +1,185 @@
+package rewrite
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/toheart/goanalysis/internal/biz/callgraph/dos"
+	"github.com/toheart/goanalysis/internal/biz/repo"
+)
+
+// Direction 沿调用图扩展的方向
+type Direction string
+
+const (
+	DirectionCallers Direction = "callers" // 上游, 调用方
+	DirectionCallees Direction = "callees" // 下游, 被调用方
+	DirectionBoth    Direction = "both"
+)
+
+// ParseDirection 解析扩展方向, 为空时默认为both
+func ParseDirection(s string) (Direction, error) {
+	switch d := Direction(s); d {
+	case "":
+		return DirectionBoth, nil
+	case DirectionCallers, DirectionCallees, DirectionBoth:
+		return d, nil
+	}
+	return "", fmt.Errorf("invalid direction %q, must be one of callers, callees, both", s)
+}
+
+// Selection
+//
+//	@Description: 按函数标识限定插桩范围, 只有选中的函数和闭包才会插桩
+type Selection struct {
+	mu      sync.Mutex
+	funcs   map[FuncID]struct{}
+	modules *moduleCache
+}
+
+// NewSelection 创建空的插桩范围
+func NewSelection() *Selection {
+	return &Selection{
+		funcs:   make(map[FuncID]struct{}),
+		modules: newModuleCache(),
+	}
+}
+
+// Add 将函数加入插桩范围
+func (s *Selection) Add(id FuncID) {
+	s.mu.Lock()
+	s.funcs[id] = struct{}{}
+	s.mu.Unlock()
+}
+
+// Has 判断函数是否在插桩范围内
+func (s *Selection) Has(id FuncID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.funcs[id]
+	return ok
+}
+
+// Len 返回选中的函数数量
+func (s *Selection) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.funcs)
+}
+
+// Funcs 返回按完整函数名排序的选中函数
+func (s *Selection) Funcs() []FuncID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]FuncID, 0, len(s.funcs))
+	for id := range s.funcs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+	return ids
+}
+
+// pkgPath 返回源文件所属包的导入路径
+func (s *Selection) pkgPath(fullPath string) string {
+	return s.modules.pkgPath(filepath.Dir(fullPath))
+}
+
+// addFile 将文件中满足条件的函数声明和闭包加入插桩范围
+func (s *Selection) addFile(fullPath string, f *ast.File, match func(node ast.Node) bool) {
+	pkg := s.pkgPath(fullPath)
+	for _, decl := range f.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || fd.Body == nil {
+			continue
+		}
+		if match(fd) {
+			s.Add(FuncID{Pkg: pkg, Name: funcDeclName(fd)})
+		}
+		for lit, name := range funcLitNames(fd) {
+			if match(lit) {
+				s.Add(FuncID{Pkg: pkg, Name: name})
+			}
+		}
+	}
+}
+
+// Expand
+//
+//	@Description: 根据静态调用图将当前范围沿调用方/被调用方扩展depth层
+//	@param store 静态分析数据库
+//	@param depth 扩展层数, 小于等于0时不扩展
+//	@param direction 扩展方向
+//	@return int 新增的函数数量
+func (s *Selection) Expand(store repo.StaticDBStore, depth int, direction Direction) (int, error) {
+	if depth <= 0 {
+		return 0, nil
+	}
+	nodes, err := store.GetAllFuncNodes()
+	if err != nil {
+		return 0, fmt.Errorf("load func nodes failed: %w", err)
+	}
+	var keys []string
+	for _, node := range nodes {
+		if s.Has(FuncID{Pkg: node.Pkg, Name: node.Name}) {
+			keys = append(keys, node.Key)
+		}
+	}
+	before := s.Len()
+	if err = s.walk(store, keys, depth, direction); err != nil {
+		return 0, err
+	}
+	return s.Len() - before, nil
+}
+
+// walk 从起始节点沿调用图广度优先遍历, 将经过的函数加入范围, depth小于0时不限制层数
+func (s *Selection) walk(store repo.StaticDBStore, keys []string, depth int, direction Direction) error {
+	frontier := keys
+	visited := make(map[string]bool)
+	for _, key := range keys {
+		visited[key] = true
+	}
+	for level := 0; (depth < 0 || level < depth) && len(frontier) > 0; level++ {
+		var next []string
+		for _, key := range frontier {
+			neighbors, err := neighborNodes(store, key, direction)
+			if err != nil {
+				return err
+			}
+			for _, node := range neighbors {
+				if visited[node.Key] {
+					continue
+				}
+				visited[node.Key] = true
+				s.Add(FuncID{Pkg: node.Pkg, Name: node.Name})
+				next = append(next, node.Key)
+			}
+		}
+		frontier = next
+	}
+	return nil
+}
+
+// neighborNodes 按方向返回函数在调用图中的相邻节点
+func neighborNodes(store repo.StaticDBStore, key string, direction Direction) ([]*dos.FuncNode, error) {
+	var result []*dos.FuncNode
+	if direction == DirectionCallers || direction == DirectionBoth {
+		callers, err := store.GetCallerEdges(key)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, callers...)
+	}
+	if direction == DirectionCallees || direction == DirectionBoth {
+		callees, err := store.GetCalleeEdges(key)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, callees...)
+	}
+	return result, nil
+}
@@ -0,0 +1,131 @@
+package rewrite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/toheart/goanalysis/internal/biz/callgraph/dos"
+	"github.com/toheart/goanalysis/internal/biz/repo"
+)
+
+// fakeStaticDB 内存中的静态调用图
+type fakeStaticDB struct {
+	repo.StaticDBStore
+	nodes map[string]*dos.FuncNode
+	edges []*dos.FuncEdge
+}
+
+func (f *fakeStaticDB) GetFuncNodeByKey(key string) (*dos.FuncNode, error) {
+	return f.nodes[key], nil
+}
+
+func (f *fakeStaticDB) GetAllFuncNodes() ([]*dos.FuncNode, error) {
+	var nodes []*dos.FuncNode
+	for _, node := range f.nodes {
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func (f *fakeStaticDB) GetCallerEdges(calleeKey string) ([]*dos.FuncNode, error) {
+	var nodes []*dos.FuncNode
+	for _, edge := range f.edges {
+		if edge.CalleeKey == calleeKey {
+			nodes = append(nodes, f.nodes[edge.CallerKey])
+		}
+	}
+	return nodes, nil
+}
+
+func (f *fakeStaticDB) GetCalleeEdges(callerKey string) ([]*dos.FuncNode, error) {
+	var nodes []*dos.FuncNode
+	for _, edge := range f.edges {
+		if edge.CallerKey == callerKey {
+			nodes = append(nodes, f.nodes[edge.CalleeKey])
+		}
+	}
+	return nodes, nil
+}
+
+// newChainDB 构造调用链 a -> b -> c -> d
+func newChainDB() *fakeStaticDB {
+	db := &fakeStaticDB{nodes: make(map[string]*dos.FuncNode)}
+	names := []string{"a", "b", "c", "d"}
+	for i, name := range names {
+		key := "n" + name
+		db.nodes[key] = &dos.FuncNode{Key: key, FullName: key + ":example.com/demo." + name, Pkg: "example.com/demo", Name: name}
+		if i > 0 {
+			db.edges = append(db.edges, &dos.FuncEdge{CallerKey: "n" + names[i-1], CalleeKey: key})
+		}
+	}
+	return db
+}
+
+func TestSelection_Expand(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		depth     int
+		expected  []string
+	}{
+		{DirectionCallees, 1, []string{"b", "c"}},
+		{DirectionCallees, 5, []string{"b", "c", "d"}},
+		{DirectionCallers, 1, []string{"a", "b"}},
+		{DirectionBoth, 1, []string{"a", "b", "c"}},
+		{DirectionBoth, 0, []string{"b"}},
+	}
+	for _, tt := range tests {
+		selection := NewSelection()
+		selection.Add(FuncID{Pkg: "example.com/demo", Name: "b"})
+		if _, err := selection.Expand(newChainDB(), tt.depth, tt.direction); err != nil {
+			t.Fatalf("Expand failed: %v", err)
+		}
+		funcs := selection.Funcs()
+		if len(funcs) != len(tt.expected) {
+			t.Errorf("%s depth %d: expected %v, got %v", tt.direction, tt.depth, tt.expected, funcs)
+			continue
+		}
+		for i, name := range tt.expected {
+			if funcs[i].Name != name {
+				t.Errorf("%s depth %d: expected %v, got %v", tt.direction, tt.depth, tt.expected, funcs)
+				break
+			}
+		}
+	}
+}
+
+func TestReachableSelection(t *testing.T) {
+	db := newChainDB()
+	db.nodes["nother"] = &dos.FuncNode{Key: "nother", Pkg: "example.com/other", Name: "b"}
+
+	tests := []struct {
+		root      string
+		depth     int
+		direction Direction
+		expected  []string
+	}{
+		{"nb", 0, DirectionCallees, []string{"b", "c", "d"}},
+		{"example.com/demo.b", 1, DirectionCallees, []string{"b", "c"}},
+		{"nc:example.com/demo.c", 0, DirectionCallers, []string{"a", "b", "c"}},
+		{"d", 2, DirectionCallers, []string{"b", "c", "d"}},
+	}
+	for _, tt := range tests {
+		selection, err := ReachableSelection(db, tt.root, tt.depth, tt.direction)
+		if err != nil {
+			t.Fatalf("ReachableSelection(%s) failed: %v", tt.root, err)
+		}
+		var names []string
+		for _, id := range selection.Funcs() {
+			names = append(names, id.Name)
+		}
+		if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("root %s: expected %v, got %v", tt.root, tt.expected, names)
+		}
+	}
+
+	if _, err := ReachableSelection(db, "b", 0, DirectionCallees); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("Expected ambiguous error, got %v", err)
+	}
+	if _, err := ReachableSelection(db, "missing", 0, DirectionCallees); err == nil {
+		t.Error("Expected error for missing root")
+	}
+}
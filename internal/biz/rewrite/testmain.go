@@ -0,0 +1,325 @@
+package rewrite
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+/**
+测试文件插桩: 测试函数和测试辅助函数与普通函数一样插桩, 测试进程退出前需要将追踪数据落盘.
+包中已有 func TestMain(m *testing.M) 时将其中的m.Run()改写为:
+
+	func() int {
+		_code := m.Run()
+		functrace.Flush()
+		return _code
+	}()
+
+没有TestMain的测试包生成 zz_trace_main_test.go, 在m.Run之后调用Flush再退出. Tracer.Init非空时在m.Run之前调用.
+Flush没有默认值: functrace模板插桩测试文件时必须指定(--tracer-flush), 避免生成引用不存在的函数、无法编译的TestMain;
+其他模板的Flush为空时不处理TestMain.
+**/
+
+const (
+	_testMainFunc     = "TestMain"
+	_testMainCodeName = "_code"
+	_testMainHeader   = "// Code generated by goanalysis rewrite. DO NOT EDIT."
+)
+
+// TestMainFileName 为没有TestMain的测试包生成的文件名
+const TestMainFileName = "zz_trace_main_test.go"
+
+// validateTestMain 校验Init和Flush函数名
+func (t *Tracer) validateTestMain() error {
+	for _, name := range []string{t.Init, t.Flush} {
+		if name != "" && (!token.IsIdentifier(name) || !token.IsExported(name)) {
+			return fmt.Errorf("invalid tracer test main func %q, must be an exported identifier", name)
+		}
+	}
+	return nil
+}
+
+// validateTests 校验测试文件插桩的模板, functrace模板必须指定Flush
+func (t *Tracer) validateTests() error {
+	if t.ImportPath == _defaultImport && t.Flush == "" {
+		return fmt.Errorf("tracer flush func is required to instrument tests with %s", _defaultImport)
+	}
+	return nil
+}
+
+// isTestFile 判断是否为测试文件
+func isTestFile(path string) bool {
+	return strings.HasSuffix(path, "_test.go")
+}
+
+// isGeneratedTestMain 判断源码是否为生成的TestMain文件
+func isGeneratedTestMain(src []byte) bool {
+	return bytes.HasPrefix(src, []byte(_testMainHeader))
+}
+
+// testMainParam 判断函数是否为 func TestMain(m *testing.M), 返回参数名; 参数未命名时名称为空
+func testMainParam(decl *ast.FuncDecl) (string, bool) {
+	if decl.Recv != nil || decl.Name.Name != _testMainFunc || decl.Body == nil {
+		return "", false
+	}
+	params := decl.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) > 1 {
+		return "", false
+	}
+	star, ok := params[0].Type.(*ast.StarExpr)
+	if !ok {
+		return "", false
+	}
+	se, ok := star.X.(*ast.SelectorExpr)
+	if !ok || se.Sel.Name != "M" {
+		return "", false
+	}
+	if len(params[0].Names) == 0 {
+		return "", true
+	}
+	return params[0].Names[0].Name, true
+}
+
+// isRunCall 判断表达式是否为 m.Run()
+func isRunCall(expr ast.Expr, param string) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return false
+	}
+	se, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || se.Sel.Name != "Run" {
+		return false
+	}
+	x, ok := se.X.(*ast.Ident)
+	return ok && x.Name == param
+}
+
+// pkgCall 生成 name.fn() 语句
+func pkgCall(name, fn string) *ast.ExprStmt {
+	return &ast.ExprStmt{X: &ast.CallExpr{Fun: &ast.SelectorExpr{X: ast.NewIdent(name), Sel: ast.NewIdent(fn)}}}
+}
+
+// isPkgCall 判断语句是否为 name.fn()
+func isPkgCall(stmt ast.Stmt, name, fn string) bool {
+	es, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	call, ok := es.X.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return false
+	}
+	se, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	x, ok := se.X.(*ast.Ident)
+	return ok && x.Name == name && se.Sel.Name == fn
+}
+
+// genFlushRun 生成在m.Run()之后调用Flush的包装调用
+func (t *Tracer) genFlushRun(name string, run ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{Fun: &ast.FuncLit{
+		Type: &ast.FuncType{
+			Params:  &ast.FieldList{},
+			Results: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("int")}}},
+		},
+		Body: &ast.BlockStmt{List: []ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent(_testMainCodeName)},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{run},
+			},
+			pkgCall(name, t.Flush),
+			&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent(_testMainCodeName)}},
+		}},
+	}}
+}
+
+// flushRun 判断表达式是否为genFlushRun生成的包装调用, 返回被包装的m.Run()调用和Flush语句
+func (t *Tracer) flushRun(expr ast.Node, name string) (ast.Expr, ast.Stmt, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 || t.Flush == "" {
+		return nil, nil, false
+	}
+	lit, ok := call.Fun.(*ast.FuncLit)
+	if !ok || len(lit.Body.List) != 3 {
+		return nil, nil, false
+	}
+	assign, ok := lit.Body.List[0].(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil, nil, false
+	}
+	if ident, ok := assign.Lhs[0].(*ast.Ident); !ok || ident.Name != _testMainCodeName {
+		return nil, nil, false
+	}
+	if !isPkgCall(lit.Body.List[1], name, t.Flush) {
+		return nil, nil, false
+	}
+	return assign.Rhs[0], lit.Body.List[1], true
+}
+
+// augmentTestMain 在已有的TestMain中插入Init调用, 并在每次m.Run()之后调用Flush; 已处理过时返回false
+func (r *Rewrite) augmentTestMain(decl *ast.FuncDecl) bool {
+	tracer, name := r.traceTemplate(), r.traceName()
+	param, ok := testMainParam(decl)
+	if !ok || param == "" || tracer.Flush == "" {
+		return false
+	}
+	wrapped, changed := false, false
+	astutil.Apply(decl.Body, func(c *astutil.Cursor) bool {
+		if _, _, ok := tracer.flushRun(c.Node(), name); ok {
+			wrapped = true
+			return false
+		}
+		return true
+	}, func(c *astutil.Cursor) bool {
+		if expr, ok := c.Node().(ast.Expr); ok && isRunCall(expr, param) {
+			c.Replace(tracer.genFlushRun(name, expr))
+			changed = true
+		}
+		return true
+	})
+	// 已插桩的TestMain再次处理时只会出现新增的m.Run(), 不重复插入Init
+	if tracer.Init != "" && !wrapped && changed {
+		init := pkgCall(name, tracer.Init)
+		anchorStmt(init, decl.Body.Lbrace)
+		decl.Body.List = append([]ast.Stmt{init}, decl.Body.List...)
+	}
+	return changed
+}
+
+// testMainSource 生成调用Init和Flush的TestMain文件
+func (t *Tracer) testMainSource(pkgName string) ([]byte, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s\n\npackage %s\n\nimport (\n\t\"os\"\n\t\"testing\"\n\n", _testMainHeader, pkgName)
+	if t.needsName() {
+		fmt.Fprintf(&sb, "\t%s %q\n)\n\n", t.Alias, t.ImportPath)
+	} else {
+		fmt.Fprintf(&sb, "\t%q\n)\n\n", t.ImportPath)
+	}
+	sb.WriteString("func TestMain(m *testing.M) {\n")
+	if t.Init != "" {
+		fmt.Fprintf(&sb, "\t%s.%s()\n", t.Alias, t.Init)
+	}
+	fmt.Fprintf(&sb, "\tcode := m.Run()\n\t%s.%s()\n\tos.Exit(code)\n}\n", t.Alias, t.Flush)
+	return format.Source([]byte(sb.String()))
+}
+
+// generateTestMains 为没有TestMain的测试包生成TestMain文件, 只在本次有文件被插桩时生成
+func (r *Rewrite) generateTestMains(files []string, report *Report) {
+	if r.traceTemplate().Flush == "" || len(report.Rewritten) == 0 {
+		return
+	}
+	testFiles := make(map[string][]string)
+	for _, path := range files {
+		if isTestFile(path) {
+			dir := filepath.Dir(path)
+			testFiles[dir] = append(testFiles[dir], path)
+		}
+	}
+	dirs := make([]string, 0, len(testFiles))
+	for dir := range testFiles {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		path, err := r.generateTestMain(dir, testFiles[dir])
+		if err != nil {
+			report.addError(path, err)
+			continue
+		}
+		if path != "" {
+			report.addGenerated(path)
+			r.sendProgress("%s: generated", path)
+		}
+	}
+}
+
+// generateTestMain 为目录中的测试包生成TestMain文件, 已有TestMain时返回空路径
+func (r *Rewrite) generateTestMain(dir string, testFiles []string) (string, error) {
+	path := filepath.Join(dir, TestMainFileName)
+	pkgName := ""
+	fset := token.NewFileSet()
+	for _, file := range testFiles {
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			// 解析错误已在重写时记录
+			continue
+		}
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv != nil || fd.Name.Name != _testMainFunc {
+				continue
+			}
+			if _, ok := testMainParam(fd); !ok {
+				return file, errors.New("TestMain is not func(*testing.M), traces of this package are not flushed")
+			}
+			return "", nil
+		}
+		if pkgName == "" || !strings.HasSuffix(f.Name.Name, "_test") {
+			pkgName = strings.TrimSuffix(f.Name.Name, "_test")
+		}
+	}
+	if pkgName == "" {
+		return "", nil
+	}
+	content, err := r.traceTemplate().testMainSource(pkgName)
+	if err != nil {
+		return path, fmt.Errorf("format: %w", err)
+	}
+	if r.dryRun != nil {
+		if err = r.dryRun.record(path, content, Stats{}); err != nil {
+			return path, fmt.Errorf("dry run: %w", err)
+		}
+		return path, nil
+	}
+	if r.overlay != nil {
+		if err = r.overlay.WriteFile(path, content); err != nil {
+			return path, fmt.Errorf("write overlay: %w", err)
+		}
+		return path, nil
+	}
+	if src, err := os.ReadFile(path); err == nil && !isGeneratedTestMain(src) {
+		return path, fmt.Errorf("%s already exists and was not generated", TestMainFileName)
+	}
+	if err = os.WriteFile(path, content, 0o644); err != nil {
+		return path, fmt.Errorf("write: %w", err)
+	}
+	return path, nil
+}
+
+// testMainSpans 计算撤销TestMain改动需要的区间: 删除Init调用, 将包装调用还原为m.Run()
+func testMainSpans(tf *token.File, src []byte, decl *ast.FuncDecl, tracer *Tracer, name string, removed map[ast.Stmt]bool) []span {
+	if _, ok := testMainParam(decl); !ok {
+		return nil
+	}
+	var cuts []span
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		run, flush, ok := tracer.flushRun(n, name)
+		if !ok {
+			return true
+		}
+		removed[flush] = true
+		text := string(src[tf.Offset(run.Pos()):tf.Offset(run.End())])
+		cuts = append(cuts, span{start: tf.Offset(n.Pos()), end: tf.Offset(n.End()), text: text})
+		return false
+	})
+	if len(cuts) > 0 && tracer.Init != "" && len(decl.Body.List) > 0 && isPkgCall(decl.Body.List[0], name, tracer.Init) {
+		first := decl.Body.List[0]
+		removed[first] = true
+		cuts = append(cuts, lineSpan(tf, src, first.Pos(), first.End()))
+	}
+	return cuts
+}
@@ -0,0 +1,222 @@
+package rewrite
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles 在dir下按相对路径写入文件
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestRewriteDir_Tests(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"calc/calc.go": "package calc\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n",
+		"calc/calc_test.go": `package calc_test
+
+import (
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	check(t, 3)
+}
+
+func check(t *testing.T, n int) {
+	t.Helper()
+}
+`,
+		"server/server.go": "package server\n\nfunc Run() {}\n",
+		"server/main_test.go": `package server
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(m.Run())
+}
+`,
+	}
+	writeFiles(t, dir, files)
+	tracer, err := NewTracer(&Tracer{Init: "Init", Flush: "CloseTraceInstance"})
+	if err != nil {
+		t.Fatalf("NewTracer failed: %v", err)
+	}
+
+	report := RewriteDir(dir, WithTests(), WithTracer(tracer))
+	if len(report.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", report.Err())
+	}
+	generated := filepath.Join(dir, "calc", TestMainFileName)
+	if len(report.Generated) != 1 || report.Generated[0] != generated {
+		t.Fatalf("Expected %s to be generated, got %v", generated, report.Generated)
+	}
+	content, err := os.ReadFile(generated)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	for _, want := range []string{"package calc\n", "functrace.Init()\n\tcode := m.Run()\n\tfunctrace.CloseTraceInstance()\n\tos.Exit(code)"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Expected %q in:\n%s", want, content)
+		}
+	}
+	calcTest, _ := os.ReadFile(filepath.Join(dir, "calc/calc_test.go"))
+	if n := strings.Count(string(calcTest), "defer functrace.Trace"); n != 2 {
+		t.Errorf("Expected test and helper to be instrumented, got %d defers:\n%s", n, calcTest)
+	}
+	mainTest, _ := os.ReadFile(filepath.Join(dir, "server/main_test.go"))
+	for _, want := range []string{"functrace.Init()\n\tos.Exit(func() int {", "_code := m.Run()\n\t\tfunctrace.CloseTraceInstance()\n\t\treturn _code\n\t}())"} {
+		if !strings.Contains(string(mainTest), want) {
+			t.Errorf("Expected %q in:\n%s", want, mainTest)
+		}
+	}
+	if strings.Contains(string(mainTest), "defer functrace.Trace") {
+		t.Errorf("TestMain should not be traced:\n%s", mainTest)
+	}
+
+	// 再次插桩不会重复修改TestMain或重新生成文件
+	report = RewriteDir(dir, WithTests(), WithTracer(tracer))
+	if len(report.Errors) != 0 || len(report.Generated) != 0 || len(report.Rewritten) != 0 {
+		t.Errorf("Expected second run to be a no-op, got %s", report)
+	}
+
+	manifest, err := UndoDir(dir, WithTracer(tracer))
+	if err != nil {
+		t.Fatalf("UndoDir failed: %v", err)
+	}
+	if len(manifest.Files) != 5 {
+		t.Errorf("Expected 5 undo entries, got %d", len(manifest.Files))
+	}
+	if _, err := os.Stat(generated); !os.IsNotExist(err) {
+		t.Errorf("Generated TestMain should be removed, stat err: %v", err)
+	}
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if string(got) != want {
+			t.Errorf("Undo should restore %s:\n%s", name, got)
+		}
+	}
+}
+
+func TestRewriteDir_TestMainSignature(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"p.go":      "package p\n\nfunc F() {}\n",
+		"p_test.go": "package p\n\nimport \"testing\"\n\nfunc TestMain(t *testing.T) {}\n",
+	})
+	tracer, err := NewTracer(&Tracer{Flush: "CloseTraceInstance"})
+	if err != nil {
+		t.Fatalf("NewTracer failed: %v", err)
+	}
+	report := RewriteDir(dir, WithTests(), WithTracer(tracer))
+	if len(report.Errors) != 1 || !strings.Contains(report.Errors[0].Message, "TestMain is not func(*testing.M)") {
+		t.Errorf("Expected TestMain signature error, got %v", report.Errors)
+	}
+	if len(report.Generated) != 0 {
+		t.Errorf("Should not generate a second TestMain, got %v", report.Generated)
+	}
+}
+
+func TestRewriteDir_TestsRequireFlush(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"p.go":      "package p\n\nfunc F() {}\n",
+		"p_test.go": "package p\n\nimport \"testing\"\n\nfunc TestF(t *testing.T) {}\n",
+	}
+	writeFiles(t, dir, files)
+	// functrace模板没有默认的落盘函数, 不指定时不改写任何文件
+	report := RewriteDir(dir, WithTests())
+	if err := report.Err(); err == nil || !strings.Contains(err.Error(), "flush func is required") {
+		t.Errorf("Expected flush func error, got %v", err)
+	}
+	for name, want := range files {
+		if got, _ := os.ReadFile(filepath.Join(dir, name)); string(got) != want {
+			t.Errorf("%s should be left untouched, got:\n%s", name, got)
+		}
+	}
+}
+
+const flushTracer = `package tr
+
+import "fmt"
+
+var calls int
+
+func Init() { fmt.Println("init") }
+
+func Trace(params ...interface{}) func() {
+	calls++
+	return func() {}
+}
+
+func Flush() { fmt.Println("flushed", calls) }
+`
+
+func TestRewriteDir_TestsFlush(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go test in short mode")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":       "module example.com/demo\n\ngo 1.21\n",
+		"tr/tr.go":     flushTracer,
+		"calc/calc.go": "package calc\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n",
+		"calc/calc_test.go": `package calc
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	check(t, Add(1, 2), 3)
+}
+
+func check(t *testing.T, got, want int) {
+	if got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
+`,
+	})
+	tracer, err := NewTracer(&Tracer{ImportPath: "example.com/demo/tr", Func: "Trace", Params: ParamsVariadic, Init: "Init", Flush: "Flush"})
+	if err != nil {
+		t.Fatalf("NewTracer failed: %v", err)
+	}
+	report := RewriteDir(filepath.Join(dir, "calc"), WithTests(), WithTracer(tracer))
+	if len(report.Errors) != 0 || len(report.Generated) != 1 {
+		t.Fatalf("Unexpected report: %s, %v", report, report.Err())
+	}
+
+	cmd := exec.Command("go", "test", "-v", "./calc")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod", "GOPROXY=off")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go test failed: %v\n%s", err, out)
+	}
+	// TestAdd、check、Add各调用一次
+	for _, want := range []string{"init\n", "--- PASS: TestAdd", "flushed 3\n"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("Expected %q in go test output:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package rewrite
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// 插桩语句的调用形式
+const (
+	ShapeDeferReturn = "defer-return" // defer pkg.Func(params)(), Func返回退出时执行的函数
+	ShapeDefer       = "defer"        // defer pkg.Func(params)
+	ShapeOTelSpan    = "otel-span"    // ctx, span := otel.Tracer(pkg).Start(ctx, name); defer span.End()
+)
+
+// 参数传递方式
+const (
+	ParamsSlice    = "slice"    // pkg.Func([]interface{}{a, b})
+	ParamsVariadic = "variadic" // pkg.Func(a, b)
+	ParamsNone     = "none"     // pkg.Func()
+)
+
+// Tracer
+//
+//	@Description: 插桩模板, 描述插入的defer语句形式, 默认为 defer functrace.Trace([]interface{}{...})()
+type Tracer struct {
+	ImportPath string `json:"importPath"`
+	Alias      string `json:"alias"` // 包名或导入别名, 为空时取导入路径最后一段
+	Func       string `json:"func"`
+	Shape      string `json:"shape"`
+	Params     string `json:"params"`
+
+	// 返回值捕获模式, 额外插入记录返回错误和panic的defer语句, 由Result指定的函数接收(err error, recovered interface{})
+	Capture bool   `json:"capture,omitempty"`
+	Result  string `json:"result,omitempty"`
+
+	// 测试文件插桩时生成或修改TestMain: Init在m.Run之前调用, Flush在m.Run之后调用; Flush为空时不处理TestMain
+	Init  string `json:"init,omitempty"`
+	Flush string `json:"flush,omitempty"`
+
+	// otel-span模式下, 没有context.Context参数的函数获取context的表达式, 为skip时不插桩
+	Fallback       string `json:"fallback,omitempty"`
+	FallbackImport string `json:"fallbackImport,omitempty"` // Fallback表达式依赖的导入路径
+}
+
+// DefaultTracer 返回functrace插桩模板, functrace没有约定接收返回值和落盘的函数, Result和Flush需要显式指定
+func DefaultTracer() *Tracer {
+	return &Tracer{
+		ImportPath: _defaultImport,
+		Alias:      "functrace",
+		Func:       "Trace",
+		Shape:      ShapeDeferReturn,
+		Params:     ParamsSlice,
+	}
+}
+
+// NewTracer 校验模板并补全默认值, 未设置导入路径时使用functrace, otel-span模式下使用otel全局TracerProvider
+func NewTracer(t *Tracer) (*Tracer, error) {
+	if t != nil && t.Shape == ShapeOTelSpan {
+		if t.Capture {
+			return nil, fmt.Errorf("result capture is not supported by %s shape", ShapeOTelSpan)
+		}
+		return newOTelTracer(t)
+	}
+	if t == nil || t.ImportPath == "" {
+		if t != nil && (t.Alias != "" || t.Func != "") {
+			return nil, fmt.Errorf("tracer import path is required")
+		}
+		tracer := DefaultTracer()
+		if t != nil {
+			tracer.Capture = t.Capture
+			tracer.Result = t.Result
+			tracer.Init = t.Init
+			tracer.Flush = t.Flush
+		}
+		if err := tracer.validateResult(); err != nil {
+			return nil, err
+		}
+		if err := tracer.validateTestMain(); err != nil {
+			return nil, err
+		}
+		return tracer, nil
+	}
+	tracer := *t
+	if tracer.Alias == "" {
+		tracer.Alias = path.Base(tracer.ImportPath)
+	}
+	if !token.IsIdentifier(tracer.Alias) || tracer.Alias == "_" {
+		return nil, fmt.Errorf("invalid tracer alias %q", tracer.Alias)
+	}
+	if !token.IsIdentifier(tracer.Func) || !token.IsExported(tracer.Func) {
+		return nil, fmt.Errorf("invalid tracer func %q, must be an exported identifier", tracer.Func)
+	}
+	switch tracer.Shape {
+	case "":
+		tracer.Shape = ShapeDeferReturn
+	case ShapeDeferReturn, ShapeDefer:
+	default:
+		return nil, fmt.Errorf("invalid tracer shape %q, must be one of %s, %s, %s", tracer.Shape, ShapeDeferReturn, ShapeDefer, ShapeOTelSpan)
+	}
+	switch tracer.Params {
+	case "":
+		tracer.Params = ParamsSlice
+	case ParamsSlice, ParamsVariadic, ParamsNone:
+	default:
+		return nil, fmt.Errorf("invalid tracer params %q, must be one of %s, %s, %s", tracer.Params, ParamsSlice, ParamsVariadic, ParamsNone)
+	}
+	if err := tracer.validateResult(); err != nil {
+		return nil, err
+	}
+	if err := tracer.validateTestMain(); err != nil {
+		return nil, err
+	}
+	return &tracer, nil
+}
+
+// String 返回插桩语句示意
+func (t *Tracer) String() string {
+	if t.Shape == ShapeOTelSpan {
+		return fmt.Sprintf("ctx, span := %s.%s(pkg).Start(ctx, name); defer span.End()", t.Alias, t.Func)
+	}
+	var args string
+	switch t.Params {
+	case ParamsSlice:
+		args = "[]interface{}{...}"
+	case ParamsVariadic:
+		args = "..."
+	}
+	stmt := fmt.Sprintf("defer %s.%s(%s)", t.Alias, t.Func, args)
+	if t.Shape == ShapeDeferReturn {
+		stmt += "()"
+	}
+	if t.Capture {
+		stmt += fmt.Sprintf("; defer func() { ... %s.%s(err, recover()) ... }()", t.Alias, t.Result)
+	}
+	return stmt
+}
+
+// localName 返回文件中引用追踪包使用的名称, 已导入时以导入声明中的别名为准
+func (t *Tracer) localName(f *ast.File) string {
+	for _, spec := range f.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || p != t.ImportPath {
+			continue
+		}
+		if spec.Name == nil {
+			return t.Alias
+		}
+		if spec.Name.Name != "_" && spec.Name.Name != "." {
+			return spec.Name.Name
+		}
+	}
+	return t.Alias
+}
+
+// isImported 判断文件是否已经以可引用的方式导入追踪包
+func (t *Tracer) isImported(f *ast.File) bool {
+	for _, spec := range f.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || p != t.ImportPath {
+			continue
+		}
+		if spec.Name == nil || (spec.Name.Name != "_" && spec.Name.Name != ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// needsName 导入时是否需要显式指定包名
+func (t *Tracer) needsName() bool {
+	base := path.Base(t.ImportPath)
+	// gopkg.in/yaml.v3、github.com/foo/go-bar 等路径的包名无法从路径推断
+	return t.Alias != base || strings.ContainsAny(base, ".-")
+}
+
+// isTraceCall 判断语句是否为按模板生成的defer语句, name为文件中追踪包的引用名
+func (t *Tracer) isTraceCall(stmt ast.Stmt, name string) bool {
+	if t.Shape == ShapeOTelSpan {
+		return t.isSpanStart(stmt, name)
+	}
+	ds, ok := stmt.(*ast.DeferStmt)
+	if !ok {
+		return false
+	}
+	fun := ds.Call.Fun
+	if t.Shape == ShapeDeferReturn {
+		ce, ok := fun.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		fun = ce.Fun
+	}
+	se, ok := fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	x, ok := se.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	return x.Name == name && se.Sel.Name == t.Func
+}
+
+// genDefer 按模板生成defer语句, name为文件中追踪包的引用名
+func (t *Tracer) genDefer(name string, params []ast.Expr) *ast.DeferStmt {
+	var args []ast.Expr
+	switch t.Params {
+	case ParamsSlice:
+		args = []ast.Expr{&ast.CompositeLit{
+			Type: &ast.ArrayType{
+				Elt: &ast.InterfaceType{ // 空接口
+					Methods: &ast.FieldList{
+						List: []*ast.Field{},
+					},
+				},
+			},
+			Elts: params,
+		}}
+	case ParamsVariadic:
+		args = params
+	}
+
+	call := &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent(name),
+			Sel: ast.NewIdent(t.Func),
+		},
+		Args: args,
+	}
+	if t.Shape == ShapeDeferReturn {
+		call = &ast.CallExpr{Fun: call}
+	}
+	return &ast.DeferStmt{Call: call}
+}
@@ -0,0 +1,145 @@
+package rewrite
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewTracer(t *testing.T) {
+	tracer, err := NewTracer(nil)
+	if err != nil || tracer.ImportPath != _defaultImport {
+		t.Fatalf("Expected default tracer, got %+v, %v", tracer, err)
+	}
+
+	tracer, err = NewTracer(&Tracer{ImportPath: "example.com/obs/go-span", Alias: "span", Func: "Start"})
+	if err != nil {
+		t.Fatalf("NewTracer failed: %v", err)
+	}
+	if tracer.Shape != ShapeDeferReturn || tracer.Params != ParamsSlice {
+		t.Errorf("Expected default shape and params, got %+v", tracer)
+	}
+	if !tracer.needsName() {
+		t.Error("go-span should be imported with an explicit name")
+	}
+	if got := tracer.String(); got != "defer span.Start([]interface{}{...})()" {
+		t.Errorf("Unexpected template: %s", got)
+	}
+
+	invalid := []*Tracer{
+		{Func: "Trace"},
+		{ImportPath: "example.com/obs", Func: "start"},
+		{ImportPath: "example.com/obs", Func: "Start", Shape: "go"},
+		{ImportPath: "example.com/obs", Func: "Start", Params: "map"},
+		{ImportPath: "example.com/go-obs", Func: "Start"},
+		// functrace模板没有默认的返回值接收函数
+		{Capture: true},
+		{ImportPath: "example.com/obs", Func: "Start", Capture: true},
+	}
+	for _, tt := range invalid {
+		if _, err = NewTracer(tt); err == nil {
+			t.Errorf("Expected error for %+v", tt)
+		}
+	}
+}
+
+func TestRewriteFile_CustomTracer(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+	content := `package main
+
+// run 运行
+func run(name string, n int) {
+	println(name, n)
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	tracer, err := NewTracer(&Tracer{ImportPath: "example.com/obs/tracing", Alias: "tr", Func: "Enter", Shape: ShapeDefer, Params: ParamsVariadic})
+	if err != nil {
+		t.Fatalf("NewTracer failed: %v", err)
+	}
+	r, err := NewRewrite(testFile, WithTracer(tracer))
+	if err != nil {
+		t.Fatalf("NewRewrite failed: %v", err)
+	}
+	r.RewriteFile()
+
+	instrumented, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	for _, want := range []string{`import tr "example.com/obs/tracing"`, "\tdefer tr.Enter(name, n)\n"} {
+		if !strings.Contains(string(instrumented), want) {
+			t.Errorf("Expected %q in:\n%s", want, instrumented)
+		}
+	}
+
+	// 再次插桩不应重复插入
+	r, err = NewRewrite(testFile, WithTracer(tracer))
+	if err != nil {
+		t.Fatalf("NewRewrite failed: %v", err)
+	}
+	r.RewriteFile()
+	again, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	if string(again) != string(instrumented) {
+		t.Errorf("Rewrite should be idempotent:\n%s", again)
+	}
+
+	entry, err := UndoFile(testFile, WithTracer(tracer))
+	if err != nil || entry == nil {
+		t.Fatalf("UndoFile failed: %v, %v", entry, err)
+	}
+	restored, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	if string(restored) != content {
+		t.Errorf("Undo should restore the original file:\n%s", restored)
+	}
+}
+
+func TestRewriteFile_AliasedImport(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+	content := `package main
+
+import ft "github.com/toheart/functrace"
+
+func traced() {
+	defer ft.Trace([]interface{}{})()
+}
+
+func plain() {
+	println("plain")
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	r, err := NewRewrite(testFile)
+	if err != nil {
+		t.Fatalf("NewRewrite failed: %v", err)
+	}
+	r.RewriteFile()
+	if stats := r.Stats(); stats.Funcs != 1 {
+		t.Errorf("Expected only plain to be instrumented, got %+v", stats)
+	}
+
+	result, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	if strings.Count(string(result), "defer ft.Trace(") != 2 || strings.Contains(string(result), "functrace.Trace") {
+		t.Errorf("Expected aliased import to be reused:\n%s", result)
+	}
+	if strings.Count(string(result), `"github.com/toheart/functrace"`) != 1 {
+		t.Errorf("Import should not be duplicated:\n%s", result)
+	}
+}
@@ -0,0 +1,310 @@
+package rewrite
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// UndoEntry 撤销插桩时单个文件的处理结果
+type UndoEntry struct {
+	Path          string `json:"path"`          // 文件路径
+	DefersRemoved int    `json:"defersRemoved"` // 移除的defer语句数量
+	ImportRemoved bool   `json:"importRemoved"` // 是否移除了追踪包导入
+	TestMain      bool   `json:"testMain"`      // 是否删除了生成的TestMain文件或还原了被修改的TestMain
+}
+
+// UndoManifest 撤销插桩的清单，记录所有被修改过的文件和处理失败的文件
+type UndoManifest struct {
+	Dir    string       `json:"dir"`
+	Files  []*UndoEntry `json:"files"`
+	Errors []*FileError `json:"errors"`
+}
+
+// Err 返回所有文件错误的合并, 没有错误时为nil
+func (m *UndoManifest) Err() error {
+	errs := make([]error, 0, len(m.Errors))
+	for _, e := range m.Errors {
+		errs = append(errs, e)
+	}
+	return errors.Join(errs...)
+}
+
+// UndoDir
+//
+//	@Description: 撤销目录中所有文件的插桩, 遍历规则与RewriteDir保持一致, 测试文件总是处理;
+//	单个文件的错误记录到清单中, 不会中断遍历
+//	@param dir
+//	@param opts 重写选项, 只使用WithTracer, 需与插桩时的模板一致
+//	@return *UndoManifest 被修改文件和失败文件的清单, 总是非nil
+//	@return error 所有文件错误的合并
+func UndoDir(dir string, opts ...RewriteOption) (*UndoManifest, error) {
+	manifest := &UndoManifest{Dir: dir}
+	_ = filepath.Walk(dir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			manifest.Errors = append(manifest.Errors, fileErrors(path, err)...)
+			return nil
+		}
+		if info.IsDir() {
+			// 跳过vendor目录
+			if info.Name() == "vendor" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// 排除
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		fullPath, err := filepath.Abs(path)
+		if err != nil {
+			manifest.Errors = append(manifest.Errors, fileErrors(path, err)...)
+			return nil
+		}
+		entry, err := UndoFile(fullPath, opts...)
+		if err != nil {
+			manifest.Errors = append(manifest.Errors, fileErrors(fullPath, err)...)
+			return nil
+		}
+		if entry != nil {
+			manifest.Files = append(manifest.Files, entry)
+		}
+		return nil
+	})
+	sort.Slice(manifest.Files, func(i, j int) bool {
+		return manifest.Files[i].Path < manifest.Files[j].Path
+	})
+	sort.SliceStable(manifest.Errors, func(i, j int) bool {
+		return manifest.Errors[i].Path < manifest.Errors[j].Path
+	})
+	return manifest, manifest.Err()
+}
+
+// UndoFile 移除单个文件中由重写器插入的defer语句、追踪包导入和 //line 指令
+// 只删除插桩代码所在的字节区间, 其余代码与注释保持原样; 文件未被插桩时返回nil
+func UndoFile(fullPath string, opts ...RewriteOption) (*UndoEntry, error) {
+	src, err := os.ReadFile(fullPath)
+	if err != nil {
+		return nil, err
+	}
+	if isGeneratedTestMain(src) {
+		if err = os.Remove(fullPath); err != nil {
+			return nil, err
+		}
+		return &UndoEntry{Path: fullPath, TestMain: true}, nil
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, fullPath, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	tf := fset.File(f.Pos())
+	probe := &Rewrite{f: f}
+	for _, opt := range opts {
+		opt(probe)
+	}
+	tracer, name := probe.traceTemplate(), probe.traceName()
+
+	var cuts []span
+	count := 0
+	removed := make(map[ast.Stmt]bool)
+	// 还原被修改的TestMain
+	var testMainCuts []span
+	if isTestFile(fullPath) {
+		for _, decl := range f.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok {
+				testMainCuts = append(testMainCuts, testMainSpans(tf, src, fd, tracer, name, removed)...)
+			}
+		}
+	}
+	cuts = append(cuts, testMainCuts...)
+	ast.Inspect(f, func(n ast.Node) bool {
+		var body *ast.BlockStmt
+		var funcType *ast.FuncType
+		switch fn := n.(type) {
+		case *ast.FuncDecl:
+			body, funcType = fn.Body, fn.Type
+		case *ast.FuncLit:
+			body, funcType = fn.Body, fn.Type
+		}
+		if body == nil {
+			return true
+		}
+		for i, stmt := range body.List {
+			if !tracer.isTraceCall(stmt, name) {
+				continue
+			}
+			count++
+			removed[stmt] = true
+			cuts = append(cuts, lineSpan(tf, src, stmt.Pos(), stmt.End()))
+			// otel-span模式下同时删除紧随其后的 defer span.End()
+			if tracer.Shape == ShapeOTelSpan && i+1 < len(body.List) && isSpanEnd(stmt, body.List[i+1]) {
+				next := body.List[i+1]
+				removed[next] = true
+				cuts = append(cuts, lineSpan(tf, src, next.Pos(), next.End()))
+			}
+			// 返回值捕获模式下同时删除紧随其后的捕获语句, 并还原返回值名称
+			if i+1 < len(body.List) && tracer.isCaptureCall(body.List[i+1], name) {
+				next := body.List[i+1]
+				removed[next] = true
+				cuts = append(cuts, lineSpan(tf, src, next.Pos(), next.End()))
+				cuts = append(cuts, resultNameSpans(tf, funcType)...)
+			}
+		}
+		return true
+	})
+	if count == 0 && len(testMainCuts) == 0 {
+		return nil, nil
+	}
+
+	entry := &UndoEntry{Path: fullPath, DefersRemoved: count, TestMain: len(testMainCuts) > 0}
+	// 仅当追踪包不再被其他代码引用时才移除导入
+	imports := make(map[string]bool)
+	if !usesPackage(f, name, removed) {
+		imports[tracer.ImportPath] = true
+	}
+	// Fallback依赖的导入在原代码中若未被使用则一定是插桩时添加的
+	if tracer.FallbackImport != "" && !usesPackage(f, importName(f, tracer.FallbackImport), removed) {
+		imports[tracer.FallbackImport] = true
+	}
+	importCuts, found := importSpans(tf, src, f, imports)
+	cuts = append(cuts, importCuts...)
+	cuts = append(cuts, lineDirectiveSpans(tf, src, f, fullPath)...)
+	entry.ImportRemoved = found[tracer.ImportPath]
+
+	// 只删除插桩添加的代码, 不格式化整个文件, 以免改动插桩期间未格式化的手工修改
+	out := applyCuts(src, cuts)
+	if _, err = parser.ParseFile(token.NewFileSet(), fullPath, out, parser.SkipObjectResolution); err != nil {
+		return nil, fmt.Errorf("parse undo result: %w", err)
+	}
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(fullPath, out, info.Mode().Perm()); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// span 待删除的字节区间 [start, end), text非空时替换为text
+type span struct {
+	start, end int
+	text       string
+}
+
+// lineSpan 计算节点所占的字节区间, 若节点独占整行则连同缩进和换行一起删除, 否则连同其后的分号一起删除
+func lineSpan(tf *token.File, src []byte, pos, end token.Pos) span {
+	start, stop := tf.Offset(pos), tf.Offset(end)
+	lineStart := start
+	for lineStart > 0 && (src[lineStart-1] == ' ' || src[lineStart-1] == '\t') {
+		lineStart--
+	}
+	lineEnd := stop
+	for lineEnd < len(src) && (src[lineEnd] == ' ' || src[lineEnd] == '\t' || src[lineEnd] == ';') {
+		lineEnd++
+	}
+	if (lineStart == 0 || src[lineStart-1] == '\n') && (lineEnd == len(src) || src[lineEnd] == '\n') {
+		if lineEnd < len(src) {
+			lineEnd++
+		}
+		return span{start: lineStart, end: lineEnd}
+	}
+	// 单行函数体中紧跟右花括号时连同前面的空格一起删除, 还原为 {}
+	if lineEnd < len(src) && src[lineEnd] == '}' {
+		start = lineStart
+	}
+	// 与后续语句同处一行时连同分隔的分号一起删除
+	return span{start: start, end: lineEnd}
+}
+
+// dropBlankLine 删除整行后前后都是空行时, 连同前面的空行一起删除, 避免留下连续的空行
+func dropBlankLine(src []byte, s span) span {
+	if s.start >= 2 && src[s.start-1] == '\n' && src[s.start-2] == '\n' && s.end < len(src) && src[s.end] == '\n' {
+		s.start--
+	}
+	return s
+}
+
+// importSpans 定位待删除的导入, 若import声明中的导入全部删除则删除整个声明
+func importSpans(tf *token.File, src []byte, f *ast.File, paths map[string]bool) ([]span, map[string]bool) {
+	var cuts []span
+	found := make(map[string]bool)
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			continue
+		}
+		var specCuts []span
+		kept := 0
+		for _, s := range gd.Specs {
+			spec := s.(*ast.ImportSpec)
+			path, err := strconv.Unquote(spec.Path.Value)
+			// 空白导入不会由插桩产生
+			if err != nil || !paths[path] || (spec.Name != nil && spec.Name.Name == "_") {
+				kept++
+				continue
+			}
+			found[path] = true
+			specCuts = append(specCuts, lineSpan(tf, src, spec.Pos(), spec.End()))
+		}
+		if len(specCuts) == 0 {
+			continue
+		}
+		if kept == 0 {
+			cuts = append(cuts, dropBlankLine(src, lineSpan(tf, src, gd.Pos(), gd.End())))
+			continue
+		}
+		cuts = append(cuts, specCuts...)
+	}
+	return cuts, found
+}
+
+// usesPackage 判断除待删除的defer外是否仍有代码引用名为name的包
+func usesPackage(f *ast.File, name string, removed map[ast.Stmt]bool) bool {
+	used := false
+	ast.Inspect(f, func(n ast.Node) bool {
+		if used {
+			return false
+		}
+		if stmt, ok := n.(ast.Stmt); ok && removed[stmt] {
+			return false
+		}
+		if se, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := se.X.(*ast.Ident); ok && x.Name == name {
+				used = true
+			}
+		}
+		return true
+	})
+	return used
+}
+
+// applyCuts 按区间删除源码内容
+func applyCuts(src []byte, cuts []span) []byte {
+	sort.Slice(cuts, func(i, j int) bool { return cuts[i].start < cuts[j].start })
+	var buf bytes.Buffer
+	last := 0
+	for _, c := range cuts {
+		if c.start < last {
+			c.start = last
+		}
+		if c.end <= c.start {
+			continue
+		}
+		buf.Write(src[last:c.start])
+		buf.WriteString(c.text)
+		last = c.end
+	}
+	buf.Write(src[last:])
+	return buf.Bytes()
+}
@@ -0,0 +1,233 @@
+package rewrite
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUndoFile_RoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package main
+
+import (
+	"fmt"
+)
+
+// main 入口函数
+func main() {
+	// 打印问候
+	fmt.Println("Hello")
+	go func() {
+		fmt.Println("goroutine") // 行尾注释
+	}()
+}
+
+func (r *Receiver) method(a, b int) string {
+	return fmt.Sprint(a, b)
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	r, err := NewRewrite(testFile)
+	if err != nil {
+		t.Fatalf("NewRewrite failed: %v", err)
+	}
+	r.RewriteFile()
+
+	rewritten, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to read rewritten file: %v", err)
+	}
+	if !strings.Contains(string(rewritten), "defer functrace.Trace") {
+		t.Fatal("Expected file to be instrumented before undo")
+	}
+
+	entry, err := UndoFile(testFile)
+	if err != nil {
+		t.Fatalf("UndoFile failed: %v", err)
+	}
+	if entry == nil {
+		t.Fatal("UndoFile should report the touched file")
+	}
+	if entry.DefersRemoved != 3 {
+		t.Errorf("Expected 3 defers removed, got %d", entry.DefersRemoved)
+	}
+	if !entry.ImportRemoved {
+		t.Error("Expected functrace import to be removed")
+	}
+
+	restored, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to read restored file: %v", err)
+	}
+	if string(restored) != content {
+		t.Errorf("Undo should restore original content, got:\n%s", restored)
+	}
+}
+
+func TestUndoFile_KeepsOtherDefersAndImport(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package main
+
+import "github.com/toheart/functrace"
+
+func main() {
+	defer functrace.Trace([]interface{}{})()
+	defer cleanup()
+	functrace.CloseTraceInstance()
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	entry, err := UndoFile(testFile)
+	if err != nil {
+		t.Fatalf("UndoFile failed: %v", err)
+	}
+	if entry == nil || entry.DefersRemoved != 1 {
+		t.Fatalf("Expected 1 defer removed, got %+v", entry)
+	}
+	// functrace仍被引用, 导入需要保留
+	if entry.ImportRemoved {
+		t.Error("Import should be kept while functrace is still referenced")
+	}
+
+	restored, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to read restored file: %v", err)
+	}
+	str := string(restored)
+	if strings.Contains(str, "functrace.Trace") {
+		t.Error("Trace defer should be removed")
+	}
+	if !strings.Contains(str, "defer cleanup()") {
+		t.Error("Other defers should be preserved")
+	}
+	if !strings.Contains(str, `import "github.com/toheart/functrace"`) {
+		t.Error("functrace import should be preserved")
+	}
+}
+
+func TestUndoFile_KeepsUnformattedEdits(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	// 插桩期间手工修改且未格式化的代码
+	content := `package main
+
+import "github.com/toheart/functrace"
+
+func main() {
+	defer functrace.Trace([]interface{}{})()
+	x:=1
+	if x>0 { println( x ) }
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	if _, err := UndoFile(testFile); err != nil {
+		t.Fatalf("UndoFile failed: %v", err)
+	}
+	restored, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to read restored file: %v", err)
+	}
+	expected := "package main\n\nfunc main() {\n\tx:=1\n\tif x>0 { println( x ) }\n}\n"
+	if string(restored) != expected {
+		t.Errorf("Undo should only remove instrumentation, got:\n%s", restored)
+	}
+}
+
+func TestUndoDir(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		filepath.Join(tmpDir, "main.go"): `package main
+
+func main() {
+	println("main")
+}
+`,
+		filepath.Join(tmpDir, "plain.go"): `package main
+
+func plain() {}
+`,
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create file %s: %v", path, err)
+		}
+	}
+
+	r, err := NewRewrite(filepath.Join(tmpDir, "main.go"))
+	if err != nil {
+		t.Fatalf("NewRewrite failed: %v", err)
+	}
+	r.RewriteFile()
+
+	manifest, err := UndoDir(tmpDir)
+	if err != nil {
+		t.Fatalf("UndoDir failed: %v", err)
+	}
+	if len(manifest.Files) != 1 {
+		t.Fatalf("Expected 1 touched file, got %d", len(manifest.Files))
+	}
+	if filepath.Base(manifest.Files[0].Path) != "main.go" {
+		t.Errorf("Expected main.go in manifest, got %s", manifest.Files[0].Path)
+	}
+
+	restored, err := os.ReadFile(filepath.Join(tmpDir, "main.go"))
+	if err != nil {
+		t.Fatalf("Failed to read main.go: %v", err)
+	}
+	if string(restored) != files[filepath.Join(tmpDir, "main.go")] {
+		t.Errorf("main.go should be restored, got:\n%s", restored)
+	}
+}
+
+func TestUndoDir_ContinuesOnError(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainPath := filepath.Join(tmpDir, "main.go")
+	original := "package main\n\nfunc main() {\n\tprintln(\"main\")\n}\n"
+	if err := os.WriteFile(mainPath, []byte(original), 0644); err != nil {
+		t.Fatalf("Failed to create main.go: %v", err)
+	}
+	r, err := NewRewrite(mainPath)
+	if err != nil {
+		t.Fatalf("NewRewrite failed: %v", err)
+	}
+	r.RewriteFile()
+	// 排在main.go之前的语法错误文件不应中断遍历
+	if err = os.WriteFile(filepath.Join(tmpDir, "broken.go"), []byte("package main\n\nfunc broken( {\n"), 0644); err != nil {
+		t.Fatalf("Failed to create broken.go: %v", err)
+	}
+
+	manifest, err := UndoDir(tmpDir)
+	if err == nil {
+		t.Fatal("Expected error for broken.go")
+	}
+	if len(manifest.Files) != 1 || filepath.Base(manifest.Files[0].Path) != "main.go" {
+		t.Errorf("Expected main.go in manifest, got %+v", manifest.Files)
+	}
+	if len(manifest.Errors) == 0 || filepath.Base(manifest.Errors[0].Path) != "broken.go" || manifest.Errors[0].Line != 3 {
+		t.Errorf("Expected broken.go syntax error in manifest, got %+v", manifest.Errors)
+	}
+	restored, err := os.ReadFile(mainPath)
+	if err != nil {
+		t.Fatalf("Failed to read main.go: %v", err)
+	}
+	if string(restored) != original {
+		t.Errorf("main.go should be restored, got:\n%s", restored)
+	}
+}
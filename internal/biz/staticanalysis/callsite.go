@@ -0,0 +1,98 @@
+package staticanalysis
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/toheart/goanalysis/internal/biz/callgraph/dos"
+	"github.com/toheart/goanalysis/internal/biz/entity"
+)
+
+// CallPairs 按 caller->callee 汇总的调用点
+type CallPairs map[string]*entity.CallPair
+
+// NewCallPairs 汇总同一对函数的全部调用点、调用类型和构建配置
+func NewCallPairs(edges []*dos.FuncEdge) CallPairs {
+	pairs := make(CallPairs, len(edges))
+	for _, edge := range edges {
+		pairs.add(edge)
+	}
+	return pairs
+}
+
+// add 将调用边计入所属函数对, 返回该函数对是否第一次出现
+func (c CallPairs) add(edge *dos.FuncEdge) bool {
+	key := edge.CallerKey + "->" + edge.CalleeKey
+	pair, ok := c[key]
+	if !ok {
+		pair = &entity.CallPair{}
+		c[key] = pair
+	}
+	if edge.CallLine > 0 {
+		pair.Sites = append(pair.Sites, entity.CallSite{
+			File:    edge.CallFile,
+			Line:    edge.CallLine,
+			Kind:    string(edge.CallKind),
+			Configs: edge.Configs,
+		})
+	}
+	if edge.CallKind != "" && !slices.Contains(pair.Kinds, string(edge.CallKind)) {
+		pair.Kinds = append(pair.Kinds, string(edge.CallKind))
+	}
+	for _, config := range edge.Configs {
+		if !slices.Contains(pair.Configs, config) {
+			pair.Configs = append(pair.Configs, config)
+		}
+	}
+	return !ok
+}
+
+// Get 返回一对函数的调用点汇总, 两者之间没有调用边时返回nil
+func (c CallPairs) Get(caller, callee string) *entity.CallPair {
+	return c[caller+"->"+callee]
+}
+
+// CountCallers 统计每个函数的调用方数量, 同一调用方的多个调用点只计一次
+func CountCallers(edges []*dos.FuncEdge) map[string]int {
+	callers := make(map[string]map[string]bool)
+	for _, edge := range edges {
+		if callers[edge.CalleeKey] == nil {
+			callers[edge.CalleeKey] = make(map[string]bool)
+		}
+		callers[edge.CalleeKey][edge.CallerKey] = true
+	}
+	counts := make(map[string]int, len(callers))
+	for callee, keys := range callers {
+		counts[callee] = len(keys)
+	}
+	return counts
+}
+
+// CallKindFilter 调用类型过滤条件, 为空时不过滤
+type CallKindFilter map[dos.CallKind]bool
+
+// NewCallKindFilter 根据调用类型名称创建过滤条件, 名称未知时返回错误
+func NewCallKindFilter(kinds []string) (CallKindFilter, error) {
+	filter := make(CallKindFilter, len(kinds))
+	for _, kind := range kinds {
+		if !dos.CallKind(kind).Valid() {
+			return nil, fmt.Errorf("unknown call kind %q, expected one of %v", kind, dos.CallKinds)
+		}
+		filter[dos.CallKind(kind)] = true
+	}
+	return filter, nil
+}
+
+// Apply 只保留指定调用类型的边
+func (f CallKindFilter) Apply(edges []*dos.FuncEdge) []*dos.FuncEdge {
+	if len(f) == 0 {
+		return edges
+	}
+	var filtered []*dos.FuncEdge
+	for _, edge := range edges {
+		if f[edge.CallKind] {
+			filtered = append(filtered, edge)
+		}
+	}
+	return filtered
+}
@@ -2,9 +2,11 @@ package staticanalysis
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/sourcegraph/conc/pool"
 	"github.com/toheart/goanalysis/internal/biz/callgraph"
+	"github.com/toheart/goanalysis/internal/biz/callgraph/dos"
 	"github.com/toheart/goanalysis/internal/biz/chanMgr"
 	"github.com/toheart/goanalysis/internal/biz/entity"
 	"github.com/toheart/goanalysis/internal/biz/repo"
@@ -19,6 +22,13 @@ import (
 	"github.com/toheart/goanalysis/internal/data"
 )
 
+var (
+	// ErrTaskCancelled 任务被用户取消
+	ErrTaskCancelled = errors.New("task cancelled by user")
+	// ErrTaskTimeout 任务超过超时时间
+	ErrTaskTimeout = errors.New("task timed out")
+)
+
 // StaticAnalysisBiz 静态分析业务逻辑
 type StaticAnalysisBiz struct {
 	sync.RWMutex
@@ -29,6 +39,13 @@ type StaticAnalysisBiz struct {
 	AnalysisTaskChan   chan *entity.AnalysisTask
 	globalChan         *chanMgr.ChannelManager
 	analysisTaskStatus map[string]entity.AnalysisTaskStatus
+	taskControls       map[string]*taskControl // 未结束任务的取消控制
+}
+
+// taskControl 任务的ctx, 提交任务时创建, 排队中的任务同样可以取消
+type taskControl struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
 }
 
 // NewStaticAnalysisBiz 创建静态分析业务逻辑实例
@@ -39,6 +56,7 @@ func NewStaticAnalysisBiz(conf *conf.Biz, data *data.Data, mgr *chanMgr.ChannelM
 		log:                log.NewHelper(logger),
 		AnalysisTaskChan:   make(chan *entity.AnalysisTask, 10),
 		analysisTaskStatus: make(map[string]entity.AnalysisTaskStatus),
+		taskControls:       make(map[string]*taskControl),
 		globalChan:         mgr,
 	}
 }
@@ -50,6 +68,19 @@ func (s *StaticAnalysisBiz) ProcessAnalysisTasks() {
 	for task := range s.AnalysisTaskChan {
 		pTask := task
 		p.Go(func() {
+			ctx := s.taskContext(pTask.ID)
+			defer s.finishTask(pTask.ID)
+			// 排队时已被取消
+			if ctx.Err() != nil {
+				s.log.Infof("skip cancelled analysis task: %s", pTask.ID)
+				return
+			}
+			if pTask.Options != nil && pTask.Options.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeoutCause(ctx, pTask.Options.Timeout, fmt.Errorf("%w after %s", ErrTaskTimeout, pTask.Options.Timeout))
+				defer cancel()
+			}
+
 			s.log.Infof("start process analysis task: %s, project path: %s", task.ID, task.ProjectPath)
 			s.SetTaskStatus(task.ID, entity.AnalysisTaskStatus{
 				Status:   entity.TaskStatusProcessing,
@@ -57,15 +88,22 @@ func (s *StaticAnalysisBiz) ProcessAnalysisTasks() {
 				Message:  "Processing...",
 			})
 			// 执行分析
-			err := s.runCallgraphAnalysis(pTask)
-			if err != nil {
+			err := s.runCallgraphAnalysis(ctx, pTask)
+			switch {
+			case errors.Is(err, ErrTaskCancelled):
+				s.log.Infof("analysis task cancelled: %s", pTask.ID)
+				s.SetTaskStatus(pTask.ID, entity.AnalysisTaskStatus{
+					Status:   entity.TaskStatusCancelled,
+					Progress: 0,
+					Message:  "Cancelled by user",
+				})
+			case err != nil:
 				s.log.Errorf("analysis task failed: %s, error: %v", pTask.ID, err)
 				s.SetTaskStatus(pTask.ID, entity.AnalysisTaskStatus{
 					Status:   entity.TaskStatusFailed,
 					Progress: 0,
-					Message:  "Failed...",
+					Message:  fmt.Sprintf("Failed: %v", err),
 				})
-				return
 			}
 		})
 	}
@@ -82,6 +120,7 @@ func (s *StaticAnalysisBiz) AnalyzeProjectPath(projectPath string, DbPath string
 		Progress: 0,
 		Message:  "Starting...",
 	})
+	s.registerTask(task.ID)
 	s.AnalysisTaskChan <- &task
 	return task.ID
 }
@@ -99,10 +138,58 @@ func (s *StaticAnalysisBiz) AnalyzeProjectPathWithOptions(projectPath string, Db
 		Progress: 0,
 		Message:  "Starting...",
 	})
+	s.registerTask(task.ID)
 	s.AnalysisTaskChan <- &task
 	return task.ID
 }
 
+// CancelAnalysisTask 取消分析任务, 排队中的任务不再执行, 执行中的任务在下一个检查点终止
+func (s *StaticAnalysisBiz) CancelAnalysisTask(taskID string) error {
+	s.Lock()
+	defer s.Unlock()
+	control, ok := s.taskControls[taskID]
+	if !ok {
+		if _, exists := s.analysisTaskStatus[taskID]; exists {
+			return fmt.Errorf("task %s already finished", taskID)
+		}
+		return fmt.Errorf("task %s not found", taskID)
+	}
+	control.cancel(ErrTaskCancelled)
+	status := s.analysisTaskStatus[taskID]
+	status.Status = entity.TaskStatusCancelled
+	status.Message = "Cancelled by user"
+	s.analysisTaskStatus[taskID] = status
+	return nil
+}
+
+// registerTask 为新提交的任务创建ctx
+func (s *StaticAnalysisBiz) registerTask(taskID string) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	s.Lock()
+	defer s.Unlock()
+	s.taskControls[taskID] = &taskControl{ctx: ctx, cancel: cancel}
+}
+
+// taskContext 返回任务的ctx, 没有注册的任务不可取消
+func (s *StaticAnalysisBiz) taskContext(taskID string) context.Context {
+	s.RLock()
+	defer s.RUnlock()
+	if control, ok := s.taskControls[taskID]; ok {
+		return control.ctx
+	}
+	return context.Background()
+}
+
+// finishTask 任务结束后释放ctx
+func (s *StaticAnalysisBiz) finishTask(taskID string) {
+	s.Lock()
+	defer s.Unlock()
+	if control, ok := s.taskControls[taskID]; ok {
+		control.cancel(nil)
+		delete(s.taskControls, taskID)
+	}
+}
+
 // VerifyProjectPath 验证项目路径是否存在
 func (s *StaticAnalysisBiz) VerifyProjectPath(path string) bool {
 	info, err := os.Stat(path)
@@ -162,38 +249,157 @@ func (s *StaticAnalysisBiz) GetFunctionAnalysis(functionName, queryType, path st
 	}, nil
 }
 
-// GetFunctionCallGraph 获取函数调用关系图
-func (s *StaticAnalysisBiz) GetFunctionCallGraph(functionName string, depth int, direction string) ([]entity.FunctionGraphNode, []entity.FunctionGraphEdge, error) {
-	// 这里实现获取函数调用关系图的逻辑
-	// 示例数据
-	nodes := []entity.FunctionGraphNode{
-		{
-			ID:        "1",
-			Name:      functionName,
-			Package:   "main",
-			CallCount: 1,
-			AvgTime:   "10ms",
-			NodeType:  "root",
-		},
-		{
-			ID:        "2",
-			Name:      "subFunc",
-			Package:   "main",
-			CallCount: 5,
-			AvgTime:   "2ms",
-			NodeType:  "callee",
-		},
+// GetFunctionCallGraph 从静态分析数据库获取函数调用关系图
+//
+//	@Description: 以functionKey为根, 按层沿调用关系查找depth层
+//	@param dbPath 静态分析数据库路径
+//	@param functionKey 函数稳定标识, 兼容旧格式key
+//	@param depth 查找层数
+//	@param direction "caller" 只查调用方, "callee" 只查被调用方, "both" 双向
+//	@param kinds 只沿这些调用类型的边查找, 为空时不过滤
+//	@return []entity.FunctionGraphNode 第一个为根节点
+//	@return []entity.FunctionGraphEdge 每对函数一条边, 带上全部调用点
+func (s *StaticAnalysisBiz) GetFunctionCallGraph(dbPath string, functionKey string, depth int, direction string, kinds CallKindFilter) ([]entity.FunctionGraphNode, []entity.FunctionGraphEdge, error) {
+	store, err := s.GetFuncNodeDB(dbPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get function node database: %w", err)
 	}
+	roots, err := store.GetFuncNodesByKeys([]string{functionKey})
+	if err != nil {
+		return nil, nil, fmt.Errorf("get function %s: %w", functionKey, err)
+	}
+	if len(roots) == 0 {
+		return nil, nil, fmt.Errorf("function not found: %s", functionKey)
+	}
+	root := roots[0]
+
+	graphNodes := []*dos.FuncNode{root}
+	nodeTypes := map[string]string{root.Key: "root"}
+	var edges []entity.FunctionGraphEdge
+	seenEdges := make(map[string]bool)
+	addEdge := func(source, target, edgeType string, pair *entity.CallPair) {
+		if seenEdges[source+"->"+target] {
+			return
+		}
+		seenEdges[source+"->"+target] = true
+		edge := entity.FunctionGraphEdge{
+			Source:    source,
+			Target:    target,
+			Value:     max(len(pair.Sites), 1),
+			Label:     "calls",
+			EdgeType:  edgeType,
+			Sites:     pair.Sites,
+			CallKinds: pair.Kinds,
+		}
+		if len(edge.Sites) > 0 {
+			edge.CallFile = edge.Sites[0].File
+			edge.CallLine = edge.Sites[0].Line
+			edge.CallKind = edge.Sites[0].Kind
+		}
+		edges = append(edges, edge)
+	}
+	// walk 从根节点按层查找, 每层只查询一次调用边和新出现的函数, upstream为true时沿调用方方向
+	walk := func(upstream bool, nodeType, edgeType string) error {
+		frontier := []string{root.Key}
+		for level := 0; level < depth && len(frontier) > 0; level++ {
+			var levelEdges []*dos.FuncEdge
+			if upstream {
+				levelEdges, err = store.GetFuncEdgesByCallees(frontier)
+			} else {
+				levelEdges, err = store.GetFuncEdgesByCallers(frontier)
+			}
+			if err != nil {
+				return err
+			}
+			levelEdges = kinds.Apply(levelEdges)
+
+			// 按函数对汇总调用点, 函数对按首次出现的顺序排列
+			pairs := make(CallPairs)
+			var firsts []*dos.FuncEdge
+			var unseen []string
+			fresh := make(map[string]bool)
+			for _, edge := range levelEdges {
+				if !pairs.add(edge) {
+					continue
+				}
+				firsts = append(firsts, edge)
+				other := edge.CalleeKey
+				if upstream {
+					other = edge.CallerKey
+				}
+				if _, ok := nodeTypes[other]; !ok && !fresh[other] {
+					fresh[other] = true
+					unseen = append(unseen, other)
+				}
+			}
+			loaded, err := store.GetFuncNodesByKeys(unseen)
+			if err != nil {
+				return err
+			}
+			for _, node := range loaded {
+				nodeTypes[node.Key] = nodeType
+				graphNodes = append(graphNodes, node)
+			}
 
-	edges := []entity.FunctionGraphEdge{
-		{
-			Source:   "1",
-			Target:   "2",
-			Label:    "calls",
-			EdgeType: "root_to_callee",
-		},
+			// 本层新加载的函数按边的顺序作为下一层
+			var following []string
+			for _, edge := range firsts {
+				other := edge.CalleeKey
+				if upstream {
+					other = edge.CallerKey
+				}
+				if _, ok := nodeTypes[other]; !ok {
+					continue
+				}
+				if fresh[other] {
+					fresh[other] = false
+					following = append(following, other)
+				}
+				addEdge(edge.CallerKey, edge.CalleeKey, edgeType, pairs.Get(edge.CallerKey, edge.CalleeKey))
+			}
+			frontier = following
+		}
+		return nil
 	}
 
+	if direction == "caller" || direction == "both" {
+		if err = walk(true, "caller", "caller_to_root"); err != nil {
+			return nil, nil, fmt.Errorf("get callers of %s: %w", functionKey, err)
+		}
+	}
+	if direction == "callee" || direction == "both" {
+		if err = walk(false, "callee", "root_to_callee"); err != nil {
+			return nil, nil, fmt.Errorf("get callees of %s: %w", functionKey, err)
+		}
+	}
+
+	// 调用方数量只统计图中的函数, 与图一样按调用类型过滤
+	keys := make([]string, 0, len(graphNodes))
+	for _, node := range graphNodes {
+		keys = append(keys, node.Key)
+	}
+	callerEdges, err := store.GetFuncEdgesByCallees(keys)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get call counts: %w", err)
+	}
+	callCounts := CountCallers(kinds.Apply(callerEdges))
+
+	nodes := make([]entity.FunctionGraphNode, 0, len(graphNodes))
+	for _, node := range graphNodes {
+		nodes = append(nodes, entity.FunctionGraphNode{
+			ID:        node.Key,
+			Name:      node.Name,
+			Package:   node.Pkg,
+			CallCount: callCounts[node.Key],
+			NodeType:  nodeTypes[node.Key],
+			File:      node.File,
+			StartLine: node.StartLine,
+			EndLine:   node.EndLine,
+			LegacyKey: node.LegacyKey,
+			Module:    node.Module,
+			Configs:   node.Configs,
+		})
+	}
 	return nodes, edges, nil
 }
 
@@ -232,7 +438,7 @@ func (s *StaticAnalysisBiz) GetTaskStatusChan(taskID string) (chan []byte, error
 }
 
 // 运行callgraph分析
-func (s *StaticAnalysisBiz) runCallgraphAnalysis(task *entity.AnalysisTask) error {
+func (s *StaticAnalysisBiz) runCallgraphAnalysis(ctx context.Context, task *entity.AnalysisTask) error {
 	s.log.Infof("start callgraph analysis for project %s, db path: %s", task.ProjectPath, task.Filename)
 
 	// 设置通道
@@ -275,52 +481,64 @@ func (s *StaticAnalysisBiz) runCallgraphAnalysis(task *entity.AnalysisTask) erro
 			options = append(options, callgraph.WithIgnorePaths(task.Options.IgnoreMethod))
 			statusChan <- []byte(fmt.Sprintf("Ignore method: %s", task.Options.IgnoreMethod))
 		}
+
+		// 设置根函数, 用于分析没有main包的库
+		if len(task.Options.Roots) > 0 {
+			options = append(options, callgraph.WithRoots(task.Options.Roots...))
+			statusChan <- []byte(fmt.Sprintf("Root functions: %s", strings.Join(task.Options.Roots, ",")))
+		}
+
+		// 设置构建配置, 多个平台或标签组合时按矩阵分析
+		options = append(options,
+			callgraph.WithBuildTags(task.Options.BuildTags...),
+			callgraph.WithPlatforms(task.Options.Platforms...),
+			callgraph.WithTagSets(task.Options.TagSets...),
+			callgraph.WithBuildEnv(task.Options.Env...))
+
+		// 设置需要保留调用边的标准库包
+		if len(task.Options.StdPackages) > 0 {
+			options = append(options, callgraph.WithStdPackages(task.Options.StdPackages...))
+			statusChan <- []byte(fmt.Sprintf("Keep standard library calls: %s", strings.Join(task.Options.StdPackages, ",")))
+		}
+
+		// 设置内存上限, 超过时终止分析
+		if task.Options.MemoryLimit > 0 {
+			options = append(options, callgraph.WithMemoryLimit(task.Options.MemoryLimit))
+			statusChan <- []byte(fmt.Sprintf("Memory limit: %d MB (process heap, shared with concurrent tasks)", task.Options.MemoryLimit>>20))
+		}
+
+		// 容错模式下跳过有错误的包
+		if task.Options.Tolerant {
+			options = append(options, callgraph.WithTolerant(true))
+			statusChan <- []byte("Tolerant mode: packages with errors will be skipped")
+		}
 	} else {
 		// 使用默认选项
 		options = append(options, callgraph.WithAlgo(callgraph.CallGraphTypeVta))
 		statusChan <- []byte("Using default analysis options")
 	}
 
+	// 诊断信息记录到任务状态, SSE端点收到消息后推送新的诊断
+	options = append(options, callgraph.WithDiagnosticHandler(func(diag dos.Diagnostic) {
+		s.AddTaskDiagnostic(task.ID, diag)
+		statusChan <- []byte(fmt.Sprintf("Diagnostic: %s", diag))
+	}))
+
 	// 创建程序分析实例
 	c := callgraph.NewProgramAnalysis(task.ProjectPath, log.NewHelper(log.With(s.log.Logger(), "module", "callgraph", "task", task.ID)), funcNodeDB, options...)
 
-	// 使用 WaitGroup 等待所有任务完成
+	// 使用 WaitGroup 等待调用图生成和数据保存完成
 	var wg sync.WaitGroup
-	// 设置完成标志
-	var completed bool
+	var treeErr, saveErr error
 	wg.Add(1)
 	// 启动调用图生成
 	go func() {
 		defer wg.Done()
-		if err := c.SetTree(statusChan); err != nil {
+		if err := c.SetTree(ctx, statusChan); err != nil {
 			errMsg := fmt.Sprintf("Call graph generation failed: %v", err)
 			statusChan <- []byte(errMsg)
 			s.log.Error(errMsg)
-			return
-		}
-		// 标记为完成
-		statusChan <- []byte("Analysis task completed")
-		completed = true
-		s.log.Info("Analysis task completed")
-	}()
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		ticker := time.NewTicker(time.Second * 3)
-		for range ticker.C {
-			if completed {
-				s.SetTaskStatus(task.ID, entity.AnalysisTaskStatus{
-					Status:   entity.TaskStatusCompleted,
-					Progress: 1.0,
-					Message:  "Completed...",
-				})
-				return
-			}
-			s.SetTaskStatus(task.ID, entity.AnalysisTaskStatus{
-				Status:   entity.TaskStatusProcessing,
-				Progress: c.GetProgress(),
-				Message:  "Processing...",
-			})
+			treeErr = err
 		}
 	}()
 	// 启动保存数据
@@ -331,20 +549,57 @@ func (s *StaticAnalysisBiz) runCallgraphAnalysis(task *entity.AnalysisTask) erro
 		statusChan <- []byte("Starting to build call graph...")
 		// 保存数据
 		statusChan <- []byte("Starting to save data to database...")
-		if err := c.SaveData(context.Background(), statusChan); err != nil {
+		if err := c.SaveData(ctx, statusChan); err != nil {
 			errMsg := fmt.Sprintf("Failed to save data: %v", err)
 			statusChan <- []byte(errMsg)
 			s.log.Error(errMsg)
-			return
+			saveErr = err
+		}
+	}()
+
+	// 定期更新进度, 生成和保存都结束后停止
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(time.Second * 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			// 取消后保持取消状态, 由ProcessAnalysisTasks设置最终状态
+			if ctx.Err() != nil {
+				continue
+			}
+			s.SetTaskStatus(task.ID, entity.AnalysisTaskStatus{
+				Status:   entity.TaskStatusProcessing,
+				Progress: c.GetProgress(),
+				Message:  "Processing...",
+			})
 		}
 	}()
 
 	// 等待任务完成
 	wg.Wait()
-	s.log.Infof("callgraph analysis for %s completed", task.ProjectPath)
-	statusChan <- []byte("EOF")
+	close(done)
 
-	return nil
+	// 生成失败时保存也会失败, 优先返回生成的错误; 失败状态由ProcessAnalysisTasks设置
+	err = treeErr
+	if err == nil {
+		err = saveErr
+	}
+	if err == nil {
+		s.SetTaskStatus(task.ID, entity.AnalysisTaskStatus{
+			Status:   entity.TaskStatusCompleted,
+			Progress: 1.0,
+			Message:  "Completed...",
+		})
+		statusChan <- []byte("Analysis task completed")
+		s.log.Infof("callgraph analysis for %s completed", task.ProjectPath)
+	}
+	statusChan <- []byte("EOF")
+	return err
 }
 
 // GetAllTasks 获取所有任务ID
@@ -378,9 +633,20 @@ func (s *StaticAnalysisBiz) GetTaskStatus(taskID string) (entity.AnalysisTaskSta
 	return status, nil
 }
 
+// SetTaskStatus 设置任务状态, 保留已记录的诊断信息
 func (s *StaticAnalysisBiz) SetTaskStatus(taskID string, status entity.AnalysisTaskStatus) {
 	s.Lock()
 	defer s.Unlock()
+	status.Diagnostics = s.analysisTaskStatus[taskID].Diagnostics
+	s.analysisTaskStatus[taskID] = status
+}
+
+// AddTaskDiagnostic 记录任务的诊断信息
+func (s *StaticAnalysisBiz) AddTaskDiagnostic(taskID string, diag dos.Diagnostic) {
+	s.Lock()
+	defer s.Unlock()
+	status := s.analysisTaskStatus[taskID]
+	status.Diagnostics = append(status.Diagnostics, diag)
 	s.analysisTaskStatus[taskID] = status
 }
 
@@ -423,16 +689,16 @@ func (s *StaticAnalysisBiz) GetTreeGraph(functionName string, dbPath string) (*e
 	}
 
 	// 获取函数调用图
-	nodes, edges, err := s.GetFunctionCallGraph(functionName, 3, "outgoing") // 获取向外的调用，深度3
+	nodes, edges, err := s.GetFunctionCallGraph(dbPath, functionName, 3, "callee", nil) // 获取向外的调用，深度3
 	if err != nil {
 		s.log.Errorf("get function call graph failed: %v", err)
 		// 返回只有根节点的树
 		return &entity.TreeGraph{Root: root}, nil
 	}
 
-	// 创建节点映射，用于快速查找
+	// 创建节点映射，用于快速查找, 第一个节点为根节点
 	nodeMap := make(map[string]*entity.TreeNode)
-	nodeMap[functionName] = root
+	nodeMap[nodes[0].ID] = root
 
 	// 建立节点ID到节点的映射
 	funcNodes := make(map[string]entity.FunctionGraphNode)
@@ -440,28 +706,23 @@ func (s *StaticAnalysisBiz) GetTreeGraph(functionName string, dbPath string) (*e
 		funcNodes[node.ID] = node
 	}
 
-	// 从调用图构建树状图
+	// 从调用图构建树状图, 边按层排列, 每个函数只挂在第一次出现的位置, 避免形成环
 	for _, edge := range edges {
-		sourceName := funcNodes[edge.Source].Name
-		targetName := funcNodes[edge.Target].Name
-
-		// 只处理从当前节点出发的边，避免形成环
-		if sourceName == functionName || nodeMap[sourceName] != nil {
-			sourceNode := nodeMap[sourceName]
-
-			// 创建或获取目标节点
-			targetNode, exists := nodeMap[targetName]
-			if !exists {
-				targetNode = &entity.TreeNode{
-					Name:  targetName,
-					Value: int64(funcNodes[edge.Target].CallCount),
-				}
-				nodeMap[targetName] = targetNode
-			}
-
-			// 添加子节点
-			sourceNode.Children = append(sourceNode.Children, targetNode)
+		sourceNode, ok := nodeMap[edge.Source]
+		if !ok {
+			continue
+		}
+		if _, exists := nodeMap[edge.Target]; exists {
+			continue
 		}
+		targetNode := &entity.TreeNode{
+			Name:  funcNodes[edge.Target].Name,
+			Value: int64(funcNodes[edge.Target].CallCount),
+		}
+		nodeMap[edge.Target] = targetNode
+
+		// 添加子节点
+		sourceNode.Children = append(sourceNode.Children, targetNode)
 	}
 
 	return &entity.TreeGraph{Root: root}, nil
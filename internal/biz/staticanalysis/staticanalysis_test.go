@@ -0,0 +1,111 @@
+package staticanalysis
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/toheart/goanalysis/internal/biz/chanMgr"
+	"github.com/toheart/goanalysis/internal/biz/entity"
+	"github.com/toheart/goanalysis/internal/conf"
+	"github.com/toheart/goanalysis/internal/data"
+)
+
+// waitTask 等待任务结束并释放ctx
+func waitTask(t *testing.T, s *StaticAnalysisBiz, taskID string) {
+	t.Helper()
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		s.RLock()
+		_, running := s.taskControls[taskID]
+		s.RUnlock()
+		if !running {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Task %s did not finish", taskID)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// newTestBiz 创建使用临时存储目录的业务实例
+func newTestBiz(t *testing.T) *StaticAnalysisBiz {
+	t.Helper()
+	storage := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(storage, "static"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	logger := log.NewStdLogger(io.Discard)
+	return NewStaticAnalysisBiz(&conf.Biz{FileStoragePath: storage}, data.NewData(logger), chanMgr.NewChannelManager(), logger)
+}
+
+func TestCancelAnalysisTask(t *testing.T) {
+	s := NewStaticAnalysisBiz(&conf.Biz{}, nil, chanMgr.NewChannelManager(), log.NewStdLogger(io.Discard))
+	taskID := s.AnalyzeProjectPathWithOptions(t.TempDir(), "cancel.db", &entity.AnalysisOptions{})
+
+	if err := s.CancelAnalysisTask(taskID); err != nil {
+		t.Fatalf("CancelAnalysisTask failed: %v", err)
+	}
+	if status, _ := s.GetTaskStatus(taskID); status.Status != entity.TaskStatusCancelled {
+		t.Errorf("Expected cancelled status, got %+v", status)
+	}
+	if err := s.CancelAnalysisTask("missing"); err == nil {
+		t.Error("Expected error for unknown task")
+	}
+
+	// 排队中被取消的任务不再执行
+	close(s.AnalysisTaskChan)
+	s.ProcessAnalysisTasks()
+	waitTask(t, s, taskID)
+	if status, _ := s.GetTaskStatus(taskID); status.Status != entity.TaskStatusCancelled {
+		t.Errorf("Cancelled task should not run, got %+v", status)
+	}
+	if err := s.CancelAnalysisTask(taskID); err == nil {
+		t.Error("Expected error for finished task")
+	}
+}
+
+func TestRunCallgraphAnalysis_Failed(t *testing.T) {
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOWORK", "off")
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module example.com/broken\n\ngo 1.21\n",
+		"main.go": "package main\n\nfunc main() {\n\tundefined()\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := newTestBiz(t)
+	go s.ProcessAnalysisTasks()
+	defer close(s.AnalysisTaskChan)
+	taskID := s.AnalyzeProjectPathWithOptions(dir, "broken.db", &entity.AnalysisOptions{Algo: "static"})
+	// 读取状态通道, 避免分析阻塞
+	go drainStatus(s, taskID)
+	waitTask(t, s, taskID)
+
+	status, err := s.GetTaskStatus(taskID)
+	if err != nil || status.Status != entity.TaskStatusFailed || !strings.Contains(status.Message, "undefined") {
+		t.Errorf("Expected failed status with the load error, got %+v, %v", status, err)
+	}
+}
+
+// drainStatus 读取任务的状态通道直到关闭
+func drainStatus(s *StaticAnalysisBiz, taskID string) {
+	for {
+		if ch, err := s.globalChan.Get(taskID); err == nil {
+			for range ch {
+			}
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -241,6 +241,7 @@ type Biz struct {
 	Openai           *OpenAI                `protobuf:"bytes,3,opt,name=openai,proto3" json:"openai,omitempty"`                                            // OpenAI配置
 	StaticStorePath  string                 `protobuf:"bytes,4,opt,name=staticStorePath,proto3" json:"staticStorePath,omitempty"`                          // 静态分析存储路径
 	RuntimeStorePath string                 `protobuf:"bytes,5,opt,name=runtimeStorePath,proto3" json:"runtimeStorePath,omitempty"`                        // 运行时分析存储路径
+	Tracer           *Tracer                `protobuf:"bytes,6,opt,name=tracer,proto3" json:"tracer,omitempty"`                                            // 插桩模板, 为空时使用functrace
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -310,6 +311,13 @@ func (x *Biz) GetRuntimeStorePath() string {
 	return ""
 }
 
+func (x *Biz) GetTracer() *Tracer {
+	if x != nil {
+		return x.Tracer
+	}
+	return nil
+}
+
 type Data struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Dbpath        string                 `protobuf:"bytes,1,opt,name=dbpath,proto3" json:"dbpath,omitempty"`
@@ -474,6 +482,131 @@ func (x *GitLab) GetCloneDir() string {
 	return ""
 }
 
+// Tracer 插桩模板, 生成 defer alias.func(params)() 形式的语句
+type Tracer struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ImportPath     string                 `protobuf:"bytes,1,opt,name=import_path,json=importPath,proto3" json:"import_path,omitempty"`             // 追踪包导入路径
+	Alias          string                 `protobuf:"bytes,2,opt,name=alias,proto3" json:"alias,omitempty"`                                         // 包名或导入别名, 为空时取导入路径最后一段
+	Func           string                 `protobuf:"bytes,3,opt,name=func,proto3" json:"func,omitempty"`                                           // 追踪函数名
+	Shape          string                 `protobuf:"bytes,4,opt,name=shape,proto3" json:"shape,omitempty"`                                         // 调用形式: defer-return、defer 或 otel-span
+	Params         string                 `protobuf:"bytes,5,opt,name=params,proto3" json:"params,omitempty"`                                       // 参数传递方式: slice、variadic 或 none
+	Fallback       string                 `protobuf:"bytes,6,opt,name=fallback,proto3" json:"fallback,omitempty"`                                   // otel-span模式下没有context参数时获取context的表达式, skip表示不插桩
+	FallbackImport string                 `protobuf:"bytes,7,opt,name=fallback_import,json=fallbackImport,proto3" json:"fallback_import,omitempty"` // fallback表达式依赖的导入路径
+	Capture        bool                   `protobuf:"varint,8,opt,name=capture,proto3" json:"capture,omitempty"`                                    // 是否记录返回的error和panic
+	Result         string                 `protobuf:"bytes,9,opt,name=result,proto3" json:"result,omitempty"`                                       // 接收返回error和panic的函数名, capture为true时必须指定
+	Init           string                 `protobuf:"bytes,10,opt,name=init,proto3" json:"init,omitempty"`                                          // 测试插桩时TestMain在m.Run之前调用的函数名
+	Flush          string                 `protobuf:"bytes,11,opt,name=flush,proto3" json:"flush,omitempty"`                                        // 测试插桩时TestMain在m.Run之后调用的落盘函数名, functrace模板插桩测试文件时必须指定
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Tracer) Reset() {
+	*x = Tracer{}
+	mi := &file_conf_conf_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tracer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tracer) ProtoMessage() {}
+
+func (x *Tracer) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tracer.ProtoReflect.Descriptor instead.
+func (*Tracer) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Tracer) GetImportPath() string {
+	if x != nil {
+		return x.ImportPath
+	}
+	return ""
+}
+
+func (x *Tracer) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *Tracer) GetFunc() string {
+	if x != nil {
+		return x.Func
+	}
+	return ""
+}
+
+func (x *Tracer) GetShape() string {
+	if x != nil {
+		return x.Shape
+	}
+	return ""
+}
+
+func (x *Tracer) GetParams() string {
+	if x != nil {
+		return x.Params
+	}
+	return ""
+}
+
+func (x *Tracer) GetFallback() string {
+	if x != nil {
+		return x.Fallback
+	}
+	return ""
+}
+
+func (x *Tracer) GetFallbackImport() string {
+	if x != nil {
+		return x.FallbackImport
+	}
+	return ""
+}
+
+func (x *Tracer) GetCapture() bool {
+	if x != nil {
+		return x.Capture
+	}
+	return false
+}
+
+func (x *Tracer) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *Tracer) GetInit() string {
+	if x != nil {
+		return x.Init
+	}
+	return ""
+}
+
+func (x *Tracer) GetFlush() string {
+	if x != nil {
+		return x.Flush
+	}
+	return ""
+}
+
 type Server_HTTP struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
@@ -485,7 +618,7 @@ type Server_HTTP struct {
 
 func (x *Server_HTTP) Reset() {
 	*x = Server_HTTP{}
-	mi := &file_conf_conf_proto_msgTypes[7]
+	mi := &file_conf_conf_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -497,7 +630,7 @@ func (x *Server_HTTP) String() string {
 func (*Server_HTTP) ProtoMessage() {}
 
 func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[7]
+	mi := &file_conf_conf_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -545,7 +678,7 @@ type Server_GRPC struct {
 
 func (x *Server_GRPC) Reset() {
 	*x = Server_GRPC{}
-	mi := &file_conf_conf_proto_msgTypes[8]
+	mi := &file_conf_conf_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -557,7 +690,7 @@ func (x *Server_GRPC) String() string {
 func (*Server_GRPC) ProtoMessage() {}
 
 func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[8]
+	mi := &file_conf_conf_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -624,13 +757,14 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\amax_age\x18\x05 \x01(\x05R\x06maxAge\x12\x1f\n" +
 	"\vmax_backups\x18\x06 \x01(\x05R\n" +
 	"maxBackups\x12\x1a\n" +
-	"\bcompress\x18\a \x01(\bR\bcompress\"\xdf\x01\n" +
+	"\bcompress\x18\a \x01(\bR\bcompress\"\x8b\x02\n" +
 	"\x03Biz\x12*\n" +
 	"\x11file_storage_path\x18\x01 \x01(\tR\x0ffileStoragePath\x12*\n" +
 	"\x06gitlab\x18\x02 \x01(\v2\x12.kratos.api.GitLabR\x06gitlab\x12*\n" +
 	"\x06openai\x18\x03 \x01(\v2\x12.kratos.api.OpenAIR\x06openai\x12(\n" +
 	"\x0fstaticStorePath\x18\x04 \x01(\tR\x0fstaticStorePath\x12*\n" +
-	"\x10runtimeStorePath\x18\x05 \x01(\tR\x10runtimeStorePath\"\x1e\n" +
+	"\x10runtimeStorePath\x18\x05 \x01(\tR\x10runtimeStorePath\x12*\n" +
+	"\x06tracer\x18\x06 \x01(\v2\x12.kratos.api.TracerR\x06tracer\"\x1e\n" +
 	"\x04Data\x12\x16\n" +
 	"\x06dbpath\x18\x01 \x01(\tR\x06dbpath\"R\n" +
 	"\x06OpenAI\x12\x17\n" +
@@ -640,7 +774,21 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\x06GitLab\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x10\n" +
 	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1b\n" +
-	"\tclone_dir\x18\x03 \x01(\tR\bcloneDirB\x1fZ\x1dgoanalysis/internal/conf;confb\x06proto3"
+	"\tclone_dir\x18\x03 \x01(\tR\bcloneDir\"\xa2\x02\n" +
+	"\x06Tracer\x12\x1f\n" +
+	"\vimport_path\x18\x01 \x01(\tR\n" +
+	"importPath\x12\x14\n" +
+	"\x05alias\x18\x02 \x01(\tR\x05alias\x12\x12\n" +
+	"\x04func\x18\x03 \x01(\tR\x04func\x12\x14\n" +
+	"\x05shape\x18\x04 \x01(\tR\x05shape\x12\x16\n" +
+	"\x06params\x18\x05 \x01(\tR\x06params\x12\x1a\n" +
+	"\bfallback\x18\x06 \x01(\tR\bfallback\x12'\n" +
+	"\x0ffallback_import\x18\a \x01(\tR\x0efallbackImport\x12\x18\n" +
+	"\acapture\x18\b \x01(\bR\acapture\x12\x16\n" +
+	"\x06result\x18\t \x01(\tR\x06result\x12\x12\n" +
+	"\x04init\x18\n" +
+	" \x01(\tR\x04init\x12\x14\n" +
+	"\x05flush\x18\v \x01(\tR\x05flushB\x1fZ\x1dgoanalysis/internal/conf;confb\x06proto3"
 
 var (
 	file_conf_conf_proto_rawDescOnce sync.Once
@@ -654,7 +802,7 @@ func file_conf_conf_proto_rawDescGZIP() []byte {
 	return file_conf_conf_proto_rawDescData
 }
 
-var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_conf_conf_proto_goTypes = []any{
 	(*Bootstrap)(nil),           // 0: kratos.api.Bootstrap
 	(*Server)(nil),              // 1: kratos.api.Server
@@ -663,26 +811,28 @@ var file_conf_conf_proto_goTypes = []any{
 	(*Data)(nil),                // 4: kratos.api.Data
 	(*OpenAI)(nil),              // 5: kratos.api.OpenAI
 	(*GitLab)(nil),              // 6: kratos.api.GitLab
-	(*Server_HTTP)(nil),         // 7: kratos.api.Server.HTTP
-	(*Server_GRPC)(nil),         // 8: kratos.api.Server.GRPC
-	(*durationpb.Duration)(nil), // 9: google.protobuf.Duration
+	(*Tracer)(nil),              // 7: kratos.api.Tracer
+	(*Server_HTTP)(nil),         // 8: kratos.api.Server.HTTP
+	(*Server_GRPC)(nil),         // 9: kratos.api.Server.GRPC
+	(*durationpb.Duration)(nil), // 10: google.protobuf.Duration
 }
 var file_conf_conf_proto_depIdxs = []int32{
 	1,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
 	3,  // 1: kratos.api.Bootstrap.biz:type_name -> kratos.api.Biz
 	2,  // 2: kratos.api.Bootstrap.logger:type_name -> kratos.api.Logger
 	4,  // 3: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
-	7,  // 4: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
-	8,  // 5: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
+	8,  // 4: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
+	9,  // 5: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
 	6,  // 6: kratos.api.Biz.gitlab:type_name -> kratos.api.GitLab
 	5,  // 7: kratos.api.Biz.openai:type_name -> kratos.api.OpenAI
-	9,  // 8: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
-	9,  // 9: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	7,  // 8: kratos.api.Biz.tracer:type_name -> kratos.api.Tracer
+	10, // 9: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
+	10, // 10: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_conf_conf_proto_init() }
@@ -696,7 +846,7 @@ func file_conf_conf_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conf_conf_proto_rawDesc), len(file_conf_conf_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
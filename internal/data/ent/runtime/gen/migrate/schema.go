@@ -50,6 +50,8 @@ var (
 		{Name: "parentId", Type: field.TypeInt64, Nullable: true},
 		{Name: "createdAt", Type: field.TypeString},
 		{Name: "seq", Type: field.TypeString, Nullable: true},
+		{Name: "error", Type: field.TypeString, Nullable: true},
+		{Name: "panic", Type: field.TypeString, Nullable: true},
 	}
 	// TraceDataTable holds the schema information for the "traceData" table.
 	TraceDataTable = &schema.Table{
@@ -1418,6 +1418,8 @@ type TraceDataMutation struct {
 	addparentId    *int64
 	createdAt      *string
 	seq            *string
+	error          *string
+	panic          *string
 	clearedFields  map[string]struct{}
 	done           bool
 	oldValue       func(context.Context) (*TraceData, error)
@@ -1936,6 +1938,104 @@ func (m *TraceDataMutation) ResetSeq() {
 	delete(m.clearedFields, tracedata.FieldSeq)
 }
 
+// SetError sets the "error" field.
+func (m *TraceDataMutation) SetError(s string) {
+	m.error = &s
+}
+
+// Error returns the value of the "error" field in the mutation.
+func (m *TraceDataMutation) Error() (r string, exists bool) {
+	v := m.error
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldError returns the old "error" field's value of the TraceData entity.
+// If the TraceData object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TraceDataMutation) OldError(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldError is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldError requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldError: %w", err)
+	}
+	return oldValue.Error, nil
+}
+
+// ClearError clears the value of the "error" field.
+func (m *TraceDataMutation) ClearError() {
+	m.error = nil
+	m.clearedFields[tracedata.FieldError] = struct{}{}
+}
+
+// ErrorCleared returns if the "error" field was cleared in this mutation.
+func (m *TraceDataMutation) ErrorCleared() bool {
+	_, ok := m.clearedFields[tracedata.FieldError]
+	return ok
+}
+
+// ResetError resets all changes to the "error" field.
+func (m *TraceDataMutation) ResetError() {
+	m.error = nil
+	delete(m.clearedFields, tracedata.FieldError)
+}
+
+// SetPanic sets the "panic" field.
+func (m *TraceDataMutation) SetPanic(s string) {
+	m.panic = &s
+}
+
+// Panic returns the value of the "panic" field in the mutation.
+func (m *TraceDataMutation) Panic() (r string, exists bool) {
+	v := m.panic
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPanic returns the old "panic" field's value of the TraceData entity.
+// If the TraceData object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TraceDataMutation) OldPanic(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPanic is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPanic requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPanic: %w", err)
+	}
+	return oldValue.Panic, nil
+}
+
+// ClearPanic clears the value of the "panic" field.
+func (m *TraceDataMutation) ClearPanic() {
+	m.panic = nil
+	m.clearedFields[tracedata.FieldPanic] = struct{}{}
+}
+
+// PanicCleared returns if the "panic" field was cleared in this mutation.
+func (m *TraceDataMutation) PanicCleared() bool {
+	_, ok := m.clearedFields[tracedata.FieldPanic]
+	return ok
+}
+
+// ResetPanic resets all changes to the "panic" field.
+func (m *TraceDataMutation) ResetPanic() {
+	m.panic = nil
+	delete(m.clearedFields, tracedata.FieldPanic)
+}
+
 // Where appends a list predicates to the TraceDataMutation builder.
 func (m *TraceDataMutation) Where(ps ...predicate.TraceData) {
 	m.predicates = append(m.predicates, ps...)
@@ -1970,7 +2070,7 @@ func (m *TraceDataMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *TraceDataMutation) Fields() []string {
-	fields := make([]string, 0, 8)
+	fields := make([]string, 0, 10)
 	if m.name != nil {
 		fields = append(fields, tracedata.FieldName)
 	}
@@ -1995,6 +2095,12 @@ func (m *TraceDataMutation) Fields() []string {
 	if m.seq != nil {
 		fields = append(fields, tracedata.FieldSeq)
 	}
+	if m.error != nil {
+		fields = append(fields, tracedata.FieldError)
+	}
+	if m.panic != nil {
+		fields = append(fields, tracedata.FieldPanic)
+	}
 	return fields
 }
 
@@ -2019,6 +2125,10 @@ func (m *TraceDataMutation) Field(name string) (ent.Value, bool) {
 		return m.CreatedAt()
 	case tracedata.FieldSeq:
 		return m.Seq()
+	case tracedata.FieldError:
+		return m.Error()
+	case tracedata.FieldPanic:
+		return m.Panic()
 	}
 	return nil, false
 }
@@ -2044,6 +2154,10 @@ func (m *TraceDataMutation) OldField(ctx context.Context, name string) (ent.Valu
 		return m.OldCreatedAt(ctx)
 	case tracedata.FieldSeq:
 		return m.OldSeq(ctx)
+	case tracedata.FieldError:
+		return m.OldError(ctx)
+	case tracedata.FieldPanic:
+		return m.OldPanic(ctx)
 	}
 	return nil, fmt.Errorf("unknown TraceData field %s", name)
 }
@@ -2109,6 +2223,20 @@ func (m *TraceDataMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetSeq(v)
 		return nil
+	case tracedata.FieldError:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetError(v)
+		return nil
+	case tracedata.FieldPanic:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPanic(v)
+		return nil
 	}
 	return fmt.Errorf("unknown TraceData field %s", name)
 }
@@ -2199,6 +2327,12 @@ func (m *TraceDataMutation) ClearedFields() []string {
 	if m.FieldCleared(tracedata.FieldSeq) {
 		fields = append(fields, tracedata.FieldSeq)
 	}
+	if m.FieldCleared(tracedata.FieldError) {
+		fields = append(fields, tracedata.FieldError)
+	}
+	if m.FieldCleared(tracedata.FieldPanic) {
+		fields = append(fields, tracedata.FieldPanic)
+	}
 	return fields
 }
 
@@ -2222,6 +2356,12 @@ func (m *TraceDataMutation) ClearField(name string) error {
 	case tracedata.FieldSeq:
 		m.ClearSeq()
 		return nil
+	case tracedata.FieldError:
+		m.ClearError()
+		return nil
+	case tracedata.FieldPanic:
+		m.ClearPanic()
+		return nil
 	}
 	return fmt.Errorf("unknown TraceData nullable field %s", name)
 }
@@ -2254,6 +2394,12 @@ func (m *TraceDataMutation) ResetField(name string) error {
 	case tracedata.FieldSeq:
 		m.ResetSeq()
 		return nil
+	case tracedata.FieldError:
+		m.ResetError()
+		return nil
+	case tracedata.FieldPanic:
+		m.ResetPanic()
+		return nil
 	}
 	return fmt.Errorf("unknown TraceData field %s", name)
 }
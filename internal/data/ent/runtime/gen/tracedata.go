@@ -31,7 +31,11 @@ type TraceData struct {
 	// CreatedAt holds the value of the "createdAt" field.
 	CreatedAt string `json:"createdAt,omitempty"`
 	// Seq holds the value of the "seq" field.
-	Seq          string `json:"seq,omitempty"`
+	Seq string `json:"seq,omitempty"`
+	// Error holds the value of the "error" field.
+	Error string `json:"error,omitempty"`
+	// Panic holds the value of the "panic" field.
+	Panic        string `json:"panic,omitempty"`
 	selectValues sql.SelectValues
 }
 
@@ -42,7 +46,7 @@ func (*TraceData) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case tracedata.FieldID, tracedata.FieldGid, tracedata.FieldIndent, tracedata.FieldParamsCount, tracedata.FieldParentId:
 			values[i] = new(sql.NullInt64)
-		case tracedata.FieldName, tracedata.FieldTimeCost, tracedata.FieldCreatedAt, tracedata.FieldSeq:
+		case tracedata.FieldName, tracedata.FieldTimeCost, tracedata.FieldCreatedAt, tracedata.FieldSeq, tracedata.FieldError, tracedata.FieldPanic:
 			values[i] = new(sql.NullString)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -113,6 +117,18 @@ func (td *TraceData) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				td.Seq = value.String
 			}
+		case tracedata.FieldError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field error", values[i])
+			} else if value.Valid {
+				td.Error = value.String
+			}
+		case tracedata.FieldPanic:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field panic", values[i])
+			} else if value.Valid {
+				td.Panic = value.String
+			}
 		default:
 			td.selectValues.Set(columns[i], values[i])
 		}
@@ -172,6 +188,12 @@ func (td *TraceData) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("seq=")
 	builder.WriteString(td.Seq)
+	builder.WriteString(", ")
+	builder.WriteString("error=")
+	builder.WriteString(td.Error)
+	builder.WriteString(", ")
+	builder.WriteString("panic=")
+	builder.WriteString(td.Panic)
 	builder.WriteByte(')')
 	return builder.String()
 }
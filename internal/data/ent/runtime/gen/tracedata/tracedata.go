@@ -27,6 +27,10 @@ const (
 	FieldCreatedAt = "createdAt"
 	// FieldSeq holds the string denoting the seq field in the database.
 	FieldSeq = "seq"
+	// FieldError holds the string denoting the error field in the database.
+	FieldError = "error"
+	// FieldPanic holds the string denoting the panic field in the database.
+	FieldPanic = "panic"
 	// Table holds the table name of the tracedata in the database.
 	Table = "traceData"
 )
@@ -42,6 +46,8 @@ var Columns = []string{
 	FieldParentId,
 	FieldCreatedAt,
 	FieldSeq,
+	FieldError,
+	FieldPanic,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -112,3 +118,13 @@ func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 func BySeq(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldSeq, opts...).ToFunc()
 }
+
+// ByError orders the results by the error field.
+func ByError(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldError, opts...).ToFunc()
+}
+
+// ByPanic orders the results by the panic field.
+func ByPanic(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPanic, opts...).ToFunc()
+}
@@ -92,6 +92,16 @@ func Seq(v string) predicate.TraceData {
 	return predicate.TraceData(sql.FieldEQ(FieldSeq, v))
 }
 
+// Error applies equality check predicate on the "error" field. It's identical to ErrorEQ.
+func Error(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldEQ(FieldError, v))
+}
+
+// Panic applies equality check predicate on the "panic" field. It's identical to PanicEQ.
+func Panic(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldEQ(FieldPanic, v))
+}
+
 // NameEQ applies the EQ predicate on the "name" field.
 func NameEQ(v string) predicate.TraceData {
 	return predicate.TraceData(sql.FieldEQ(FieldName, v))
@@ -542,6 +552,156 @@ func SeqContainsFold(v string) predicate.TraceData {
 	return predicate.TraceData(sql.FieldContainsFold(FieldSeq, v))
 }
 
+// ErrorEQ applies the EQ predicate on the "error" field.
+func ErrorEQ(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldEQ(FieldError, v))
+}
+
+// ErrorNEQ applies the NEQ predicate on the "error" field.
+func ErrorNEQ(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldNEQ(FieldError, v))
+}
+
+// ErrorIn applies the In predicate on the "error" field.
+func ErrorIn(vs ...string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldIn(FieldError, vs...))
+}
+
+// ErrorNotIn applies the NotIn predicate on the "error" field.
+func ErrorNotIn(vs ...string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldNotIn(FieldError, vs...))
+}
+
+// ErrorGT applies the GT predicate on the "error" field.
+func ErrorGT(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldGT(FieldError, v))
+}
+
+// ErrorGTE applies the GTE predicate on the "error" field.
+func ErrorGTE(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldGTE(FieldError, v))
+}
+
+// ErrorLT applies the LT predicate on the "error" field.
+func ErrorLT(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldLT(FieldError, v))
+}
+
+// ErrorLTE applies the LTE predicate on the "error" field.
+func ErrorLTE(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldLTE(FieldError, v))
+}
+
+// ErrorContains applies the Contains predicate on the "error" field.
+func ErrorContains(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldContains(FieldError, v))
+}
+
+// ErrorHasPrefix applies the HasPrefix predicate on the "error" field.
+func ErrorHasPrefix(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldHasPrefix(FieldError, v))
+}
+
+// ErrorHasSuffix applies the HasSuffix predicate on the "error" field.
+func ErrorHasSuffix(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldHasSuffix(FieldError, v))
+}
+
+// ErrorIsNil applies the IsNil predicate on the "error" field.
+func ErrorIsNil() predicate.TraceData {
+	return predicate.TraceData(sql.FieldIsNull(FieldError))
+}
+
+// ErrorNotNil applies the NotNil predicate on the "error" field.
+func ErrorNotNil() predicate.TraceData {
+	return predicate.TraceData(sql.FieldNotNull(FieldError))
+}
+
+// ErrorEqualFold applies the EqualFold predicate on the "error" field.
+func ErrorEqualFold(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldEqualFold(FieldError, v))
+}
+
+// ErrorContainsFold applies the ContainsFold predicate on the "error" field.
+func ErrorContainsFold(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldContainsFold(FieldError, v))
+}
+
+// PanicEQ applies the EQ predicate on the "panic" field.
+func PanicEQ(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldEQ(FieldPanic, v))
+}
+
+// PanicNEQ applies the NEQ predicate on the "panic" field.
+func PanicNEQ(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldNEQ(FieldPanic, v))
+}
+
+// PanicIn applies the In predicate on the "panic" field.
+func PanicIn(vs ...string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldIn(FieldPanic, vs...))
+}
+
+// PanicNotIn applies the NotIn predicate on the "panic" field.
+func PanicNotIn(vs ...string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldNotIn(FieldPanic, vs...))
+}
+
+// PanicGT applies the GT predicate on the "panic" field.
+func PanicGT(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldGT(FieldPanic, v))
+}
+
+// PanicGTE applies the GTE predicate on the "panic" field.
+func PanicGTE(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldGTE(FieldPanic, v))
+}
+
+// PanicLT applies the LT predicate on the "panic" field.
+func PanicLT(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldLT(FieldPanic, v))
+}
+
+// PanicLTE applies the LTE predicate on the "panic" field.
+func PanicLTE(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldLTE(FieldPanic, v))
+}
+
+// PanicContains applies the Contains predicate on the "panic" field.
+func PanicContains(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldContains(FieldPanic, v))
+}
+
+// PanicHasPrefix applies the HasPrefix predicate on the "panic" field.
+func PanicHasPrefix(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldHasPrefix(FieldPanic, v))
+}
+
+// PanicHasSuffix applies the HasSuffix predicate on the "panic" field.
+func PanicHasSuffix(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldHasSuffix(FieldPanic, v))
+}
+
+// PanicIsNil applies the IsNil predicate on the "panic" field.
+func PanicIsNil() predicate.TraceData {
+	return predicate.TraceData(sql.FieldIsNull(FieldPanic))
+}
+
+// PanicNotNil applies the NotNil predicate on the "panic" field.
+func PanicNotNil() predicate.TraceData {
+	return predicate.TraceData(sql.FieldNotNull(FieldPanic))
+}
+
+// PanicEqualFold applies the EqualFold predicate on the "panic" field.
+func PanicEqualFold(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldEqualFold(FieldPanic, v))
+}
+
+// PanicContainsFold applies the ContainsFold predicate on the "panic" field.
+func PanicContainsFold(v string) predicate.TraceData {
+	return predicate.TraceData(sql.FieldContainsFold(FieldPanic, v))
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.TraceData) predicate.TraceData {
 	return predicate.TraceData(sql.AndPredicates(predicates...))
@@ -107,6 +107,34 @@ func (tdc *TraceDataCreate) SetNillableSeq(s *string) *TraceDataCreate {
 	return tdc
 }
 
+// SetError sets the "error" field.
+func (tdc *TraceDataCreate) SetError(s string) *TraceDataCreate {
+	tdc.mutation.SetError(s)
+	return tdc
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (tdc *TraceDataCreate) SetNillableError(s *string) *TraceDataCreate {
+	if s != nil {
+		tdc.SetError(*s)
+	}
+	return tdc
+}
+
+// SetPanic sets the "panic" field.
+func (tdc *TraceDataCreate) SetPanic(s string) *TraceDataCreate {
+	tdc.mutation.SetPanic(s)
+	return tdc
+}
+
+// SetNillablePanic sets the "panic" field if the given value is not nil.
+func (tdc *TraceDataCreate) SetNillablePanic(s *string) *TraceDataCreate {
+	if s != nil {
+		tdc.SetPanic(*s)
+	}
+	return tdc
+}
+
 // SetID sets the "id" field.
 func (tdc *TraceDataCreate) SetID(i int) *TraceDataCreate {
 	tdc.mutation.SetID(i)
@@ -249,6 +277,14 @@ func (tdc *TraceDataCreate) createSpec() (*TraceData, *sqlgraph.CreateSpec) {
 		_spec.SetField(tracedata.FieldSeq, field.TypeString, value)
 		_node.Seq = value
 	}
+	if value, ok := tdc.mutation.Error(); ok {
+		_spec.SetField(tracedata.FieldError, field.TypeString, value)
+		_node.Error = value
+	}
+	if value, ok := tdc.mutation.Panic(); ok {
+		_spec.SetField(tracedata.FieldPanic, field.TypeString, value)
+		_node.Panic = value
+	}
 	return _node, _spec
 }
 
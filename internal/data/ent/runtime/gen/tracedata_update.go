@@ -185,6 +185,46 @@ func (tdu *TraceDataUpdate) ClearSeq() *TraceDataUpdate {
 	return tdu
 }
 
+// SetError sets the "error" field.
+func (tdu *TraceDataUpdate) SetError(s string) *TraceDataUpdate {
+	tdu.mutation.SetError(s)
+	return tdu
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (tdu *TraceDataUpdate) SetNillableError(s *string) *TraceDataUpdate {
+	if s != nil {
+		tdu.SetError(*s)
+	}
+	return tdu
+}
+
+// ClearError clears the value of the "error" field.
+func (tdu *TraceDataUpdate) ClearError() *TraceDataUpdate {
+	tdu.mutation.ClearError()
+	return tdu
+}
+
+// SetPanic sets the "panic" field.
+func (tdu *TraceDataUpdate) SetPanic(s string) *TraceDataUpdate {
+	tdu.mutation.SetPanic(s)
+	return tdu
+}
+
+// SetNillablePanic sets the "panic" field if the given value is not nil.
+func (tdu *TraceDataUpdate) SetNillablePanic(s *string) *TraceDataUpdate {
+	if s != nil {
+		tdu.SetPanic(*s)
+	}
+	return tdu
+}
+
+// ClearPanic clears the value of the "panic" field.
+func (tdu *TraceDataUpdate) ClearPanic() *TraceDataUpdate {
+	tdu.mutation.ClearPanic()
+	return tdu
+}
+
 // Mutation returns the TraceDataMutation object of the builder.
 func (tdu *TraceDataUpdate) Mutation() *TraceDataMutation {
 	return tdu.mutation
@@ -284,6 +324,18 @@ func (tdu *TraceDataUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if tdu.mutation.SeqCleared() {
 		_spec.ClearField(tracedata.FieldSeq, field.TypeString)
 	}
+	if value, ok := tdu.mutation.Error(); ok {
+		_spec.SetField(tracedata.FieldError, field.TypeString, value)
+	}
+	if tdu.mutation.ErrorCleared() {
+		_spec.ClearField(tracedata.FieldError, field.TypeString)
+	}
+	if value, ok := tdu.mutation.Panic(); ok {
+		_spec.SetField(tracedata.FieldPanic, field.TypeString, value)
+	}
+	if tdu.mutation.PanicCleared() {
+		_spec.ClearField(tracedata.FieldPanic, field.TypeString)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, tdu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{tracedata.Label}
@@ -462,6 +514,46 @@ func (tduo *TraceDataUpdateOne) ClearSeq() *TraceDataUpdateOne {
 	return tduo
 }
 
+// SetError sets the "error" field.
+func (tduo *TraceDataUpdateOne) SetError(s string) *TraceDataUpdateOne {
+	tduo.mutation.SetError(s)
+	return tduo
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (tduo *TraceDataUpdateOne) SetNillableError(s *string) *TraceDataUpdateOne {
+	if s != nil {
+		tduo.SetError(*s)
+	}
+	return tduo
+}
+
+// ClearError clears the value of the "error" field.
+func (tduo *TraceDataUpdateOne) ClearError() *TraceDataUpdateOne {
+	tduo.mutation.ClearError()
+	return tduo
+}
+
+// SetPanic sets the "panic" field.
+func (tduo *TraceDataUpdateOne) SetPanic(s string) *TraceDataUpdateOne {
+	tduo.mutation.SetPanic(s)
+	return tduo
+}
+
+// SetNillablePanic sets the "panic" field if the given value is not nil.
+func (tduo *TraceDataUpdateOne) SetNillablePanic(s *string) *TraceDataUpdateOne {
+	if s != nil {
+		tduo.SetPanic(*s)
+	}
+	return tduo
+}
+
+// ClearPanic clears the value of the "panic" field.
+func (tduo *TraceDataUpdateOne) ClearPanic() *TraceDataUpdateOne {
+	tduo.mutation.ClearPanic()
+	return tduo
+}
+
 // Mutation returns the TraceDataMutation object of the builder.
 func (tduo *TraceDataUpdateOne) Mutation() *TraceDataMutation {
 	return tduo.mutation
@@ -591,6 +683,18 @@ func (tduo *TraceDataUpdateOne) sqlSave(ctx context.Context) (_node *TraceData,
 	if tduo.mutation.SeqCleared() {
 		_spec.ClearField(tracedata.FieldSeq, field.TypeString)
 	}
+	if value, ok := tduo.mutation.Error(); ok {
+		_spec.SetField(tracedata.FieldError, field.TypeString, value)
+	}
+	if tduo.mutation.ErrorCleared() {
+		_spec.ClearField(tracedata.FieldError, field.TypeString)
+	}
+	if value, ok := tduo.mutation.Panic(); ok {
+		_spec.SetField(tracedata.FieldPanic, field.TypeString, value)
+	}
+	if tduo.mutation.PanicCleared() {
+		_spec.ClearField(tracedata.FieldPanic, field.TypeString)
+	}
 	_node = &TraceData{config: tduo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
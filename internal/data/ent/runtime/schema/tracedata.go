@@ -38,6 +38,12 @@ func (TraceData) Fields() []ent.Field {
 		field.String("seq").
 			StorageKey("seq").
 			Optional(),
+		field.String("error").
+			StorageKey("error").
+			Optional(),
+		field.String("panic").
+			StorageKey("panic").
+			Optional(),
 	}
 }
 
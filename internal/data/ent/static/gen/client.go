@@ -16,6 +16,7 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"github.com/toheart/goanalysis/internal/data/ent/static/gen/funcedge"
 	"github.com/toheart/goanalysis/internal/data/ent/static/gen/funcnode"
+	"github.com/toheart/goanalysis/internal/data/ent/static/gen/packagehash"
 )
 
 // Client is the client that holds all ent builders.
@@ -27,6 +28,8 @@ type Client struct {
 	FuncEdge *FuncEdgeClient
 	// FuncNode is the client for interacting with the FuncNode builders.
 	FuncNode *FuncNodeClient
+	// PackageHash is the client for interacting with the PackageHash builders.
+	PackageHash *PackageHashClient
 }
 
 // NewClient creates a new client configured with the given options.
@@ -40,6 +43,7 @@ func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
 	c.FuncEdge = NewFuncEdgeClient(c.config)
 	c.FuncNode = NewFuncNodeClient(c.config)
+	c.PackageHash = NewPackageHashClient(c.config)
 }
 
 type (
@@ -130,10 +134,11 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 	cfg := c.config
 	cfg.driver = tx
 	return &Tx{
-		ctx:      ctx,
-		config:   cfg,
-		FuncEdge: NewFuncEdgeClient(cfg),
-		FuncNode: NewFuncNodeClient(cfg),
+		ctx:         ctx,
+		config:      cfg,
+		FuncEdge:    NewFuncEdgeClient(cfg),
+		FuncNode:    NewFuncNodeClient(cfg),
+		PackageHash: NewPackageHashClient(cfg),
 	}, nil
 }
 
@@ -151,10 +156,11 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 	cfg := c.config
 	cfg.driver = &txDriver{tx: tx, drv: c.driver}
 	return &Tx{
-		ctx:      ctx,
-		config:   cfg,
-		FuncEdge: NewFuncEdgeClient(cfg),
-		FuncNode: NewFuncNodeClient(cfg),
+		ctx:         ctx,
+		config:      cfg,
+		FuncEdge:    NewFuncEdgeClient(cfg),
+		FuncNode:    NewFuncNodeClient(cfg),
+		PackageHash: NewPackageHashClient(cfg),
 	}, nil
 }
 
@@ -185,6 +191,7 @@ func (c *Client) Close() error {
 func (c *Client) Use(hooks ...Hook) {
 	c.FuncEdge.Use(hooks...)
 	c.FuncNode.Use(hooks...)
+	c.PackageHash.Use(hooks...)
 }
 
 // Intercept adds the query interceptors to all the entity clients.
@@ -192,6 +199,7 @@ func (c *Client) Use(hooks ...Hook) {
 func (c *Client) Intercept(interceptors ...Interceptor) {
 	c.FuncEdge.Intercept(interceptors...)
 	c.FuncNode.Intercept(interceptors...)
+	c.PackageHash.Intercept(interceptors...)
 }
 
 // Mutate implements the ent.Mutator interface.
@@ -201,6 +209,8 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 		return c.FuncEdge.mutate(ctx, m)
 	case *FuncNodeMutation:
 		return c.FuncNode.mutate(ctx, m)
+	case *PackageHashMutation:
+		return c.PackageHash.mutate(ctx, m)
 	default:
 		return nil, fmt.Errorf("gen: unknown mutation type %T", m)
 	}
@@ -472,12 +482,145 @@ func (c *FuncNodeClient) mutate(ctx context.Context, m *FuncNodeMutation) (Value
 	}
 }
 
+// PackageHashClient is a client for the PackageHash schema.
+type PackageHashClient struct {
+	config
+}
+
+// NewPackageHashClient returns a client for the PackageHash from the given config.
+func NewPackageHashClient(c config) *PackageHashClient {
+	return &PackageHashClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `packagehash.Hooks(f(g(h())))`.
+func (c *PackageHashClient) Use(hooks ...Hook) {
+	c.hooks.PackageHash = append(c.hooks.PackageHash, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `packagehash.Intercept(f(g(h())))`.
+func (c *PackageHashClient) Intercept(interceptors ...Interceptor) {
+	c.inters.PackageHash = append(c.inters.PackageHash, interceptors...)
+}
+
+// Create returns a builder for creating a PackageHash entity.
+func (c *PackageHashClient) Create() *PackageHashCreate {
+	mutation := newPackageHashMutation(c.config, OpCreate)
+	return &PackageHashCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of PackageHash entities.
+func (c *PackageHashClient) CreateBulk(builders ...*PackageHashCreate) *PackageHashCreateBulk {
+	return &PackageHashCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *PackageHashClient) MapCreateBulk(slice any, setFunc func(*PackageHashCreate, int)) *PackageHashCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &PackageHashCreateBulk{err: fmt.Errorf("calling to PackageHashClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*PackageHashCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &PackageHashCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for PackageHash.
+func (c *PackageHashClient) Update() *PackageHashUpdate {
+	mutation := newPackageHashMutation(c.config, OpUpdate)
+	return &PackageHashUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *PackageHashClient) UpdateOne(ph *PackageHash) *PackageHashUpdateOne {
+	mutation := newPackageHashMutation(c.config, OpUpdateOne, withPackageHash(ph))
+	return &PackageHashUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *PackageHashClient) UpdateOneID(id int) *PackageHashUpdateOne {
+	mutation := newPackageHashMutation(c.config, OpUpdateOne, withPackageHashID(id))
+	return &PackageHashUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for PackageHash.
+func (c *PackageHashClient) Delete() *PackageHashDelete {
+	mutation := newPackageHashMutation(c.config, OpDelete)
+	return &PackageHashDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *PackageHashClient) DeleteOne(ph *PackageHash) *PackageHashDeleteOne {
+	return c.DeleteOneID(ph.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *PackageHashClient) DeleteOneID(id int) *PackageHashDeleteOne {
+	builder := c.Delete().Where(packagehash.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &PackageHashDeleteOne{builder}
+}
+
+// Query returns a query builder for PackageHash.
+func (c *PackageHashClient) Query() *PackageHashQuery {
+	return &PackageHashQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypePackageHash},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a PackageHash entity by its id.
+func (c *PackageHashClient) Get(ctx context.Context, id int) (*PackageHash, error) {
+	return c.Query().Where(packagehash.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *PackageHashClient) GetX(ctx context.Context, id int) *PackageHash {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *PackageHashClient) Hooks() []Hook {
+	return c.hooks.PackageHash
+}
+
+// Interceptors returns the client interceptors.
+func (c *PackageHashClient) Interceptors() []Interceptor {
+	return c.inters.PackageHash
+}
+
+func (c *PackageHashClient) mutate(ctx context.Context, m *PackageHashMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&PackageHashCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&PackageHashUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&PackageHashUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&PackageHashDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("gen: unknown PackageHash mutation op: %q", m.Op())
+	}
+}
+
 // hooks and interceptors per client, for fast access.
 type (
 	hooks struct {
-		FuncEdge, FuncNode []ent.Hook
+		FuncEdge, FuncNode, PackageHash []ent.Hook
 	}
 	inters struct {
-		FuncEdge, FuncNode []ent.Interceptor
+		FuncEdge, FuncNode, PackageHash []ent.Interceptor
 	}
 )
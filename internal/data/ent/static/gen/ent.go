@@ -14,6 +14,7 @@ import (
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/toheart/goanalysis/internal/data/ent/static/gen/funcedge"
 	"github.com/toheart/goanalysis/internal/data/ent/static/gen/funcnode"
+	"github.com/toheart/goanalysis/internal/data/ent/static/gen/packagehash"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -74,8 +75,9 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			funcedge.Table: funcedge.ValidColumn,
-			funcnode.Table: funcnode.ValidColumn,
+			funcedge.Table:    funcedge.ValidColumn,
+			funcnode.Table:    funcnode.ValidColumn,
+			packagehash.Table: packagehash.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
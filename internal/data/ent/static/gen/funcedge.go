@@ -3,6 +3,7 @@
 package gen
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -21,10 +22,18 @@ type FuncEdge struct {
 	CreatedAt time.Time `json:"CreatedAt,omitempty"`
 	// UpdatedAt holds the value of the "UpdatedAt" field.
 	UpdatedAt time.Time `json:"UpdatedAt,omitempty"`
-	// CallerKey holds the value of the "CallerKey" field.
+	// 调用方的稳定Key
 	CallerKey string `json:"CallerKey,omitempty"`
-	// CalleeKey holds the value of the "CalleeKey" field.
-	CalleeKey    string `json:"CalleeKey,omitempty"`
+	// 被调用方的稳定Key
+	CalleeKey string `json:"CalleeKey,omitempty"`
+	// 调用点所在文件, 项目内的文件为相对项目目录的路径
+	CallFile string `json:"call_file,omitempty"`
+	// 调用点行号, 没有调用点(如合成调用)时为0
+	CallLine int `json:"call_line,omitempty"`
+	// 调用类型: static/interface/dynamic/closure/go/defer, 没有调用点时为空
+	CallKind string `json:"call_kind,omitempty"`
+	// 矩阵模式下存在这条边的构建配置, 如 linux/amd64, 非矩阵模式为空
+	BuildConfigs []string `json:"build_configs,omitempty"`
 	selectValues sql.SelectValues
 }
 
@@ -33,9 +42,11 @@ func (*FuncEdge) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case funcedge.FieldID:
+		case funcedge.FieldBuildConfigs:
+			values[i] = new([]byte)
+		case funcedge.FieldID, funcedge.FieldCallLine:
 			values[i] = new(sql.NullInt64)
-		case funcedge.FieldCallerKey, funcedge.FieldCalleeKey:
+		case funcedge.FieldCallerKey, funcedge.FieldCalleeKey, funcedge.FieldCallFile, funcedge.FieldCallKind:
 			values[i] = new(sql.NullString)
 		case funcedge.FieldCreatedAt, funcedge.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
@@ -84,6 +95,32 @@ func (fe *FuncEdge) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				fe.CalleeKey = value.String
 			}
+		case funcedge.FieldCallFile:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field call_file", values[i])
+			} else if value.Valid {
+				fe.CallFile = value.String
+			}
+		case funcedge.FieldCallLine:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field call_line", values[i])
+			} else if value.Valid {
+				fe.CallLine = int(value.Int64)
+			}
+		case funcedge.FieldCallKind:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field call_kind", values[i])
+			} else if value.Valid {
+				fe.CallKind = value.String
+			}
+		case funcedge.FieldBuildConfigs:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field build_configs", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &fe.BuildConfigs); err != nil {
+					return fmt.Errorf("unmarshal field build_configs: %w", err)
+				}
+			}
 		default:
 			fe.selectValues.Set(columns[i], values[i])
 		}
@@ -131,6 +168,18 @@ func (fe *FuncEdge) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("CalleeKey=")
 	builder.WriteString(fe.CalleeKey)
+	builder.WriteString(", ")
+	builder.WriteString("call_file=")
+	builder.WriteString(fe.CallFile)
+	builder.WriteString(", ")
+	builder.WriteString("call_line=")
+	builder.WriteString(fmt.Sprintf("%v", fe.CallLine))
+	builder.WriteString(", ")
+	builder.WriteString("call_kind=")
+	builder.WriteString(fe.CallKind)
+	builder.WriteString(", ")
+	builder.WriteString("build_configs=")
+	builder.WriteString(fmt.Sprintf("%v", fe.BuildConfigs))
 	builder.WriteByte(')')
 	return builder.String()
 }
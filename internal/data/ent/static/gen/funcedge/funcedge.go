@@ -19,6 +19,14 @@ const (
 	FieldCallerKey = "caller_key"
 	// FieldCalleeKey holds the string denoting the calleekey field in the database.
 	FieldCalleeKey = "callee_key"
+	// FieldCallFile holds the string denoting the call_file field in the database.
+	FieldCallFile = "call_file"
+	// FieldCallLine holds the string denoting the call_line field in the database.
+	FieldCallLine = "call_line"
+	// FieldCallKind holds the string denoting the call_kind field in the database.
+	FieldCallKind = "call_kind"
+	// FieldBuildConfigs holds the string denoting the build_configs field in the database.
+	FieldBuildConfigs = "build_configs"
 	// Table holds the table name of the funcedge in the database.
 	Table = "func_edges"
 )
@@ -30,6 +38,10 @@ var Columns = []string{
 	FieldUpdatedAt,
 	FieldCallerKey,
 	FieldCalleeKey,
+	FieldCallFile,
+	FieldCallLine,
+	FieldCallKind,
+	FieldBuildConfigs,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -42,6 +54,15 @@ func ValidColumn(column string) bool {
 	return false
 }
 
+var (
+	// DefaultCallFile holds the default value on creation for the "call_file" field.
+	DefaultCallFile string
+	// DefaultCallLine holds the default value on creation for the "call_line" field.
+	DefaultCallLine int
+	// DefaultCallKind holds the default value on creation for the "call_kind" field.
+	DefaultCallKind string
+)
+
 // OrderOption defines the ordering options for the FuncEdge queries.
 type OrderOption func(*sql.Selector)
 
@@ -69,3 +90,18 @@ func ByCallerKey(opts ...sql.OrderTermOption) OrderOption {
 func ByCalleeKey(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCalleeKey, opts...).ToFunc()
 }
+
+// ByCallFile orders the results by the call_file field.
+func ByCallFile(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCallFile, opts...).ToFunc()
+}
+
+// ByCallLine orders the results by the call_line field.
+func ByCallLine(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCallLine, opts...).ToFunc()
+}
+
+// ByCallKind orders the results by the call_kind field.
+func ByCallKind(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCallKind, opts...).ToFunc()
+}
@@ -74,6 +74,21 @@ func CalleeKey(v string) predicate.FuncEdge {
 	return predicate.FuncEdge(sql.FieldEQ(FieldCalleeKey, v))
 }
 
+// CallFile applies equality check predicate on the "call_file" field. It's identical to CallFileEQ.
+func CallFile(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldEQ(FieldCallFile, v))
+}
+
+// CallLine applies equality check predicate on the "call_line" field. It's identical to CallLineEQ.
+func CallLine(v int) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldEQ(FieldCallLine, v))
+}
+
+// CallKind applies equality check predicate on the "call_kind" field. It's identical to CallKindEQ.
+func CallKind(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldEQ(FieldCallKind, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "CreatedAt" field.
 func CreatedAtEQ(v time.Time) predicate.FuncEdge {
 	return predicate.FuncEdge(sql.FieldEQ(FieldCreatedAt, v))
@@ -284,6 +299,186 @@ func CalleeKeyContainsFold(v string) predicate.FuncEdge {
 	return predicate.FuncEdge(sql.FieldContainsFold(FieldCalleeKey, v))
 }
 
+// CallFileEQ applies the EQ predicate on the "call_file" field.
+func CallFileEQ(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldEQ(FieldCallFile, v))
+}
+
+// CallFileNEQ applies the NEQ predicate on the "call_file" field.
+func CallFileNEQ(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldNEQ(FieldCallFile, v))
+}
+
+// CallFileIn applies the In predicate on the "call_file" field.
+func CallFileIn(vs ...string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldIn(FieldCallFile, vs...))
+}
+
+// CallFileNotIn applies the NotIn predicate on the "call_file" field.
+func CallFileNotIn(vs ...string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldNotIn(FieldCallFile, vs...))
+}
+
+// CallFileGT applies the GT predicate on the "call_file" field.
+func CallFileGT(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldGT(FieldCallFile, v))
+}
+
+// CallFileGTE applies the GTE predicate on the "call_file" field.
+func CallFileGTE(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldGTE(FieldCallFile, v))
+}
+
+// CallFileLT applies the LT predicate on the "call_file" field.
+func CallFileLT(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldLT(FieldCallFile, v))
+}
+
+// CallFileLTE applies the LTE predicate on the "call_file" field.
+func CallFileLTE(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldLTE(FieldCallFile, v))
+}
+
+// CallFileContains applies the Contains predicate on the "call_file" field.
+func CallFileContains(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldContains(FieldCallFile, v))
+}
+
+// CallFileHasPrefix applies the HasPrefix predicate on the "call_file" field.
+func CallFileHasPrefix(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldHasPrefix(FieldCallFile, v))
+}
+
+// CallFileHasSuffix applies the HasSuffix predicate on the "call_file" field.
+func CallFileHasSuffix(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldHasSuffix(FieldCallFile, v))
+}
+
+// CallFileEqualFold applies the EqualFold predicate on the "call_file" field.
+func CallFileEqualFold(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldEqualFold(FieldCallFile, v))
+}
+
+// CallFileContainsFold applies the ContainsFold predicate on the "call_file" field.
+func CallFileContainsFold(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldContainsFold(FieldCallFile, v))
+}
+
+// CallLineEQ applies the EQ predicate on the "call_line" field.
+func CallLineEQ(v int) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldEQ(FieldCallLine, v))
+}
+
+// CallLineNEQ applies the NEQ predicate on the "call_line" field.
+func CallLineNEQ(v int) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldNEQ(FieldCallLine, v))
+}
+
+// CallLineIn applies the In predicate on the "call_line" field.
+func CallLineIn(vs ...int) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldIn(FieldCallLine, vs...))
+}
+
+// CallLineNotIn applies the NotIn predicate on the "call_line" field.
+func CallLineNotIn(vs ...int) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldNotIn(FieldCallLine, vs...))
+}
+
+// CallLineGT applies the GT predicate on the "call_line" field.
+func CallLineGT(v int) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldGT(FieldCallLine, v))
+}
+
+// CallLineGTE applies the GTE predicate on the "call_line" field.
+func CallLineGTE(v int) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldGTE(FieldCallLine, v))
+}
+
+// CallLineLT applies the LT predicate on the "call_line" field.
+func CallLineLT(v int) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldLT(FieldCallLine, v))
+}
+
+// CallLineLTE applies the LTE predicate on the "call_line" field.
+func CallLineLTE(v int) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldLTE(FieldCallLine, v))
+}
+
+// CallKindEQ applies the EQ predicate on the "call_kind" field.
+func CallKindEQ(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldEQ(FieldCallKind, v))
+}
+
+// CallKindNEQ applies the NEQ predicate on the "call_kind" field.
+func CallKindNEQ(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldNEQ(FieldCallKind, v))
+}
+
+// CallKindIn applies the In predicate on the "call_kind" field.
+func CallKindIn(vs ...string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldIn(FieldCallKind, vs...))
+}
+
+// CallKindNotIn applies the NotIn predicate on the "call_kind" field.
+func CallKindNotIn(vs ...string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldNotIn(FieldCallKind, vs...))
+}
+
+// CallKindGT applies the GT predicate on the "call_kind" field.
+func CallKindGT(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldGT(FieldCallKind, v))
+}
+
+// CallKindGTE applies the GTE predicate on the "call_kind" field.
+func CallKindGTE(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldGTE(FieldCallKind, v))
+}
+
+// CallKindLT applies the LT predicate on the "call_kind" field.
+func CallKindLT(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldLT(FieldCallKind, v))
+}
+
+// CallKindLTE applies the LTE predicate on the "call_kind" field.
+func CallKindLTE(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldLTE(FieldCallKind, v))
+}
+
+// CallKindContains applies the Contains predicate on the "call_kind" field.
+func CallKindContains(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldContains(FieldCallKind, v))
+}
+
+// CallKindHasPrefix applies the HasPrefix predicate on the "call_kind" field.
+func CallKindHasPrefix(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldHasPrefix(FieldCallKind, v))
+}
+
+// CallKindHasSuffix applies the HasSuffix predicate on the "call_kind" field.
+func CallKindHasSuffix(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldHasSuffix(FieldCallKind, v))
+}
+
+// CallKindEqualFold applies the EqualFold predicate on the "call_kind" field.
+func CallKindEqualFold(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldEqualFold(FieldCallKind, v))
+}
+
+// CallKindContainsFold applies the ContainsFold predicate on the "call_kind" field.
+func CallKindContainsFold(v string) predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldContainsFold(FieldCallKind, v))
+}
+
+// BuildConfigsIsNil applies the IsNil predicate on the "build_configs" field.
+func BuildConfigsIsNil() predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldIsNull(FieldBuildConfigs))
+}
+
+// BuildConfigsNotNil applies the NotNil predicate on the "build_configs" field.
+func BuildConfigsNotNil() predicate.FuncEdge {
+	return predicate.FuncEdge(sql.FieldNotNull(FieldBuildConfigs))
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.FuncEdge) predicate.FuncEdge {
 	return predicate.FuncEdge(sql.AndPredicates(predicates...))
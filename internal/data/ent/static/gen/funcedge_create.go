@@ -44,6 +44,54 @@ func (fec *FuncEdgeCreate) SetCalleeKey(s string) *FuncEdgeCreate {
 	return fec
 }
 
+// SetCallFile sets the "call_file" field.
+func (fec *FuncEdgeCreate) SetCallFile(s string) *FuncEdgeCreate {
+	fec.mutation.SetCallFile(s)
+	return fec
+}
+
+// SetNillableCallFile sets the "call_file" field if the given value is not nil.
+func (fec *FuncEdgeCreate) SetNillableCallFile(s *string) *FuncEdgeCreate {
+	if s != nil {
+		fec.SetCallFile(*s)
+	}
+	return fec
+}
+
+// SetCallLine sets the "call_line" field.
+func (fec *FuncEdgeCreate) SetCallLine(i int) *FuncEdgeCreate {
+	fec.mutation.SetCallLine(i)
+	return fec
+}
+
+// SetNillableCallLine sets the "call_line" field if the given value is not nil.
+func (fec *FuncEdgeCreate) SetNillableCallLine(i *int) *FuncEdgeCreate {
+	if i != nil {
+		fec.SetCallLine(*i)
+	}
+	return fec
+}
+
+// SetCallKind sets the "call_kind" field.
+func (fec *FuncEdgeCreate) SetCallKind(s string) *FuncEdgeCreate {
+	fec.mutation.SetCallKind(s)
+	return fec
+}
+
+// SetNillableCallKind sets the "call_kind" field if the given value is not nil.
+func (fec *FuncEdgeCreate) SetNillableCallKind(s *string) *FuncEdgeCreate {
+	if s != nil {
+		fec.SetCallKind(*s)
+	}
+	return fec
+}
+
+// SetBuildConfigs sets the "build_configs" field.
+func (fec *FuncEdgeCreate) SetBuildConfigs(s []string) *FuncEdgeCreate {
+	fec.mutation.SetBuildConfigs(s)
+	return fec
+}
+
 // Mutation returns the FuncEdgeMutation object of the builder.
 func (fec *FuncEdgeCreate) Mutation() *FuncEdgeMutation {
 	return fec.mutation
@@ -51,6 +99,7 @@ func (fec *FuncEdgeCreate) Mutation() *FuncEdgeMutation {
 
 // Save creates the FuncEdge in the database.
 func (fec *FuncEdgeCreate) Save(ctx context.Context) (*FuncEdge, error) {
+	fec.defaults()
 	return withHooks(ctx, fec.sqlSave, fec.mutation, fec.hooks)
 }
 
@@ -76,6 +125,22 @@ func (fec *FuncEdgeCreate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (fec *FuncEdgeCreate) defaults() {
+	if _, ok := fec.mutation.CallFile(); !ok {
+		v := funcedge.DefaultCallFile
+		fec.mutation.SetCallFile(v)
+	}
+	if _, ok := fec.mutation.CallLine(); !ok {
+		v := funcedge.DefaultCallLine
+		fec.mutation.SetCallLine(v)
+	}
+	if _, ok := fec.mutation.CallKind(); !ok {
+		v := funcedge.DefaultCallKind
+		fec.mutation.SetCallKind(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (fec *FuncEdgeCreate) check() error {
 	if _, ok := fec.mutation.CreatedAt(); !ok {
@@ -90,6 +155,15 @@ func (fec *FuncEdgeCreate) check() error {
 	if _, ok := fec.mutation.CalleeKey(); !ok {
 		return &ValidationError{Name: "CalleeKey", err: errors.New(`gen: missing required field "FuncEdge.CalleeKey"`)}
 	}
+	if _, ok := fec.mutation.CallFile(); !ok {
+		return &ValidationError{Name: "call_file", err: errors.New(`gen: missing required field "FuncEdge.call_file"`)}
+	}
+	if _, ok := fec.mutation.CallLine(); !ok {
+		return &ValidationError{Name: "call_line", err: errors.New(`gen: missing required field "FuncEdge.call_line"`)}
+	}
+	if _, ok := fec.mutation.CallKind(); !ok {
+		return &ValidationError{Name: "call_kind", err: errors.New(`gen: missing required field "FuncEdge.call_kind"`)}
+	}
 	return nil
 }
 
@@ -132,6 +206,22 @@ func (fec *FuncEdgeCreate) createSpec() (*FuncEdge, *sqlgraph.CreateSpec) {
 		_spec.SetField(funcedge.FieldCalleeKey, field.TypeString, value)
 		_node.CalleeKey = value
 	}
+	if value, ok := fec.mutation.CallFile(); ok {
+		_spec.SetField(funcedge.FieldCallFile, field.TypeString, value)
+		_node.CallFile = value
+	}
+	if value, ok := fec.mutation.CallLine(); ok {
+		_spec.SetField(funcedge.FieldCallLine, field.TypeInt, value)
+		_node.CallLine = value
+	}
+	if value, ok := fec.mutation.CallKind(); ok {
+		_spec.SetField(funcedge.FieldCallKind, field.TypeString, value)
+		_node.CallKind = value
+	}
+	if value, ok := fec.mutation.BuildConfigs(); ok {
+		_spec.SetField(funcedge.FieldBuildConfigs, field.TypeJSON, value)
+		_node.BuildConfigs = value
+	}
 	return _node, _spec
 }
 
@@ -153,6 +243,7 @@ func (fecb *FuncEdgeCreateBulk) Save(ctx context.Context) ([]*FuncEdge, error) {
 	for i := range fecb.builders {
 		func(i int, root context.Context) {
 			builder := fecb.builders[i]
+			builder.defaults()
 			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
 				mutation, ok := m.(*FuncEdgeMutation)
 				if !ok {
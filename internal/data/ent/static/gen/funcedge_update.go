@@ -10,6 +10,7 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
 	"github.com/toheart/goanalysis/internal/data/ent/static/gen/funcedge"
 	"github.com/toheart/goanalysis/internal/data/ent/static/gen/predicate"
@@ -84,6 +85,73 @@ func (feu *FuncEdgeUpdate) SetNillableCalleeKey(s *string) *FuncEdgeUpdate {
 	return feu
 }
 
+// SetCallFile sets the "call_file" field.
+func (feu *FuncEdgeUpdate) SetCallFile(s string) *FuncEdgeUpdate {
+	feu.mutation.SetCallFile(s)
+	return feu
+}
+
+// SetNillableCallFile sets the "call_file" field if the given value is not nil.
+func (feu *FuncEdgeUpdate) SetNillableCallFile(s *string) *FuncEdgeUpdate {
+	if s != nil {
+		feu.SetCallFile(*s)
+	}
+	return feu
+}
+
+// SetCallLine sets the "call_line" field.
+func (feu *FuncEdgeUpdate) SetCallLine(i int) *FuncEdgeUpdate {
+	feu.mutation.ResetCallLine()
+	feu.mutation.SetCallLine(i)
+	return feu
+}
+
+// SetNillableCallLine sets the "call_line" field if the given value is not nil.
+func (feu *FuncEdgeUpdate) SetNillableCallLine(i *int) *FuncEdgeUpdate {
+	if i != nil {
+		feu.SetCallLine(*i)
+	}
+	return feu
+}
+
+// AddCallLine adds i to the "call_line" field.
+func (feu *FuncEdgeUpdate) AddCallLine(i int) *FuncEdgeUpdate {
+	feu.mutation.AddCallLine(i)
+	return feu
+}
+
+// SetCallKind sets the "call_kind" field.
+func (feu *FuncEdgeUpdate) SetCallKind(s string) *FuncEdgeUpdate {
+	feu.mutation.SetCallKind(s)
+	return feu
+}
+
+// SetNillableCallKind sets the "call_kind" field if the given value is not nil.
+func (feu *FuncEdgeUpdate) SetNillableCallKind(s *string) *FuncEdgeUpdate {
+	if s != nil {
+		feu.SetCallKind(*s)
+	}
+	return feu
+}
+
+// SetBuildConfigs sets the "build_configs" field.
+func (feu *FuncEdgeUpdate) SetBuildConfigs(s []string) *FuncEdgeUpdate {
+	feu.mutation.SetBuildConfigs(s)
+	return feu
+}
+
+// AppendBuildConfigs appends s to the "build_configs" field.
+func (feu *FuncEdgeUpdate) AppendBuildConfigs(s []string) *FuncEdgeUpdate {
+	feu.mutation.AppendBuildConfigs(s)
+	return feu
+}
+
+// ClearBuildConfigs clears the value of the "build_configs" field.
+func (feu *FuncEdgeUpdate) ClearBuildConfigs() *FuncEdgeUpdate {
+	feu.mutation.ClearBuildConfigs()
+	return feu
+}
+
 // Mutation returns the FuncEdgeMutation object of the builder.
 func (feu *FuncEdgeUpdate) Mutation() *FuncEdgeMutation {
 	return feu.mutation
@@ -137,6 +205,29 @@ func (feu *FuncEdgeUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := feu.mutation.CalleeKey(); ok {
 		_spec.SetField(funcedge.FieldCalleeKey, field.TypeString, value)
 	}
+	if value, ok := feu.mutation.CallFile(); ok {
+		_spec.SetField(funcedge.FieldCallFile, field.TypeString, value)
+	}
+	if value, ok := feu.mutation.CallLine(); ok {
+		_spec.SetField(funcedge.FieldCallLine, field.TypeInt, value)
+	}
+	if value, ok := feu.mutation.AddedCallLine(); ok {
+		_spec.AddField(funcedge.FieldCallLine, field.TypeInt, value)
+	}
+	if value, ok := feu.mutation.CallKind(); ok {
+		_spec.SetField(funcedge.FieldCallKind, field.TypeString, value)
+	}
+	if value, ok := feu.mutation.BuildConfigs(); ok {
+		_spec.SetField(funcedge.FieldBuildConfigs, field.TypeJSON, value)
+	}
+	if value, ok := feu.mutation.AppendedBuildConfigs(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, funcedge.FieldBuildConfigs, value)
+		})
+	}
+	if feu.mutation.BuildConfigsCleared() {
+		_spec.ClearField(funcedge.FieldBuildConfigs, field.TypeJSON)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, feu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{funcedge.Label}
@@ -213,6 +304,73 @@ func (feuo *FuncEdgeUpdateOne) SetNillableCalleeKey(s *string) *FuncEdgeUpdateOn
 	return feuo
 }
 
+// SetCallFile sets the "call_file" field.
+func (feuo *FuncEdgeUpdateOne) SetCallFile(s string) *FuncEdgeUpdateOne {
+	feuo.mutation.SetCallFile(s)
+	return feuo
+}
+
+// SetNillableCallFile sets the "call_file" field if the given value is not nil.
+func (feuo *FuncEdgeUpdateOne) SetNillableCallFile(s *string) *FuncEdgeUpdateOne {
+	if s != nil {
+		feuo.SetCallFile(*s)
+	}
+	return feuo
+}
+
+// SetCallLine sets the "call_line" field.
+func (feuo *FuncEdgeUpdateOne) SetCallLine(i int) *FuncEdgeUpdateOne {
+	feuo.mutation.ResetCallLine()
+	feuo.mutation.SetCallLine(i)
+	return feuo
+}
+
+// SetNillableCallLine sets the "call_line" field if the given value is not nil.
+func (feuo *FuncEdgeUpdateOne) SetNillableCallLine(i *int) *FuncEdgeUpdateOne {
+	if i != nil {
+		feuo.SetCallLine(*i)
+	}
+	return feuo
+}
+
+// AddCallLine adds i to the "call_line" field.
+func (feuo *FuncEdgeUpdateOne) AddCallLine(i int) *FuncEdgeUpdateOne {
+	feuo.mutation.AddCallLine(i)
+	return feuo
+}
+
+// SetCallKind sets the "call_kind" field.
+func (feuo *FuncEdgeUpdateOne) SetCallKind(s string) *FuncEdgeUpdateOne {
+	feuo.mutation.SetCallKind(s)
+	return feuo
+}
+
+// SetNillableCallKind sets the "call_kind" field if the given value is not nil.
+func (feuo *FuncEdgeUpdateOne) SetNillableCallKind(s *string) *FuncEdgeUpdateOne {
+	if s != nil {
+		feuo.SetCallKind(*s)
+	}
+	return feuo
+}
+
+// SetBuildConfigs sets the "build_configs" field.
+func (feuo *FuncEdgeUpdateOne) SetBuildConfigs(s []string) *FuncEdgeUpdateOne {
+	feuo.mutation.SetBuildConfigs(s)
+	return feuo
+}
+
+// AppendBuildConfigs appends s to the "build_configs" field.
+func (feuo *FuncEdgeUpdateOne) AppendBuildConfigs(s []string) *FuncEdgeUpdateOne {
+	feuo.mutation.AppendBuildConfigs(s)
+	return feuo
+}
+
+// ClearBuildConfigs clears the value of the "build_configs" field.
+func (feuo *FuncEdgeUpdateOne) ClearBuildConfigs() *FuncEdgeUpdateOne {
+	feuo.mutation.ClearBuildConfigs()
+	return feuo
+}
+
 // Mutation returns the FuncEdgeMutation object of the builder.
 func (feuo *FuncEdgeUpdateOne) Mutation() *FuncEdgeMutation {
 	return feuo.mutation
@@ -296,6 +454,29 @@ func (feuo *FuncEdgeUpdateOne) sqlSave(ctx context.Context) (_node *FuncEdge, er
 	if value, ok := feuo.mutation.CalleeKey(); ok {
 		_spec.SetField(funcedge.FieldCalleeKey, field.TypeString, value)
 	}
+	if value, ok := feuo.mutation.CallFile(); ok {
+		_spec.SetField(funcedge.FieldCallFile, field.TypeString, value)
+	}
+	if value, ok := feuo.mutation.CallLine(); ok {
+		_spec.SetField(funcedge.FieldCallLine, field.TypeInt, value)
+	}
+	if value, ok := feuo.mutation.AddedCallLine(); ok {
+		_spec.AddField(funcedge.FieldCallLine, field.TypeInt, value)
+	}
+	if value, ok := feuo.mutation.CallKind(); ok {
+		_spec.SetField(funcedge.FieldCallKind, field.TypeString, value)
+	}
+	if value, ok := feuo.mutation.BuildConfigs(); ok {
+		_spec.SetField(funcedge.FieldBuildConfigs, field.TypeJSON, value)
+	}
+	if value, ok := feuo.mutation.AppendedBuildConfigs(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, funcedge.FieldBuildConfigs, value)
+		})
+	}
+	if feuo.mutation.BuildConfigsCleared() {
+		_spec.ClearField(funcedge.FieldBuildConfigs, field.TypeJSON)
+	}
 	_node = &FuncEdge{config: feuo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
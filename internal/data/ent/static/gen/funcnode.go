@@ -3,6 +3,7 @@
 package gen
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -17,14 +18,26 @@ type FuncNode struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID int `json:"id,omitempty"`
-	// 短格式唯一标识，如 n6796
+	// 稳定唯一标识, 包路径+函数名, 如 crypto/hmac.New$1; 旧版本数据库中为 n6796 格式
 	Key string `json:"key,omitempty"`
+	// 调用图节点ID生成的旧格式标识, 如 n6796
+	LegacyKey string `json:"legacy_key,omitempty"`
 	// 完整的函数路径，如 crypto/hmac.New$1
 	FullName string `json:"full_name,omitempty"`
 	// Pkg holds the value of the "pkg" field.
 	Pkg string `json:"pkg,omitempty"`
+	// 所属模块, 标准库为空; 工作区和多模块仓库中用于区分项目内的模块
+	Module string `json:"module,omitempty"`
 	// Name holds the value of the "name" field.
 	Name string `json:"name,omitempty"`
+	// 声明所在文件, 项目内的文件为相对项目目录的路径
+	File string `json:"file,omitempty"`
+	// 函数起始行, 合成函数为0
+	StartLine int `json:"start_line,omitempty"`
+	// 函数结束行
+	EndLine int `json:"end_line,omitempty"`
+	// 矩阵模式下存在这个函数的构建配置, 非矩阵模式为空
+	BuildConfigs []string `json:"build_configs,omitempty"`
 	// CreatedAt holds the value of the "CreatedAt" field.
 	CreatedAt time.Time `json:"CreatedAt,omitempty"`
 	// UpdatedAt holds the value of the "UpdatedAt" field.
@@ -37,9 +50,11 @@ func (*FuncNode) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case funcnode.FieldID:
+		case funcnode.FieldBuildConfigs:
+			values[i] = new([]byte)
+		case funcnode.FieldID, funcnode.FieldStartLine, funcnode.FieldEndLine:
 			values[i] = new(sql.NullInt64)
-		case funcnode.FieldKey, funcnode.FieldFullName, funcnode.FieldPkg, funcnode.FieldName:
+		case funcnode.FieldKey, funcnode.FieldLegacyKey, funcnode.FieldFullName, funcnode.FieldPkg, funcnode.FieldModule, funcnode.FieldName, funcnode.FieldFile:
 			values[i] = new(sql.NullString)
 		case funcnode.FieldCreatedAt, funcnode.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
@@ -70,6 +85,12 @@ func (fn *FuncNode) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				fn.Key = value.String
 			}
+		case funcnode.FieldLegacyKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field legacy_key", values[i])
+			} else if value.Valid {
+				fn.LegacyKey = value.String
+			}
 		case funcnode.FieldFullName:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field full_name", values[i])
@@ -82,12 +103,44 @@ func (fn *FuncNode) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				fn.Pkg = value.String
 			}
+		case funcnode.FieldModule:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field module", values[i])
+			} else if value.Valid {
+				fn.Module = value.String
+			}
 		case funcnode.FieldName:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field name", values[i])
 			} else if value.Valid {
 				fn.Name = value.String
 			}
+		case funcnode.FieldFile:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field file", values[i])
+			} else if value.Valid {
+				fn.File = value.String
+			}
+		case funcnode.FieldStartLine:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field start_line", values[i])
+			} else if value.Valid {
+				fn.StartLine = int(value.Int64)
+			}
+		case funcnode.FieldEndLine:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field end_line", values[i])
+			} else if value.Valid {
+				fn.EndLine = int(value.Int64)
+			}
+		case funcnode.FieldBuildConfigs:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field build_configs", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &fn.BuildConfigs); err != nil {
+					return fmt.Errorf("unmarshal field build_configs: %w", err)
+				}
+			}
 		case funcnode.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field CreatedAt", values[i])
@@ -139,15 +192,33 @@ func (fn *FuncNode) String() string {
 	builder.WriteString("key=")
 	builder.WriteString(fn.Key)
 	builder.WriteString(", ")
+	builder.WriteString("legacy_key=")
+	builder.WriteString(fn.LegacyKey)
+	builder.WriteString(", ")
 	builder.WriteString("full_name=")
 	builder.WriteString(fn.FullName)
 	builder.WriteString(", ")
 	builder.WriteString("pkg=")
 	builder.WriteString(fn.Pkg)
 	builder.WriteString(", ")
+	builder.WriteString("module=")
+	builder.WriteString(fn.Module)
+	builder.WriteString(", ")
 	builder.WriteString("name=")
 	builder.WriteString(fn.Name)
 	builder.WriteString(", ")
+	builder.WriteString("file=")
+	builder.WriteString(fn.File)
+	builder.WriteString(", ")
+	builder.WriteString("start_line=")
+	builder.WriteString(fmt.Sprintf("%v", fn.StartLine))
+	builder.WriteString(", ")
+	builder.WriteString("end_line=")
+	builder.WriteString(fmt.Sprintf("%v", fn.EndLine))
+	builder.WriteString(", ")
+	builder.WriteString("build_configs=")
+	builder.WriteString(fmt.Sprintf("%v", fn.BuildConfigs))
+	builder.WriteString(", ")
 	builder.WriteString("CreatedAt=")
 	builder.WriteString(fn.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
@@ -15,12 +15,24 @@ const (
 	FieldID = "id"
 	// FieldKey holds the string denoting the key field in the database.
 	FieldKey = "key"
+	// FieldLegacyKey holds the string denoting the legacy_key field in the database.
+	FieldLegacyKey = "legacy_key"
 	// FieldFullName holds the string denoting the full_name field in the database.
 	FieldFullName = "full_name"
 	// FieldPkg holds the string denoting the pkg field in the database.
 	FieldPkg = "pkg"
+	// FieldModule holds the string denoting the module field in the database.
+	FieldModule = "module"
 	// FieldName holds the string denoting the name field in the database.
 	FieldName = "name"
+	// FieldFile holds the string denoting the file field in the database.
+	FieldFile = "file"
+	// FieldStartLine holds the string denoting the start_line field in the database.
+	FieldStartLine = "start_line"
+	// FieldEndLine holds the string denoting the end_line field in the database.
+	FieldEndLine = "end_line"
+	// FieldBuildConfigs holds the string denoting the build_configs field in the database.
+	FieldBuildConfigs = "build_configs"
 	// FieldCreatedAt holds the string denoting the createdat field in the database.
 	FieldCreatedAt = "created_at"
 	// FieldUpdatedAt holds the string denoting the updatedat field in the database.
@@ -33,9 +45,15 @@ const (
 var Columns = []string{
 	FieldID,
 	FieldKey,
+	FieldLegacyKey,
 	FieldFullName,
 	FieldPkg,
+	FieldModule,
 	FieldName,
+	FieldFile,
+	FieldStartLine,
+	FieldEndLine,
+	FieldBuildConfigs,
 	FieldCreatedAt,
 	FieldUpdatedAt,
 }
@@ -53,12 +71,22 @@ func ValidColumn(column string) bool {
 var (
 	// KeyValidator is a validator for the "key" field. It is called by the builders before save.
 	KeyValidator func(string) error
+	// DefaultLegacyKey holds the default value on creation for the "legacy_key" field.
+	DefaultLegacyKey string
 	// FullNameValidator is a validator for the "full_name" field. It is called by the builders before save.
 	FullNameValidator func(string) error
 	// PkgValidator is a validator for the "pkg" field. It is called by the builders before save.
 	PkgValidator func(string) error
+	// DefaultModule holds the default value on creation for the "module" field.
+	DefaultModule string
 	// NameValidator is a validator for the "name" field. It is called by the builders before save.
 	NameValidator func(string) error
+	// DefaultFile holds the default value on creation for the "file" field.
+	DefaultFile string
+	// DefaultStartLine holds the default value on creation for the "start_line" field.
+	DefaultStartLine int
+	// DefaultEndLine holds the default value on creation for the "end_line" field.
+	DefaultEndLine int
 	// DefaultCreatedAt holds the default value on creation for the "CreatedAt" field.
 	DefaultCreatedAt func() time.Time
 	// DefaultUpdatedAt holds the default value on creation for the "UpdatedAt" field.
@@ -78,6 +106,11 @@ func ByKey(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldKey, opts...).ToFunc()
 }
 
+// ByLegacyKey orders the results by the legacy_key field.
+func ByLegacyKey(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLegacyKey, opts...).ToFunc()
+}
+
 // ByFullName orders the results by the full_name field.
 func ByFullName(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldFullName, opts...).ToFunc()
@@ -88,11 +121,31 @@ func ByPkg(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldPkg, opts...).ToFunc()
 }
 
+// ByModule orders the results by the module field.
+func ByModule(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldModule, opts...).ToFunc()
+}
+
 // ByName orders the results by the name field.
 func ByName(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldName, opts...).ToFunc()
 }
 
+// ByFile orders the results by the file field.
+func ByFile(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldFile, opts...).ToFunc()
+}
+
+// ByStartLine orders the results by the start_line field.
+func ByStartLine(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStartLine, opts...).ToFunc()
+}
+
+// ByEndLine orders the results by the end_line field.
+func ByEndLine(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEndLine, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the CreatedAt field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
@@ -59,6 +59,11 @@ func Key(v string) predicate.FuncNode {
 	return predicate.FuncNode(sql.FieldEQ(FieldKey, v))
 }
 
+// LegacyKey applies equality check predicate on the "legacy_key" field. It's identical to LegacyKeyEQ.
+func LegacyKey(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldEQ(FieldLegacyKey, v))
+}
+
 // FullName applies equality check predicate on the "full_name" field. It's identical to FullNameEQ.
 func FullName(v string) predicate.FuncNode {
 	return predicate.FuncNode(sql.FieldEQ(FieldFullName, v))
@@ -69,11 +74,31 @@ func Pkg(v string) predicate.FuncNode {
 	return predicate.FuncNode(sql.FieldEQ(FieldPkg, v))
 }
 
+// Module applies equality check predicate on the "module" field. It's identical to ModuleEQ.
+func Module(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldEQ(FieldModule, v))
+}
+
 // Name applies equality check predicate on the "name" field. It's identical to NameEQ.
 func Name(v string) predicate.FuncNode {
 	return predicate.FuncNode(sql.FieldEQ(FieldName, v))
 }
 
+// File applies equality check predicate on the "file" field. It's identical to FileEQ.
+func File(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldEQ(FieldFile, v))
+}
+
+// StartLine applies equality check predicate on the "start_line" field. It's identical to StartLineEQ.
+func StartLine(v int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldEQ(FieldStartLine, v))
+}
+
+// EndLine applies equality check predicate on the "end_line" field. It's identical to EndLineEQ.
+func EndLine(v int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldEQ(FieldEndLine, v))
+}
+
 // CreatedAt applies equality check predicate on the "CreatedAt" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.FuncNode {
 	return predicate.FuncNode(sql.FieldEQ(FieldCreatedAt, v))
@@ -149,6 +174,71 @@ func KeyContainsFold(v string) predicate.FuncNode {
 	return predicate.FuncNode(sql.FieldContainsFold(FieldKey, v))
 }
 
+// LegacyKeyEQ applies the EQ predicate on the "legacy_key" field.
+func LegacyKeyEQ(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldEQ(FieldLegacyKey, v))
+}
+
+// LegacyKeyNEQ applies the NEQ predicate on the "legacy_key" field.
+func LegacyKeyNEQ(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldNEQ(FieldLegacyKey, v))
+}
+
+// LegacyKeyIn applies the In predicate on the "legacy_key" field.
+func LegacyKeyIn(vs ...string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldIn(FieldLegacyKey, vs...))
+}
+
+// LegacyKeyNotIn applies the NotIn predicate on the "legacy_key" field.
+func LegacyKeyNotIn(vs ...string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldNotIn(FieldLegacyKey, vs...))
+}
+
+// LegacyKeyGT applies the GT predicate on the "legacy_key" field.
+func LegacyKeyGT(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldGT(FieldLegacyKey, v))
+}
+
+// LegacyKeyGTE applies the GTE predicate on the "legacy_key" field.
+func LegacyKeyGTE(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldGTE(FieldLegacyKey, v))
+}
+
+// LegacyKeyLT applies the LT predicate on the "legacy_key" field.
+func LegacyKeyLT(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldLT(FieldLegacyKey, v))
+}
+
+// LegacyKeyLTE applies the LTE predicate on the "legacy_key" field.
+func LegacyKeyLTE(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldLTE(FieldLegacyKey, v))
+}
+
+// LegacyKeyContains applies the Contains predicate on the "legacy_key" field.
+func LegacyKeyContains(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldContains(FieldLegacyKey, v))
+}
+
+// LegacyKeyHasPrefix applies the HasPrefix predicate on the "legacy_key" field.
+func LegacyKeyHasPrefix(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldHasPrefix(FieldLegacyKey, v))
+}
+
+// LegacyKeyHasSuffix applies the HasSuffix predicate on the "legacy_key" field.
+func LegacyKeyHasSuffix(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldHasSuffix(FieldLegacyKey, v))
+}
+
+// LegacyKeyEqualFold applies the EqualFold predicate on the "legacy_key" field.
+func LegacyKeyEqualFold(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldEqualFold(FieldLegacyKey, v))
+}
+
+// LegacyKeyContainsFold applies the ContainsFold predicate on the "legacy_key" field.
+func LegacyKeyContainsFold(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldContainsFold(FieldLegacyKey, v))
+}
+
 // FullNameEQ applies the EQ predicate on the "full_name" field.
 func FullNameEQ(v string) predicate.FuncNode {
 	return predicate.FuncNode(sql.FieldEQ(FieldFullName, v))
@@ -279,6 +369,71 @@ func PkgContainsFold(v string) predicate.FuncNode {
 	return predicate.FuncNode(sql.FieldContainsFold(FieldPkg, v))
 }
 
+// ModuleEQ applies the EQ predicate on the "module" field.
+func ModuleEQ(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldEQ(FieldModule, v))
+}
+
+// ModuleNEQ applies the NEQ predicate on the "module" field.
+func ModuleNEQ(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldNEQ(FieldModule, v))
+}
+
+// ModuleIn applies the In predicate on the "module" field.
+func ModuleIn(vs ...string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldIn(FieldModule, vs...))
+}
+
+// ModuleNotIn applies the NotIn predicate on the "module" field.
+func ModuleNotIn(vs ...string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldNotIn(FieldModule, vs...))
+}
+
+// ModuleGT applies the GT predicate on the "module" field.
+func ModuleGT(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldGT(FieldModule, v))
+}
+
+// ModuleGTE applies the GTE predicate on the "module" field.
+func ModuleGTE(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldGTE(FieldModule, v))
+}
+
+// ModuleLT applies the LT predicate on the "module" field.
+func ModuleLT(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldLT(FieldModule, v))
+}
+
+// ModuleLTE applies the LTE predicate on the "module" field.
+func ModuleLTE(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldLTE(FieldModule, v))
+}
+
+// ModuleContains applies the Contains predicate on the "module" field.
+func ModuleContains(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldContains(FieldModule, v))
+}
+
+// ModuleHasPrefix applies the HasPrefix predicate on the "module" field.
+func ModuleHasPrefix(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldHasPrefix(FieldModule, v))
+}
+
+// ModuleHasSuffix applies the HasSuffix predicate on the "module" field.
+func ModuleHasSuffix(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldHasSuffix(FieldModule, v))
+}
+
+// ModuleEqualFold applies the EqualFold predicate on the "module" field.
+func ModuleEqualFold(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldEqualFold(FieldModule, v))
+}
+
+// ModuleContainsFold applies the ContainsFold predicate on the "module" field.
+func ModuleContainsFold(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldContainsFold(FieldModule, v))
+}
+
 // NameEQ applies the EQ predicate on the "name" field.
 func NameEQ(v string) predicate.FuncNode {
 	return predicate.FuncNode(sql.FieldEQ(FieldName, v))
@@ -344,6 +499,161 @@ func NameContainsFold(v string) predicate.FuncNode {
 	return predicate.FuncNode(sql.FieldContainsFold(FieldName, v))
 }
 
+// FileEQ applies the EQ predicate on the "file" field.
+func FileEQ(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldEQ(FieldFile, v))
+}
+
+// FileNEQ applies the NEQ predicate on the "file" field.
+func FileNEQ(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldNEQ(FieldFile, v))
+}
+
+// FileIn applies the In predicate on the "file" field.
+func FileIn(vs ...string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldIn(FieldFile, vs...))
+}
+
+// FileNotIn applies the NotIn predicate on the "file" field.
+func FileNotIn(vs ...string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldNotIn(FieldFile, vs...))
+}
+
+// FileGT applies the GT predicate on the "file" field.
+func FileGT(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldGT(FieldFile, v))
+}
+
+// FileGTE applies the GTE predicate on the "file" field.
+func FileGTE(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldGTE(FieldFile, v))
+}
+
+// FileLT applies the LT predicate on the "file" field.
+func FileLT(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldLT(FieldFile, v))
+}
+
+// FileLTE applies the LTE predicate on the "file" field.
+func FileLTE(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldLTE(FieldFile, v))
+}
+
+// FileContains applies the Contains predicate on the "file" field.
+func FileContains(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldContains(FieldFile, v))
+}
+
+// FileHasPrefix applies the HasPrefix predicate on the "file" field.
+func FileHasPrefix(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldHasPrefix(FieldFile, v))
+}
+
+// FileHasSuffix applies the HasSuffix predicate on the "file" field.
+func FileHasSuffix(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldHasSuffix(FieldFile, v))
+}
+
+// FileEqualFold applies the EqualFold predicate on the "file" field.
+func FileEqualFold(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldEqualFold(FieldFile, v))
+}
+
+// FileContainsFold applies the ContainsFold predicate on the "file" field.
+func FileContainsFold(v string) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldContainsFold(FieldFile, v))
+}
+
+// StartLineEQ applies the EQ predicate on the "start_line" field.
+func StartLineEQ(v int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldEQ(FieldStartLine, v))
+}
+
+// StartLineNEQ applies the NEQ predicate on the "start_line" field.
+func StartLineNEQ(v int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldNEQ(FieldStartLine, v))
+}
+
+// StartLineIn applies the In predicate on the "start_line" field.
+func StartLineIn(vs ...int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldIn(FieldStartLine, vs...))
+}
+
+// StartLineNotIn applies the NotIn predicate on the "start_line" field.
+func StartLineNotIn(vs ...int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldNotIn(FieldStartLine, vs...))
+}
+
+// StartLineGT applies the GT predicate on the "start_line" field.
+func StartLineGT(v int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldGT(FieldStartLine, v))
+}
+
+// StartLineGTE applies the GTE predicate on the "start_line" field.
+func StartLineGTE(v int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldGTE(FieldStartLine, v))
+}
+
+// StartLineLT applies the LT predicate on the "start_line" field.
+func StartLineLT(v int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldLT(FieldStartLine, v))
+}
+
+// StartLineLTE applies the LTE predicate on the "start_line" field.
+func StartLineLTE(v int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldLTE(FieldStartLine, v))
+}
+
+// EndLineEQ applies the EQ predicate on the "end_line" field.
+func EndLineEQ(v int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldEQ(FieldEndLine, v))
+}
+
+// EndLineNEQ applies the NEQ predicate on the "end_line" field.
+func EndLineNEQ(v int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldNEQ(FieldEndLine, v))
+}
+
+// EndLineIn applies the In predicate on the "end_line" field.
+func EndLineIn(vs ...int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldIn(FieldEndLine, vs...))
+}
+
+// EndLineNotIn applies the NotIn predicate on the "end_line" field.
+func EndLineNotIn(vs ...int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldNotIn(FieldEndLine, vs...))
+}
+
+// EndLineGT applies the GT predicate on the "end_line" field.
+func EndLineGT(v int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldGT(FieldEndLine, v))
+}
+
+// EndLineGTE applies the GTE predicate on the "end_line" field.
+func EndLineGTE(v int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldGTE(FieldEndLine, v))
+}
+
+// EndLineLT applies the LT predicate on the "end_line" field.
+func EndLineLT(v int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldLT(FieldEndLine, v))
+}
+
+// EndLineLTE applies the LTE predicate on the "end_line" field.
+func EndLineLTE(v int) predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldLTE(FieldEndLine, v))
+}
+
+// BuildConfigsIsNil applies the IsNil predicate on the "build_configs" field.
+func BuildConfigsIsNil() predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldIsNull(FieldBuildConfigs))
+}
+
+// BuildConfigsNotNil applies the NotNil predicate on the "build_configs" field.
+func BuildConfigsNotNil() predicate.FuncNode {
+	return predicate.FuncNode(sql.FieldNotNull(FieldBuildConfigs))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "CreatedAt" field.
 func CreatedAtEQ(v time.Time) predicate.FuncNode {
 	return predicate.FuncNode(sql.FieldEQ(FieldCreatedAt, v))
@@ -26,6 +26,20 @@ func (fnc *FuncNodeCreate) SetKey(s string) *FuncNodeCreate {
 	return fnc
 }
 
+// SetLegacyKey sets the "legacy_key" field.
+func (fnc *FuncNodeCreate) SetLegacyKey(s string) *FuncNodeCreate {
+	fnc.mutation.SetLegacyKey(s)
+	return fnc
+}
+
+// SetNillableLegacyKey sets the "legacy_key" field if the given value is not nil.
+func (fnc *FuncNodeCreate) SetNillableLegacyKey(s *string) *FuncNodeCreate {
+	if s != nil {
+		fnc.SetLegacyKey(*s)
+	}
+	return fnc
+}
+
 // SetFullName sets the "full_name" field.
 func (fnc *FuncNodeCreate) SetFullName(s string) *FuncNodeCreate {
 	fnc.mutation.SetFullName(s)
@@ -38,12 +52,74 @@ func (fnc *FuncNodeCreate) SetPkg(s string) *FuncNodeCreate {
 	return fnc
 }
 
+// SetModule sets the "module" field.
+func (fnc *FuncNodeCreate) SetModule(s string) *FuncNodeCreate {
+	fnc.mutation.SetModule(s)
+	return fnc
+}
+
+// SetNillableModule sets the "module" field if the given value is not nil.
+func (fnc *FuncNodeCreate) SetNillableModule(s *string) *FuncNodeCreate {
+	if s != nil {
+		fnc.SetModule(*s)
+	}
+	return fnc
+}
+
 // SetName sets the "name" field.
 func (fnc *FuncNodeCreate) SetName(s string) *FuncNodeCreate {
 	fnc.mutation.SetName(s)
 	return fnc
 }
 
+// SetFile sets the "file" field.
+func (fnc *FuncNodeCreate) SetFile(s string) *FuncNodeCreate {
+	fnc.mutation.SetFile(s)
+	return fnc
+}
+
+// SetNillableFile sets the "file" field if the given value is not nil.
+func (fnc *FuncNodeCreate) SetNillableFile(s *string) *FuncNodeCreate {
+	if s != nil {
+		fnc.SetFile(*s)
+	}
+	return fnc
+}
+
+// SetStartLine sets the "start_line" field.
+func (fnc *FuncNodeCreate) SetStartLine(i int) *FuncNodeCreate {
+	fnc.mutation.SetStartLine(i)
+	return fnc
+}
+
+// SetNillableStartLine sets the "start_line" field if the given value is not nil.
+func (fnc *FuncNodeCreate) SetNillableStartLine(i *int) *FuncNodeCreate {
+	if i != nil {
+		fnc.SetStartLine(*i)
+	}
+	return fnc
+}
+
+// SetEndLine sets the "end_line" field.
+func (fnc *FuncNodeCreate) SetEndLine(i int) *FuncNodeCreate {
+	fnc.mutation.SetEndLine(i)
+	return fnc
+}
+
+// SetNillableEndLine sets the "end_line" field if the given value is not nil.
+func (fnc *FuncNodeCreate) SetNillableEndLine(i *int) *FuncNodeCreate {
+	if i != nil {
+		fnc.SetEndLine(*i)
+	}
+	return fnc
+}
+
+// SetBuildConfigs sets the "build_configs" field.
+func (fnc *FuncNodeCreate) SetBuildConfigs(s []string) *FuncNodeCreate {
+	fnc.mutation.SetBuildConfigs(s)
+	return fnc
+}
+
 // SetCreatedAt sets the "CreatedAt" field.
 func (fnc *FuncNodeCreate) SetCreatedAt(t time.Time) *FuncNodeCreate {
 	fnc.mutation.SetCreatedAt(t)
@@ -107,6 +183,26 @@ func (fnc *FuncNodeCreate) ExecX(ctx context.Context) {
 
 // defaults sets the default values of the builder before save.
 func (fnc *FuncNodeCreate) defaults() {
+	if _, ok := fnc.mutation.LegacyKey(); !ok {
+		v := funcnode.DefaultLegacyKey
+		fnc.mutation.SetLegacyKey(v)
+	}
+	if _, ok := fnc.mutation.Module(); !ok {
+		v := funcnode.DefaultModule
+		fnc.mutation.SetModule(v)
+	}
+	if _, ok := fnc.mutation.File(); !ok {
+		v := funcnode.DefaultFile
+		fnc.mutation.SetFile(v)
+	}
+	if _, ok := fnc.mutation.StartLine(); !ok {
+		v := funcnode.DefaultStartLine
+		fnc.mutation.SetStartLine(v)
+	}
+	if _, ok := fnc.mutation.EndLine(); !ok {
+		v := funcnode.DefaultEndLine
+		fnc.mutation.SetEndLine(v)
+	}
 	if _, ok := fnc.mutation.CreatedAt(); !ok {
 		v := funcnode.DefaultCreatedAt()
 		fnc.mutation.SetCreatedAt(v)
@@ -127,6 +223,9 @@ func (fnc *FuncNodeCreate) check() error {
 			return &ValidationError{Name: "key", err: fmt.Errorf(`gen: validator failed for field "FuncNode.key": %w`, err)}
 		}
 	}
+	if _, ok := fnc.mutation.LegacyKey(); !ok {
+		return &ValidationError{Name: "legacy_key", err: errors.New(`gen: missing required field "FuncNode.legacy_key"`)}
+	}
 	if _, ok := fnc.mutation.FullName(); !ok {
 		return &ValidationError{Name: "full_name", err: errors.New(`gen: missing required field "FuncNode.full_name"`)}
 	}
@@ -143,6 +242,9 @@ func (fnc *FuncNodeCreate) check() error {
 			return &ValidationError{Name: "pkg", err: fmt.Errorf(`gen: validator failed for field "FuncNode.pkg": %w`, err)}
 		}
 	}
+	if _, ok := fnc.mutation.Module(); !ok {
+		return &ValidationError{Name: "module", err: errors.New(`gen: missing required field "FuncNode.module"`)}
+	}
 	if _, ok := fnc.mutation.Name(); !ok {
 		return &ValidationError{Name: "name", err: errors.New(`gen: missing required field "FuncNode.name"`)}
 	}
@@ -151,6 +253,15 @@ func (fnc *FuncNodeCreate) check() error {
 			return &ValidationError{Name: "name", err: fmt.Errorf(`gen: validator failed for field "FuncNode.name": %w`, err)}
 		}
 	}
+	if _, ok := fnc.mutation.File(); !ok {
+		return &ValidationError{Name: "file", err: errors.New(`gen: missing required field "FuncNode.file"`)}
+	}
+	if _, ok := fnc.mutation.StartLine(); !ok {
+		return &ValidationError{Name: "start_line", err: errors.New(`gen: missing required field "FuncNode.start_line"`)}
+	}
+	if _, ok := fnc.mutation.EndLine(); !ok {
+		return &ValidationError{Name: "end_line", err: errors.New(`gen: missing required field "FuncNode.end_line"`)}
+	}
 	if _, ok := fnc.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "CreatedAt", err: errors.New(`gen: missing required field "FuncNode.CreatedAt"`)}
 	}
@@ -187,6 +298,10 @@ func (fnc *FuncNodeCreate) createSpec() (*FuncNode, *sqlgraph.CreateSpec) {
 		_spec.SetField(funcnode.FieldKey, field.TypeString, value)
 		_node.Key = value
 	}
+	if value, ok := fnc.mutation.LegacyKey(); ok {
+		_spec.SetField(funcnode.FieldLegacyKey, field.TypeString, value)
+		_node.LegacyKey = value
+	}
 	if value, ok := fnc.mutation.FullName(); ok {
 		_spec.SetField(funcnode.FieldFullName, field.TypeString, value)
 		_node.FullName = value
@@ -195,10 +310,30 @@ func (fnc *FuncNodeCreate) createSpec() (*FuncNode, *sqlgraph.CreateSpec) {
 		_spec.SetField(funcnode.FieldPkg, field.TypeString, value)
 		_node.Pkg = value
 	}
+	if value, ok := fnc.mutation.Module(); ok {
+		_spec.SetField(funcnode.FieldModule, field.TypeString, value)
+		_node.Module = value
+	}
 	if value, ok := fnc.mutation.Name(); ok {
 		_spec.SetField(funcnode.FieldName, field.TypeString, value)
 		_node.Name = value
 	}
+	if value, ok := fnc.mutation.File(); ok {
+		_spec.SetField(funcnode.FieldFile, field.TypeString, value)
+		_node.File = value
+	}
+	if value, ok := fnc.mutation.StartLine(); ok {
+		_spec.SetField(funcnode.FieldStartLine, field.TypeInt, value)
+		_node.StartLine = value
+	}
+	if value, ok := fnc.mutation.EndLine(); ok {
+		_spec.SetField(funcnode.FieldEndLine, field.TypeInt, value)
+		_node.EndLine = value
+	}
+	if value, ok := fnc.mutation.BuildConfigs(); ok {
+		_spec.SetField(funcnode.FieldBuildConfigs, field.TypeJSON, value)
+		_node.BuildConfigs = value
+	}
 	if value, ok := fnc.mutation.CreatedAt(); ok {
 		_spec.SetField(funcnode.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -10,6 +10,7 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
 	"github.com/toheart/goanalysis/internal/data/ent/static/gen/funcnode"
 	"github.com/toheart/goanalysis/internal/data/ent/static/gen/predicate"
@@ -42,6 +43,20 @@ func (fnu *FuncNodeUpdate) SetNillableKey(s *string) *FuncNodeUpdate {
 	return fnu
 }
 
+// SetLegacyKey sets the "legacy_key" field.
+func (fnu *FuncNodeUpdate) SetLegacyKey(s string) *FuncNodeUpdate {
+	fnu.mutation.SetLegacyKey(s)
+	return fnu
+}
+
+// SetNillableLegacyKey sets the "legacy_key" field if the given value is not nil.
+func (fnu *FuncNodeUpdate) SetNillableLegacyKey(s *string) *FuncNodeUpdate {
+	if s != nil {
+		fnu.SetLegacyKey(*s)
+	}
+	return fnu
+}
+
 // SetFullName sets the "full_name" field.
 func (fnu *FuncNodeUpdate) SetFullName(s string) *FuncNodeUpdate {
 	fnu.mutation.SetFullName(s)
@@ -70,6 +85,20 @@ func (fnu *FuncNodeUpdate) SetNillablePkg(s *string) *FuncNodeUpdate {
 	return fnu
 }
 
+// SetModule sets the "module" field.
+func (fnu *FuncNodeUpdate) SetModule(s string) *FuncNodeUpdate {
+	fnu.mutation.SetModule(s)
+	return fnu
+}
+
+// SetNillableModule sets the "module" field if the given value is not nil.
+func (fnu *FuncNodeUpdate) SetNillableModule(s *string) *FuncNodeUpdate {
+	if s != nil {
+		fnu.SetModule(*s)
+	}
+	return fnu
+}
+
 // SetName sets the "name" field.
 func (fnu *FuncNodeUpdate) SetName(s string) *FuncNodeUpdate {
 	fnu.mutation.SetName(s)
@@ -84,6 +113,80 @@ func (fnu *FuncNodeUpdate) SetNillableName(s *string) *FuncNodeUpdate {
 	return fnu
 }
 
+// SetFile sets the "file" field.
+func (fnu *FuncNodeUpdate) SetFile(s string) *FuncNodeUpdate {
+	fnu.mutation.SetFile(s)
+	return fnu
+}
+
+// SetNillableFile sets the "file" field if the given value is not nil.
+func (fnu *FuncNodeUpdate) SetNillableFile(s *string) *FuncNodeUpdate {
+	if s != nil {
+		fnu.SetFile(*s)
+	}
+	return fnu
+}
+
+// SetStartLine sets the "start_line" field.
+func (fnu *FuncNodeUpdate) SetStartLine(i int) *FuncNodeUpdate {
+	fnu.mutation.ResetStartLine()
+	fnu.mutation.SetStartLine(i)
+	return fnu
+}
+
+// SetNillableStartLine sets the "start_line" field if the given value is not nil.
+func (fnu *FuncNodeUpdate) SetNillableStartLine(i *int) *FuncNodeUpdate {
+	if i != nil {
+		fnu.SetStartLine(*i)
+	}
+	return fnu
+}
+
+// AddStartLine adds i to the "start_line" field.
+func (fnu *FuncNodeUpdate) AddStartLine(i int) *FuncNodeUpdate {
+	fnu.mutation.AddStartLine(i)
+	return fnu
+}
+
+// SetEndLine sets the "end_line" field.
+func (fnu *FuncNodeUpdate) SetEndLine(i int) *FuncNodeUpdate {
+	fnu.mutation.ResetEndLine()
+	fnu.mutation.SetEndLine(i)
+	return fnu
+}
+
+// SetNillableEndLine sets the "end_line" field if the given value is not nil.
+func (fnu *FuncNodeUpdate) SetNillableEndLine(i *int) *FuncNodeUpdate {
+	if i != nil {
+		fnu.SetEndLine(*i)
+	}
+	return fnu
+}
+
+// AddEndLine adds i to the "end_line" field.
+func (fnu *FuncNodeUpdate) AddEndLine(i int) *FuncNodeUpdate {
+	fnu.mutation.AddEndLine(i)
+	return fnu
+}
+
+// SetBuildConfigs sets the "build_configs" field.
+func (fnu *FuncNodeUpdate) SetBuildConfigs(s []string) *FuncNodeUpdate {
+	fnu.mutation.SetBuildConfigs(s)
+	return fnu
+}
+
+// AppendBuildConfigs appends s to the "build_configs" field.
+func (fnu *FuncNodeUpdate) AppendBuildConfigs(s []string) *FuncNodeUpdate {
+	fnu.mutation.AppendBuildConfigs(s)
+	return fnu
+}
+
+// ClearBuildConfigs clears the value of the "build_configs" field.
+func (fnu *FuncNodeUpdate) ClearBuildConfigs() *FuncNodeUpdate {
+	fnu.mutation.ClearBuildConfigs()
+	return fnu
+}
+
 // SetCreatedAt sets the "CreatedAt" field.
 func (fnu *FuncNodeUpdate) SetCreatedAt(t time.Time) *FuncNodeUpdate {
 	fnu.mutation.SetCreatedAt(t)
@@ -184,15 +287,47 @@ func (fnu *FuncNodeUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := fnu.mutation.Key(); ok {
 		_spec.SetField(funcnode.FieldKey, field.TypeString, value)
 	}
+	if value, ok := fnu.mutation.LegacyKey(); ok {
+		_spec.SetField(funcnode.FieldLegacyKey, field.TypeString, value)
+	}
 	if value, ok := fnu.mutation.FullName(); ok {
 		_spec.SetField(funcnode.FieldFullName, field.TypeString, value)
 	}
 	if value, ok := fnu.mutation.Pkg(); ok {
 		_spec.SetField(funcnode.FieldPkg, field.TypeString, value)
 	}
+	if value, ok := fnu.mutation.Module(); ok {
+		_spec.SetField(funcnode.FieldModule, field.TypeString, value)
+	}
 	if value, ok := fnu.mutation.Name(); ok {
 		_spec.SetField(funcnode.FieldName, field.TypeString, value)
 	}
+	if value, ok := fnu.mutation.File(); ok {
+		_spec.SetField(funcnode.FieldFile, field.TypeString, value)
+	}
+	if value, ok := fnu.mutation.StartLine(); ok {
+		_spec.SetField(funcnode.FieldStartLine, field.TypeInt, value)
+	}
+	if value, ok := fnu.mutation.AddedStartLine(); ok {
+		_spec.AddField(funcnode.FieldStartLine, field.TypeInt, value)
+	}
+	if value, ok := fnu.mutation.EndLine(); ok {
+		_spec.SetField(funcnode.FieldEndLine, field.TypeInt, value)
+	}
+	if value, ok := fnu.mutation.AddedEndLine(); ok {
+		_spec.AddField(funcnode.FieldEndLine, field.TypeInt, value)
+	}
+	if value, ok := fnu.mutation.BuildConfigs(); ok {
+		_spec.SetField(funcnode.FieldBuildConfigs, field.TypeJSON, value)
+	}
+	if value, ok := fnu.mutation.AppendedBuildConfigs(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, funcnode.FieldBuildConfigs, value)
+		})
+	}
+	if fnu.mutation.BuildConfigsCleared() {
+		_spec.ClearField(funcnode.FieldBuildConfigs, field.TypeJSON)
+	}
 	if value, ok := fnu.mutation.CreatedAt(); ok {
 		_spec.SetField(funcnode.FieldCreatedAt, field.TypeTime, value)
 	}
@@ -233,6 +368,20 @@ func (fnuo *FuncNodeUpdateOne) SetNillableKey(s *string) *FuncNodeUpdateOne {
 	return fnuo
 }
 
+// SetLegacyKey sets the "legacy_key" field.
+func (fnuo *FuncNodeUpdateOne) SetLegacyKey(s string) *FuncNodeUpdateOne {
+	fnuo.mutation.SetLegacyKey(s)
+	return fnuo
+}
+
+// SetNillableLegacyKey sets the "legacy_key" field if the given value is not nil.
+func (fnuo *FuncNodeUpdateOne) SetNillableLegacyKey(s *string) *FuncNodeUpdateOne {
+	if s != nil {
+		fnuo.SetLegacyKey(*s)
+	}
+	return fnuo
+}
+
 // SetFullName sets the "full_name" field.
 func (fnuo *FuncNodeUpdateOne) SetFullName(s string) *FuncNodeUpdateOne {
 	fnuo.mutation.SetFullName(s)
@@ -261,6 +410,20 @@ func (fnuo *FuncNodeUpdateOne) SetNillablePkg(s *string) *FuncNodeUpdateOne {
 	return fnuo
 }
 
+// SetModule sets the "module" field.
+func (fnuo *FuncNodeUpdateOne) SetModule(s string) *FuncNodeUpdateOne {
+	fnuo.mutation.SetModule(s)
+	return fnuo
+}
+
+// SetNillableModule sets the "module" field if the given value is not nil.
+func (fnuo *FuncNodeUpdateOne) SetNillableModule(s *string) *FuncNodeUpdateOne {
+	if s != nil {
+		fnuo.SetModule(*s)
+	}
+	return fnuo
+}
+
 // SetName sets the "name" field.
 func (fnuo *FuncNodeUpdateOne) SetName(s string) *FuncNodeUpdateOne {
 	fnuo.mutation.SetName(s)
@@ -275,6 +438,80 @@ func (fnuo *FuncNodeUpdateOne) SetNillableName(s *string) *FuncNodeUpdateOne {
 	return fnuo
 }
 
+// SetFile sets the "file" field.
+func (fnuo *FuncNodeUpdateOne) SetFile(s string) *FuncNodeUpdateOne {
+	fnuo.mutation.SetFile(s)
+	return fnuo
+}
+
+// SetNillableFile sets the "file" field if the given value is not nil.
+func (fnuo *FuncNodeUpdateOne) SetNillableFile(s *string) *FuncNodeUpdateOne {
+	if s != nil {
+		fnuo.SetFile(*s)
+	}
+	return fnuo
+}
+
+// SetStartLine sets the "start_line" field.
+func (fnuo *FuncNodeUpdateOne) SetStartLine(i int) *FuncNodeUpdateOne {
+	fnuo.mutation.ResetStartLine()
+	fnuo.mutation.SetStartLine(i)
+	return fnuo
+}
+
+// SetNillableStartLine sets the "start_line" field if the given value is not nil.
+func (fnuo *FuncNodeUpdateOne) SetNillableStartLine(i *int) *FuncNodeUpdateOne {
+	if i != nil {
+		fnuo.SetStartLine(*i)
+	}
+	return fnuo
+}
+
+// AddStartLine adds i to the "start_line" field.
+func (fnuo *FuncNodeUpdateOne) AddStartLine(i int) *FuncNodeUpdateOne {
+	fnuo.mutation.AddStartLine(i)
+	return fnuo
+}
+
+// SetEndLine sets the "end_line" field.
+func (fnuo *FuncNodeUpdateOne) SetEndLine(i int) *FuncNodeUpdateOne {
+	fnuo.mutation.ResetEndLine()
+	fnuo.mutation.SetEndLine(i)
+	return fnuo
+}
+
+// SetNillableEndLine sets the "end_line" field if the given value is not nil.
+func (fnuo *FuncNodeUpdateOne) SetNillableEndLine(i *int) *FuncNodeUpdateOne {
+	if i != nil {
+		fnuo.SetEndLine(*i)
+	}
+	return fnuo
+}
+
+// AddEndLine adds i to the "end_line" field.
+func (fnuo *FuncNodeUpdateOne) AddEndLine(i int) *FuncNodeUpdateOne {
+	fnuo.mutation.AddEndLine(i)
+	return fnuo
+}
+
+// SetBuildConfigs sets the "build_configs" field.
+func (fnuo *FuncNodeUpdateOne) SetBuildConfigs(s []string) *FuncNodeUpdateOne {
+	fnuo.mutation.SetBuildConfigs(s)
+	return fnuo
+}
+
+// AppendBuildConfigs appends s to the "build_configs" field.
+func (fnuo *FuncNodeUpdateOne) AppendBuildConfigs(s []string) *FuncNodeUpdateOne {
+	fnuo.mutation.AppendBuildConfigs(s)
+	return fnuo
+}
+
+// ClearBuildConfigs clears the value of the "build_configs" field.
+func (fnuo *FuncNodeUpdateOne) ClearBuildConfigs() *FuncNodeUpdateOne {
+	fnuo.mutation.ClearBuildConfigs()
+	return fnuo
+}
+
 // SetCreatedAt sets the "CreatedAt" field.
 func (fnuo *FuncNodeUpdateOne) SetCreatedAt(t time.Time) *FuncNodeUpdateOne {
 	fnuo.mutation.SetCreatedAt(t)
@@ -405,15 +642,47 @@ func (fnuo *FuncNodeUpdateOne) sqlSave(ctx context.Context) (_node *FuncNode, er
 	if value, ok := fnuo.mutation.Key(); ok {
 		_spec.SetField(funcnode.FieldKey, field.TypeString, value)
 	}
+	if value, ok := fnuo.mutation.LegacyKey(); ok {
+		_spec.SetField(funcnode.FieldLegacyKey, field.TypeString, value)
+	}
 	if value, ok := fnuo.mutation.FullName(); ok {
 		_spec.SetField(funcnode.FieldFullName, field.TypeString, value)
 	}
 	if value, ok := fnuo.mutation.Pkg(); ok {
 		_spec.SetField(funcnode.FieldPkg, field.TypeString, value)
 	}
+	if value, ok := fnuo.mutation.Module(); ok {
+		_spec.SetField(funcnode.FieldModule, field.TypeString, value)
+	}
 	if value, ok := fnuo.mutation.Name(); ok {
 		_spec.SetField(funcnode.FieldName, field.TypeString, value)
 	}
+	if value, ok := fnuo.mutation.File(); ok {
+		_spec.SetField(funcnode.FieldFile, field.TypeString, value)
+	}
+	if value, ok := fnuo.mutation.StartLine(); ok {
+		_spec.SetField(funcnode.FieldStartLine, field.TypeInt, value)
+	}
+	if value, ok := fnuo.mutation.AddedStartLine(); ok {
+		_spec.AddField(funcnode.FieldStartLine, field.TypeInt, value)
+	}
+	if value, ok := fnuo.mutation.EndLine(); ok {
+		_spec.SetField(funcnode.FieldEndLine, field.TypeInt, value)
+	}
+	if value, ok := fnuo.mutation.AddedEndLine(); ok {
+		_spec.AddField(funcnode.FieldEndLine, field.TypeInt, value)
+	}
+	if value, ok := fnuo.mutation.BuildConfigs(); ok {
+		_spec.SetField(funcnode.FieldBuildConfigs, field.TypeJSON, value)
+	}
+	if value, ok := fnuo.mutation.AppendedBuildConfigs(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, funcnode.FieldBuildConfigs, value)
+		})
+	}
+	if fnuo.mutation.BuildConfigsCleared() {
+		_spec.ClearField(funcnode.FieldBuildConfigs, field.TypeJSON)
+	}
 	if value, ok := fnuo.mutation.CreatedAt(); ok {
 		_spec.SetField(funcnode.FieldCreatedAt, field.TypeTime, value)
 	}
@@ -33,6 +33,18 @@ func (f FuncNodeFunc) Mutate(ctx context.Context, m gen.Mutation) (gen.Value, er
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *gen.FuncNodeMutation", m)
 }
 
+// The PackageHashFunc type is an adapter to allow the use of ordinary
+// function as PackageHash mutator.
+type PackageHashFunc func(context.Context, *gen.PackageHashMutation) (gen.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f PackageHashFunc) Mutate(ctx context.Context, m gen.Mutation) (gen.Value, error) {
+	if mv, ok := m.(*gen.PackageHashMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *gen.PackageHashMutation", m)
+}
+
 // Condition is a hook condition function.
 type Condition func(context.Context, gen.Mutation) bool
 
@@ -15,6 +15,10 @@ var (
 		{Name: "updated_at", Type: field.TypeTime},
 		{Name: "caller_key", Type: field.TypeString},
 		{Name: "callee_key", Type: field.TypeString},
+		{Name: "call_file", Type: field.TypeString, Default: ""},
+		{Name: "call_line", Type: field.TypeInt, Default: 0},
+		{Name: "call_kind", Type: field.TypeString, Default: ""},
+		{Name: "build_configs", Type: field.TypeJSON, Nullable: true},
 	}
 	// FuncEdgesTable holds the schema information for the "func_edges" table.
 	FuncEdgesTable = &schema.Table{
@@ -38,9 +42,15 @@ var (
 	FuncNodesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
 		{Name: "key", Type: field.TypeString, Unique: true},
+		{Name: "legacy_key", Type: field.TypeString, Default: ""},
 		{Name: "full_name", Type: field.TypeString},
 		{Name: "pkg", Type: field.TypeString},
+		{Name: "module", Type: field.TypeString, Default: ""},
 		{Name: "name", Type: field.TypeString},
+		{Name: "file", Type: field.TypeString, Default: ""},
+		{Name: "start_line", Type: field.TypeInt, Default: 0},
+		{Name: "end_line", Type: field.TypeInt, Default: 0},
+		{Name: "build_configs", Type: field.TypeJSON, Nullable: true},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
 	}
@@ -53,7 +63,12 @@ var (
 			{
 				Name:    "funcnode_pkg",
 				Unique:  false,
-				Columns: []*schema.Column{FuncNodesColumns[3]},
+				Columns: []*schema.Column{FuncNodesColumns[4]},
+			},
+			{
+				Name:    "funcnode_module",
+				Unique:  false,
+				Columns: []*schema.Column{FuncNodesColumns[5]},
 			},
 			{
 				Name:    "funcnode_key",
@@ -61,16 +76,42 @@ var (
 				Columns: []*schema.Column{FuncNodesColumns[1]},
 			},
 			{
-				Name:    "funcnode_full_name",
+				Name:    "funcnode_legacy_key",
 				Unique:  false,
 				Columns: []*schema.Column{FuncNodesColumns[2]},
 			},
+			{
+				Name:    "funcnode_full_name",
+				Unique:  false,
+				Columns: []*schema.Column{FuncNodesColumns[3]},
+			},
+		},
+	}
+	// PackageHashesColumns holds the columns for the "package_hashes" table.
+	PackageHashesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "pkg", Type: field.TypeString},
+		{Name: "hash", Type: field.TypeString},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// PackageHashesTable holds the schema information for the "package_hashes" table.
+	PackageHashesTable = &schema.Table{
+		Name:       "package_hashes",
+		Columns:    PackageHashesColumns,
+		PrimaryKey: []*schema.Column{PackageHashesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "packagehash_pkg",
+				Unique:  true,
+				Columns: []*schema.Column{PackageHashesColumns[1]},
+			},
 		},
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
 		FuncEdgesTable,
 		FuncNodesTable,
+		PackageHashesTable,
 	}
 )
 
@@ -13,6 +13,7 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"github.com/toheart/goanalysis/internal/data/ent/static/gen/funcedge"
 	"github.com/toheart/goanalysis/internal/data/ent/static/gen/funcnode"
+	"github.com/toheart/goanalysis/internal/data/ent/static/gen/packagehash"
 	"github.com/toheart/goanalysis/internal/data/ent/static/gen/predicate"
 )
 
@@ -25,24 +26,31 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
-	TypeFuncEdge = "FuncEdge"
-	TypeFuncNode = "FuncNode"
+	TypeFuncEdge    = "FuncEdge"
+	TypeFuncNode    = "FuncNode"
+	TypePackageHash = "PackageHash"
 )
 
 // FuncEdgeMutation represents an operation that mutates the FuncEdge nodes in the graph.
 type FuncEdgeMutation struct {
 	config
-	op            Op
-	typ           string
-	id            *int
-	_CreatedAt    *time.Time
-	_UpdatedAt    *time.Time
-	_CallerKey    *string
-	_CalleeKey    *string
-	clearedFields map[string]struct{}
-	done          bool
-	oldValue      func(context.Context) (*FuncEdge, error)
-	predicates    []predicate.FuncEdge
+	op                  Op
+	typ                 string
+	id                  *int
+	_CreatedAt          *time.Time
+	_UpdatedAt          *time.Time
+	_CallerKey          *string
+	_CalleeKey          *string
+	call_file           *string
+	call_line           *int
+	addcall_line        *int
+	call_kind           *string
+	build_configs       *[]string
+	appendbuild_configs []string
+	clearedFields       map[string]struct{}
+	done                bool
+	oldValue            func(context.Context) (*FuncEdge, error)
+	predicates          []predicate.FuncEdge
 }
 
 var _ ent.Mutation = (*FuncEdgeMutation)(nil)
@@ -287,6 +295,199 @@ func (m *FuncEdgeMutation) ResetCalleeKey() {
 	m._CalleeKey = nil
 }
 
+// SetCallFile sets the "call_file" field.
+func (m *FuncEdgeMutation) SetCallFile(s string) {
+	m.call_file = &s
+}
+
+// CallFile returns the value of the "call_file" field in the mutation.
+func (m *FuncEdgeMutation) CallFile() (r string, exists bool) {
+	v := m.call_file
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCallFile returns the old "call_file" field's value of the FuncEdge entity.
+// If the FuncEdge object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *FuncEdgeMutation) OldCallFile(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCallFile is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCallFile requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCallFile: %w", err)
+	}
+	return oldValue.CallFile, nil
+}
+
+// ResetCallFile resets all changes to the "call_file" field.
+func (m *FuncEdgeMutation) ResetCallFile() {
+	m.call_file = nil
+}
+
+// SetCallLine sets the "call_line" field.
+func (m *FuncEdgeMutation) SetCallLine(i int) {
+	m.call_line = &i
+	m.addcall_line = nil
+}
+
+// CallLine returns the value of the "call_line" field in the mutation.
+func (m *FuncEdgeMutation) CallLine() (r int, exists bool) {
+	v := m.call_line
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCallLine returns the old "call_line" field's value of the FuncEdge entity.
+// If the FuncEdge object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *FuncEdgeMutation) OldCallLine(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCallLine is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCallLine requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCallLine: %w", err)
+	}
+	return oldValue.CallLine, nil
+}
+
+// AddCallLine adds i to the "call_line" field.
+func (m *FuncEdgeMutation) AddCallLine(i int) {
+	if m.addcall_line != nil {
+		*m.addcall_line += i
+	} else {
+		m.addcall_line = &i
+	}
+}
+
+// AddedCallLine returns the value that was added to the "call_line" field in this mutation.
+func (m *FuncEdgeMutation) AddedCallLine() (r int, exists bool) {
+	v := m.addcall_line
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetCallLine resets all changes to the "call_line" field.
+func (m *FuncEdgeMutation) ResetCallLine() {
+	m.call_line = nil
+	m.addcall_line = nil
+}
+
+// SetCallKind sets the "call_kind" field.
+func (m *FuncEdgeMutation) SetCallKind(s string) {
+	m.call_kind = &s
+}
+
+// CallKind returns the value of the "call_kind" field in the mutation.
+func (m *FuncEdgeMutation) CallKind() (r string, exists bool) {
+	v := m.call_kind
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCallKind returns the old "call_kind" field's value of the FuncEdge entity.
+// If the FuncEdge object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *FuncEdgeMutation) OldCallKind(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCallKind is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCallKind requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCallKind: %w", err)
+	}
+	return oldValue.CallKind, nil
+}
+
+// ResetCallKind resets all changes to the "call_kind" field.
+func (m *FuncEdgeMutation) ResetCallKind() {
+	m.call_kind = nil
+}
+
+// SetBuildConfigs sets the "build_configs" field.
+func (m *FuncEdgeMutation) SetBuildConfigs(s []string) {
+	m.build_configs = &s
+	m.appendbuild_configs = nil
+}
+
+// BuildConfigs returns the value of the "build_configs" field in the mutation.
+func (m *FuncEdgeMutation) BuildConfigs() (r []string, exists bool) {
+	v := m.build_configs
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldBuildConfigs returns the old "build_configs" field's value of the FuncEdge entity.
+// If the FuncEdge object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *FuncEdgeMutation) OldBuildConfigs(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldBuildConfigs is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldBuildConfigs requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldBuildConfigs: %w", err)
+	}
+	return oldValue.BuildConfigs, nil
+}
+
+// AppendBuildConfigs adds s to the "build_configs" field.
+func (m *FuncEdgeMutation) AppendBuildConfigs(s []string) {
+	m.appendbuild_configs = append(m.appendbuild_configs, s...)
+}
+
+// AppendedBuildConfigs returns the list of values that were appended to the "build_configs" field in this mutation.
+func (m *FuncEdgeMutation) AppendedBuildConfigs() ([]string, bool) {
+	if len(m.appendbuild_configs) == 0 {
+		return nil, false
+	}
+	return m.appendbuild_configs, true
+}
+
+// ClearBuildConfigs clears the value of the "build_configs" field.
+func (m *FuncEdgeMutation) ClearBuildConfigs() {
+	m.build_configs = nil
+	m.appendbuild_configs = nil
+	m.clearedFields[funcedge.FieldBuildConfigs] = struct{}{}
+}
+
+// BuildConfigsCleared returns if the "build_configs" field was cleared in this mutation.
+func (m *FuncEdgeMutation) BuildConfigsCleared() bool {
+	_, ok := m.clearedFields[funcedge.FieldBuildConfigs]
+	return ok
+}
+
+// ResetBuildConfigs resets all changes to the "build_configs" field.
+func (m *FuncEdgeMutation) ResetBuildConfigs() {
+	m.build_configs = nil
+	m.appendbuild_configs = nil
+	delete(m.clearedFields, funcedge.FieldBuildConfigs)
+}
+
 // Where appends a list predicates to the FuncEdgeMutation builder.
 func (m *FuncEdgeMutation) Where(ps ...predicate.FuncEdge) {
 	m.predicates = append(m.predicates, ps...)
@@ -321,7 +522,7 @@ func (m *FuncEdgeMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *FuncEdgeMutation) Fields() []string {
-	fields := make([]string, 0, 4)
+	fields := make([]string, 0, 8)
 	if m._CreatedAt != nil {
 		fields = append(fields, funcedge.FieldCreatedAt)
 	}
@@ -334,6 +535,18 @@ func (m *FuncEdgeMutation) Fields() []string {
 	if m._CalleeKey != nil {
 		fields = append(fields, funcedge.FieldCalleeKey)
 	}
+	if m.call_file != nil {
+		fields = append(fields, funcedge.FieldCallFile)
+	}
+	if m.call_line != nil {
+		fields = append(fields, funcedge.FieldCallLine)
+	}
+	if m.call_kind != nil {
+		fields = append(fields, funcedge.FieldCallKind)
+	}
+	if m.build_configs != nil {
+		fields = append(fields, funcedge.FieldBuildConfigs)
+	}
 	return fields
 }
 
@@ -350,6 +563,14 @@ func (m *FuncEdgeMutation) Field(name string) (ent.Value, bool) {
 		return m.CallerKey()
 	case funcedge.FieldCalleeKey:
 		return m.CalleeKey()
+	case funcedge.FieldCallFile:
+		return m.CallFile()
+	case funcedge.FieldCallLine:
+		return m.CallLine()
+	case funcedge.FieldCallKind:
+		return m.CallKind()
+	case funcedge.FieldBuildConfigs:
+		return m.BuildConfigs()
 	}
 	return nil, false
 }
@@ -367,6 +588,14 @@ func (m *FuncEdgeMutation) OldField(ctx context.Context, name string) (ent.Value
 		return m.OldCallerKey(ctx)
 	case funcedge.FieldCalleeKey:
 		return m.OldCalleeKey(ctx)
+	case funcedge.FieldCallFile:
+		return m.OldCallFile(ctx)
+	case funcedge.FieldCallLine:
+		return m.OldCallLine(ctx)
+	case funcedge.FieldCallKind:
+		return m.OldCallKind(ctx)
+	case funcedge.FieldBuildConfigs:
+		return m.OldBuildConfigs(ctx)
 	}
 	return nil, fmt.Errorf("unknown FuncEdge field %s", name)
 }
@@ -404,6 +633,34 @@ func (m *FuncEdgeMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetCalleeKey(v)
 		return nil
+	case funcedge.FieldCallFile:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCallFile(v)
+		return nil
+	case funcedge.FieldCallLine:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCallLine(v)
+		return nil
+	case funcedge.FieldCallKind:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCallKind(v)
+		return nil
+	case funcedge.FieldBuildConfigs:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetBuildConfigs(v)
+		return nil
 	}
 	return fmt.Errorf("unknown FuncEdge field %s", name)
 }
@@ -411,13 +668,21 @@ func (m *FuncEdgeMutation) SetField(name string, value ent.Value) error {
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
 func (m *FuncEdgeMutation) AddedFields() []string {
-	return nil
+	var fields []string
+	if m.addcall_line != nil {
+		fields = append(fields, funcedge.FieldCallLine)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
 func (m *FuncEdgeMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case funcedge.FieldCallLine:
+		return m.AddedCallLine()
+	}
 	return nil, false
 }
 
@@ -426,6 +691,13 @@ func (m *FuncEdgeMutation) AddedField(name string) (ent.Value, bool) {
 // type.
 func (m *FuncEdgeMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case funcedge.FieldCallLine:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddCallLine(v)
+		return nil
 	}
 	return fmt.Errorf("unknown FuncEdge numeric field %s", name)
 }
@@ -433,7 +705,11 @@ func (m *FuncEdgeMutation) AddField(name string, value ent.Value) error {
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
 func (m *FuncEdgeMutation) ClearedFields() []string {
-	return nil
+	var fields []string
+	if m.FieldCleared(funcedge.FieldBuildConfigs) {
+		fields = append(fields, funcedge.FieldBuildConfigs)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
@@ -446,6 +722,11 @@ func (m *FuncEdgeMutation) FieldCleared(name string) bool {
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
 func (m *FuncEdgeMutation) ClearField(name string) error {
+	switch name {
+	case funcedge.FieldBuildConfigs:
+		m.ClearBuildConfigs()
+		return nil
+	}
 	return fmt.Errorf("unknown FuncEdge nullable field %s", name)
 }
 
@@ -465,6 +746,18 @@ func (m *FuncEdgeMutation) ResetField(name string) error {
 	case funcedge.FieldCalleeKey:
 		m.ResetCalleeKey()
 		return nil
+	case funcedge.FieldCallFile:
+		m.ResetCallFile()
+		return nil
+	case funcedge.FieldCallLine:
+		m.ResetCallLine()
+		return nil
+	case funcedge.FieldCallKind:
+		m.ResetCallKind()
+		return nil
+	case funcedge.FieldBuildConfigs:
+		m.ResetBuildConfigs()
+		return nil
 	}
 	return fmt.Errorf("unknown FuncEdge field %s", name)
 }
@@ -520,19 +813,28 @@ func (m *FuncEdgeMutation) ResetEdge(name string) error {
 // FuncNodeMutation represents an operation that mutates the FuncNode nodes in the graph.
 type FuncNodeMutation struct {
 	config
-	op            Op
-	typ           string
-	id            *int
-	key           *string
-	full_name     *string
-	pkg           *string
-	name          *string
-	_CreatedAt    *time.Time
-	_UpdatedAt    *time.Time
-	clearedFields map[string]struct{}
-	done          bool
-	oldValue      func(context.Context) (*FuncNode, error)
-	predicates    []predicate.FuncNode
+	op                  Op
+	typ                 string
+	id                  *int
+	key                 *string
+	legacy_key          *string
+	full_name           *string
+	pkg                 *string
+	module              *string
+	name                *string
+	file                *string
+	start_line          *int
+	addstart_line       *int
+	end_line            *int
+	addend_line         *int
+	build_configs       *[]string
+	appendbuild_configs []string
+	_CreatedAt          *time.Time
+	_UpdatedAt          *time.Time
+	clearedFields       map[string]struct{}
+	done                bool
+	oldValue            func(context.Context) (*FuncNode, error)
+	predicates          []predicate.FuncNode
 }
 
 var _ ent.Mutation = (*FuncNodeMutation)(nil)
@@ -669,6 +971,42 @@ func (m *FuncNodeMutation) ResetKey() {
 	m.key = nil
 }
 
+// SetLegacyKey sets the "legacy_key" field.
+func (m *FuncNodeMutation) SetLegacyKey(s string) {
+	m.legacy_key = &s
+}
+
+// LegacyKey returns the value of the "legacy_key" field in the mutation.
+func (m *FuncNodeMutation) LegacyKey() (r string, exists bool) {
+	v := m.legacy_key
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLegacyKey returns the old "legacy_key" field's value of the FuncNode entity.
+// If the FuncNode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *FuncNodeMutation) OldLegacyKey(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLegacyKey is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLegacyKey requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLegacyKey: %w", err)
+	}
+	return oldValue.LegacyKey, nil
+}
+
+// ResetLegacyKey resets all changes to the "legacy_key" field.
+func (m *FuncNodeMutation) ResetLegacyKey() {
+	m.legacy_key = nil
+}
+
 // SetFullName sets the "full_name" field.
 func (m *FuncNodeMutation) SetFullName(s string) {
 	m.full_name = &s
@@ -741,6 +1079,42 @@ func (m *FuncNodeMutation) ResetPkg() {
 	m.pkg = nil
 }
 
+// SetModule sets the "module" field.
+func (m *FuncNodeMutation) SetModule(s string) {
+	m.module = &s
+}
+
+// Module returns the value of the "module" field in the mutation.
+func (m *FuncNodeMutation) Module() (r string, exists bool) {
+	v := m.module
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldModule returns the old "module" field's value of the FuncNode entity.
+// If the FuncNode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *FuncNodeMutation) OldModule(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldModule is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldModule requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldModule: %w", err)
+	}
+	return oldValue.Module, nil
+}
+
+// ResetModule resets all changes to the "module" field.
+func (m *FuncNodeMutation) ResetModule() {
+	m.module = nil
+}
+
 // SetName sets the "name" field.
 func (m *FuncNodeMutation) SetName(s string) {
 	m.name = &s
@@ -777,104 +1151,317 @@ func (m *FuncNodeMutation) ResetName() {
 	m.name = nil
 }
 
-// SetCreatedAt sets the "CreatedAt" field.
-func (m *FuncNodeMutation) SetCreatedAt(t time.Time) {
-	m._CreatedAt = &t
+// SetFile sets the "file" field.
+func (m *FuncNodeMutation) SetFile(s string) {
+	m.file = &s
 }
 
-// CreatedAt returns the value of the "CreatedAt" field in the mutation.
-func (m *FuncNodeMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m._CreatedAt
+// File returns the value of the "file" field in the mutation.
+func (m *FuncNodeMutation) File() (r string, exists bool) {
+	v := m.file
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedAt returns the old "CreatedAt" field's value of the FuncNode entity.
+// OldFile returns the old "file" field's value of the FuncNode entity.
 // If the FuncNode object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *FuncNodeMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *FuncNodeMutation) OldFile(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldFile is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+		return v, errors.New("OldFile requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldFile: %w", err)
 	}
-	return oldValue.CreatedAt, nil
+	return oldValue.File, nil
 }
 
-// ResetCreatedAt resets all changes to the "CreatedAt" field.
-func (m *FuncNodeMutation) ResetCreatedAt() {
-	m._CreatedAt = nil
+// ResetFile resets all changes to the "file" field.
+func (m *FuncNodeMutation) ResetFile() {
+	m.file = nil
 }
 
-// SetUpdatedAt sets the "UpdatedAt" field.
-func (m *FuncNodeMutation) SetUpdatedAt(t time.Time) {
-	m._UpdatedAt = &t
+// SetStartLine sets the "start_line" field.
+func (m *FuncNodeMutation) SetStartLine(i int) {
+	m.start_line = &i
+	m.addstart_line = nil
 }
 
-// UpdatedAt returns the value of the "UpdatedAt" field in the mutation.
-func (m *FuncNodeMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m._UpdatedAt
+// StartLine returns the value of the "start_line" field in the mutation.
+func (m *FuncNodeMutation) StartLine() (r int, exists bool) {
+	v := m.start_line
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUpdatedAt returns the old "UpdatedAt" field's value of the FuncNode entity.
+// OldStartLine returns the old "start_line" field's value of the FuncNode entity.
 // If the FuncNode object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *FuncNodeMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *FuncNodeMutation) OldStartLine(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldStartLine is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+		return v, errors.New("OldStartLine requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldStartLine: %w", err)
 	}
-	return oldValue.UpdatedAt, nil
+	return oldValue.StartLine, nil
 }
 
-// ResetUpdatedAt resets all changes to the "UpdatedAt" field.
-func (m *FuncNodeMutation) ResetUpdatedAt() {
-	m._UpdatedAt = nil
+// AddStartLine adds i to the "start_line" field.
+func (m *FuncNodeMutation) AddStartLine(i int) {
+	if m.addstart_line != nil {
+		*m.addstart_line += i
+	} else {
+		m.addstart_line = &i
+	}
 }
 
-// Where appends a list predicates to the FuncNodeMutation builder.
-func (m *FuncNodeMutation) Where(ps ...predicate.FuncNode) {
-	m.predicates = append(m.predicates, ps...)
+// AddedStartLine returns the value that was added to the "start_line" field in this mutation.
+func (m *FuncNodeMutation) AddedStartLine() (r int, exists bool) {
+	v := m.addstart_line
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// WhereP appends storage-level predicates to the FuncNodeMutation builder. Using this method,
-// users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *FuncNodeMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.FuncNode, len(ps))
-	for i := range ps {
-		p[i] = ps[i]
-	}
-	m.Where(p...)
+// ResetStartLine resets all changes to the "start_line" field.
+func (m *FuncNodeMutation) ResetStartLine() {
+	m.start_line = nil
+	m.addstart_line = nil
 }
 
-// Op returns the operation name.
-func (m *FuncNodeMutation) Op() Op {
-	return m.op
+// SetEndLine sets the "end_line" field.
+func (m *FuncNodeMutation) SetEndLine(i int) {
+	m.end_line = &i
+	m.addend_line = nil
 }
 
-// SetOp allows setting the mutation operation.
-func (m *FuncNodeMutation) SetOp(op Op) {
-	m.op = op
+// EndLine returns the value of the "end_line" field in the mutation.
+func (m *FuncNodeMutation) EndLine() (r int, exists bool) {
+	v := m.end_line
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// Type returns the node type of this mutation (FuncNode).
+// OldEndLine returns the old "end_line" field's value of the FuncNode entity.
+// If the FuncNode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *FuncNodeMutation) OldEndLine(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEndLine is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEndLine requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEndLine: %w", err)
+	}
+	return oldValue.EndLine, nil
+}
+
+// AddEndLine adds i to the "end_line" field.
+func (m *FuncNodeMutation) AddEndLine(i int) {
+	if m.addend_line != nil {
+		*m.addend_line += i
+	} else {
+		m.addend_line = &i
+	}
+}
+
+// AddedEndLine returns the value that was added to the "end_line" field in this mutation.
+func (m *FuncNodeMutation) AddedEndLine() (r int, exists bool) {
+	v := m.addend_line
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetEndLine resets all changes to the "end_line" field.
+func (m *FuncNodeMutation) ResetEndLine() {
+	m.end_line = nil
+	m.addend_line = nil
+}
+
+// SetBuildConfigs sets the "build_configs" field.
+func (m *FuncNodeMutation) SetBuildConfigs(s []string) {
+	m.build_configs = &s
+	m.appendbuild_configs = nil
+}
+
+// BuildConfigs returns the value of the "build_configs" field in the mutation.
+func (m *FuncNodeMutation) BuildConfigs() (r []string, exists bool) {
+	v := m.build_configs
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldBuildConfigs returns the old "build_configs" field's value of the FuncNode entity.
+// If the FuncNode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *FuncNodeMutation) OldBuildConfigs(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldBuildConfigs is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldBuildConfigs requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldBuildConfigs: %w", err)
+	}
+	return oldValue.BuildConfigs, nil
+}
+
+// AppendBuildConfigs adds s to the "build_configs" field.
+func (m *FuncNodeMutation) AppendBuildConfigs(s []string) {
+	m.appendbuild_configs = append(m.appendbuild_configs, s...)
+}
+
+// AppendedBuildConfigs returns the list of values that were appended to the "build_configs" field in this mutation.
+func (m *FuncNodeMutation) AppendedBuildConfigs() ([]string, bool) {
+	if len(m.appendbuild_configs) == 0 {
+		return nil, false
+	}
+	return m.appendbuild_configs, true
+}
+
+// ClearBuildConfigs clears the value of the "build_configs" field.
+func (m *FuncNodeMutation) ClearBuildConfigs() {
+	m.build_configs = nil
+	m.appendbuild_configs = nil
+	m.clearedFields[funcnode.FieldBuildConfigs] = struct{}{}
+}
+
+// BuildConfigsCleared returns if the "build_configs" field was cleared in this mutation.
+func (m *FuncNodeMutation) BuildConfigsCleared() bool {
+	_, ok := m.clearedFields[funcnode.FieldBuildConfigs]
+	return ok
+}
+
+// ResetBuildConfigs resets all changes to the "build_configs" field.
+func (m *FuncNodeMutation) ResetBuildConfigs() {
+	m.build_configs = nil
+	m.appendbuild_configs = nil
+	delete(m.clearedFields, funcnode.FieldBuildConfigs)
+}
+
+// SetCreatedAt sets the "CreatedAt" field.
+func (m *FuncNodeMutation) SetCreatedAt(t time.Time) {
+	m._CreatedAt = &t
+}
+
+// CreatedAt returns the value of the "CreatedAt" field in the mutation.
+func (m *FuncNodeMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m._CreatedAt
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "CreatedAt" field's value of the FuncNode entity.
+// If the FuncNode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *FuncNodeMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "CreatedAt" field.
+func (m *FuncNodeMutation) ResetCreatedAt() {
+	m._CreatedAt = nil
+}
+
+// SetUpdatedAt sets the "UpdatedAt" field.
+func (m *FuncNodeMutation) SetUpdatedAt(t time.Time) {
+	m._UpdatedAt = &t
+}
+
+// UpdatedAt returns the value of the "UpdatedAt" field in the mutation.
+func (m *FuncNodeMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m._UpdatedAt
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "UpdatedAt" field's value of the FuncNode entity.
+// If the FuncNode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *FuncNodeMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "UpdatedAt" field.
+func (m *FuncNodeMutation) ResetUpdatedAt() {
+	m._UpdatedAt = nil
+}
+
+// Where appends a list predicates to the FuncNodeMutation builder.
+func (m *FuncNodeMutation) Where(ps ...predicate.FuncNode) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the FuncNodeMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *FuncNodeMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.FuncNode, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *FuncNodeMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *FuncNodeMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (FuncNode).
 func (m *FuncNodeMutation) Type() string {
 	return m.typ
 }
@@ -883,19 +1470,37 @@ func (m *FuncNodeMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *FuncNodeMutation) Fields() []string {
-	fields := make([]string, 0, 6)
+	fields := make([]string, 0, 12)
 	if m.key != nil {
 		fields = append(fields, funcnode.FieldKey)
 	}
+	if m.legacy_key != nil {
+		fields = append(fields, funcnode.FieldLegacyKey)
+	}
 	if m.full_name != nil {
 		fields = append(fields, funcnode.FieldFullName)
 	}
 	if m.pkg != nil {
 		fields = append(fields, funcnode.FieldPkg)
 	}
+	if m.module != nil {
+		fields = append(fields, funcnode.FieldModule)
+	}
 	if m.name != nil {
 		fields = append(fields, funcnode.FieldName)
 	}
+	if m.file != nil {
+		fields = append(fields, funcnode.FieldFile)
+	}
+	if m.start_line != nil {
+		fields = append(fields, funcnode.FieldStartLine)
+	}
+	if m.end_line != nil {
+		fields = append(fields, funcnode.FieldEndLine)
+	}
+	if m.build_configs != nil {
+		fields = append(fields, funcnode.FieldBuildConfigs)
+	}
 	if m._CreatedAt != nil {
 		fields = append(fields, funcnode.FieldCreatedAt)
 	}
@@ -912,12 +1517,24 @@ func (m *FuncNodeMutation) Field(name string) (ent.Value, bool) {
 	switch name {
 	case funcnode.FieldKey:
 		return m.Key()
+	case funcnode.FieldLegacyKey:
+		return m.LegacyKey()
 	case funcnode.FieldFullName:
 		return m.FullName()
 	case funcnode.FieldPkg:
 		return m.Pkg()
+	case funcnode.FieldModule:
+		return m.Module()
 	case funcnode.FieldName:
 		return m.Name()
+	case funcnode.FieldFile:
+		return m.File()
+	case funcnode.FieldStartLine:
+		return m.StartLine()
+	case funcnode.FieldEndLine:
+		return m.EndLine()
+	case funcnode.FieldBuildConfigs:
+		return m.BuildConfigs()
 	case funcnode.FieldCreatedAt:
 		return m.CreatedAt()
 	case funcnode.FieldUpdatedAt:
@@ -933,12 +1550,24 @@ func (m *FuncNodeMutation) OldField(ctx context.Context, name string) (ent.Value
 	switch name {
 	case funcnode.FieldKey:
 		return m.OldKey(ctx)
+	case funcnode.FieldLegacyKey:
+		return m.OldLegacyKey(ctx)
 	case funcnode.FieldFullName:
 		return m.OldFullName(ctx)
 	case funcnode.FieldPkg:
 		return m.OldPkg(ctx)
+	case funcnode.FieldModule:
+		return m.OldModule(ctx)
 	case funcnode.FieldName:
 		return m.OldName(ctx)
+	case funcnode.FieldFile:
+		return m.OldFile(ctx)
+	case funcnode.FieldStartLine:
+		return m.OldStartLine(ctx)
+	case funcnode.FieldEndLine:
+		return m.OldEndLine(ctx)
+	case funcnode.FieldBuildConfigs:
+		return m.OldBuildConfigs(ctx)
 	case funcnode.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
 	case funcnode.FieldUpdatedAt:
@@ -959,6 +1588,13 @@ func (m *FuncNodeMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetKey(v)
 		return nil
+	case funcnode.FieldLegacyKey:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLegacyKey(v)
+		return nil
 	case funcnode.FieldFullName:
 		v, ok := value.(string)
 		if !ok {
@@ -973,6 +1609,13 @@ func (m *FuncNodeMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetPkg(v)
 		return nil
+	case funcnode.FieldModule:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetModule(v)
+		return nil
 	case funcnode.FieldName:
 		v, ok := value.(string)
 		if !ok {
@@ -980,6 +1623,34 @@ func (m *FuncNodeMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetName(v)
 		return nil
+	case funcnode.FieldFile:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetFile(v)
+		return nil
+	case funcnode.FieldStartLine:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStartLine(v)
+		return nil
+	case funcnode.FieldEndLine:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEndLine(v)
+		return nil
+	case funcnode.FieldBuildConfigs:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetBuildConfigs(v)
+		return nil
 	case funcnode.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
@@ -1001,13 +1672,26 @@ func (m *FuncNodeMutation) SetField(name string, value ent.Value) error {
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
 func (m *FuncNodeMutation) AddedFields() []string {
-	return nil
+	var fields []string
+	if m.addstart_line != nil {
+		fields = append(fields, funcnode.FieldStartLine)
+	}
+	if m.addend_line != nil {
+		fields = append(fields, funcnode.FieldEndLine)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
 func (m *FuncNodeMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case funcnode.FieldStartLine:
+		return m.AddedStartLine()
+	case funcnode.FieldEndLine:
+		return m.AddedEndLine()
+	}
 	return nil, false
 }
 
@@ -1016,6 +1700,20 @@ func (m *FuncNodeMutation) AddedField(name string) (ent.Value, bool) {
 // type.
 func (m *FuncNodeMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case funcnode.FieldStartLine:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddStartLine(v)
+		return nil
+	case funcnode.FieldEndLine:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddEndLine(v)
+		return nil
 	}
 	return fmt.Errorf("unknown FuncNode numeric field %s", name)
 }
@@ -1023,7 +1721,11 @@ func (m *FuncNodeMutation) AddField(name string, value ent.Value) error {
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
 func (m *FuncNodeMutation) ClearedFields() []string {
-	return nil
+	var fields []string
+	if m.FieldCleared(funcnode.FieldBuildConfigs) {
+		fields = append(fields, funcnode.FieldBuildConfigs)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
@@ -1036,6 +1738,11 @@ func (m *FuncNodeMutation) FieldCleared(name string) bool {
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
 func (m *FuncNodeMutation) ClearField(name string) error {
+	switch name {
+	case funcnode.FieldBuildConfigs:
+		m.ClearBuildConfigs()
+		return nil
+	}
 	return fmt.Errorf("unknown FuncNode nullable field %s", name)
 }
 
@@ -1046,15 +1753,33 @@ func (m *FuncNodeMutation) ResetField(name string) error {
 	case funcnode.FieldKey:
 		m.ResetKey()
 		return nil
+	case funcnode.FieldLegacyKey:
+		m.ResetLegacyKey()
+		return nil
 	case funcnode.FieldFullName:
 		m.ResetFullName()
 		return nil
 	case funcnode.FieldPkg:
 		m.ResetPkg()
 		return nil
+	case funcnode.FieldModule:
+		m.ResetModule()
+		return nil
 	case funcnode.FieldName:
 		m.ResetName()
 		return nil
+	case funcnode.FieldFile:
+		m.ResetFile()
+		return nil
+	case funcnode.FieldStartLine:
+		m.ResetStartLine()
+		return nil
+	case funcnode.FieldEndLine:
+		m.ResetEndLine()
+		return nil
+	case funcnode.FieldBuildConfigs:
+		m.ResetBuildConfigs()
+		return nil
 	case funcnode.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
@@ -1112,3 +1837,437 @@ func (m *FuncNodeMutation) ClearEdge(name string) error {
 func (m *FuncNodeMutation) ResetEdge(name string) error {
 	return fmt.Errorf("unknown FuncNode edge %s", name)
 }
+
+// PackageHashMutation represents an operation that mutates the PackageHash nodes in the graph.
+type PackageHashMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int
+	pkg           *string
+	hash          *string
+	_UpdatedAt    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*PackageHash, error)
+	predicates    []predicate.PackageHash
+}
+
+var _ ent.Mutation = (*PackageHashMutation)(nil)
+
+// packagehashOption allows management of the mutation configuration using functional options.
+type packagehashOption func(*PackageHashMutation)
+
+// newPackageHashMutation creates new mutation for the PackageHash entity.
+func newPackageHashMutation(c config, op Op, opts ...packagehashOption) *PackageHashMutation {
+	m := &PackageHashMutation{
+		config:        c,
+		op:            op,
+		typ:           TypePackageHash,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withPackageHashID sets the ID field of the mutation.
+func withPackageHashID(id int) packagehashOption {
+	return func(m *PackageHashMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *PackageHash
+		)
+		m.oldValue = func(ctx context.Context) (*PackageHash, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().PackageHash.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withPackageHash sets the old PackageHash of the mutation.
+func withPackageHash(node *PackageHash) packagehashOption {
+	return func(m *PackageHashMutation) {
+		m.oldValue = func(context.Context) (*PackageHash, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m PackageHashMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m PackageHashMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("gen: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *PackageHashMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *PackageHashMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().PackageHash.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetPkg sets the "pkg" field.
+func (m *PackageHashMutation) SetPkg(s string) {
+	m.pkg = &s
+}
+
+// Pkg returns the value of the "pkg" field in the mutation.
+func (m *PackageHashMutation) Pkg() (r string, exists bool) {
+	v := m.pkg
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPkg returns the old "pkg" field's value of the PackageHash entity.
+// If the PackageHash object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PackageHashMutation) OldPkg(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPkg is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPkg requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPkg: %w", err)
+	}
+	return oldValue.Pkg, nil
+}
+
+// ResetPkg resets all changes to the "pkg" field.
+func (m *PackageHashMutation) ResetPkg() {
+	m.pkg = nil
+}
+
+// SetHash sets the "hash" field.
+func (m *PackageHashMutation) SetHash(s string) {
+	m.hash = &s
+}
+
+// Hash returns the value of the "hash" field in the mutation.
+func (m *PackageHashMutation) Hash() (r string, exists bool) {
+	v := m.hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldHash returns the old "hash" field's value of the PackageHash entity.
+// If the PackageHash object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PackageHashMutation) OldHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldHash: %w", err)
+	}
+	return oldValue.Hash, nil
+}
+
+// ResetHash resets all changes to the "hash" field.
+func (m *PackageHashMutation) ResetHash() {
+	m.hash = nil
+}
+
+// SetUpdatedAt sets the "UpdatedAt" field.
+func (m *PackageHashMutation) SetUpdatedAt(t time.Time) {
+	m._UpdatedAt = &t
+}
+
+// UpdatedAt returns the value of the "UpdatedAt" field in the mutation.
+func (m *PackageHashMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m._UpdatedAt
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "UpdatedAt" field's value of the PackageHash entity.
+// If the PackageHash object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PackageHashMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "UpdatedAt" field.
+func (m *PackageHashMutation) ResetUpdatedAt() {
+	m._UpdatedAt = nil
+}
+
+// Where appends a list predicates to the PackageHashMutation builder.
+func (m *PackageHashMutation) Where(ps ...predicate.PackageHash) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the PackageHashMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *PackageHashMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.PackageHash, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *PackageHashMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *PackageHashMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (PackageHash).
+func (m *PackageHashMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *PackageHashMutation) Fields() []string {
+	fields := make([]string, 0, 3)
+	if m.pkg != nil {
+		fields = append(fields, packagehash.FieldPkg)
+	}
+	if m.hash != nil {
+		fields = append(fields, packagehash.FieldHash)
+	}
+	if m._UpdatedAt != nil {
+		fields = append(fields, packagehash.FieldUpdatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *PackageHashMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case packagehash.FieldPkg:
+		return m.Pkg()
+	case packagehash.FieldHash:
+		return m.Hash()
+	case packagehash.FieldUpdatedAt:
+		return m.UpdatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *PackageHashMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case packagehash.FieldPkg:
+		return m.OldPkg(ctx)
+	case packagehash.FieldHash:
+		return m.OldHash(ctx)
+	case packagehash.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown PackageHash field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PackageHashMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case packagehash.FieldPkg:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPkg(v)
+		return nil
+	case packagehash.FieldHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHash(v)
+		return nil
+	case packagehash.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown PackageHash field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *PackageHashMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *PackageHashMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PackageHashMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown PackageHash numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *PackageHashMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *PackageHashMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *PackageHashMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown PackageHash nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *PackageHashMutation) ResetField(name string) error {
+	switch name {
+	case packagehash.FieldPkg:
+		m.ResetPkg()
+		return nil
+	case packagehash.FieldHash:
+		m.ResetHash()
+		return nil
+	case packagehash.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown PackageHash field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *PackageHashMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *PackageHashMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *PackageHashMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *PackageHashMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *PackageHashMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *PackageHashMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *PackageHashMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown PackageHash unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *PackageHashMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown PackageHash edge %s", name)
+}
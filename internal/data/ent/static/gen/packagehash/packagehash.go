@@ -0,0 +1,74 @@
+// Code generated by ent, DO NOT EDIT.
+
+package packagehash
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the packagehash type in the database.
+	Label = "package_hash"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldPkg holds the string denoting the pkg field in the database.
+	FieldPkg = "pkg"
+	// FieldHash holds the string denoting the hash field in the database.
+	FieldHash = "hash"
+	// FieldUpdatedAt holds the string denoting the updatedat field in the database.
+	FieldUpdatedAt = "updated_at"
+	// Table holds the table name of the packagehash in the database.
+	Table = "package_hashes"
+)
+
+// Columns holds all SQL columns for packagehash fields.
+var Columns = []string{
+	FieldID,
+	FieldPkg,
+	FieldHash,
+	FieldUpdatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// PkgValidator is a validator for the "pkg" field. It is called by the builders before save.
+	PkgValidator func(string) error
+	// HashValidator is a validator for the "hash" field. It is called by the builders before save.
+	HashValidator func(string) error
+	// DefaultUpdatedAt holds the default value on creation for the "UpdatedAt" field.
+	DefaultUpdatedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the PackageHash queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByPkg orders the results by the pkg field.
+func ByPkg(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPkg, opts...).ToFunc()
+}
+
+// ByHash orders the results by the hash field.
+func ByHash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldHash, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the UpdatedAt field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
@@ -0,0 +1,255 @@
+// Code generated by ent, DO NOT EDIT.
+
+package packagehash
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/toheart/goanalysis/internal/data/ent/static/gen/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldLTE(FieldID, id))
+}
+
+// Pkg applies equality check predicate on the "pkg" field. It's identical to PkgEQ.
+func Pkg(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldEQ(FieldPkg, v))
+}
+
+// Hash applies equality check predicate on the "hash" field. It's identical to HashEQ.
+func Hash(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldEQ(FieldHash, v))
+}
+
+// UpdatedAt applies equality check predicate on the "UpdatedAt" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// PkgEQ applies the EQ predicate on the "pkg" field.
+func PkgEQ(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldEQ(FieldPkg, v))
+}
+
+// PkgNEQ applies the NEQ predicate on the "pkg" field.
+func PkgNEQ(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldNEQ(FieldPkg, v))
+}
+
+// PkgIn applies the In predicate on the "pkg" field.
+func PkgIn(vs ...string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldIn(FieldPkg, vs...))
+}
+
+// PkgNotIn applies the NotIn predicate on the "pkg" field.
+func PkgNotIn(vs ...string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldNotIn(FieldPkg, vs...))
+}
+
+// PkgGT applies the GT predicate on the "pkg" field.
+func PkgGT(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldGT(FieldPkg, v))
+}
+
+// PkgGTE applies the GTE predicate on the "pkg" field.
+func PkgGTE(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldGTE(FieldPkg, v))
+}
+
+// PkgLT applies the LT predicate on the "pkg" field.
+func PkgLT(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldLT(FieldPkg, v))
+}
+
+// PkgLTE applies the LTE predicate on the "pkg" field.
+func PkgLTE(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldLTE(FieldPkg, v))
+}
+
+// PkgContains applies the Contains predicate on the "pkg" field.
+func PkgContains(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldContains(FieldPkg, v))
+}
+
+// PkgHasPrefix applies the HasPrefix predicate on the "pkg" field.
+func PkgHasPrefix(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldHasPrefix(FieldPkg, v))
+}
+
+// PkgHasSuffix applies the HasSuffix predicate on the "pkg" field.
+func PkgHasSuffix(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldHasSuffix(FieldPkg, v))
+}
+
+// PkgEqualFold applies the EqualFold predicate on the "pkg" field.
+func PkgEqualFold(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldEqualFold(FieldPkg, v))
+}
+
+// PkgContainsFold applies the ContainsFold predicate on the "pkg" field.
+func PkgContainsFold(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldContainsFold(FieldPkg, v))
+}
+
+// HashEQ applies the EQ predicate on the "hash" field.
+func HashEQ(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldEQ(FieldHash, v))
+}
+
+// HashNEQ applies the NEQ predicate on the "hash" field.
+func HashNEQ(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldNEQ(FieldHash, v))
+}
+
+// HashIn applies the In predicate on the "hash" field.
+func HashIn(vs ...string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldIn(FieldHash, vs...))
+}
+
+// HashNotIn applies the NotIn predicate on the "hash" field.
+func HashNotIn(vs ...string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldNotIn(FieldHash, vs...))
+}
+
+// HashGT applies the GT predicate on the "hash" field.
+func HashGT(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldGT(FieldHash, v))
+}
+
+// HashGTE applies the GTE predicate on the "hash" field.
+func HashGTE(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldGTE(FieldHash, v))
+}
+
+// HashLT applies the LT predicate on the "hash" field.
+func HashLT(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldLT(FieldHash, v))
+}
+
+// HashLTE applies the LTE predicate on the "hash" field.
+func HashLTE(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldLTE(FieldHash, v))
+}
+
+// HashContains applies the Contains predicate on the "hash" field.
+func HashContains(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldContains(FieldHash, v))
+}
+
+// HashHasPrefix applies the HasPrefix predicate on the "hash" field.
+func HashHasPrefix(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldHasPrefix(FieldHash, v))
+}
+
+// HashHasSuffix applies the HasSuffix predicate on the "hash" field.
+func HashHasSuffix(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldHasSuffix(FieldHash, v))
+}
+
+// HashEqualFold applies the EqualFold predicate on the "hash" field.
+func HashEqualFold(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldEqualFold(FieldHash, v))
+}
+
+// HashContainsFold applies the ContainsFold predicate on the "hash" field.
+func HashContainsFold(v string) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldContainsFold(FieldHash, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "UpdatedAt" field.
+func UpdatedAtEQ(v time.Time) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "UpdatedAt" field.
+func UpdatedAtNEQ(v time.Time) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "UpdatedAt" field.
+func UpdatedAtIn(vs ...time.Time) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "UpdatedAt" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "UpdatedAt" field.
+func UpdatedAtGT(v time.Time) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "UpdatedAt" field.
+func UpdatedAtGTE(v time.Time) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "UpdatedAt" field.
+func UpdatedAtLT(v time.Time) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "UpdatedAt" field.
+func UpdatedAtLTE(v time.Time) predicate.PackageHash {
+	return predicate.PackageHash(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.PackageHash) predicate.PackageHash {
+	return predicate.PackageHash(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.PackageHash) predicate.PackageHash {
+	return predicate.PackageHash(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.PackageHash) predicate.PackageHash {
+	return predicate.PackageHash(sql.NotPredicates(p))
+}
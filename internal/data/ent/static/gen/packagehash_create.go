@@ -0,0 +1,238 @@
+// Code generated by ent, DO NOT EDIT.
+
+package gen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/toheart/goanalysis/internal/data/ent/static/gen/packagehash"
+)
+
+// PackageHashCreate is the builder for creating a PackageHash entity.
+type PackageHashCreate struct {
+	config
+	mutation *PackageHashMutation
+	hooks    []Hook
+}
+
+// SetPkg sets the "pkg" field.
+func (phc *PackageHashCreate) SetPkg(s string) *PackageHashCreate {
+	phc.mutation.SetPkg(s)
+	return phc
+}
+
+// SetHash sets the "hash" field.
+func (phc *PackageHashCreate) SetHash(s string) *PackageHashCreate {
+	phc.mutation.SetHash(s)
+	return phc
+}
+
+// SetUpdatedAt sets the "UpdatedAt" field.
+func (phc *PackageHashCreate) SetUpdatedAt(t time.Time) *PackageHashCreate {
+	phc.mutation.SetUpdatedAt(t)
+	return phc
+}
+
+// SetNillableUpdatedAt sets the "UpdatedAt" field if the given value is not nil.
+func (phc *PackageHashCreate) SetNillableUpdatedAt(t *time.Time) *PackageHashCreate {
+	if t != nil {
+		phc.SetUpdatedAt(*t)
+	}
+	return phc
+}
+
+// Mutation returns the PackageHashMutation object of the builder.
+func (phc *PackageHashCreate) Mutation() *PackageHashMutation {
+	return phc.mutation
+}
+
+// Save creates the PackageHash in the database.
+func (phc *PackageHashCreate) Save(ctx context.Context) (*PackageHash, error) {
+	phc.defaults()
+	return withHooks(ctx, phc.sqlSave, phc.mutation, phc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (phc *PackageHashCreate) SaveX(ctx context.Context) *PackageHash {
+	v, err := phc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (phc *PackageHashCreate) Exec(ctx context.Context) error {
+	_, err := phc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (phc *PackageHashCreate) ExecX(ctx context.Context) {
+	if err := phc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (phc *PackageHashCreate) defaults() {
+	if _, ok := phc.mutation.UpdatedAt(); !ok {
+		v := packagehash.DefaultUpdatedAt()
+		phc.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (phc *PackageHashCreate) check() error {
+	if _, ok := phc.mutation.Pkg(); !ok {
+		return &ValidationError{Name: "pkg", err: errors.New(`gen: missing required field "PackageHash.pkg"`)}
+	}
+	if v, ok := phc.mutation.Pkg(); ok {
+		if err := packagehash.PkgValidator(v); err != nil {
+			return &ValidationError{Name: "pkg", err: fmt.Errorf(`gen: validator failed for field "PackageHash.pkg": %w`, err)}
+		}
+	}
+	if _, ok := phc.mutation.Hash(); !ok {
+		return &ValidationError{Name: "hash", err: errors.New(`gen: missing required field "PackageHash.hash"`)}
+	}
+	if v, ok := phc.mutation.Hash(); ok {
+		if err := packagehash.HashValidator(v); err != nil {
+			return &ValidationError{Name: "hash", err: fmt.Errorf(`gen: validator failed for field "PackageHash.hash": %w`, err)}
+		}
+	}
+	if _, ok := phc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "UpdatedAt", err: errors.New(`gen: missing required field "PackageHash.UpdatedAt"`)}
+	}
+	return nil
+}
+
+func (phc *PackageHashCreate) sqlSave(ctx context.Context) (*PackageHash, error) {
+	if err := phc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := phc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, phc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	phc.mutation.id = &_node.ID
+	phc.mutation.done = true
+	return _node, nil
+}
+
+func (phc *PackageHashCreate) createSpec() (*PackageHash, *sqlgraph.CreateSpec) {
+	var (
+		_node = &PackageHash{config: phc.config}
+		_spec = sqlgraph.NewCreateSpec(packagehash.Table, sqlgraph.NewFieldSpec(packagehash.FieldID, field.TypeInt))
+	)
+	if value, ok := phc.mutation.Pkg(); ok {
+		_spec.SetField(packagehash.FieldPkg, field.TypeString, value)
+		_node.Pkg = value
+	}
+	if value, ok := phc.mutation.Hash(); ok {
+		_spec.SetField(packagehash.FieldHash, field.TypeString, value)
+		_node.Hash = value
+	}
+	if value, ok := phc.mutation.UpdatedAt(); ok {
+		_spec.SetField(packagehash.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// PackageHashCreateBulk is the builder for creating many PackageHash entities in bulk.
+type PackageHashCreateBulk struct {
+	config
+	err      error
+	builders []*PackageHashCreate
+}
+
+// Save creates the PackageHash entities in the database.
+func (phcb *PackageHashCreateBulk) Save(ctx context.Context) ([]*PackageHash, error) {
+	if phcb.err != nil {
+		return nil, phcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(phcb.builders))
+	nodes := make([]*PackageHash, len(phcb.builders))
+	mutators := make([]Mutator, len(phcb.builders))
+	for i := range phcb.builders {
+		func(i int, root context.Context) {
+			builder := phcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PackageHashMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, phcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, phcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, phcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (phcb *PackageHashCreateBulk) SaveX(ctx context.Context) []*PackageHash {
+	v, err := phcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (phcb *PackageHashCreateBulk) Exec(ctx context.Context) error {
+	_, err := phcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (phcb *PackageHashCreateBulk) ExecX(ctx context.Context) {
+	if err := phcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package gen
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/toheart/goanalysis/internal/data/ent/static/gen/packagehash"
+	"github.com/toheart/goanalysis/internal/data/ent/static/gen/predicate"
+)
+
+// PackageHashDelete is the builder for deleting a PackageHash entity.
+type PackageHashDelete struct {
+	config
+	hooks    []Hook
+	mutation *PackageHashMutation
+}
+
+// Where appends a list predicates to the PackageHashDelete builder.
+func (phd *PackageHashDelete) Where(ps ...predicate.PackageHash) *PackageHashDelete {
+	phd.mutation.Where(ps...)
+	return phd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (phd *PackageHashDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, phd.sqlExec, phd.mutation, phd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (phd *PackageHashDelete) ExecX(ctx context.Context) int {
+	n, err := phd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (phd *PackageHashDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(packagehash.Table, sqlgraph.NewFieldSpec(packagehash.FieldID, field.TypeInt))
+	if ps := phd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, phd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	phd.mutation.done = true
+	return affected, err
+}
+
+// PackageHashDeleteOne is the builder for deleting a single PackageHash entity.
+type PackageHashDeleteOne struct {
+	phd *PackageHashDelete
+}
+
+// Where appends a list predicates to the PackageHashDelete builder.
+func (phdo *PackageHashDeleteOne) Where(ps ...predicate.PackageHash) *PackageHashDeleteOne {
+	phdo.phd.mutation.Where(ps...)
+	return phdo
+}
+
+// Exec executes the deletion query.
+func (phdo *PackageHashDeleteOne) Exec(ctx context.Context) error {
+	n, err := phdo.phd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{packagehash.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (phdo *PackageHashDeleteOne) ExecX(ctx context.Context) {
+	if err := phdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,527 @@
+// Code generated by ent, DO NOT EDIT.
+
+package gen
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/toheart/goanalysis/internal/data/ent/static/gen/packagehash"
+	"github.com/toheart/goanalysis/internal/data/ent/static/gen/predicate"
+)
+
+// PackageHashQuery is the builder for querying PackageHash entities.
+type PackageHashQuery struct {
+	config
+	ctx        *QueryContext
+	order      []packagehash.OrderOption
+	inters     []Interceptor
+	predicates []predicate.PackageHash
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the PackageHashQuery builder.
+func (phq *PackageHashQuery) Where(ps ...predicate.PackageHash) *PackageHashQuery {
+	phq.predicates = append(phq.predicates, ps...)
+	return phq
+}
+
+// Limit the number of records to be returned by this query.
+func (phq *PackageHashQuery) Limit(limit int) *PackageHashQuery {
+	phq.ctx.Limit = &limit
+	return phq
+}
+
+// Offset to start from.
+func (phq *PackageHashQuery) Offset(offset int) *PackageHashQuery {
+	phq.ctx.Offset = &offset
+	return phq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (phq *PackageHashQuery) Unique(unique bool) *PackageHashQuery {
+	phq.ctx.Unique = &unique
+	return phq
+}
+
+// Order specifies how the records should be ordered.
+func (phq *PackageHashQuery) Order(o ...packagehash.OrderOption) *PackageHashQuery {
+	phq.order = append(phq.order, o...)
+	return phq
+}
+
+// First returns the first PackageHash entity from the query.
+// Returns a *NotFoundError when no PackageHash was found.
+func (phq *PackageHashQuery) First(ctx context.Context) (*PackageHash, error) {
+	nodes, err := phq.Limit(1).All(setContextOp(ctx, phq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{packagehash.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (phq *PackageHashQuery) FirstX(ctx context.Context) *PackageHash {
+	node, err := phq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first PackageHash ID from the query.
+// Returns a *NotFoundError when no PackageHash ID was found.
+func (phq *PackageHashQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = phq.Limit(1).IDs(setContextOp(ctx, phq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{packagehash.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (phq *PackageHashQuery) FirstIDX(ctx context.Context) int {
+	id, err := phq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single PackageHash entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one PackageHash entity is found.
+// Returns a *NotFoundError when no PackageHash entities are found.
+func (phq *PackageHashQuery) Only(ctx context.Context) (*PackageHash, error) {
+	nodes, err := phq.Limit(2).All(setContextOp(ctx, phq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{packagehash.Label}
+	default:
+		return nil, &NotSingularError{packagehash.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (phq *PackageHashQuery) OnlyX(ctx context.Context) *PackageHash {
+	node, err := phq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only PackageHash ID in the query.
+// Returns a *NotSingularError when more than one PackageHash ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (phq *PackageHashQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = phq.Limit(2).IDs(setContextOp(ctx, phq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{packagehash.Label}
+	default:
+		err = &NotSingularError{packagehash.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (phq *PackageHashQuery) OnlyIDX(ctx context.Context) int {
+	id, err := phq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of PackageHashes.
+func (phq *PackageHashQuery) All(ctx context.Context) ([]*PackageHash, error) {
+	ctx = setContextOp(ctx, phq.ctx, ent.OpQueryAll)
+	if err := phq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*PackageHash, *PackageHashQuery]()
+	return withInterceptors[[]*PackageHash](ctx, phq, qr, phq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (phq *PackageHashQuery) AllX(ctx context.Context) []*PackageHash {
+	nodes, err := phq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of PackageHash IDs.
+func (phq *PackageHashQuery) IDs(ctx context.Context) (ids []int, err error) {
+	if phq.ctx.Unique == nil && phq.path != nil {
+		phq.Unique(true)
+	}
+	ctx = setContextOp(ctx, phq.ctx, ent.OpQueryIDs)
+	if err = phq.Select(packagehash.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (phq *PackageHashQuery) IDsX(ctx context.Context) []int {
+	ids, err := phq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (phq *PackageHashQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, phq.ctx, ent.OpQueryCount)
+	if err := phq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, phq, querierCount[*PackageHashQuery](), phq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (phq *PackageHashQuery) CountX(ctx context.Context) int {
+	count, err := phq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (phq *PackageHashQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, phq.ctx, ent.OpQueryExist)
+	switch _, err := phq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("gen: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (phq *PackageHashQuery) ExistX(ctx context.Context) bool {
+	exist, err := phq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the PackageHashQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (phq *PackageHashQuery) Clone() *PackageHashQuery {
+	if phq == nil {
+		return nil
+	}
+	return &PackageHashQuery{
+		config:     phq.config,
+		ctx:        phq.ctx.Clone(),
+		order:      append([]packagehash.OrderOption{}, phq.order...),
+		inters:     append([]Interceptor{}, phq.inters...),
+		predicates: append([]predicate.PackageHash{}, phq.predicates...),
+		// clone intermediate query.
+		sql:  phq.sql.Clone(),
+		path: phq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		Pkg string `json:"pkg,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.PackageHash.Query().
+//		GroupBy(packagehash.FieldPkg).
+//		Aggregate(gen.Count()).
+//		Scan(ctx, &v)
+func (phq *PackageHashQuery) GroupBy(field string, fields ...string) *PackageHashGroupBy {
+	phq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &PackageHashGroupBy{build: phq}
+	grbuild.flds = &phq.ctx.Fields
+	grbuild.label = packagehash.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		Pkg string `json:"pkg,omitempty"`
+//	}
+//
+//	client.PackageHash.Query().
+//		Select(packagehash.FieldPkg).
+//		Scan(ctx, &v)
+func (phq *PackageHashQuery) Select(fields ...string) *PackageHashSelect {
+	phq.ctx.Fields = append(phq.ctx.Fields, fields...)
+	sbuild := &PackageHashSelect{PackageHashQuery: phq}
+	sbuild.label = packagehash.Label
+	sbuild.flds, sbuild.scan = &phq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a PackageHashSelect configured with the given aggregations.
+func (phq *PackageHashQuery) Aggregate(fns ...AggregateFunc) *PackageHashSelect {
+	return phq.Select().Aggregate(fns...)
+}
+
+func (phq *PackageHashQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range phq.inters {
+		if inter == nil {
+			return fmt.Errorf("gen: uninitialized interceptor (forgotten import gen/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, phq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range phq.ctx.Fields {
+		if !packagehash.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("gen: invalid field %q for query", f)}
+		}
+	}
+	if phq.path != nil {
+		prev, err := phq.path(ctx)
+		if err != nil {
+			return err
+		}
+		phq.sql = prev
+	}
+	return nil
+}
+
+func (phq *PackageHashQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*PackageHash, error) {
+	var (
+		nodes = []*PackageHash{}
+		_spec = phq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*PackageHash).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &PackageHash{config: phq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, phq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (phq *PackageHashQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := phq.querySpec()
+	_spec.Node.Columns = phq.ctx.Fields
+	if len(phq.ctx.Fields) > 0 {
+		_spec.Unique = phq.ctx.Unique != nil && *phq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, phq.driver, _spec)
+}
+
+func (phq *PackageHashQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(packagehash.Table, packagehash.Columns, sqlgraph.NewFieldSpec(packagehash.FieldID, field.TypeInt))
+	_spec.From = phq.sql
+	if unique := phq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if phq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := phq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, packagehash.FieldID)
+		for i := range fields {
+			if fields[i] != packagehash.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := phq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := phq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := phq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := phq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (phq *PackageHashQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(phq.driver.Dialect())
+	t1 := builder.Table(packagehash.Table)
+	columns := phq.ctx.Fields
+	if len(columns) == 0 {
+		columns = packagehash.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if phq.sql != nil {
+		selector = phq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if phq.ctx.Unique != nil && *phq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range phq.predicates {
+		p(selector)
+	}
+	for _, p := range phq.order {
+		p(selector)
+	}
+	if offset := phq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := phq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// PackageHashGroupBy is the group-by builder for PackageHash entities.
+type PackageHashGroupBy struct {
+	selector
+	build *PackageHashQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (phgb *PackageHashGroupBy) Aggregate(fns ...AggregateFunc) *PackageHashGroupBy {
+	phgb.fns = append(phgb.fns, fns...)
+	return phgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (phgb *PackageHashGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, phgb.build.ctx, ent.OpQueryGroupBy)
+	if err := phgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*PackageHashQuery, *PackageHashGroupBy](ctx, phgb.build, phgb, phgb.build.inters, v)
+}
+
+func (phgb *PackageHashGroupBy) sqlScan(ctx context.Context, root *PackageHashQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(phgb.fns))
+	for _, fn := range phgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*phgb.flds)+len(phgb.fns))
+		for _, f := range *phgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*phgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := phgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// PackageHashSelect is the builder for selecting fields of PackageHash entities.
+type PackageHashSelect struct {
+	*PackageHashQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (phs *PackageHashSelect) Aggregate(fns ...AggregateFunc) *PackageHashSelect {
+	phs.fns = append(phs.fns, fns...)
+	return phs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (phs *PackageHashSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, phs.ctx, ent.OpQuerySelect)
+	if err := phs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*PackageHashQuery, *PackageHashSelect](ctx, phs.PackageHashQuery, phs, phs.inters, v)
+}
+
+func (phs *PackageHashSelect) sqlScan(ctx context.Context, root *PackageHashQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(phs.fns))
+	for _, fn := range phs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*phs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := phs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,314 @@
+// Code generated by ent, DO NOT EDIT.
+
+package gen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/toheart/goanalysis/internal/data/ent/static/gen/packagehash"
+	"github.com/toheart/goanalysis/internal/data/ent/static/gen/predicate"
+)
+
+// PackageHashUpdate is the builder for updating PackageHash entities.
+type PackageHashUpdate struct {
+	config
+	hooks    []Hook
+	mutation *PackageHashMutation
+}
+
+// Where appends a list predicates to the PackageHashUpdate builder.
+func (phu *PackageHashUpdate) Where(ps ...predicate.PackageHash) *PackageHashUpdate {
+	phu.mutation.Where(ps...)
+	return phu
+}
+
+// SetPkg sets the "pkg" field.
+func (phu *PackageHashUpdate) SetPkg(s string) *PackageHashUpdate {
+	phu.mutation.SetPkg(s)
+	return phu
+}
+
+// SetNillablePkg sets the "pkg" field if the given value is not nil.
+func (phu *PackageHashUpdate) SetNillablePkg(s *string) *PackageHashUpdate {
+	if s != nil {
+		phu.SetPkg(*s)
+	}
+	return phu
+}
+
+// SetHash sets the "hash" field.
+func (phu *PackageHashUpdate) SetHash(s string) *PackageHashUpdate {
+	phu.mutation.SetHash(s)
+	return phu
+}
+
+// SetNillableHash sets the "hash" field if the given value is not nil.
+func (phu *PackageHashUpdate) SetNillableHash(s *string) *PackageHashUpdate {
+	if s != nil {
+		phu.SetHash(*s)
+	}
+	return phu
+}
+
+// SetUpdatedAt sets the "UpdatedAt" field.
+func (phu *PackageHashUpdate) SetUpdatedAt(t time.Time) *PackageHashUpdate {
+	phu.mutation.SetUpdatedAt(t)
+	return phu
+}
+
+// SetNillableUpdatedAt sets the "UpdatedAt" field if the given value is not nil.
+func (phu *PackageHashUpdate) SetNillableUpdatedAt(t *time.Time) *PackageHashUpdate {
+	if t != nil {
+		phu.SetUpdatedAt(*t)
+	}
+	return phu
+}
+
+// Mutation returns the PackageHashMutation object of the builder.
+func (phu *PackageHashUpdate) Mutation() *PackageHashMutation {
+	return phu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (phu *PackageHashUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, phu.sqlSave, phu.mutation, phu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (phu *PackageHashUpdate) SaveX(ctx context.Context) int {
+	affected, err := phu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (phu *PackageHashUpdate) Exec(ctx context.Context) error {
+	_, err := phu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (phu *PackageHashUpdate) ExecX(ctx context.Context) {
+	if err := phu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (phu *PackageHashUpdate) check() error {
+	if v, ok := phu.mutation.Pkg(); ok {
+		if err := packagehash.PkgValidator(v); err != nil {
+			return &ValidationError{Name: "pkg", err: fmt.Errorf(`gen: validator failed for field "PackageHash.pkg": %w`, err)}
+		}
+	}
+	if v, ok := phu.mutation.Hash(); ok {
+		if err := packagehash.HashValidator(v); err != nil {
+			return &ValidationError{Name: "hash", err: fmt.Errorf(`gen: validator failed for field "PackageHash.hash": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (phu *PackageHashUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := phu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(packagehash.Table, packagehash.Columns, sqlgraph.NewFieldSpec(packagehash.FieldID, field.TypeInt))
+	if ps := phu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := phu.mutation.Pkg(); ok {
+		_spec.SetField(packagehash.FieldPkg, field.TypeString, value)
+	}
+	if value, ok := phu.mutation.Hash(); ok {
+		_spec.SetField(packagehash.FieldHash, field.TypeString, value)
+	}
+	if value, ok := phu.mutation.UpdatedAt(); ok {
+		_spec.SetField(packagehash.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, phu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{packagehash.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	phu.mutation.done = true
+	return n, nil
+}
+
+// PackageHashUpdateOne is the builder for updating a single PackageHash entity.
+type PackageHashUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PackageHashMutation
+}
+
+// SetPkg sets the "pkg" field.
+func (phuo *PackageHashUpdateOne) SetPkg(s string) *PackageHashUpdateOne {
+	phuo.mutation.SetPkg(s)
+	return phuo
+}
+
+// SetNillablePkg sets the "pkg" field if the given value is not nil.
+func (phuo *PackageHashUpdateOne) SetNillablePkg(s *string) *PackageHashUpdateOne {
+	if s != nil {
+		phuo.SetPkg(*s)
+	}
+	return phuo
+}
+
+// SetHash sets the "hash" field.
+func (phuo *PackageHashUpdateOne) SetHash(s string) *PackageHashUpdateOne {
+	phuo.mutation.SetHash(s)
+	return phuo
+}
+
+// SetNillableHash sets the "hash" field if the given value is not nil.
+func (phuo *PackageHashUpdateOne) SetNillableHash(s *string) *PackageHashUpdateOne {
+	if s != nil {
+		phuo.SetHash(*s)
+	}
+	return phuo
+}
+
+// SetUpdatedAt sets the "UpdatedAt" field.
+func (phuo *PackageHashUpdateOne) SetUpdatedAt(t time.Time) *PackageHashUpdateOne {
+	phuo.mutation.SetUpdatedAt(t)
+	return phuo
+}
+
+// SetNillableUpdatedAt sets the "UpdatedAt" field if the given value is not nil.
+func (phuo *PackageHashUpdateOne) SetNillableUpdatedAt(t *time.Time) *PackageHashUpdateOne {
+	if t != nil {
+		phuo.SetUpdatedAt(*t)
+	}
+	return phuo
+}
+
+// Mutation returns the PackageHashMutation object of the builder.
+func (phuo *PackageHashUpdateOne) Mutation() *PackageHashMutation {
+	return phuo.mutation
+}
+
+// Where appends a list predicates to the PackageHashUpdate builder.
+func (phuo *PackageHashUpdateOne) Where(ps ...predicate.PackageHash) *PackageHashUpdateOne {
+	phuo.mutation.Where(ps...)
+	return phuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (phuo *PackageHashUpdateOne) Select(field string, fields ...string) *PackageHashUpdateOne {
+	phuo.fields = append([]string{field}, fields...)
+	return phuo
+}
+
+// Save executes the query and returns the updated PackageHash entity.
+func (phuo *PackageHashUpdateOne) Save(ctx context.Context) (*PackageHash, error) {
+	return withHooks(ctx, phuo.sqlSave, phuo.mutation, phuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (phuo *PackageHashUpdateOne) SaveX(ctx context.Context) *PackageHash {
+	node, err := phuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (phuo *PackageHashUpdateOne) Exec(ctx context.Context) error {
+	_, err := phuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (phuo *PackageHashUpdateOne) ExecX(ctx context.Context) {
+	if err := phuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (phuo *PackageHashUpdateOne) check() error {
+	if v, ok := phuo.mutation.Pkg(); ok {
+		if err := packagehash.PkgValidator(v); err != nil {
+			return &ValidationError{Name: "pkg", err: fmt.Errorf(`gen: validator failed for field "PackageHash.pkg": %w`, err)}
+		}
+	}
+	if v, ok := phuo.mutation.Hash(); ok {
+		if err := packagehash.HashValidator(v); err != nil {
+			return &ValidationError{Name: "hash", err: fmt.Errorf(`gen: validator failed for field "PackageHash.hash": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (phuo *PackageHashUpdateOne) sqlSave(ctx context.Context) (_node *PackageHash, err error) {
+	if err := phuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(packagehash.Table, packagehash.Columns, sqlgraph.NewFieldSpec(packagehash.FieldID, field.TypeInt))
+	id, ok := phuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`gen: missing "PackageHash.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := phuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, packagehash.FieldID)
+		for _, f := range fields {
+			if !packagehash.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("gen: invalid field %q for query", f)}
+			}
+			if f != packagehash.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := phuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := phuo.mutation.Pkg(); ok {
+		_spec.SetField(packagehash.FieldPkg, field.TypeString, value)
+	}
+	if value, ok := phuo.mutation.Hash(); ok {
+		_spec.SetField(packagehash.FieldHash, field.TypeString, value)
+	}
+	if value, ok := phuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(packagehash.FieldUpdatedAt, field.TypeTime, value)
+	}
+	_node = &PackageHash{config: phuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, phuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{packagehash.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	phuo.mutation.done = true
+	return _node, nil
+}
@@ -11,3 +11,6 @@ type FuncEdge func(*sql.Selector)
 
 // FuncNode is the predicate function for funcnode builders.
 type FuncNode func(*sql.Selector)
+
+// PackageHash is the predicate function for packagehash builders.
+type PackageHash func(*sql.Selector)
@@ -5,7 +5,9 @@ package gen
 import (
 	"time"
 
+	"github.com/toheart/goanalysis/internal/data/ent/static/gen/funcedge"
 	"github.com/toheart/goanalysis/internal/data/ent/static/gen/funcnode"
+	"github.com/toheart/goanalysis/internal/data/ent/static/gen/packagehash"
 	"github.com/toheart/goanalysis/internal/data/ent/static/schema"
 )
 
@@ -13,30 +15,78 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	funcedgeFields := schema.FuncEdge{}.Fields()
+	_ = funcedgeFields
+	// funcedgeDescCallFile is the schema descriptor for call_file field.
+	funcedgeDescCallFile := funcedgeFields[4].Descriptor()
+	// funcedge.DefaultCallFile holds the default value on creation for the call_file field.
+	funcedge.DefaultCallFile = funcedgeDescCallFile.Default.(string)
+	// funcedgeDescCallLine is the schema descriptor for call_line field.
+	funcedgeDescCallLine := funcedgeFields[5].Descriptor()
+	// funcedge.DefaultCallLine holds the default value on creation for the call_line field.
+	funcedge.DefaultCallLine = funcedgeDescCallLine.Default.(int)
+	// funcedgeDescCallKind is the schema descriptor for call_kind field.
+	funcedgeDescCallKind := funcedgeFields[6].Descriptor()
+	// funcedge.DefaultCallKind holds the default value on creation for the call_kind field.
+	funcedge.DefaultCallKind = funcedgeDescCallKind.Default.(string)
 	funcnodeFields := schema.FuncNode{}.Fields()
 	_ = funcnodeFields
 	// funcnodeDescKey is the schema descriptor for key field.
 	funcnodeDescKey := funcnodeFields[0].Descriptor()
 	// funcnode.KeyValidator is a validator for the "key" field. It is called by the builders before save.
 	funcnode.KeyValidator = funcnodeDescKey.Validators[0].(func(string) error)
+	// funcnodeDescLegacyKey is the schema descriptor for legacy_key field.
+	funcnodeDescLegacyKey := funcnodeFields[1].Descriptor()
+	// funcnode.DefaultLegacyKey holds the default value on creation for the legacy_key field.
+	funcnode.DefaultLegacyKey = funcnodeDescLegacyKey.Default.(string)
 	// funcnodeDescFullName is the schema descriptor for full_name field.
-	funcnodeDescFullName := funcnodeFields[1].Descriptor()
+	funcnodeDescFullName := funcnodeFields[2].Descriptor()
 	// funcnode.FullNameValidator is a validator for the "full_name" field. It is called by the builders before save.
 	funcnode.FullNameValidator = funcnodeDescFullName.Validators[0].(func(string) error)
 	// funcnodeDescPkg is the schema descriptor for pkg field.
-	funcnodeDescPkg := funcnodeFields[2].Descriptor()
+	funcnodeDescPkg := funcnodeFields[3].Descriptor()
 	// funcnode.PkgValidator is a validator for the "pkg" field. It is called by the builders before save.
 	funcnode.PkgValidator = funcnodeDescPkg.Validators[0].(func(string) error)
+	// funcnodeDescModule is the schema descriptor for module field.
+	funcnodeDescModule := funcnodeFields[4].Descriptor()
+	// funcnode.DefaultModule holds the default value on creation for the module field.
+	funcnode.DefaultModule = funcnodeDescModule.Default.(string)
 	// funcnodeDescName is the schema descriptor for name field.
-	funcnodeDescName := funcnodeFields[3].Descriptor()
+	funcnodeDescName := funcnodeFields[5].Descriptor()
 	// funcnode.NameValidator is a validator for the "name" field. It is called by the builders before save.
 	funcnode.NameValidator = funcnodeDescName.Validators[0].(func(string) error)
+	// funcnodeDescFile is the schema descriptor for file field.
+	funcnodeDescFile := funcnodeFields[6].Descriptor()
+	// funcnode.DefaultFile holds the default value on creation for the file field.
+	funcnode.DefaultFile = funcnodeDescFile.Default.(string)
+	// funcnodeDescStartLine is the schema descriptor for start_line field.
+	funcnodeDescStartLine := funcnodeFields[7].Descriptor()
+	// funcnode.DefaultStartLine holds the default value on creation for the start_line field.
+	funcnode.DefaultStartLine = funcnodeDescStartLine.Default.(int)
+	// funcnodeDescEndLine is the schema descriptor for end_line field.
+	funcnodeDescEndLine := funcnodeFields[8].Descriptor()
+	// funcnode.DefaultEndLine holds the default value on creation for the end_line field.
+	funcnode.DefaultEndLine = funcnodeDescEndLine.Default.(int)
 	// funcnodeDescCreatedAt is the schema descriptor for CreatedAt field.
-	funcnodeDescCreatedAt := funcnodeFields[4].Descriptor()
+	funcnodeDescCreatedAt := funcnodeFields[10].Descriptor()
 	// funcnode.DefaultCreatedAt holds the default value on creation for the CreatedAt field.
 	funcnode.DefaultCreatedAt = funcnodeDescCreatedAt.Default.(func() time.Time)
 	// funcnodeDescUpdatedAt is the schema descriptor for UpdatedAt field.
-	funcnodeDescUpdatedAt := funcnodeFields[5].Descriptor()
+	funcnodeDescUpdatedAt := funcnodeFields[11].Descriptor()
 	// funcnode.DefaultUpdatedAt holds the default value on creation for the UpdatedAt field.
 	funcnode.DefaultUpdatedAt = funcnodeDescUpdatedAt.Default.(func() time.Time)
+	packagehashFields := schema.PackageHash{}.Fields()
+	_ = packagehashFields
+	// packagehashDescPkg is the schema descriptor for pkg field.
+	packagehashDescPkg := packagehashFields[0].Descriptor()
+	// packagehash.PkgValidator is a validator for the "pkg" field. It is called by the builders before save.
+	packagehash.PkgValidator = packagehashDescPkg.Validators[0].(func(string) error)
+	// packagehashDescHash is the schema descriptor for hash field.
+	packagehashDescHash := packagehashFields[1].Descriptor()
+	// packagehash.HashValidator is a validator for the "hash" field. It is called by the builders before save.
+	packagehash.HashValidator = packagehashDescHash.Validators[0].(func(string) error)
+	// packagehashDescUpdatedAt is the schema descriptor for UpdatedAt field.
+	packagehashDescUpdatedAt := packagehashFields[2].Descriptor()
+	// packagehash.DefaultUpdatedAt holds the default value on creation for the UpdatedAt field.
+	packagehash.DefaultUpdatedAt = packagehashDescUpdatedAt.Default.(func() time.Time)
 }
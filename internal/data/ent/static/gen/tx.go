@@ -16,6 +16,8 @@ type Tx struct {
 	FuncEdge *FuncEdgeClient
 	// FuncNode is the client for interacting with the FuncNode builders.
 	FuncNode *FuncNodeClient
+	// PackageHash is the client for interacting with the PackageHash builders.
+	PackageHash *PackageHashClient
 
 	// lazily loaded.
 	client     *Client
@@ -149,6 +151,7 @@ func (tx *Tx) Client() *Client {
 func (tx *Tx) init() {
 	tx.FuncEdge = NewFuncEdgeClient(tx.config)
 	tx.FuncNode = NewFuncNodeClient(tx.config)
+	tx.PackageHash = NewPackageHashClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
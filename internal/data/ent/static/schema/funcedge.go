@@ -15,8 +15,22 @@ func (FuncEdge) Fields() []ent.Field {
 	return []ent.Field{
 		field.Time("CreatedAt"),
 		field.Time("UpdatedAt"),
-		field.String("CallerKey"),
-		field.String("CalleeKey"),
+		field.String("CallerKey").
+			Comment("调用方的稳定Key"),
+		field.String("CalleeKey").
+			Comment("被调用方的稳定Key"),
+		field.String("call_file").
+			Default("").
+			Comment("调用点所在文件, 项目内的文件为相对项目目录的路径"),
+		field.Int("call_line").
+			Default(0).
+			Comment("调用点行号, 没有调用点(如合成调用)时为0"),
+		field.String("call_kind").
+			Default("").
+			Comment("调用类型: static/interface/dynamic/closure/go/defer, 没有调用点时为空"),
+		field.Strings("build_configs").
+			Optional().
+			Comment("矩阵模式下存在这条边的构建配置, 如 linux/amd64, 非矩阵模式为空"),
 	}
 }
 
@@ -19,14 +19,32 @@ func (FuncNode) Fields() []ent.Field {
 		field.String("key").
 			Unique().
 			NotEmpty().
-			Comment("短格式唯一标识，如 n6796"),
+			Comment("稳定唯一标识, 包路径+函数名, 如 crypto/hmac.New$1; 旧版本数据库中为 n6796 格式"),
+		field.String("legacy_key").
+			Default("").
+			Comment("调用图节点ID生成的旧格式标识, 如 n6796"),
 		field.String("full_name").
 			NotEmpty().
 			Comment("完整的函数路径，如 crypto/hmac.New$1"),
 		field.String("pkg").
 			NotEmpty(),
+		field.String("module").
+			Default("").
+			Comment("所属模块, 标准库为空; 工作区和多模块仓库中用于区分项目内的模块"),
 		field.String("name").
 			NotEmpty(),
+		field.String("file").
+			Default("").
+			Comment("声明所在文件, 项目内的文件为相对项目目录的路径"),
+		field.Int("start_line").
+			Default(0).
+			Comment("函数起始行, 合成函数为0"),
+		field.Int("end_line").
+			Default(0).
+			Comment("函数结束行"),
+		field.Strings("build_configs").
+			Optional().
+			Comment("矩阵模式下存在这个函数的构建配置, 非矩阵模式为空"),
 		field.Time("CreatedAt").
 			Default(time.Now),
 		field.Time("UpdatedAt").
@@ -43,8 +61,10 @@ func (FuncNode) Edges() []ent.Edge {
 func (FuncNode) Indexes() []ent.Index {
 	return []ent.Index{
 		index.Fields("pkg"),
+		index.Fields("module"),
 		index.Fields("key").
 			Unique(),
+		index.Fields("legacy_key"),
 		index.Fields("full_name"),
 	}
 }
@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// PackageHash 增量分析记录的包内容摘要
+type PackageHash struct {
+	ent.Schema
+}
+
+// Fields of the PackageHash.
+func (PackageHash) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("pkg").
+			NotEmpty().
+			Comment("包路径"),
+		field.String("hash").
+			NotEmpty().
+			Comment("包源码、依赖版本和分析配置的摘要"),
+		field.Time("UpdatedAt").
+			Default(time.Now),
+	}
+}
+
+// Edges of the PackageHash.
+func (PackageHash) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the PackageHash.
+func (PackageHash) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("pkg").
+			Unique(),
+	}
+}
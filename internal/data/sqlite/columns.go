@@ -0,0 +1,67 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+/**
+兼容旧版本生成的数据库: 读取时不迁移表结构, 查询实体时只选择数据库中存在的列,
+缺少的列读出为零值. 已指定列的查询(如Select、GroupBy)和计数查询不受影响.
+**/
+
+// tableColumns 读取表的列名, 表不存在时返回空集合
+func tableColumns(drv *sql.Driver, table string) (map[string]bool, error) {
+	rows, err := drv.DB().Query(fmt.Sprintf("PRAGMA table_info(%q)", table))
+	if err != nil {
+		return nil, fmt.Errorf("query %s columns failed: %w", table, err)
+	}
+	defer rows.Close()
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, typ        string
+			dflt             any
+		)
+		if err = rows.Scan(&cid, &name, &typ, &notNull, &dflt, &pk); err != nil {
+			return nil, fmt.Errorf("scan %s columns failed: %w", table, err)
+		}
+		columns[name] = true
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("read %s columns failed: %w", table, err)
+	}
+	return columns, nil
+}
+
+// splitColumns 将schema中的列分为数据库中存在和缺少的两组, 表不存在时都视为存在
+func splitColumns(columns map[string]bool, schema []string) (present, missing []string) {
+	for _, column := range schema {
+		if len(columns) == 0 || columns[column] {
+			present = append(present, column)
+		} else {
+			missing = append(missing, column)
+		}
+	}
+	return present, missing
+}
+
+// selectPresentColumns 查询实体时只选择present返回的列, present返回空时不限制
+func selectPresentColumns(present func() []string) ent.Interceptor {
+	return ent.InterceptFunc(func(next ent.Querier) ent.Querier {
+		return ent.QuerierFunc(func(ctx context.Context, query ent.Query) (ent.Value, error) {
+			qc := ent.QueryFromContext(ctx)
+			if columns := present(); qc != nil && len(qc.Fields) == 0 && len(columns) > 0 {
+				switch qc.Op {
+				case ent.OpQueryAll, ent.OpQueryFirst, ent.OpQueryOnly:
+					qc.Fields = append([]string(nil), columns...)
+				}
+			}
+			return next.Query(ctx, query)
+		})
+	})
+}
@@ -3,42 +3,86 @@ package sqlite
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
 	"github.com/toheart/goanalysis/internal/biz/callgraph/dos"
 	"github.com/toheart/goanalysis/internal/biz/repo"
 	"github.com/toheart/goanalysis/internal/data/ent/static/gen"
 	"github.com/toheart/goanalysis/internal/data/ent/static/gen/funcedge"
 	"github.com/toheart/goanalysis/internal/data/ent/static/gen/funcnode"
+	"github.com/toheart/goanalysis/internal/data/ent/static/gen/predicate"
 )
 
+// _sqliteBatchSize 单条语句中IN条件的参数数量上限, 低于SQLite默认的变量数限制
+const _sqliteBatchSize = 500
+
 var _ repo.StaticDBStore = (*StaticEntDBImpl)(nil)
 
 // StaticEntDBImpl 使用 Ent 框架的静态分析数据库实现
 type StaticEntDBImpl struct {
-	client *gen.Client
+	client  *gen.Client
+	drv     *sql.Driver
+	columns atomic.Pointer[staticColumns]
+}
+
+// staticColumns 旧版本生成的数据库中存在的列, 为空表示不缺列
+type staticColumns struct {
+	nodes     []string
+	edges     []string
+	legacyKey bool // func_nodes表是否有legacy_key列
 }
 
 // NewStaticEntDBImpl 创建函数节点数据库（使用 Ent 框架）
+// 打开时不迁移表结构, 查询只读取已有的列; 只有InitTable会迁移
 func NewStaticEntDBImpl(dbPath string) (*StaticEntDBImpl, error) {
-	// 创建 Ent 客户端
-	client, err := gen.Open(dialect.SQLite, ParseDBPath(dbPath))
+	drv, err := sql.Open(dialect.SQLite, ParseDBPath(dbPath))
 	if err != nil {
 		return nil, fmt.Errorf("create ent client failed: %w", err)
 	}
 
-	return &StaticEntDBImpl{client: client}, nil
+	s := &StaticEntDBImpl{client: gen.NewClient(gen.Driver(drv)), drv: drv}
+	if err = s.loadColumns(); err != nil {
+		drv.Close()
+		return nil, err
+	}
+	s.client.FuncNode.Intercept(selectPresentColumns(func() []string { return s.columns.Load().nodes }))
+	s.client.FuncEdge.Intercept(selectPresentColumns(func() []string { return s.columns.Load().edges }))
+	return s, nil
+}
+
+// loadColumns 读取func_nodes和func_edges表中存在的列
+func (s *StaticEntDBImpl) loadColumns() error {
+	nodeColumns, err := tableColumns(s.drv, funcnode.Table)
+	if err != nil {
+		return err
+	}
+	edgeColumns, err := tableColumns(s.drv, funcedge.Table)
+	if err != nil {
+		return err
+	}
+
+	columns := &staticColumns{legacyKey: len(nodeColumns) == 0 || nodeColumns[funcnode.FieldLegacyKey]}
+	if present, missing := splitColumns(nodeColumns, funcnode.Columns); len(missing) > 0 {
+		columns.nodes = present
+	}
+	if present, missing := splitColumns(edgeColumns, funcedge.Columns); len(missing) > 0 {
+		columns.edges = present
+	}
+	s.columns.Store(columns)
+	return nil
 }
 
-// InitTable 初始化数据库表
+// InitTable 初始化数据库表, 旧版本生成的数据库补齐缺少的列
 func (s *StaticEntDBImpl) InitTable() error {
 	ctx := context.Background()
 	// 自动创建表结构
 	if err := s.client.Schema.Create(ctx); err != nil {
 		return fmt.Errorf("创建表结构失败: %w", err)
 	}
-	return nil
+	return s.loadColumns()
 }
 
 // SaveFuncNode 保存函数节点
@@ -59,18 +103,30 @@ func (s *StaticEntDBImpl) SaveFuncNode(node *dos.FuncNode) error {
 		_, err = s.client.FuncNode.
 			Update().
 			Where(funcnode.Key(node.Key)).
+			SetLegacyKey(node.LegacyKey).
 			SetFullName(node.FullName).
 			SetPkg(node.Pkg).
+			SetModule(node.Module).
 			SetName(node.Name).
+			SetFile(node.File).
+			SetStartLine(node.StartLine).
+			SetEndLine(node.EndLine).
+			SetBuildConfigs(node.Configs).
 			Save(ctx)
 	} else {
 		// 创建节点
 		_, err = s.client.FuncNode.
 			Create().
 			SetKey(node.Key).
+			SetLegacyKey(node.LegacyKey).
 			SetFullName(node.FullName).
 			SetPkg(node.Pkg).
+			SetModule(node.Module).
 			SetName(node.Name).
+			SetFile(node.File).
+			SetStartLine(node.StartLine).
+			SetEndLine(node.EndLine).
+			SetBuildConfigs(node.Configs).
 			Save(ctx)
 	}
 
@@ -88,6 +144,10 @@ func (s *StaticEntDBImpl) SaveFuncEdge(edge *dos.FuncEdge) error {
 	_, err := s.client.FuncEdge.Create().
 		SetCallerKey(edge.CallerKey).
 		SetCalleeKey(edge.CalleeKey).
+		SetCallFile(edge.CallFile).
+		SetCallLine(edge.CallLine).
+		SetCallKind(string(edge.CallKind)).
+		SetBuildConfigs(edge.Configs).
 		SetCreatedAt(time.Now()).
 		SetUpdatedAt(time.Now()).
 		Save(ctx)
@@ -97,15 +157,12 @@ func (s *StaticEntDBImpl) SaveFuncEdge(edge *dos.FuncEdge) error {
 	return nil
 }
 
-// GetFuncNodeByKey 根据Key获取函数节点
+// GetFuncNodeByKey 根据Key获取函数节点, 同时支持稳定Key和旧格式Key
 func (s *StaticEntDBImpl) GetFuncNodeByKey(key string) (*dos.FuncNode, error) {
 	ctx := context.Background()
 
 	// 查询函数节点
-	funcEnt, err := s.client.FuncNode.
-		Query().
-		Where(funcnode.Key(key)).
-		Only(ctx)
+	funcEnt, err := s.queryFuncNode(ctx, key)
 	if err != nil {
 		if gen.IsNotFound(err) {
 			return nil, nil // 节点不存在
@@ -114,21 +171,16 @@ func (s *StaticEntDBImpl) GetFuncNodeByKey(key string) (*dos.FuncNode, error) {
 	}
 
 	// 转换为业务实体
-	node := &dos.FuncNode{
-		Key:      funcEnt.Key,
-		FullName: funcEnt.FullName,
-		Pkg:      funcEnt.Pkg,
-		Name:     funcEnt.Name,
-	}
+	node := toFuncNode(funcEnt)
 
 	// 获取父节点
-	parents, err := s.GetCallerEdges(key)
+	parents, err := s.GetCallerEdges(node.Key)
 	if err == nil {
 		node.Parents = parents
 	}
 
 	// 获取子节点
-	children, err := s.GetCalleeEdges(key)
+	children, err := s.GetCalleeEdges(node.Key)
 	if err == nil {
 		node.Childrens = children
 	}
@@ -136,10 +188,42 @@ func (s *StaticEntDBImpl) GetFuncNodeByKey(key string) (*dos.FuncNode, error) {
 	return node, nil
 }
 
+// queryFuncNode 按稳定Key查询节点, 找不到时按旧格式Key查询
+func (s *StaticEntDBImpl) queryFuncNode(ctx context.Context, key string) (*gen.FuncNode, error) {
+	funcEnt, err := s.client.FuncNode.
+		Query().
+		Where(funcnode.Key(key)).
+		Only(ctx)
+	if err == nil || !gen.IsNotFound(err) || key == "" || !s.columns.Load().legacyKey {
+		return funcEnt, err
+	}
+	return s.client.FuncNode.
+		Query().
+		Where(funcnode.LegacyKey(key)).
+		First(ctx)
+}
+
+// resolveKey 将旧格式Key转换为边中使用的稳定Key, 节点不存在时原样返回
+func (s *StaticEntDBImpl) resolveKey(ctx context.Context, key string) (string, error) {
+	funcEnt, err := s.queryFuncNode(ctx, key)
+	if err != nil {
+		if gen.IsNotFound(err) {
+			return key, nil
+		}
+		return "", fmt.Errorf("resolve func key failed: %w", err)
+	}
+	return funcEnt.Key, nil
+}
+
 // GetCallerEdges 获取调用该函数的所有节点
 func (s *StaticEntDBImpl) GetCallerEdges(calleeKey string) ([]*dos.FuncNode, error) {
 	ctx := context.Background()
 
+	calleeKey, err := s.resolveKey(ctx, calleeKey)
+	if err != nil {
+		return nil, err
+	}
+
 	// 查询调用该函数的节点
 	callers, err := s.client.FuncEdge.
 		Query().
@@ -159,13 +243,7 @@ func (s *StaticEntDBImpl) GetCallerEdges(calleeKey string) ([]*dos.FuncNode, err
 		if err != nil {
 			return nil, fmt.Errorf("get caller node failed: %w", err)
 		}
-		node := &dos.FuncNode{
-			Key:      funcNode.Key,
-			FullName: funcNode.FullName,
-			Pkg:      funcNode.Pkg,
-			Name:     funcNode.Name,
-		}
-		nodes = append(nodes, node)
+		nodes = append(nodes, toFuncNode(funcNode))
 	}
 
 	return nodes, nil
@@ -175,6 +253,11 @@ func (s *StaticEntDBImpl) GetCallerEdges(calleeKey string) ([]*dos.FuncNode, err
 func (s *StaticEntDBImpl) GetCalleeEdges(callerKey string) ([]*dos.FuncNode, error) {
 	ctx := context.Background()
 
+	callerKey, err := s.resolveKey(ctx, callerKey)
+	if err != nil {
+		return nil, err
+	}
+
 	// 查询调用该函数的节点
 	callers, err := s.client.FuncEdge.
 		Query().
@@ -194,17 +277,68 @@ func (s *StaticEntDBImpl) GetCalleeEdges(callerKey string) ([]*dos.FuncNode, err
 		if err != nil {
 			return nil, fmt.Errorf("get caller node failed: %w", err)
 		}
-		node := &dos.FuncNode{
-			Key:      funcNode.Key,
-			FullName: funcNode.FullName,
-			Pkg:      funcNode.Pkg,
-			Name:     funcNode.Name,
+		nodes = append(nodes, toFuncNode(funcNode))
+	}
+	return nodes, nil
+}
+
+// GetFuncNodesByKeys 批量获取函数节点, 同时支持稳定Key和旧格式Key, 不加载调用关系
+func (s *StaticEntDBImpl) GetFuncNodesByKeys(keys []string) ([]*dos.FuncNode, error) {
+	ctx := context.Background()
+
+	legacy := s.columns.Load().legacyKey
+	var nodes []*dos.FuncNode
+	for i := 0; i < len(keys); i += _sqliteBatchSize {
+		batch := keys[i:min(i+_sqliteBatchSize, len(keys))]
+		where := funcnode.KeyIn(batch...)
+		if legacy {
+			where = funcnode.Or(where, funcnode.LegacyKeyIn(batch...))
+		}
+		funcEnts, err := s.client.FuncNode.
+			Query().
+			Where(where).
+			All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get func nodes failed: %w", err)
+		}
+		for _, funcEnt := range funcEnts {
+			nodes = append(nodes, toFuncNode(funcEnt))
 		}
-		nodes = append(nodes, node)
 	}
 	return nodes, nil
 }
 
+// GetFuncEdgesByCallers 获取调用方属于callerKeys的边, 按保存的顺序返回
+func (s *StaticEntDBImpl) GetFuncEdgesByCallers(callerKeys []string) ([]*dos.FuncEdge, error) {
+	return s.queryFuncEdges(callerKeys, funcedge.CallerKeyIn)
+}
+
+// GetFuncEdgesByCallees 获取被调用方属于calleeKeys的边, 按保存的顺序返回
+func (s *StaticEntDBImpl) GetFuncEdgesByCallees(calleeKeys []string) ([]*dos.FuncEdge, error) {
+	return s.queryFuncEdges(calleeKeys, funcedge.CalleeKeyIn)
+}
+
+// queryFuncEdges 按keys分批查询调用边
+func (s *StaticEntDBImpl) queryFuncEdges(keys []string, in func(...string) predicate.FuncEdge) ([]*dos.FuncEdge, error) {
+	ctx := context.Background()
+
+	var edges []*dos.FuncEdge
+	for i := 0; i < len(keys); i += _sqliteBatchSize {
+		funcEdges, err := s.client.FuncEdge.
+			Query().
+			Where(in(keys[i:min(i+_sqliteBatchSize, len(keys))]...)).
+			Order(gen.Asc(funcedge.FieldID)).
+			All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get func edges failed: %w", err)
+		}
+		for _, funcEdge := range funcEdges {
+			edges = append(edges, toFuncEdge(funcEdge))
+		}
+	}
+	return edges, nil
+}
+
 // GetAllFuncNodes 获取所有函数节点
 func (s *StaticEntDBImpl) GetAllFuncNodes() ([]*dos.FuncNode, error) {
 	ctx := context.Background()
@@ -220,13 +354,7 @@ func (s *StaticEntDBImpl) GetAllFuncNodes() ([]*dos.FuncNode, error) {
 	// 转换为业务实体
 	var nodes []*dos.FuncNode
 	for _, funcEnt := range funcEnts {
-		node := &dos.FuncNode{
-			Key:      funcEnt.Key,
-			FullName: funcEnt.FullName,
-			Pkg:      funcEnt.Pkg,
-			Name:     funcEnt.Name,
-		}
-		nodes = append(nodes, node)
+		nodes = append(nodes, toFuncNode(funcEnt))
 	}
 
 	return nodes, nil
@@ -247,11 +375,7 @@ func (s *StaticEntDBImpl) GetAllFuncEdges() ([]*dos.FuncEdge, error) {
 	// 转换为业务实体
 	var edges []*dos.FuncEdge
 	for _, funcEdge := range funcEdges {
-		edge := &dos.FuncEdge{
-			CallerKey: funcEdge.CallerKey,
-			CalleeKey: funcEdge.CalleeKey,
-		}
-		edges = append(edges, edge)
+		edges = append(edges, toFuncEdge(funcEdge))
 	}
 
 	return edges, nil
@@ -279,18 +403,199 @@ func (s *StaticEntDBImpl) SearchFuncNodes(query string, limit int) ([]*dos.FuncN
 	// 转换为业务实体
 	var nodes []*dos.FuncNode
 	for _, funcEnt := range funcEnts {
-		node := &dos.FuncNode{
-			Key:      funcEnt.Key,
-			FullName: funcEnt.FullName,
-			Pkg:      funcEnt.Pkg,
-			Name:     funcEnt.Name,
-		}
-		nodes = append(nodes, node)
+		nodes = append(nodes, toFuncNode(funcEnt))
 	}
 
 	return nodes, nil
 }
 
+// GetPackageHashes 获取增量分析记录的包摘要
+func (s *StaticEntDBImpl) GetPackageHashes() ([]*dos.PackageHash, error) {
+	ctx := context.Background()
+
+	hashEnts, err := s.client.PackageHash.
+		Query().
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get package hashes failed: %w", err)
+	}
+
+	hashes := make([]*dos.PackageHash, 0, len(hashEnts))
+	for _, hashEnt := range hashEnts {
+		hashes = append(hashes, &dos.PackageHash{Pkg: hashEnt.Pkg, Hash: hashEnt.Hash})
+	}
+	return hashes, nil
+}
+
+// SavePackageHashes 用本次分析的包摘要替换已有记录
+func (s *StaticEntDBImpl) SavePackageHashes(hashes []*dos.PackageHash) error {
+	ctx := context.Background()
+
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	if _, err = tx.PackageHash.Delete().Exec(ctx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("delete package hashes failed: %w", err)
+	}
+	now := time.Now()
+	for i := 0; i < len(hashes); i += _sqliteBatchSize {
+		batch := hashes[i:min(i+_sqliteBatchSize, len(hashes))]
+		builders := make([]*gen.PackageHashCreate, 0, len(batch))
+		for _, hash := range batch {
+			builders = append(builders, tx.PackageHash.Create().
+				SetPkg(hash.Pkg).
+				SetHash(hash.Hash).
+				SetUpdatedAt(now))
+		}
+		if _, err = tx.PackageHash.CreateBulk(builders...).Save(ctx); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("save package hashes failed: %w", err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit package hashes failed: %w", err)
+	}
+	return nil
+}
+
+// DeleteFuncEdges 删除调用方属于pkgs中的包, 或调用类型属于kinds的边
+func (s *StaticEntDBImpl) DeleteFuncEdges(pkgs []string, kinds []dos.CallKind) (int, error) {
+	ctx := context.Background()
+
+	deleted := 0
+	for i := 0; i < len(pkgs); i += _sqliteBatchSize {
+		keys, err := s.client.FuncNode.
+			Query().
+			Where(funcnode.PkgIn(pkgs[i:min(i+_sqliteBatchSize, len(pkgs))]...)).
+			Select(funcnode.FieldKey).
+			Strings(ctx)
+		if err != nil {
+			return deleted, fmt.Errorf("query package nodes failed: %w", err)
+		}
+		for j := 0; j < len(keys); j += _sqliteBatchSize {
+			n, err := s.client.FuncEdge.
+				Delete().
+				Where(funcedge.CallerKeyIn(keys[j:min(j+_sqliteBatchSize, len(keys))]...)).
+				Exec(ctx)
+			if err != nil {
+				return deleted, fmt.Errorf("delete package edges failed: %w", err)
+			}
+			deleted += n
+		}
+	}
+	if len(kinds) > 0 {
+		values := make([]string, 0, len(kinds))
+		for _, kind := range kinds {
+			values = append(values, string(kind))
+		}
+		n, err := s.client.FuncEdge.
+			Delete().
+			Where(funcedge.CallKindIn(values...)).
+			Exec(ctx)
+		if err != nil {
+			return deleted, fmt.Errorf("delete edges by kind failed: %w", err)
+		}
+		deleted += n
+	}
+	return deleted, nil
+}
+
+// DeleteOrphanFuncNodes 删除不再出现在任何边中的节点
+func (s *StaticEntDBImpl) DeleteOrphanFuncNodes() (int, error) {
+	ctx := context.Background()
+
+	edges, err := s.client.FuncEdge.
+		Query().
+		Select(funcedge.FieldCallerKey, funcedge.FieldCalleeKey).
+		All(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("query func edges failed: %w", err)
+	}
+	used := make(map[string]bool, len(edges)*2)
+	for _, edge := range edges {
+		used[edge.CallerKey] = true
+		used[edge.CalleeKey] = true
+	}
+	keys, err := s.client.FuncNode.
+		Query().
+		Select(funcnode.FieldKey).
+		Strings(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("query func nodes failed: %w", err)
+	}
+	var orphans []string
+	for _, key := range keys {
+		if !used[key] {
+			orphans = append(orphans, key)
+		}
+	}
+	deleted := 0
+	for i := 0; i < len(orphans); i += _sqliteBatchSize {
+		n, err := s.client.FuncNode.
+			Delete().
+			Where(funcnode.KeyIn(orphans[i:min(i+_sqliteBatchSize, len(orphans))]...)).
+			Exec(ctx)
+		if err != nil {
+			return deleted, fmt.Errorf("delete orphan nodes failed: %w", err)
+		}
+		deleted += n
+	}
+	return deleted, nil
+}
+
+// ClearCallGraph 清空所有节点、边和包摘要
+func (s *StaticEntDBImpl) ClearCallGraph() error {
+	ctx := context.Background()
+
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	if _, err = tx.FuncEdge.Delete().Exec(ctx); err == nil {
+		if _, err = tx.FuncNode.Delete().Exec(ctx); err == nil {
+			_, err = tx.PackageHash.Delete().Exec(ctx)
+		}
+	}
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("clear call graph failed: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit clear call graph failed: %w", err)
+	}
+	return nil
+}
+
+// toFuncNode 将ent实体转换为业务实体
+func toFuncNode(funcEnt *gen.FuncNode) *dos.FuncNode {
+	return &dos.FuncNode{
+		Key:       funcEnt.Key,
+		LegacyKey: funcEnt.LegacyKey,
+		FullName:  funcEnt.FullName,
+		Pkg:       funcEnt.Pkg,
+		Name:      funcEnt.Name,
+		File:      funcEnt.File,
+		StartLine: funcEnt.StartLine,
+		EndLine:   funcEnt.EndLine,
+		Module:    funcEnt.Module,
+		Configs:   funcEnt.BuildConfigs,
+	}
+}
+
+// toFuncEdge 将ent实体转换为业务实体
+func toFuncEdge(funcEdge *gen.FuncEdge) *dos.FuncEdge {
+	return &dos.FuncEdge{
+		CallerKey: funcEdge.CallerKey,
+		CalleeKey: funcEdge.CalleeKey,
+		CallFile:  funcEdge.CallFile,
+		CallLine:  funcEdge.CallLine,
+		CallKind:  dos.CallKind(funcEdge.CallKind),
+		Configs:   funcEdge.BuildConfigs,
+	}
+}
+
 // Close 关闭数据库连接
 func (s *StaticEntDBImpl) Close() error {
 	return s.client.Close()
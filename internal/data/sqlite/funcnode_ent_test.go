@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewStaticEntDBImpl_OldSchema(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "static.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open db: %v", err)
+	}
+	defer db.Close()
+	// 旧版本生成的表没有稳定Key、位置信息和调用类型列, key列保存旧格式Key
+	stmts := []string{
+		`CREATE TABLE "func_nodes" ("id" INTEGER PRIMARY KEY AUTOINCREMENT, "key" TEXT NOT NULL UNIQUE, "full_name" TEXT NOT NULL, "pkg" TEXT NOT NULL, "name" TEXT NOT NULL, "created_at" DATETIME NOT NULL, "updated_at" DATETIME NOT NULL)`,
+		`CREATE TABLE "func_edges" ("id" INTEGER PRIMARY KEY AUTOINCREMENT, "created_at" DATETIME NOT NULL, "updated_at" DATETIME NOT NULL, "caller_key" TEXT NOT NULL, "callee_key" TEXT NOT NULL)`,
+		`INSERT INTO "func_nodes" ("key", "full_name", "pkg", "name", "created_at", "updated_at") VALUES ('n1', 'example.com/demo.main', 'example.com/demo', 'main', '2024-01-01 00:00:00', '2024-01-01 00:00:00'), ('n2', 'example.com/demo.run', 'example.com/demo', 'run', '2024-01-01 00:00:00', '2024-01-01 00:00:00')`,
+		`INSERT INTO "func_edges" ("created_at", "updated_at", "caller_key", "callee_key") VALUES ('2024-01-01 00:00:00', '2024-01-01 00:00:00', 'n1', 'n2')`,
+	}
+	for _, stmt := range stmts {
+		if _, err = db.Exec(stmt); err != nil {
+			t.Fatalf("Failed to exec %q: %v", stmt, err)
+		}
+	}
+	columnCount := func() int {
+		var count int
+		if err := db.QueryRow(`SELECT (SELECT COUNT(*) FROM pragma_table_info('func_nodes')) + (SELECT COUNT(*) FROM pragma_table_info('func_edges'))`).Scan(&count); err != nil {
+			t.Fatalf("Failed to query columns: %v", err)
+		}
+		return count
+	}
+
+	store, err := NewStaticEntDBImpl(dbPath)
+	if err != nil {
+		t.Fatalf("NewStaticEntDBImpl failed: %v", err)
+	}
+	defer store.Close()
+
+	node, err := store.GetFuncNodeByKey("n1")
+	if err != nil || node.Name != "main" || node.File != "" || len(node.Childrens) != 1 {
+		t.Fatalf("GetFuncNodeByKey failed: %+v, %v", node, err)
+	}
+	if node, err = store.GetFuncNodeByKey("n3"); err != nil || node != nil {
+		t.Errorf("Expected no node for unknown key, got %+v, %v", node, err)
+	}
+	nodes, err := store.GetFuncNodesByKeys([]string{"n1", "n2"})
+	if err != nil || len(nodes) != 2 {
+		t.Fatalf("GetFuncNodesByKeys failed: %v, %v", nodes, err)
+	}
+	edges, err := store.GetFuncEdgesByCallers([]string{"n1"})
+	if err != nil || len(edges) != 1 || edges[0].CalleeKey != "n2" || edges[0].CallKind != "" {
+		t.Fatalf("GetFuncEdgesByCallers failed: %v, %v", edges, err)
+	}
+	if edges, err = store.GetAllFuncEdges(); err != nil || len(edges) != 1 {
+		t.Fatalf("GetAllFuncEdges failed: %v, %v", edges, err)
+	}
+	// 读取不应修改数据库文件
+	if count := columnCount(); count != 12 {
+		t.Errorf("Expected tables to stay unchanged, found %d columns", count)
+	}
+
+	// 分析前迁移表结构, 之后读取新增的列
+	if err = store.InitTable(); err != nil {
+		t.Fatalf("InitTable failed: %v", err)
+	}
+	if count := columnCount(); count <= 12 {
+		t.Errorf("Expected InitTable to add columns, found %d columns", count)
+	}
+	if node, err = store.GetFuncNodeByKey("n1"); err != nil || node.Name != "main" {
+		t.Fatalf("GetFuncNodeByKey after InitTable failed: %+v, %v", node, err)
+	}
+}
@@ -33,11 +33,21 @@ func NewTraceEntDB(dbPath string) (*TraceEntDB, error) {
 	}
 
 	// 创建 Ent 客户端
-	client, err := gen.Open(dialect.SQLite, ParseDBPath(dbPath))
+	drv, err := sql.Open(dialect.SQLite, ParseDBPath(dbPath))
 	if err != nil {
 		return nil, fmt.Errorf("create ent client failed: %w", err)
 	}
+	columns, err := tableColumns(drv, tracedata.Table)
+	if err != nil {
+		drv.Close()
+		return nil, err
+	}
 
+	client := gen.NewClient(gen.Driver(drv))
+	// 旧版本functrace生成的数据库没有error和panic列, 只查询存在的列, 不修改数据库文件
+	if present, missing := splitColumns(columns, tracedata.Columns); len(missing) > 0 {
+		client.TraceData.Intercept(selectPresentColumns(func() []string { return present }))
+	}
 	return &TraceEntDB{client: client}, nil
 }
 
@@ -75,6 +85,8 @@ func (d *TraceEntDB) GetTracesByGID(gid uint64, depth int, createTime string) ([
 			ParentId:   uint64(trace.ParentId),
 			CreatedAt:  createdAt.Format(time.RFC3339Nano),
 			Seq:        trace.Seq,
+			Error:      trace.Error,
+			Panic:      trace.Panic,
 		}
 
 		result = append(result, traceData)
@@ -112,6 +124,8 @@ func (d *TraceEntDB) GetTraceByID(id int) (*dos.TraceData, error) {
 		ParentId:   uint64(trace.ParentId),
 		CreatedAt:  createdAt.Format(time.RFC3339Nano),
 		Seq:        trace.Seq,
+		Error:      trace.Error,
+		Panic:      trace.Panic,
 	}
 
 	return traceData, nil
@@ -148,6 +162,8 @@ func (d *TraceEntDB) GetTraceChildren(parentID int64) ([]dos.TraceData, error) {
 			ParentId:   uint64(trace.ParentId),
 			CreatedAt:  createdAt.Format(time.RFC3339Nano),
 			Seq:        trace.Seq,
+			Error:      trace.Error,
+			Panic:      trace.Panic,
 		}
 
 		result = append(result, traceData)
@@ -385,6 +401,8 @@ func (d *TraceEntDB) GetTracesByParentId(parentId int64) ([]dos.TraceData, error
 			ParentId:   uint64(trace.ParentId),
 			CreatedAt:  createdAt.Format(time.RFC3339Nano),
 			Seq:        trace.Seq,
+			Error:      trace.Error,
+			Panic:      trace.Panic,
 		}
 
 		result = append(result, traceData)
@@ -837,6 +855,8 @@ func (d *TraceEntDB) GetTracesByFuncName(functionName string) ([]dos.TraceData,
 			ParentId:   uint64(trace.ParentId),
 			CreatedAt:  createdAt.Format(time.RFC3339Nano),
 			Seq:        trace.Seq,
+			Error:      trace.Error,
+			Panic:      trace.Panic,
 		}
 
 		result = append(result, traceData)
@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	msqlite "modernc.org/sqlite"
+)
+
+func init() {
+	sql.Register("sqlite3", &msqlite.Driver{})
+}
+
+func TestNewTraceEntDB_MissingResultColumns(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "trace.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open db: %v", err)
+	}
+	defer db.Close()
+	// 旧版本functrace生成的表没有error和panic列
+	stmts := []string{
+		`CREATE TABLE "traceData" ("id" INTEGER PRIMARY KEY AUTOINCREMENT, "name" TEXT, "gid" INTEGER, "indent" INTEGER, "paramsCount" INTEGER, "timeCost" TEXT, "parentId" INTEGER, "createdAt" TEXT, "seq" TEXT)`,
+		`INSERT INTO "traceData" ("name", "gid", "indent", "paramsCount", "timeCost", "parentId", "createdAt", "seq") VALUES ('main.main', 1, 0, 0, '1ms', 0, '2024-01-01T00:00:00Z', '1')`,
+	}
+	for _, stmt := range stmts {
+		if _, err = db.Exec(stmt); err != nil {
+			t.Fatalf("Failed to exec %q: %v", stmt, err)
+		}
+	}
+
+	traceDB, err := NewTraceEntDB(dbPath)
+	if err != nil {
+		t.Fatalf("NewTraceEntDB failed: %v", err)
+	}
+	defer traceDB.Close()
+	trace, err := traceDB.GetTraceByID(1)
+	if err != nil || trace == nil {
+		t.Fatalf("GetTraceByID failed: %v, %v", trace, err)
+	}
+	if trace.Name != "main.main" || trace.Error != "" || trace.Panic != "" {
+		t.Errorf("Unexpected trace: %+v", trace)
+	}
+	traces, err := traceDB.GetTracesByGID(1, 10, "2000-01-01T00:00:00Z")
+	if err != nil || len(traces) != 1 {
+		t.Fatalf("GetTracesByGID failed: %v, %v", traces, err)
+	}
+
+	// 读取不应修改数据库文件
+	var count int
+	if err = db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('traceData') WHERE name IN ('error', 'panic')`).Scan(&count); err != nil {
+		t.Fatalf("Failed to query columns: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected traceData to stay unchanged, found %d result columns", count)
+	}
+}
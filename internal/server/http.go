@@ -100,6 +100,8 @@ func (h *HttpServer) handleAnalysisEvents(w http.ResponseWriter, r *http.Request
 
 	// 标记是否已经发送了完成消息
 	completedSent := false
+	// 已推送的诊断数量
+	diagnosticsSent := 0
 
 	// 监听消息和完成信号
 	for {
@@ -107,11 +109,21 @@ func (h *HttpServer) handleAnalysisEvents(w http.ResponseWriter, r *http.Request
 		case msg, ok := <-statusChan:
 			// 如果通道已关闭，发送完成消息并退出
 			if !ok {
+				if err := h.sendDiagnostics(w, taskId, &diagnosticsSent); err != nil {
+					h.log.Errorf("Failed to send diagnostics: %v", err)
+				}
 				if !completedSent {
 					completedMsg := entity.AnalysisEvent{
 						Type:    entity.TaskStatusCompleted,
 						Message: "Analysis task completed",
 					}
+					// 取消的任务通道关闭时同样结束事件流
+					if status, err := h.staticBiz.GetTaskStatus(taskId); err == nil && status.Status == entity.TaskStatusCancelled {
+						completedMsg = entity.AnalysisEvent{
+							Type:    entity.TaskStatusCancelled,
+							Message: status.Message,
+						}
+					}
 					if err := sendSSEEvent(w, completedMsg); err != nil {
 						h.log.Errorf("Failed to send completion message: %v", err)
 					}
@@ -131,6 +143,11 @@ func (h *HttpServer) handleAnalysisEvents(w http.ResponseWriter, r *http.Request
 				h.log.Errorf("Failed to send message: %v", err)
 				return
 			}
+			// 推送消息之后新记录的诊断
+			if err := h.sendDiagnostics(w, taskId, &diagnosticsSent); err != nil {
+				h.log.Errorf("Failed to send diagnostics: %v", err)
+				return
+			}
 			flusher.Flush()
 
 		case <-done:
@@ -141,6 +158,26 @@ func (h *HttpServer) handleAnalysisEvents(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// sendDiagnostics 推送任务状态中第sent条之后的诊断信息
+func (h *HttpServer) sendDiagnostics(w http.ResponseWriter, taskId string, sent *int) error {
+	status, err := h.staticBiz.GetTaskStatus(taskId)
+	if err != nil {
+		return nil
+	}
+	for ; *sent < len(status.Diagnostics); *sent++ {
+		diag := status.Diagnostics[*sent]
+		event := entity.AnalysisEvent{
+			Type:       entity.AnalysisEventDiagnostic,
+			Message:    diag.String(),
+			Diagnostic: &diag,
+		}
+		if err := sendSSEEvent(w, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // sendSSEEvent 发送SSE事件
 func sendSSEEvent(w http.ResponseWriter, data interface{}) error {
 	// 将数据转换为JSON
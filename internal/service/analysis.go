@@ -2,12 +2,14 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	v1 "github.com/toheart/goanalysis/api/analysis/v1"
 	"github.com/toheart/goanalysis/internal/biz/analysis"
+	"github.com/toheart/goanalysis/internal/biz/entity"
 	"github.com/toheart/goanalysis/internal/biz/rewrite"
 	"google.golang.org/grpc"
 )
@@ -55,6 +57,8 @@ func (a *AnalysisService) GetAnalysisByGID(ctx context.Context, in *v1.AnalysisB
 			TimeCost:   trace.TimeCost,
 			ParentId:   int64(trace.ParentId),
 			Seq:        trace.Seq, // 添加seq字段
+			Error:      trace.Error,
+			Panic:      trace.Panic,
 		}
 
 		reply.TraceData = append(reply.TraceData, traceData)
@@ -230,6 +234,8 @@ func (a *AnalysisService) GetTracesByParentFunc(ctx context.Context, in *v1.GetT
 			ParamCount: int32(trace.ParamCount),
 			TimeCost:   trace.TimeCost,
 			ParentId:   int64(trace.ParentId),
+			Error:      trace.Error,
+			Panic:      trace.Panic,
 		}
 
 		reply.TraceData = append(reply.TraceData, traceData)
@@ -333,15 +339,201 @@ func (a *AnalysisService) InstrumentProject(ctx context.Context, in *v1.Instrume
 		}
 	}()
 
+	tracer, err := a.uc.Tracer()
+	if err != nil {
+		return &v1.InstrumentProjectReply{
+			Success: false,
+			Message: fmt.Sprintf("插桩模板配置无效: %v", err),
+		}, nil
+	}
+
+	// 撤销插桩操作
+	if in.Undo {
+		manifest, err := rewrite.UndoDir(in.Path, rewrite.WithTracer(tracer))
+		files := make([]string, 0, len(manifest.Files))
+		for _, entry := range manifest.Files {
+			files = append(files, entry.Path)
+		}
+		if err != nil {
+			a.log.Errorf("undo instrumentation failed: %v", err)
+			return &v1.InstrumentProjectReply{
+				Success: false,
+				Message: fmt.Sprintf("撤销插桩失败, 已修改 %d 个文件, 失败 %d 个: %v", len(files), len(manifest.Errors), err),
+				Files:   files,
+			}, nil
+		}
+		return &v1.InstrumentProjectReply{
+			Success: true,
+			Message: fmt.Sprintf("撤销插桩成功, 共修改 %d 个文件", len(files)),
+			Files:   files,
+		}, nil
+	}
+
+	filter, err := rewrite.NewFilter(toFilterConfig(in.Filter))
+	if err != nil {
+		return &v1.InstrumentProjectReply{
+			Success: false,
+			Message: fmt.Sprintf("过滤规则无效: %v", err),
+		}, nil
+	}
+	opts := []rewrite.RewriteOption{rewrite.WithFilter(filter), rewrite.WithTracer(tracer)}
+	if in.LineDirectives {
+		opts = append(opts, rewrite.WithLineDirectives())
+	}
+	if in.Tests {
+		opts = append(opts, rewrite.WithTests())
+	}
+
+	// 异步模式, 进度通过SSE端点推送, 结果通过GetInstrumentTask获取
+	if in.Async {
+		taskID := a.uc.StartInstrumentTask(in.Path, func(progress chan []byte) *v1.InstrumentProjectReply {
+			return a.instrument(in, filter, append(opts, rewrite.WithProgress(progress)))
+		})
+		return &v1.InstrumentProjectReply{
+			Success: true,
+			Message: "插桩任务已启动",
+			TaskId:  taskID,
+		}, nil
+	}
+	return a.instrument(in, filter, opts), nil
+}
+
+// instrument 按请求的模式执行插桩并生成响应
+func (a *AnalysisService) instrument(in *v1.InstrumentProjectReq, filter *rewrite.Filter, opts []rewrite.RewriteOption) *v1.InstrumentProjectReply {
+	// 预览模式, 不修改文件
+	if in.DryRun {
+		dryRun := rewrite.NewDryRun()
+		report := rewrite.RewriteDir(in.Path, append(opts, rewrite.WithDryRun(dryRun))...)
+		files := dryRun.Files()
+		diffs := make([]*v1.InstrumentFileDiff, 0, len(files))
+		for _, file := range files {
+			diffs = append(diffs, &v1.InstrumentFileDiff{
+				Path:  file.Path,
+				Diff:  file.Diff,
+				Stats: toInstrumentStats(file.Stats),
+			})
+		}
+		stats := dryRun.Stats()
+		return &v1.InstrumentProjectReply{
+			Success: len(report.Errors) == 0,
+			Message: fmt.Sprintf("预览完成, 共 %d 个文件, %d 处插桩, %d 个错误", len(files), stats.Total(), len(report.Errors)),
+			Skipped: toInstrumentSkipped(filter.Skipped()),
+			Diffs:   diffs,
+			Stats:   toInstrumentStats(stats),
+			Report:  toInstrumentReport(report),
+		}
+	}
+
+	// overlay模式, 不修改源文件
+	if in.OverlayDir != "" {
+		overlay := rewrite.NewOverlay(in.OverlayDir)
+		report := rewrite.RewriteDir(in.Path, append(opts, rewrite.WithOverlay(overlay))...)
+		overlayPath, err := overlay.Save()
+		if err != nil {
+			a.log.Errorf("save overlay failed: %v", err)
+			return &v1.InstrumentProjectReply{
+				Success: false,
+				Message: fmt.Sprintf("生成overlay文件失败: %v", err),
+				Report:  toInstrumentReport(report),
+			}
+		}
+		return &v1.InstrumentProjectReply{
+			Success:     len(report.Errors) == 0,
+			Message:     fmt.Sprintf("项目插桩完成, 共生成 %d 个插桩文件, %d 个错误", overlay.Len(), len(report.Errors)),
+			OverlayPath: overlayPath,
+			Skipped:     toInstrumentSkipped(filter.Skipped()),
+			Stats:       toInstrumentStats(report.Stats),
+			Report:      toInstrumentReport(report),
+		}
+	}
+
 	// 执行插桩操作
-	rewrite.RewriteDir(in.Path)
-	// 暂时返回成功
+	report := rewrite.RewriteDir(in.Path, opts...)
+	a.log.Infof("instrument %s: %s", in.Path, report)
 	return &v1.InstrumentProjectReply{
-		Success: true,
-		Message: "项目插桩成功",
+		Success: len(report.Errors) == 0,
+		Message: fmt.Sprintf("项目插桩完成, 共修改 %d 个文件, %d 处插桩, %d 个错误", len(report.Rewritten), report.Stats.Total(), len(report.Errors)),
+		Skipped: toInstrumentSkipped(filter.Skipped()),
+		Stats:   toInstrumentStats(report.Stats),
+		Report:  toInstrumentReport(report),
+	}
+}
+
+// GetInstrumentTask 获取异步插桩任务的状态和结果
+func (a *AnalysisService) GetInstrumentTask(ctx context.Context, in *v1.GetInstrumentTaskReq) (*v1.GetInstrumentTaskReply, error) {
+	task, ok := a.uc.GetInstrumentTask(in.TaskId)
+	if !ok {
+		return &v1.GetInstrumentTaskReply{
+			TaskId: in.TaskId,
+			Status: entity.TaskStatusNotFound,
+		}, nil
+	}
+	return &v1.GetInstrumentTaskReply{
+		TaskId: task.ID,
+		Status: int32(task.Status),
+		Result: task.Result,
 	}, nil
 }
 
+func toFilterConfig(in *v1.InstrumentFilter) *rewrite.FilterConfig {
+	if in == nil {
+		return nil
+	}
+	return &rewrite.FilterConfig{
+		IncludePkgs:  in.IncludePkgs,
+		ExcludePkgs:  in.ExcludePkgs,
+		IncludeFiles: in.IncludeFiles,
+		ExcludeFiles: in.ExcludeFiles,
+		IncludeFuncs: in.IncludeFuncs,
+		ExcludeFuncs: in.ExcludeFuncs,
+		IncludeRecvs: in.IncludeRecvs,
+		ExcludeRecvs: in.ExcludeRecvs,
+	}
+}
+
+// toInstrumentStats 转换插桩统计
+func toInstrumentStats(stats rewrite.Stats) *v1.InstrumentStats {
+	return &v1.InstrumentStats{
+		Funcs:      int32(stats.Funcs),
+		Closures:   int32(stats.Closures),
+		Goroutines: int32(stats.Goroutines),
+	}
+}
+
+// toInstrumentReport 转换插桩报告
+func toInstrumentReport(report *rewrite.Report) *v1.InstrumentReport {
+	errs := make([]*v1.InstrumentError, 0, len(report.Errors))
+	for _, e := range report.Errors {
+		errs = append(errs, &v1.InstrumentError{
+			File:    e.Path,
+			Line:    int32(e.Line),
+			Column:  int32(e.Column),
+			Message: e.Message,
+		})
+	}
+	return &v1.InstrumentReport{
+		Rewritten: report.Rewritten,
+		Skipped:   report.Skipped,
+		Errors:    errs,
+		Stats:     toInstrumentStats(report.Stats),
+		Generated: report.Generated,
+	}
+}
+
+// toInstrumentSkipped 转换跳过记录
+func toInstrumentSkipped(records []*rewrite.SkipRecord) []*v1.InstrumentSkipped {
+	skipped := make([]*v1.InstrumentSkipped, 0, len(records))
+	for _, record := range records {
+		skipped = append(skipped, &v1.InstrumentSkipped{
+			File:   record.File,
+			Func:   record.Func,
+			Reason: record.Reason,
+			Detail: record.Detail,
+		})
+	}
+	return skipped
+}
+
 // GetFunctionCallStats 获取函数调用统计分析
 func (a *AnalysisService) GetFunctionCallStats(ctx context.Context, req *v1.GetFunctionCallStatsReq) (*v1.GetFunctionCallStatsReply, error) {
 	a.log.Infof("get function call stats, function: %s, dbpath: %s", req.FunctionName, req.DbPath)
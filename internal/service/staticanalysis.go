@@ -90,6 +90,15 @@ func (s *StaticAnalysisService) AnalyzeProjectPath(ctx context.Context, req *v1.
 	options := &entity.AnalysisOptions{
 		Algo:         req.Algo,
 		IgnoreMethod: req.IgnoreMethod,
+		Roots:        req.Roots,
+		BuildTags:    req.BuildTags,
+		Platforms:    req.Platforms,
+		TagSets:      req.TagSets,
+		Env:          req.Env,
+		StdPackages:  req.StdPackages,
+		Tolerant:     req.Tolerant,
+		Timeout:      time.Duration(req.TimeoutSeconds) * time.Second,
+		MemoryLimit:  uint64(max(req.MemoryLimitMb, 0)) << 20,
 	}
 
 	// 启动分析任务
@@ -198,11 +207,8 @@ func (s *StaticAnalysisService) GetHotFunctions(ctx context.Context, req *v1.Get
 		return nil, fmt.Errorf("Failed to get function edges: %v", err)
 	}
 
-	// 统计热点函数（被调用次数最多的函数）
-	funcCallCounts := make(map[string]int)
-	for _, edge := range edges {
-		funcCallCounts[edge.CalleeKey]++
-	}
+	// 统计热点函数（调用方最多的函数）, 同一调用方的多个调用点只计一次
+	funcCallCounts := staticanalysis.CountCallers(edges)
 
 	// 创建一个函数键到函数节点的映射
 	funcNodeMap := make(map[string]*dos.FuncNode)
@@ -326,8 +332,20 @@ func (s *StaticAnalysisService) GetFunctionCallGraph(ctx context.Context, req *v
 		return nil, fmt.Errorf("Invalid direction: %s, should be 'caller', 'callee' or 'both'", direction)
 	}
 
-	s.log.Infof("Getting call graph for function %s, depth: %d, direction: %s", req.FunctionKey, depth, direction)
-	nodes, edges, err := s.uc.GetFunctionCallGraph(req.FunctionKey, depth, direction)
+	kinds, err := staticanalysis.NewCallKindFilter(req.CallKinds)
+	if err != nil {
+		s.log.Errorf("Invalid call kinds: %v", err)
+		return nil, err
+	}
+
+	// 验证文件是否存在
+	if _, err := os.Stat(req.DbPath); err != nil {
+		s.log.Errorf("Database file not found: %s", req.DbPath)
+		return nil, fmt.Errorf("database file not found: %s", req.DbPath)
+	}
+
+	s.log.Infof("Getting call graph for function %s, depth: %d, direction: %s, db: %s", req.FunctionKey, depth, direction, req.DbPath)
+	nodes, edges, err := s.uc.GetFunctionCallGraph(req.DbPath, req.FunctionKey, depth, direction, kinds)
 	if err != nil {
 		s.log.Errorf("Failed to get function call graph: %v", err)
 		return nil, err
@@ -336,23 +354,35 @@ func (s *StaticAnalysisService) GetFunctionCallGraph(ctx context.Context, req *v
 	var protoNodes []*v1.GetFunctionCallGraphReply_GraphNode
 	for _, node := range nodes {
 		protoNodes = append(protoNodes, &v1.GetFunctionCallGraphReply_GraphNode{
-			Key:       node.ID,
-			Name:      node.Name,
-			Package:   node.Package,
-			CallCount: int32(node.CallCount),
-			AvgTime:   node.AvgTime,
-			NodeType:  node.NodeType,
+			Key:          node.ID,
+			Name:         node.Name,
+			Package:      node.Package,
+			CallCount:    int32(node.CallCount),
+			AvgTime:      node.AvgTime,
+			NodeType:     node.NodeType,
+			File:         node.File,
+			StartLine:    int32(node.StartLine),
+			EndLine:      int32(node.EndLine),
+			LegacyKey:    node.LegacyKey,
+			Module:       node.Module,
+			BuildConfigs: node.Configs,
 		})
 	}
 
 	var protoEdges []*v1.GetFunctionCallGraphReply_GraphEdge
 	for _, edge := range edges {
-		protoEdges = append(protoEdges, &v1.GetFunctionCallGraphReply_GraphEdge{
-			Source:   edge.Source,
-			Target:   edge.Target,
-			Label:    edge.Label,
-			EdgeType: edge.EdgeType,
-		})
+		protoEdge := &v1.GetFunctionCallGraphReply_GraphEdge{
+			Source:    edge.Source,
+			Target:    edge.Target,
+			Label:     edge.Label,
+			EdgeType:  edge.EdgeType,
+			CallFile:  edge.CallFile,
+			CallLine:  int32(edge.CallLine),
+			CallKind:  edge.CallKind,
+			CallKinds: edge.CallKinds,
+			Sites:     toProtoCallSites(edge.Sites),
+		}
+		protoEdges = append(protoEdges, protoEdge)
 	}
 
 	return &v1.GetFunctionCallGraphReply{
@@ -521,12 +551,39 @@ func (s *StaticAnalysisService) GetAnalysisTaskStatus(ctx context.Context, req *
 
 	resp := &v1.GetAnalysisTaskStatusResponse{
 		Status:   int32(status.Status),
+		Message:  status.Message,
 		Progress: float32(progress * 100), // 转换为百分比
 	}
+	for _, diag := range status.Diagnostics {
+		resp.Diagnostics = append(resp.Diagnostics, &v1.PackageDiagnostic{
+			Package: diag.Package,
+			File:    diag.File,
+			Line:    int32(diag.Line),
+			Column:  int32(diag.Column),
+			Kind:    diag.Kind,
+			Message: diag.Message,
+		})
+	}
 
 	return resp, nil
 }
 
+// CancelAnalysisTask 取消分析任务
+func (s *StaticAnalysisService) CancelAnalysisTask(ctx context.Context, req *v1.CancelAnalysisTaskRequest) (*v1.CancelAnalysisTaskResponse, error) {
+	s.log.Infof("cancel analysis task: %s", req.TaskId)
+	if err := s.uc.CancelAnalysisTask(req.TaskId); err != nil {
+		s.log.Warnf("failed to cancel task: %v", err)
+		return &v1.CancelAnalysisTaskResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	return &v1.CancelAnalysisTaskResponse{
+		Success: true,
+		Message: "Analysis task cancelled",
+	}, nil
+}
+
 // GetPackageDependencies 分页获取包依赖关系
 func (s *StaticAnalysisService) GetPackageDependencies(ctx context.Context, req *v1.GetPackageDependenciesRequest) (*v1.GetPackageDependenciesResponse, error) {
 	s.log.Infof("Getting package dependencies for db: %s, page: %d, pageSize: %d", req.DbPath, req.Page, req.PageSize)
@@ -669,9 +726,14 @@ func (s *StaticAnalysisService) SearchFunctions(ctx context.Context, req *v1.Sea
 	for _, node := range nodes {
 		matchedFunctions = append(matchedFunctions, &v1.FunctionInfo{
 			Key:       node.Key,
+			LegacyKey: node.LegacyKey,
 			Name:      node.Name,
 			Package:   node.Pkg,
+			Module:    node.Module,
 			CallCount: 0, // 不计算调用次数，提高性能
+			File:      node.File,
+			StartLine: int32(node.StartLine),
+			EndLine:   int32(node.EndLine),
 		})
 	}
 
@@ -732,17 +794,25 @@ func (s *StaticAnalysisService) GetFunctionUpstream(ctx context.Context, req *v1
 		return nil, fmt.Errorf("Failed to get function edges: %v", err)
 	}
 
-	// 统计函数被调用次数
-	funcCallCounts := make(map[string]int)
-	for _, edge := range allEdges {
-		funcCallCounts[edge.CalleeKey]++
+	// 按调用类型过滤边
+	kinds, err := staticanalysis.NewCallKindFilter(req.CallKinds)
+	if err != nil {
+		s.log.Errorf("Invalid call kinds: %v", err)
+		return nil, err
 	}
+	edges := kinds.Apply(allEdges)
+
+	// 统计过滤后每个函数的调用方数量
+	funcCallCounts := staticanalysis.CountCallers(edges)
+
+	// 每对函数的调用点
+	sites := staticanalysis.NewCallPairs(edges)
 
 	// 创建调用方到被调用方的映射
 	callerToCallees := make(map[string][]string)
 	calleeToCallers := make(map[string][]string)
 
-	for _, edge := range allEdges {
+	for _, edge := range edges {
 		callerToCallees[edge.CallerKey] = append(callerToCallees[edge.CallerKey], edge.CalleeKey)
 		calleeToCallers[edge.CalleeKey] = append(calleeToCallers[edge.CalleeKey], edge.CallerKey)
 	}
@@ -753,18 +823,13 @@ func (s *StaticAnalysisService) GetFunctionUpstream(ctx context.Context, req *v1
 	var graphEdges []*v1.GraphEdge
 
 	// 添加目标节点
-	graphNodes = append(graphNodes, &v1.GraphNode{
-		Key:       targetNode.Key,
-		Name:      targetNode.Name,
-		Package:   targetNode.Pkg,
-		CallCount: int32(funcCallCounts[targetNode.Key]),
-	})
+	graphNodes = append(graphNodes, toGraphNode(targetNode, funcCallCounts[targetNode.Key]))
 
 	// 记录已访问的节点
 	visited[targetNode.Key] = true
 
 	// 递归查找所有上游调用
-	s.findAllUpstreamCalls(targetNode, funcNodeDB, calleeToCallers, funcCallCounts, visited, &graphNodes, &graphEdges)
+	s.findAllUpstreamCalls(targetNode, funcNodeDB, calleeToCallers, funcCallCounts, sites, visited, &graphNodes, &graphEdges)
 
 	// 查找最顶层调用函数（没有被其他函数调用的函数）
 	topLevelNodes := s.findTopLevelCallers(graphNodes, graphEdges)
@@ -784,6 +849,7 @@ func (s *StaticAnalysisService) findAllUpstreamCalls(
 	funcNodeDB repo.StaticDBStore,
 	calleeToCallers map[string][]string,
 	funcCallCounts map[string]int,
+	sites staticanalysis.CallPairs,
 	visited map[string]bool,
 	graphNodes *[]*v1.GraphNode,
 	graphEdges *[]*v1.GraphEdge,
@@ -810,23 +876,64 @@ func (s *StaticAnalysisService) findAllUpstreamCalls(
 		visited[callerKey] = true
 
 		// 添加节点
-		*graphNodes = append(*graphNodes, &v1.GraphNode{
-			Key:       callerNode.Key,
-			Name:      callerNode.Name,
-			Package:   callerNode.Pkg,
-			CallCount: int32(funcCallCounts[callerNode.Key]),
-		})
+		*graphNodes = append(*graphNodes, toGraphNode(callerNode, funcCallCounts[callerNode.Key]))
 
 		// 添加边
-		*graphEdges = append(*graphEdges, &v1.GraphEdge{
-			Source: callerNode.Key,
-			Target: currentNode.Key,
-			Value:  1,
-		})
+		*graphEdges = append(*graphEdges, toGraphEdge(callerNode.Key, currentNode.Key, sites))
 
 		// 递归查找上游调用
-		s.findAllUpstreamCalls(callerNode, funcNodeDB, calleeToCallers, funcCallCounts, visited, graphNodes, graphEdges)
+		s.findAllUpstreamCalls(callerNode, funcNodeDB, calleeToCallers, funcCallCounts, sites, visited, graphNodes, graphEdges)
+	}
+}
+
+// toGraphNode 将函数节点转换为图节点
+func toGraphNode(node *dos.FuncNode, callCount int) *v1.GraphNode {
+	return &v1.GraphNode{
+		Key:          node.Key,
+		LegacyKey:    node.LegacyKey,
+		Name:         node.Name,
+		Package:      node.Pkg,
+		CallCount:    int32(callCount),
+		File:         node.File,
+		StartLine:    int32(node.StartLine),
+		EndLine:      int32(node.EndLine),
+		BuildConfigs: node.Configs,
+		Module:       node.Module,
+	}
+}
+
+// toGraphEdge 生成图边, 带上调用点位置和调用类型
+func toGraphEdge(source, target string, pairs staticanalysis.CallPairs) *v1.GraphEdge {
+	edge := &v1.GraphEdge{
+		Source: source,
+		Target: target,
+		Value:  1,
+	}
+	if pair := pairs.Get(source, target); pair != nil {
+		edge.Sites = toProtoCallSites(pair.Sites)
+		if len(edge.Sites) > 0 {
+			edge.Value = int32(len(edge.Sites))
+			edge.CallFile = edge.Sites[0].File
+			edge.CallLine = edge.Sites[0].Line
+		}
+		edge.CallKinds = pair.Kinds
+		edge.BuildConfigs = pair.Configs
+	}
+	return edge
+}
+
+// toProtoCallSites 将调用点转换为API格式
+func toProtoCallSites(sites []entity.CallSite) []*v1.CallSite {
+	var protoSites []*v1.CallSite
+	for _, site := range sites {
+		protoSites = append(protoSites, &v1.CallSite{
+			File:         site.File,
+			Line:         int32(site.Line),
+			Kind:         site.Kind,
+			BuildConfigs: site.Configs,
+		})
 	}
+	return protoSites
 }
 
 // findTopLevelCallers 查找最顶层调用函数（没有被其他函数调用的函数）
@@ -881,17 +988,25 @@ func (s *StaticAnalysisService) GetFunctionDownstream(ctx context.Context, req *
 		return nil, fmt.Errorf("Failed to get function edges: %v", err)
 	}
 
-	// 统计函数被调用次数
-	funcCallCounts := make(map[string]int)
-	for _, edge := range allEdges {
-		funcCallCounts[edge.CalleeKey]++
+	// 按调用类型过滤边
+	kinds, err := staticanalysis.NewCallKindFilter(req.CallKinds)
+	if err != nil {
+		s.log.Errorf("Invalid call kinds: %v", err)
+		return nil, err
 	}
+	edges := kinds.Apply(allEdges)
+
+	// 统计过滤后每个函数的调用方数量
+	funcCallCounts := staticanalysis.CountCallers(edges)
+
+	// 每对函数的调用点
+	sites := staticanalysis.NewCallPairs(edges)
 
 	// 创建调用方到被调用方的映射
 	callerToCallees := make(map[string][]string)
 	calleeToCallers := make(map[string][]string)
 
-	for _, edge := range allEdges {
+	for _, edge := range edges {
 		callerToCallees[edge.CallerKey] = append(callerToCallees[edge.CallerKey], edge.CalleeKey)
 		calleeToCallers[edge.CalleeKey] = append(calleeToCallers[edge.CalleeKey], edge.CallerKey)
 	}
@@ -902,18 +1017,13 @@ func (s *StaticAnalysisService) GetFunctionDownstream(ctx context.Context, req *
 	var graphEdges []*v1.GraphEdge
 
 	// 添加目标节点
-	graphNodes = append(graphNodes, &v1.GraphNode{
-		Key:       targetNode.Key,
-		Name:      targetNode.Name,
-		Package:   targetNode.Pkg,
-		CallCount: int32(funcCallCounts[targetNode.Key]),
-	})
+	graphNodes = append(graphNodes, toGraphNode(targetNode, funcCallCounts[targetNode.Key]))
 
 	// 记录已访问的节点
 	visited[targetNode.Key] = true
 
 	// 递归查找所有下游调用
-	s.findAllDownstreamCalls(targetNode, funcNodeDB, callerToCallees, funcCallCounts, visited, &graphNodes, &graphEdges)
+	s.findAllDownstreamCalls(targetNode, funcNodeDB, callerToCallees, funcCallCounts, sites, visited, &graphNodes, &graphEdges)
 
 	// 查找最底层被调用函数（不调用其他函数的函数）
 	leafNodes := s.findLeafNodes(graphNodes, graphEdges)
@@ -933,6 +1043,7 @@ func (s *StaticAnalysisService) findAllDownstreamCalls(
 	funcNodeDB repo.StaticDBStore,
 	callerToCallees map[string][]string,
 	funcCallCounts map[string]int,
+	sites staticanalysis.CallPairs,
 	visited map[string]bool,
 	graphNodes *[]*v1.GraphNode,
 	graphEdges *[]*v1.GraphEdge,
@@ -959,22 +1070,13 @@ func (s *StaticAnalysisService) findAllDownstreamCalls(
 		visited[calleeKey] = true
 
 		// 添加节点
-		*graphNodes = append(*graphNodes, &v1.GraphNode{
-			Key:       calleeNode.Key,
-			Name:      calleeNode.Name,
-			Package:   calleeNode.Pkg,
-			CallCount: int32(funcCallCounts[calleeNode.Key]),
-		})
+		*graphNodes = append(*graphNodes, toGraphNode(calleeNode, funcCallCounts[calleeNode.Key]))
 
 		// 添加边
-		*graphEdges = append(*graphEdges, &v1.GraphEdge{
-			Source: currentNode.Key,
-			Target: calleeNode.Key,
-			Value:  1,
-		})
+		*graphEdges = append(*graphEdges, toGraphEdge(currentNode.Key, calleeNode.Key, sites))
 
 		// 递归查找下游调用
-		s.findAllDownstreamCalls(calleeNode, funcNodeDB, callerToCallees, funcCallCounts, visited, graphNodes, graphEdges)
+		s.findAllDownstreamCalls(calleeNode, funcNodeDB, callerToCallees, funcCallCounts, sites, visited, graphNodes, graphEdges)
 	}
 }
 
@@ -1030,17 +1132,25 @@ func (s *StaticAnalysisService) GetFunctionFullChain(ctx context.Context, req *v
 		return nil, fmt.Errorf("Failed to get function edges: %v", err)
 	}
 
-	// 统计函数被调用次数
-	funcCallCounts := make(map[string]int)
-	for _, edge := range allEdges {
-		funcCallCounts[edge.CalleeKey]++
+	// 按调用类型过滤边
+	kinds, err := staticanalysis.NewCallKindFilter(req.CallKinds)
+	if err != nil {
+		s.log.Errorf("Invalid call kinds: %v", err)
+		return nil, err
 	}
+	edges := kinds.Apply(allEdges)
+
+	// 统计过滤后每个函数的调用方数量
+	funcCallCounts := staticanalysis.CountCallers(edges)
+
+	// 每对函数的调用点
+	sites := staticanalysis.NewCallPairs(edges)
 
 	// 创建调用方到被调用方的映射
 	callerToCallees := make(map[string][]string)
 	calleeToCallers := make(map[string][]string)
 
-	for _, edge := range allEdges {
+	for _, edge := range edges {
 		callerToCallees[edge.CallerKey] = append(callerToCallees[edge.CallerKey], edge.CalleeKey)
 		calleeToCallers[edge.CalleeKey] = append(calleeToCallers[edge.CalleeKey], edge.CallerKey)
 	}
@@ -1052,22 +1162,17 @@ func (s *StaticAnalysisService) GetFunctionFullChain(ctx context.Context, req *v
 	var graphEdges []*v1.GraphEdge
 
 	// 添加目标节点
-	graphNodes = append(graphNodes, &v1.GraphNode{
-		Key:       targetNode.Key,
-		Name:      targetNode.Name,
-		Package:   targetNode.Pkg,
-		CallCount: int32(funcCallCounts[targetNode.Key]),
-	})
+	graphNodes = append(graphNodes, toGraphNode(targetNode, funcCallCounts[targetNode.Key]))
 
 	// 记录已访问的节点
 	upstreamVisited[targetNode.Key] = true
 	downstreamVisited[targetNode.Key] = true
 
 	// 递归查找所有上游调用
-	s.findAllUpstreamCalls(targetNode, funcNodeDB, calleeToCallers, funcCallCounts, upstreamVisited, &graphNodes, &graphEdges)
+	s.findAllUpstreamCalls(targetNode, funcNodeDB, calleeToCallers, funcCallCounts, sites, upstreamVisited, &graphNodes, &graphEdges)
 
 	// 递归查找所有下游调用
-	s.findAllDownstreamCalls(targetNode, funcNodeDB, callerToCallees, funcCallCounts, downstreamVisited, &graphNodes, &graphEdges)
+	s.findAllDownstreamCalls(targetNode, funcNodeDB, callerToCallees, funcCallCounts, sites, downstreamVisited, &graphNodes, &graphEdges)
 
 	// 合并节点（去重）
 	mergedNodes := s.mergeNodes(graphNodes)
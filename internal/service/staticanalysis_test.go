@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	v1 "github.com/toheart/goanalysis/api/staticanalysis/v1"
+	"github.com/toheart/goanalysis/internal/biz/chanMgr"
+	"github.com/toheart/goanalysis/internal/biz/entity"
+	"github.com/toheart/goanalysis/internal/biz/staticanalysis"
+	"github.com/toheart/goanalysis/internal/conf"
+	"github.com/toheart/goanalysis/internal/data"
+)
+
+// newTestStaticService 创建使用临时存储目录的静态分析服务, 返回服务和数据库目录
+func newTestStaticService(t *testing.T) (*StaticAnalysisService, string) {
+	t.Helper()
+	storage := t.TempDir()
+	dbDir := filepath.Join(storage, "static")
+	if err := os.MkdirAll(dbDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	logger := log.NewStdLogger(io.Discard)
+	uc := staticanalysis.NewStaticAnalysisBiz(&conf.Biz{FileStoragePath: storage}, data.NewData(logger), chanMgr.NewChannelManager(), logger)
+	t.Cleanup(func() { close(uc.AnalysisTaskChan) })
+	return NewStaticAnalysisService(uc, logger), dbDir
+}
+
+// writeProject 在dir下写入项目文件
+func writeProject(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// analyzeProject 通过服务提交分析任务并等待结束, 返回生成的数据库路径
+func analyzeProject(t *testing.T, s *StaticAnalysisService, dbDir string, req *v1.AnalyzeProjectPathRequest) string {
+	t.Helper()
+	resp, err := s.AnalyzeProjectPath(context.Background(), req)
+	if err != nil || !resp.Success {
+		t.Fatalf("AnalyzeProjectPath failed: %v %+v", err, resp)
+	}
+	// 没有SSE客户端时读取状态通道, 避免分析阻塞
+	go func() {
+		for {
+			if ch, err := s.uc.GetTaskStatusChan(resp.TaskId); err == nil {
+				for range ch {
+				}
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	deadline := time.Now().Add(60 * time.Second)
+	for {
+		status, err := s.GetAnalysisTaskStatus(context.Background(), &v1.GetAnalysisTaskStatusRequest{TaskId: resp.TaskId})
+		if err != nil {
+			t.Fatalf("GetAnalysisTaskStatus failed: %v", err)
+		}
+		if status.Status == entity.TaskStatusCompleted {
+			break
+		}
+		if status.Status == entity.TaskStatusFailed || time.Now().After(deadline) {
+			t.Fatalf("Analysis task did not complete: %+v", status)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dbDir, "*.db"))
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("Expected one database in %s, got %v %v", dbDir, matches, err)
+	}
+	return matches[0]
+}
+
+func TestStaticAnalysisService_BuildMatrix(t *testing.T) {
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOWORK", "off")
+	dir := t.TempDir()
+	writeProject(t, dir, map[string]string{
+		"go.mod":          "module example.com/demo\n\ngo 1.21\n",
+		"main.go":         "package main\n\nfunc main() {\n\topen()\n}\n",
+		"open_linux.go":   "package main\n\nfunc open() {}\n",
+		"open_windows.go": "package main\n\nfunc open() {}\n",
+	})
+
+	s, dbDir := newTestStaticService(t)
+	dbPath := analyzeProject(t, s, dbDir, &v1.AnalyzeProjectPathRequest{
+		Path:      dir,
+		Algo:      "static",
+		Platforms: []string{"linux/amd64", "windows/amd64"},
+	})
+
+	store, err := s.uc.GetFuncNodeDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open db: %v", err)
+	}
+	nodes, err := store.GetAllFuncNodes()
+	if err != nil {
+		t.Fatalf("GetAllFuncNodes failed: %v", err)
+	}
+	configs := make(map[string]string)
+	for _, node := range nodes {
+		configs[node.Name+"@"+node.File] = fmt.Sprint(node.Configs)
+	}
+	expected := map[string]string{
+		"main@main.go":         "[linux/amd64 windows/amd64]",
+		"open@open_linux.go":   "[linux/amd64]",
+		"open@open_windows.go": "[windows/amd64]",
+	}
+	for name, config := range expected {
+		if configs[name] != config {
+			keys := make([]string, 0, len(configs))
+			for key := range configs {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			t.Errorf("%s: expected configs %s, got %q (nodes %v)", name, config, configs[name], keys)
+		}
+	}
+}
+
+func TestStaticAnalysisService_FunctionCallGraph(t *testing.T) {
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOWORK", "off")
+	dir := t.TempDir()
+	writeProject(t, dir, map[string]string{
+		"go.mod":  "module example.com/demo\n\ngo 1.21\n",
+		"main.go": "package main\n\nfunc main() {\n\thelper()\n\thelper()\n}\n\nfunc helper() {\n\tleaf()\n}\n\nfunc leaf() {}\n",
+	})
+
+	s, dbDir := newTestStaticService(t)
+	dbPath := analyzeProject(t, s, dbDir, &v1.AnalyzeProjectPathRequest{Path: dir, Algo: "static"})
+
+	graph, err := s.GetFunctionCallGraph(context.Background(), &v1.GetFunctionCallGraphReq{
+		DbPath:      dbPath,
+		FunctionKey: "example.com/demo.helper",
+		Depth:       1,
+	})
+	if err != nil {
+		t.Fatalf("GetFunctionCallGraph failed: %v", err)
+	}
+	nodeTypes := make(map[string]string)
+	for _, node := range graph.Nodes {
+		nodeTypes[node.Name] = node.NodeType
+		// main两次调用helper, 调用方数量只计一次
+		if node.Name == "helper" && node.CallCount != 1 {
+			t.Errorf("Expected helper to have 1 caller, got %d", node.CallCount)
+		}
+	}
+	expectedTypes := map[string]string{"helper": "root", "main": "caller", "leaf": "callee"}
+	if fmt.Sprint(nodeTypes) != fmt.Sprint(expectedTypes) {
+		t.Errorf("Expected nodes %v, got %v", expectedTypes, nodeTypes)
+	}
+	sites := make(map[string]string)
+	for _, edge := range graph.Edges {
+		var lines []string
+		for _, site := range edge.Sites {
+			lines = append(lines, fmt.Sprintf("%s:%d %s", site.File, site.Line, site.Kind))
+		}
+		sites[edge.Source+"->"+edge.Target] = fmt.Sprint(lines)
+		if fmt.Sprint(edge.CallKinds) != "[static]" {
+			t.Errorf("Expected call kinds [static] on %s->%s, got %v", edge.Source, edge.Target, edge.CallKinds)
+		}
+	}
+	expectedSites := map[string]string{
+		"example.com/demo.main->example.com/demo.helper": "[main.go:4 static main.go:5 static]",
+		"example.com/demo.helper->example.com/demo.leaf": "[main.go:9 static]",
+	}
+	if fmt.Sprint(sites) != fmt.Sprint(expectedSites) {
+		t.Errorf("Expected edge sites %v, got %v", expectedSites, sites)
+	}
+
+	// 两层按层查找, 根节点可以用旧格式Key指定
+	store, err := s.uc.GetFuncNodeDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open db: %v", err)
+	}
+	mainNode, err := store.GetFuncNodeByKey("example.com/demo.main")
+	if err != nil || mainNode == nil {
+		t.Fatalf("GetFuncNodeByKey failed: %v, %v", mainNode, err)
+	}
+	graph, err = s.GetFunctionCallGraph(context.Background(), &v1.GetFunctionCallGraphReq{
+		DbPath:      dbPath,
+		FunctionKey: mainNode.LegacyKey,
+		Depth:       2,
+		Direction:   "callee",
+	})
+	if err != nil {
+		t.Fatalf("GetFunctionCallGraph failed: %v", err)
+	}
+	var names []string
+	for _, node := range graph.Nodes {
+		names = append(names, node.Name+":"+node.NodeType)
+	}
+	if fmt.Sprint(names) != "[main:root helper:callee leaf:callee]" || len(graph.Edges) != 2 {
+		t.Errorf("Expected main -> helper -> leaf, got nodes %v edges %v", names, graph.Edges)
+	}
+	if root := graph.Nodes[0]; root.Key != "example.com/demo.main" || root.LegacyKey != mainNode.LegacyKey || root.Module != "example.com/demo" {
+		t.Errorf("Expected stable key, legacy key and module on the root, got %+v", root)
+	}
+
+	// 只沿go调用查找时没有邻居, 未知的调用类型返回错误
+	graph, err = s.GetFunctionCallGraph(context.Background(), &v1.GetFunctionCallGraphReq{
+		DbPath:      dbPath,
+		FunctionKey: "example.com/demo.helper",
+		CallKinds:   []string{"go"},
+	})
+	if err != nil || len(graph.Nodes) != 1 || len(graph.Edges) != 0 || graph.Nodes[0].CallCount != 0 {
+		t.Errorf("Expected only the root without callers with call kind go, got %v, %v", graph, err)
+	}
+	if _, err = s.GetFunctionCallGraph(context.Background(), &v1.GetFunctionCallGraphReq{
+		DbPath:      dbPath,
+		FunctionKey: "example.com/demo.helper",
+		CallKinds:   []string{"goroutine"},
+	}); err == nil {
+		t.Error("Expected an error for unknown call kind")
+	}
+
+	downstream, err := s.GetFunctionDownstream(context.Background(), &v1.GetFunctionDownstreamRequest{
+		DbPath:      dbPath,
+		FunctionKey: "example.com/demo.main",
+	})
+	if err != nil {
+		t.Fatalf("GetFunctionDownstream failed: %v", err)
+	}
+	for _, edge := range downstream.Edges {
+		if edge.Target == "example.com/demo.helper" && (len(edge.Sites) != 2 || edge.Value != 2 || edge.CallLine != 4) {
+			t.Errorf("Expected both call sites on main->helper, got %+v", edge)
+		}
+	}
+	for _, node := range downstream.Nodes {
+		if node.Key == "example.com/demo.helper" && node.CallCount != 1 {
+			t.Errorf("Expected helper to have 1 caller, got %d", node.CallCount)
+		}
+	}
+
+	// 调用方数量只统计过滤后的边
+	upstream, err := s.GetFunctionUpstream(context.Background(), &v1.GetFunctionUpstreamRequest{
+		DbPath:      dbPath,
+		FunctionKey: "example.com/demo.helper",
+		CallKinds:   []string{"go"},
+	})
+	if err != nil || len(upstream.Nodes) != 1 || upstream.Nodes[0].CallCount != 0 {
+		t.Errorf("Expected helper without callers with call kind go, got %v, %v", upstream, err)
+	}
+}